@@ -5,37 +5,137 @@ package main
 //go:generate go fmt ../internal/interfaces/api.gen.go
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kodmain/thetiptop/api/config"
 	"github.com/kodmain/thetiptop/api/env"
 	"github.com/kodmain/thetiptop/api/internal/application"
 	"github.com/kodmain/thetiptop/api/internal/application/hook"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	gameService "github.com/kodmain/thetiptop/api/internal/application/services/game"
+	userService "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/docs/generated"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	gameDomain "github.com/kodmain/thetiptop/api/internal/domain/game/services"
 	eventStore "github.com/kodmain/thetiptop/api/internal/domain/store/events"
 	repoStore "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	userDomain "github.com/kodmain/thetiptop/api/internal/domain/user/services"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger/levels"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/server"
 	"github.com/kodmain/thetiptop/api/internal/interfaces"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
-var callBack hook.Handler = func(tags ...string) {
-	events.HydrateDBWithTickets(
-		repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+// hydrateErr is the error of the hydrations run by the database hook, returned once the configuration is loaded
+var hydrateErr error
+
+// callBack hydrates the game and the stores when a database is initialized
+// The handler is synchronous, so that its error is known when the configuration is loaded and stops the server.
+var callBack hook.HandlerSync = func(tags ...string) {
+	hydrateErr = errors.Join(hydrateErr, hydrate())
+}
+
+// hydrate prepares the game and the stores of the configuration before the server starts
+//
+// Returns:
+// - error: an error if the game or the stores cannot be prepared
+func hydrate() error {
+	if err := hydrateGame(
 		config.Get("project.tickets.required", 10000).(int),
-		config.Get("project.tickets.types", map[string]int{}).(map[string]int),
-	)
+		config.Get("project.tickets.chunk", events.DefaultTicketChunkSize).(int),
+	); err != nil {
+		return err
+	}
+
+	return hydrateStores()
+}
+
+// hydrateStores reconciles the stores of the configuration, the default stores seed an empty database otherwise
+//
+// Returns:
+// - error: an error if the stores cannot be synchronized
+func hydrateStores() error {
+	stores := []*eventStore.StoreSeed{}
+	for _, store := range config.Get("project.stores", []config.Store{}).([]config.Store) {
+		stores = append(stores, &eventStore.StoreSeed{
+			Store: &storeTransfert.Store{
+				Label:    aws.String(store.Label),
+				IsOnline: aws.Bool(store.IsOnline),
+			},
+			Caisses: store.Caisses,
+		})
+	}
 
-	eventStore.CreateStores(
+	return eventStore.SyncStores(
 		repoStore.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		stores,
 	)
 }
 
+// hydrateGame migrates the game tables, synchronizes the prizes and the campaign of the configuration and generates the missing tickets
+//
+// Parameters:
+// - required: int the total number of tickets
+// - chunk: int the number of tickets inserted at once
+//
+// Returns:
+// - error: an error if the game cannot be prepared
+func hydrateGame(required, chunk int) error {
+	store := database.Get(config.GetString("services.game.database", config.DEFAULT))
+	if err := repositories.Migrate(store); err != nil {
+		return err
+	}
+
+	gameRepository := repositories.NewGameRepository(store)
+
+	prizes := []*transfert.Prize{}
+	for _, prize := range config.Get("project.prizes", []config.Prize{}).([]config.Prize) {
+		prizes = append(prizes, &transfert.Prize{
+			Code:        aws.String(prize.Code),
+			Label:       aws.String(prize.Label),
+			Description: aws.String(prize.Description),
+			Value:       aws.Float64(prize.Value),
+			Image:       aws.String(prize.Image),
+			Percentage:  aws.Int(prize.Percentage),
+		})
+	}
+
+	dispatch, err := events.SyncPrizes(gameRepository, prizes)
+	if err != nil {
+		return err
+	}
+
+	if err := events.HydrateDBWithTickets(gameRepository, required, dispatch, chunk); err != nil {
+		return err
+	}
+
+	_, err = events.SyncCampaign(gameRepository, &transfert.Campaign{
+		Label:      aws.String(config.GetString("project.campaign.label", "")),
+		Start:      aws.String(config.GetString("project.campaign.start", "")),
+		End:        aws.String(config.GetString("project.campaign.end", "")),
+		ClaimUntil: aws.String(config.GetString("project.campaign.claimuntil", "")),
+		Timezone:   aws.String(config.GetString("project.campaign.timezone", "")),
+	})
+
+	return err
+}
+
 // Helper use Cobra package to create a CLI and give Args gesture
 var Helper *cobra.Command = &cobra.Command{
 	Use:                   "thetiptop",
@@ -49,7 +149,11 @@ var Helper *cobra.Command = &cobra.Command{
 		logger.SetLevel(levels.DEBUG)
 		hook.Register(hook.EventOnDBInit, callBack)
 
-		return config.Load(env.CONFIG_URI)
+		if err := config.Load(env.CONFIG_URI); err != nil {
+			return err
+		}
+
+		return hydrateErr
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger.Info("starting application")
@@ -78,6 +182,220 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// ticketsCmd regroupe les commandes de gestion des tickets
+var ticketsCmd = &cobra.Command{
+	Use:   "tickets",
+	Short: "manage tickets",
+	Long:  "manage the tickets of the game",
+}
+
+// ticketsGenerateCmd génère les tickets manquants
+var ticketsGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "generate tickets",
+	Long:  "generate the missing tickets of the configured prizes, an interrupted generation resumes where it stopped",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		logger.Info("loading configuration")
+		return config.Load(env.CONFIG_URI)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		required, err := cmd.Flags().GetInt("required")
+		if err != nil {
+			return err
+		}
+
+		chunk, err := cmd.Flags().GetInt("chunk")
+		if err != nil {
+			return err
+		}
+
+		if required <= 0 {
+			required = config.Get("project.tickets.required", 10000).(int)
+		}
+
+		if chunk <= 0 {
+			chunk = config.Get("project.tickets.chunk", events.DefaultTicketChunkSize).(int)
+		}
+
+		return hydrateGame(required, chunk)
+	},
+}
+
+// ticketsExportCmd exporte les tickets à imprimer
+var ticketsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export tickets",
+	Long:  "export the tickets of a store as a printable sheet, the count assigns new tickets to the store before the export",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		logger.Info("loading configuration")
+		return config.Load(env.CONFIG_URI)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dto := &transfert.TicketExport{}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		dto.Format = aws.String(format)
+
+		if store, _ := cmd.Flags().GetString("store"); store != "" {
+			dto.StoreID = aws.String(store)
+		}
+
+		if count, _ := cmd.Flags().GetInt("count"); count != 0 {
+			dto.Count = aws.Int(count)
+		}
+
+		status, response := gameService.ExportTickets(
+			gameDomain.Game(
+				&security.UserAccess{Role: security.ROLE_ADMIN},
+				repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+				repoStore.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+				nil, nil,
+			),
+			dto,
+		)
+
+		render, ok := response.(sheet.Render)
+		if !ok {
+			return fmt.Errorf("export failed with status %d: %v", status, response)
+		}
+
+		var out io.Writer = cmd.OutOrStdout()
+		if output, _ := cmd.Flags().GetString("output"); output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+
+		return render(out)
+	},
+}
+
+// AdminPasswordEnv est la variable d'environnement portant le mot de passe de l'administrateur
+const AdminPasswordEnv = "THETIPTOP_ADMIN_PASSWORD"
+
+// adminCmd regroupe les commandes de gestion des administrateurs
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "manage admins",
+	Long:  "manage the admins of the application",
+}
+
+// adminCreateCmd crée le premier administrateur
+var adminCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "create an admin",
+	Long:  "create an admin, an existing account is promoted instead provided the password matches; the password is read from " + AdminPasswordEnv + " or from the standard input",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		logger.Info("loading configuration")
+		return config.Load(env.CONFIG_URI)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		email, err := cmd.Flags().GetString("email")
+		if err != nil {
+			return err
+		}
+
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
+
+		status, response := userService.CreateAdmin(
+			userDomain.User(
+				&security.UserAccess{Role: security.ROLE_ADMIN},
+				userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+				nil, nil,
+			),
+			&userTransfert.Credential{
+				Email:    aws.String(email),
+				Password: aws.String(password),
+			},
+		)
+
+		if status != http.StatusCreated {
+			return fmt.Errorf("admin creation failed with status %d: %v", status, response)
+		}
+
+		logger.Info("admin created")
+		return nil
+	},
+}
+
+// passwordTerminal reads a password from a terminal without echo
+type passwordTerminal interface {
+	IsTerminal(fd int) bool
+	ReadPassword(fd int) ([]byte, error)
+}
+
+// systemTerminal is the terminal of the process
+type systemTerminal struct{}
+
+func (systemTerminal) IsTerminal(fd int) bool              { return term.IsTerminal(fd) }
+func (systemTerminal) ReadPassword(fd int) ([]byte, error) { return term.ReadPassword(fd) }
+
+// terminal is the terminal the password is prompted on
+var terminal passwordTerminal = systemTerminal{}
+
+// readPassword reads the password of the admin from the environment, or from the standard input without echo
+// A piped standard input is read up to its first line, so the password never shows in the arguments of the process.
+//
+// Parameters:
+// - cmd: *cobra.Command the command prompting for the password
+//
+// Returns:
+// - string: the password
+// - error: an error if the password is empty or cannot be read
+func readPassword(cmd *cobra.Command) (string, error) {
+	if password, ok := os.LookupEnv(AdminPasswordEnv); ok && password != "" {
+		return password, nil
+	}
+
+	var password string
+	if fd := int(os.Stdin.Fd()); terminal.IsTerminal(fd) {
+		fmt.Fprint(cmd.ErrOrStderr(), "Password: ")
+		raw, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", err
+		}
+		password = string(raw)
+	} else {
+		line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if password == "" {
+		return "", fmt.Errorf("the password is required, set %s or type it on the standard input", AdminPasswordEnv)
+	}
+
+	return password, nil
+}
+
+func init() {
+	ticketsGenerateCmd.Flags().Int("required", 0, "Nombre total de tickets, celui de la configuration par défaut")
+	ticketsGenerateCmd.Flags().Int("chunk", 0, "Nombre de tickets insérés à la fois, celui de la configuration par défaut")
+	ticketsCmd.AddCommand(ticketsGenerateCmd)
+
+	ticketsExportCmd.Flags().String("format", sheet.CSV, "Format de la planche, csv ou pdf")
+	ticketsExportCmd.Flags().String("store", "", "Identifiant de la boutique")
+	ticketsExportCmd.Flags().Int("count", 0, "Nombre de tickets à attribuer à la boutique avant l'export")
+	ticketsExportCmd.Flags().String("output", "", "Fichier de destination, la sortie standard par défaut")
+	ticketsCmd.AddCommand(ticketsExportCmd)
+
+	adminCreateCmd.Flags().String("email", "", "Adresse email de l'administrateur")
+	adminCreateCmd.MarkFlagRequired("email")
+	adminCmd.AddCommand(adminCreateCmd)
+}
+
 // @title		TheTipTop
 // @version		dev
 // @description	TheTipTop API
@@ -88,11 +406,13 @@ var versionCmd = &cobra.Command{
 // @name 						Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 func main() {
-	env.CONFIG_URI = Helper.Flags().String("config", env.DEFAULT_CONFIG_URI, "URI de la configuration")
-	env.AWS_PROFILE = Helper.Flags().String("profile", env.DEFAULT_AWS_PROFILE, "Profil AWS")
+	env.CONFIG_URI = Helper.PersistentFlags().String("config", env.DEFAULT_CONFIG_URI, "URI de la configuration")
+	env.AWS_PROFILE = Helper.PersistentFlags().String("profile", env.DEFAULT_AWS_PROFILE, "Profil AWS")
 	env.PORT_HTTP = Helper.Flags().Int("http-port", env.DEFAULT_PORT_HTTP, "Port HTTP")
 	env.PORT_HTTPS = Helper.Flags().Int("https-port", env.DEFAULT_PORT_HTTPS, "Port HTTPS")
 
 	Helper.AddCommand(versionCmd)
+	Helper.AddCommand(ticketsCmd)
+	Helper.AddCommand(adminCmd)
 	Helper.Execute()
 }
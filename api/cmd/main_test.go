@@ -2,7 +2,10 @@ package main
 
 import (
 	"bytes"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -56,6 +59,136 @@ func TestVersionCmd(t *testing.T) {
 	cmd.Run(cmd, nil)
 }
 
+func TestTicketsGenerateCmd(t *testing.T) {
+	env.CONFIG_URI = aws.String("../config.test.yml")
+
+	cmd := ticketsGenerateCmd
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	assert.Nil(t, cmd.Flags().Set("required", "50"))
+	assert.Nil(t, cmd.Flags().Set("chunk", "20"))
+
+	assert.Nil(t, cmd.PreRunE(cmd, nil))
+	assert.Nil(t, cmd.RunE(cmd, nil))
+
+	// Une seconde exécution reprend là où la première s'est arrêtée
+	assert.Nil(t, cmd.RunE(cmd, nil))
+}
+
+func TestTicketsExportCmd(t *testing.T) {
+	env.CONFIG_URI = aws.String("../config.test.yml")
+
+	generate := ticketsGenerateCmd
+	assert.Nil(t, generate.Flags().Set("required", "50"))
+	assert.Nil(t, generate.PreRunE(generate, nil))
+	assert.Nil(t, generate.RunE(generate, nil))
+
+	cmd := ticketsExportCmd
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	assert.Nil(t, cmd.Flags().Set("store", "123e4567-e89b-12d3-a456-426614174000"))
+	assert.Nil(t, cmd.Flags().Set("count", "3"))
+
+	assert.Nil(t, cmd.PreRunE(cmd, nil))
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.GreaterOrEqual(t, strings.Count(b.String(), "\n"), 4)
+	assert.Contains(t, b.String(), "code,store,qr")
+
+	// La planche PDF est écrite dans le fichier demandé
+	output := filepath.Join(t.TempDir(), "tickets.pdf")
+	assert.Nil(t, cmd.Flags().Set("format", "pdf"))
+	assert.Nil(t, cmd.Flags().Set("count", "0"))
+	assert.Nil(t, cmd.Flags().Set("output", output))
+	assert.Nil(t, cmd.RunE(cmd, nil))
+
+	content, err := os.ReadFile(output)
+	assert.Nil(t, err)
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-")))
+
+	assert.Nil(t, cmd.Flags().Set("format", "xls"))
+	assert.NotNil(t, cmd.RunE(cmd, nil))
+}
+
+// fakeTerminal simule un terminal pour la saisie du mot de passe
+type fakeTerminal struct {
+	tty      bool
+	password string
+	err      error
+	reads    int
+}
+
+func (f *fakeTerminal) IsTerminal(fd int) bool { return f.tty }
+
+func (f *fakeTerminal) ReadPassword(fd int) ([]byte, error) {
+	f.reads++
+	return []byte(f.password), f.err
+}
+
+func TestReadPassword(t *testing.T) {
+	cmd := adminCreateCmd
+
+	defer func(previous passwordTerminal) { terminal = previous }(terminal)
+	terminal = &fakeTerminal{}
+
+	// La variable d'environnement prime sur l'entrée standard
+	t.Setenv(AdminPasswordEnv, "Aa1@azertyuiop")
+	cmd.SetIn(strings.NewReader("ignored\n"))
+	password, err := readPassword(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "Aa1@azertyuiop", password)
+
+	// Une entrée standard redirigée est lue jusqu'à sa première ligne
+	t.Setenv(AdminPasswordEnv, "")
+	cmd.SetIn(strings.NewReader("Bb2@azertyuiop\r\nignored\n"))
+	password, err = readPassword(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bb2@azertyuiop", password)
+
+	// Un mot de passe vide est refusé
+	cmd.SetIn(strings.NewReader(""))
+	_, err = readPassword(cmd)
+	assert.NotNil(t, err)
+}
+
+func TestReadPasswordTerminal(t *testing.T) {
+	cmd := adminCreateCmd
+	t.Setenv(AdminPasswordEnv, "")
+
+	defer func(previous passwordTerminal) { terminal = previous }(terminal)
+
+	// Un terminal est lu sans écho, l'entrée standard n'est pas consultée
+	fake := &fakeTerminal{tty: true, password: "Cc3@azertyuiop"}
+	terminal = fake
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	stdin := strings.NewReader("ignored\n")
+	cmd.SetIn(stdin)
+	password, err := readPassword(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "Cc3@azertyuiop", password)
+	assert.Equal(t, 1, fake.reads)
+	assert.Equal(t, 8, stdin.Len())
+	assert.Contains(t, b.String(), "Password: ")
+
+	// Une erreur du terminal est remontée
+	terminal = &fakeTerminal{tty: true, err: io.ErrUnexpectedEOF}
+	_, err = readPassword(cmd)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	// Un mot de passe vide saisi au terminal est refusé
+	terminal = &fakeTerminal{tty: true}
+	_, err = readPassword(cmd)
+	assert.NotNil(t, err)
+
+	// Une entrée redirigée n'est pas un terminal
+	file, err := os.CreateTemp(t.TempDir(), "stdin")
+	assert.Nil(t, err)
+	defer file.Close()
+	assert.False(t, systemTerminal{}.IsTerminal(int(file.Fd())))
+}
+
 func TestMain(t *testing.T) {
 	env.CONFIG_URI = aws.String("../config.test.yml")
 	env.PORT_HTTP = aws.Int(8080)
@@ -64,4 +197,5 @@ func TestMain(t *testing.T) {
 
 	main()
 	callBack()
+	assert.Nil(t, hydrateErr)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/aws/s3"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
 )
 
@@ -37,16 +38,55 @@ type Config struct {
 		Validation struct {
 			Expire string `yaml:"expire"`
 		} `yaml:"validation"`
-		JWT *jwt.JWT `yaml:"jwt"`
+		Pairing struct {
+			Expire string `yaml:"expire"`
+		} `yaml:"pairing"`
+		JWT     *jwt.JWT         `yaml:"jwt"`
+		Tickets *token.Generator `yaml:"tickets"`
+		Links   *token.Link      `yaml:"links"`
 	} `yaml:"security"`
 	Project struct {
 		Tickets struct {
-			Required int            `yaml:"required"`
-			Types    map[string]int `yaml:"types"`
+			Required int     `yaml:"required"`
+			Chunk    int     `yaml:"chunk"`
+			Minimum  float64 `yaml:"minimum"`
 		} `yaml:"tickets"`
+		Prizes   []Prize `yaml:"prizes"`
+		Stores   []Store `yaml:"stores"`
+		Campaign struct {
+			Label      string `yaml:"label"`
+			Start      string `yaml:"start"`
+			End        string `yaml:"end"`
+			ClaimUntil string `yaml:"claim_until"`
+			Timezone   string `yaml:"timezone"`
+		} `yaml:"campaign"`
+		Draw struct {
+			Recipients []string `yaml:"recipients"`
+		} `yaml:"draw"`
+		Stock struct {
+			Recipients []string `yaml:"recipients"`
+			Threshold  int      `yaml:"threshold"`
+		} `yaml:"stock"`
 	} `yaml:"project"`
 }
 
+// Prize describes a prize of the game and its share of the tickets
+type Prize struct {
+	Code        string  `yaml:"code"`
+	Label       string  `yaml:"label"`
+	Description string  `yaml:"description"`
+	Value       float64 `yaml:"value"`
+	Image       string  `yaml:"image"`
+	Percentage  int     `yaml:"percentage"`
+}
+
+// Store describes a store of the game and the number of caisses it must at least have
+type Store struct {
+	Label    string `yaml:"label"`
+	IsOnline bool   `yaml:"is_online"`
+	Caisses  int    `yaml:"caisses"`
+}
+
 // Get Retrieve the value from cfg based on the provided key
 // Retrieves a value from a config structure by key, following a path syntax (e.g. "parent.child").
 // If the value is not found or is nil, it returns the provided defaultValue.
@@ -201,6 +241,14 @@ func (cfg *Config) Initialize() error {
 		return err
 	}
 
+	if err := token.Configure(cfg.Security.Tickets); err != nil {
+		return fmt.Errorf("invalid ticket codes: %w", err)
+	}
+
+	if err := token.ConfigureLinks(cfg.Security.Links); err != nil {
+		return fmt.Errorf("invalid claim links: %w", err)
+	}
+
 	return nil
 }
 
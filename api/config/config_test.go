@@ -66,11 +66,30 @@ func TestAll(t *testing.T) {
 	// Security - validation
 	assert.Equal(t, "30m", config.Get("security.validation.expire", "default-value"))
 
+	// Security - pairing
+	assert.Equal(t, "10m", config.Get("security.pairing.expire", "default-value"))
+
 	// Security - jwt
 	assert.Equal(t, "Europe/Paris", config.Get("security.jwt.tz", "default-value"))
 	assert.Equal(t, "secret", config.Get("security.jwt.secret", "default-value"))
 	assert.Equal(t, 15, config.GetInt("security.jwt.expire", 0))
 	assert.Equal(t, 30, config.GetInt("security.jwt.refresh", 0))
+
+	// Project - prizes
+	prizes := config.Get("project.prizes", []config.Prize{}).([]config.Prize)
+	if assert.Len(t, prizes, 1) {
+		assert.Equal(t, "infuser", prizes[0].Code)
+		assert.Equal(t, float64(8), prizes[0].Value)
+		assert.Equal(t, 100, prizes[0].Percentage)
+	}
+
+	// Project - stores
+	stores := config.Get("project.stores", []config.Store{}).([]config.Store)
+	if assert.Len(t, stores, 1) {
+		assert.Equal(t, "TestStore", stores[0].Label)
+		assert.False(t, stores[0].IsOnline)
+		assert.Equal(t, 2, stores[0].Caisses)
+	}
 }
 
 func TestGet(t *testing.T) {
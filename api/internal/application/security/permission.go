@@ -10,6 +10,9 @@ type PermissionInterface interface {
 	IsGrantedByRoles(roles ...Role) bool
 	IsGrantedByRules(rules ...Rule) bool
 	GetCredentialID() *string
+	GetRole() Role
+	GetIP() *string
+	GetUserAgent() *string
 	CanRead(ressource database.Entity, rules ...Rule) bool
 	CanCreate(ressource database.Entity, rules ...Rule) bool
 	CanUpdate(ressource database.Entity, rules ...Rule) bool
@@ -19,6 +22,8 @@ type PermissionInterface interface {
 type UserAccess struct {
 	CredentialID string
 	Role         Role
+	IP           string
+	UserAgent    string
 }
 
 type Role string
@@ -28,6 +33,7 @@ const (
 	ROLE_ADMIN     Role = "admin"
 	ROLE_ANONYMOUS Role = "anonymous"
 	ROLE_CONNECTED Role = "connected"
+	ROLE_DEVICE    Role = jwt.ROLE_DEVICE
 )
 
 func (p *UserAccess) IsAuthenticated() bool {
@@ -42,6 +48,41 @@ func (p *UserAccess) GetCredentialID() *string {
 	return &p.CredentialID
 }
 
+func (p *UserAccess) GetRole() Role {
+	return p.Role
+}
+
+func (p *UserAccess) GetIP() *string {
+	if p.IP == "" {
+		return nil
+	}
+
+	return &p.IP
+}
+
+func (p *UserAccess) GetUserAgent() *string {
+	if p.UserAgent == "" {
+		return nil
+	}
+
+	return &p.UserAgent
+}
+
+// WithClient records the device the request comes from, to trace the actions of the user
+//
+// Parameters:
+// - ip: string the IP address of the client
+// - userAgent: string the user agent of the client
+//
+// Returns:
+// - *UserAccess: the same access, for chaining
+func (p *UserAccess) WithClient(ip, userAgent string) *UserAccess {
+	p.IP = ip
+	p.UserAgent = userAgent
+
+	return p
+}
+
 func (p *UserAccess) IsGrantedByRules(rules ...Rule) bool {
 	for _, rule := range rules {
 		if rule(p) {
@@ -43,6 +43,21 @@ func TestGetCredentialID(t *testing.T) {
 	assert.Nil(t, p.GetCredentialID())
 }
 
+func TestGetRole(t *testing.T) {
+	p := &security.UserAccess{Role: security.ROLE_ADMIN}
+	assert.Equal(t, security.ROLE_ADMIN, p.GetRole())
+}
+
+func TestWithClient(t *testing.T) {
+	p := &security.UserAccess{}
+	assert.Nil(t, p.GetIP())
+	assert.Nil(t, p.GetUserAgent())
+
+	p = p.WithClient("127.0.0.1", "curl/8.0")
+	assert.Equal(t, aws.String("127.0.0.1"), p.GetIP())
+	assert.Equal(t, aws.String("curl/8.0"), p.GetUserAgent())
+}
+
 func TestIsAuthenticated(t *testing.T) {
 	p := &security.UserAccess{CredentialID: "test-id"}
 	assert.True(t, p.IsAuthenticated())
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func CreateCampaign(service services.CampaignServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"label":       {validator.Required},
+		"start":       {validator.Required, validator.DateTime},
+		"end":         {validator.Required, validator.DateTime},
+		"claim_until": {validator.Required, validator.DateTime},
+		"timezone":    {validator.Required, validator.Timezone},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	campaign, err := service.CreateCampaign(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, campaign
+}
+
+func GetCampaign(service services.CampaignServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	campaign, err := service.GetCampaign(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, campaign
+}
+
+func GetCampaigns(service services.CampaignServiceInterface) (int, any) {
+	campaigns, err := service.GetCampaigns()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, campaigns
+}
+
+func UpdateCampaign(service services.CampaignServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	mandatory := data.Validator{
+		"id": {validator.Required, validator.ID},
+	}
+
+	if dtoCampaign.Start != nil {
+		mandatory["start"] = []data.Control{validator.DateTime}
+	}
+
+	if dtoCampaign.End != nil {
+		mandatory["end"] = []data.Control{validator.DateTime}
+	}
+
+	if dtoCampaign.ClaimUntil != nil {
+		mandatory["claim_until"] = []data.Control{validator.DateTime}
+	}
+
+	if dtoCampaign.Timezone != nil {
+		mandatory["timezone"] = []data.Control{validator.Timezone}
+	}
+
+	if err := dtoCampaign.Check(mandatory); err != nil {
+		return err.Code(), err
+	}
+
+	campaign, err := service.UpdateCampaign(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, campaign
+}
+
+func DeleteCampaign(service services.CampaignServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.DeleteCampaign(dtoCampaign); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
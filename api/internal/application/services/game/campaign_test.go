@@ -0,0 +1,194 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const campaignID = "123e4567-e89b-12d3-a456-426614174002"
+
+func TestCreateCampaign(t *testing.T) {
+	dto := func() *transfert.Campaign {
+		return &transfert.Campaign{
+			Label:      aws.String("Ouverture Nice"),
+			Start:      aws.String("2024-11-01 00:00:00"),
+			End:        aws.String("2024-11-30 23:59:59"),
+			ClaimUntil: aws.String("2024-12-30 23:59:59"),
+			Timezone:   aws.String("Europe/Paris"),
+		}
+	}
+
+	t.Run("should create the campaign", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dtoCampaign := dto()
+		expected := &entities.Campaign{ID: campaignID}
+		mockService.On("CreateCampaign", dtoCampaign).Return(expected, nil)
+
+		statusCode, response := game.CreateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject an invalid date", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dtoCampaign := dto()
+		dtoCampaign.End = aws.String("30/11/2024")
+
+		statusCode, _ := game.CreateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "CreateCampaign")
+	})
+
+	t.Run("should reject an unknown timezone", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dtoCampaign := dto()
+		dtoCampaign.Timezone = aws.String("Paris")
+
+		statusCode, _ := game.CreateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("should return the service error", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dtoCampaign := dto()
+		mockService.On("CreateCampaign", dtoCampaign).Return(nil, errors_domain_game.ErrCampaignInvalidWindow)
+
+		statusCode, response := game.CreateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidWindow, response)
+	})
+}
+
+func TestGetCampaign(t *testing.T) {
+	t.Run("should return the campaign", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dto := &transfert.Campaign{ID: aws.String(campaignID)}
+		expected := &entities.Campaign{ID: campaignID}
+		mockService.On("GetCampaign", dto).Return(expected, nil)
+
+		statusCode, response := game.GetCampaign(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject an invalid id", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+
+		statusCode, _ := game.GetCampaign(mockService, &transfert.Campaign{ID: aws.String("1")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("should return the service error", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dto := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("GetCampaign", dto).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		statusCode, _ := game.GetCampaign(mockService, dto)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestGetCampaigns(t *testing.T) {
+	t.Run("should return the campaigns", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		expected := []*entities.Campaign{{ID: campaignID}}
+		mockService.On("GetCampaigns").Return(expected, nil)
+
+		statusCode, response := game.GetCampaigns(mockService)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should return the service error", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		mockService.On("GetCampaigns").Return(nil, errors.ErrUnauthorized)
+
+		statusCode, _ := game.GetCampaigns(mockService)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+	})
+}
+
+func TestUpdateCampaign(t *testing.T) {
+	t.Run("should update the campaign", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dto := &transfert.Campaign{ID: aws.String(campaignID), ClaimUntil: aws.String("2025-01-15 23:59:59")}
+		expected := &entities.Campaign{ID: campaignID}
+		mockService.On("UpdateCampaign", dto).Return(expected, nil)
+
+		statusCode, response := game.UpdateCampaign(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should validate the given fields only", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+
+		statusCode, _ := game.UpdateCampaign(mockService, &transfert.Campaign{ID: aws.String(campaignID), Timezone: aws.String("Paris")})
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+
+		statusCode, _ = game.UpdateCampaign(mockService, &transfert.Campaign{ID: aws.String(campaignID), Start: aws.String("demain")})
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+
+		mockService.AssertNotCalled(t, "UpdateCampaign")
+	})
+
+	t.Run("should return the service error", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dto := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("UpdateCampaign", dto).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		statusCode, _ := game.UpdateCampaign(mockService, dto)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestDeleteCampaign(t *testing.T) {
+	t.Run("should delete the campaign", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dto := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("DeleteCampaign", dto).Return(nil)
+
+		statusCode, response := game.DeleteCampaign(mockService, dto)
+
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+	})
+
+	t.Run("should reject an invalid id", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+
+		statusCode, _ := game.DeleteCampaign(mockService, &transfert.Campaign{})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("should return the service error", func(t *testing.T) {
+		mockService := new(DomainCampaignService)
+		dto := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("DeleteCampaign", dto).Return(errors_domain_game.ErrCampaignNotFound)
+
+		statusCode, _ := game.DeleteCampaign(mockService, dto)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
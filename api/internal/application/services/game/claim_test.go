@@ -0,0 +1,98 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimTicket(t *testing.T) {
+	code, _ := token.Generate(12)
+	dtoClaim := &transfert.Claim{
+		Token: code.PointerString(),
+		IP:    aws.String("203.0.113.7"),
+	}
+
+	t.Run("should claim ticket successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		claimedTicket := &entities.Ticket{ID: "1", Status: entities.TicketClaimed}
+		mockService.On("ClaimTicket", dtoClaim).Return(claimedTicket, nil)
+
+		statusCode, response := game.ClaimTicket(mockService, dtoClaim)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, claimedTicket, response)
+		mockService.AssertCalled(t, "ClaimTicket", dtoClaim)
+	})
+
+	t.Run("should reject a bad check digit before the service", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.ClaimTicket(mockService, &transfert.Claim{Token: aws.String("000000000001")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "ClaimTicket")
+	})
+
+	t.Run("should return error when token is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.ClaimTicket(mockService, &transfert.Claim{})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "ClaimTicket")
+	})
+
+	t.Run("should return error when claims are locked", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("ClaimTicket", dtoClaim).Return(nil, errors_domain_game.ErrTicketClaimLocked)
+
+		statusCode, response := game.ClaimTicket(mockService, dtoClaim)
+
+		assert.Equal(t, http.StatusTooManyRequests, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTicketClaimLocked, response)
+	})
+}
+
+func TestGetClaimAttempts(t *testing.T) {
+	t.Run("should list attempts successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoAttempt := &transfert.ClaimAttempt{IP: aws.String("203.0.113.7")}
+		attempts := []*entities.ClaimAttempt{{ID: "1", IP: dtoAttempt.IP}}
+		mockService.On("GetClaimAttempts", dtoAttempt).Return(attempts, nil)
+
+		statusCode, response := game.GetClaimAttempts(mockService, dtoAttempt)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, attempts, response)
+	})
+
+	t.Run("should return error when credential is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.GetClaimAttempts(mockService, &transfert.ClaimAttempt{CredentialID: aws.String("not-an-id")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetClaimAttempts")
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoAttempt := &transfert.ClaimAttempt{}
+		mockService.On("GetClaimAttempts", dtoAttempt).Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.GetClaimAttempts(mockService, dtoAttempt)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
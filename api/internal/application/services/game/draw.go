@@ -0,0 +1,69 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+func CreateDraw(service services.DrawServiceInterface, dtoDraw *transfert.Draw) (int, any) {
+	if err := dtoDraw.Check(data.Validator{
+		"commitment": {validator.Required, validator.SHA256},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if dtoDraw.Count != nil && *dtoDraw.Count < 1 {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	draw, err := service.CreateDraw(dtoDraw)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, draw
+}
+
+func RunDraw(service services.DrawServiceInterface, dtoDraw *transfert.Draw) (int, any) {
+	if err := dtoDraw.Check(data.Validator{
+		"id":   {validator.Required, validator.ID},
+		"seed": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	draw, err := service.RunDraw(dtoDraw)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, draw
+}
+
+func GetDraw(service services.DrawServiceInterface, dtoDraw *transfert.Draw) (int, any) {
+	if err := dtoDraw.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	draw, err := service.GetDraw(dtoDraw)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, draw
+}
+
+func GetDraws(service services.DrawServiceInterface) (int, any) {
+	draws, err := service.GetDraws()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, draws
+}
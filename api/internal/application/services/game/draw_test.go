@@ -0,0 +1,151 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	drawID     = "123e4567-e89b-12d3-a456-426614174000"
+	commitment = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+)
+
+func TestCreateDraw(t *testing.T) {
+	t.Run("should register the draw", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+		dto := &transfert.Draw{Commitment: aws.String(commitment)}
+		expected := &entities.Draw{ID: drawID}
+		mockService.On("CreateDraw", dto).Return(expected, nil)
+
+		statusCode, response := game.CreateDraw(mockService, dto)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject an invalid commitment", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+
+		statusCode, _ := game.CreateDraw(mockService, &transfert.Draw{Commitment: aws.String("abc")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "CreateDraw")
+	})
+
+	t.Run("should reject a negative count", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+
+		statusCode, _ := game.CreateDraw(mockService, &transfert.Draw{Commitment: aws.String(commitment), Count: aws.Int(0)})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "CreateDraw")
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+		dto := &transfert.Draw{Commitment: aws.String(commitment)}
+		mockService.On("CreateDraw", dto).Return(nil, errors_domain_game.ErrDrawAlreadyExists)
+
+		statusCode, response := game.CreateDraw(mockService, dto)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrDrawAlreadyExists, response)
+	})
+}
+
+func TestRunDraw(t *testing.T) {
+	t.Run("should run the draw", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+		dto := &transfert.Draw{ID: aws.String(drawID), Seed: aws.String("seed")}
+		expected := &entities.Draw{ID: drawID}
+		mockService.On("RunDraw", dto).Return(expected, nil)
+
+		statusCode, response := game.RunDraw(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should require the seed", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+
+		statusCode, _ := game.RunDraw(mockService, &transfert.Draw{ID: aws.String(drawID)})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "RunDraw")
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+		dto := &transfert.Draw{ID: aws.String(drawID), Seed: aws.String("seed")}
+		mockService.On("RunDraw", dto).Return(nil, errors_domain_game.ErrDrawCommitmentMismatch)
+
+		statusCode, response := game.RunDraw(mockService, dto)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrDrawCommitmentMismatch, response)
+	})
+}
+
+func TestGetDraw(t *testing.T) {
+	t.Run("should return the draw", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+		dto := &transfert.Draw{ID: aws.String(drawID)}
+		expected := &entities.Draw{ID: drawID}
+		mockService.On("GetDraw", dto).Return(expected, nil)
+
+		statusCode, response := game.GetDraw(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject an invalid id", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+
+		statusCode, _ := game.GetDraw(mockService, &transfert.Draw{ID: aws.String("invalid")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+		dto := &transfert.Draw{ID: aws.String(drawID)}
+		mockService.On("GetDraw", dto).Return(nil, errors_domain_game.ErrDrawNotFound)
+
+		statusCode, _ := game.GetDraw(mockService, dto)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestGetDraws(t *testing.T) {
+	t.Run("should return the draws", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+		expected := []*entities.Draw{{ID: drawID}}
+		mockService.On("GetDraws").Return(expected, nil)
+
+		statusCode, response := game.GetDraws(mockService)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainDrawService)
+		mockService.On("GetDraws").Return(nil, errors.ErrUnauthorized)
+
+		statusCode, _ := game.GetDraws(mockService)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+	})
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
+)
+
+// ExportTickets validates the export and prepares the sheet of the tickets
+// On success the response is the sheet.Render writing the sheet, the tickets are read while it is written.
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoExport: *transfert.TicketExport the format, the store and the number of tickets to assign
+//
+// Returns:
+// - int: the HTTP status
+// - any: the sheet.Render on success, the error otherwise
+func ExportTickets(service services.GameServiceInterface, dtoExport *transfert.TicketExport) (int, any) {
+	mandatory := data.Validator{
+		"format": {validator.Required, validator.SheetFormat},
+	}
+
+	if dtoExport.StoreID != nil || (dtoExport.Count != nil && *dtoExport.Count != 0) {
+		mandatory["store_id"] = []data.Control{validator.Required, validator.ID}
+	}
+
+	if err := dtoExport.Check(mandatory); err != nil {
+		return err.Code(), err
+	}
+
+	if dtoExport.Count != nil && *dtoExport.Count < 0 {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	tickets, err := service.ExportTickets(dtoExport)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	title := "TheTipTop"
+	if dtoExport.StoreID != nil {
+		title += " " + *dtoExport.StoreID
+	}
+
+	return fiber.StatusOK, sheet.Render(func(w io.Writer) error {
+		writer, err := sheet.New(*dtoExport.Format, w, title)
+		if err != nil {
+			return err
+		}
+
+		for ticket, err := range tickets {
+			if err != nil {
+				return err
+			}
+
+			if err := writer.Write(TicketLabel(ticket)); err != nil {
+				return err
+			}
+		}
+
+		return writer.Close()
+	})
+}
+
+// TicketLabel describes how a ticket is printed
+//
+// Parameters:
+// - ticket: *entities.Ticket the ticket
+//
+// Returns:
+// - *sheet.Label: the printed code, its store and its signed claim link as QR code
+func TicketLabel(ticket *entities.Ticket) *sheet.Label {
+	label := &sheet.Label{
+		Code: ticket.Token.String(),
+		QR:   token.Links().Sign(ticket.Token.String(), time.Now()),
+	}
+
+	if ticket.StoreID != nil {
+		label.Store = *ticket.StoreID
+	}
+
+	return label
+}
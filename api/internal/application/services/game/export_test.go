@@ -0,0 +1,107 @@
+package game_test
+
+import (
+	"bytes"
+	"iter"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func tickets(list ...*entities.Ticket) iter.Seq2[*entities.Ticket, errors.ErrorInterface] {
+	return func(yield func(*entities.Ticket, errors.ErrorInterface) bool) {
+		for _, ticket := range list {
+			if !yield(ticket, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestExportTickets(t *testing.T) {
+	storeID := aws.String("123e4567-e89b-12d3-a456-426614174000")
+
+	t.Run("should render the tickets as CSV", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.TicketExport{Format: aws.String(sheet.CSV), StoreID: storeID, Count: aws.Int(2)}
+		mockService.On("ExportTickets", dto).Return(tickets(
+			&entities.Ticket{Token: "000000000018", StoreID: storeID},
+			&entities.Ticket{Token: "000000000026", StoreID: storeID},
+		), nil)
+
+		statusCode, response := game.ExportTickets(mockService, dto)
+		assert.Equal(t, fiber.StatusOK, statusCode)
+
+		render, ok := response.(sheet.Render)
+		assert.True(t, ok)
+
+		out := &bytes.Buffer{}
+		assert.NoError(t, render(out))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[2], "000000000026,"+*storeID+",/game/ticket/000000000026/claim?expires="))
+	})
+
+	t.Run("should stop the sheet on a reading error", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.TicketExport{Format: aws.String(sheet.PDF)}
+		mockService.On("ExportTickets", dto).Return(iter.Seq2[*entities.Ticket, errors.ErrorInterface](
+			func(yield func(*entities.Ticket, errors.ErrorInterface) bool) {
+				yield(nil, errors.ErrInternalServer)
+			},
+		), nil)
+
+		_, response := game.ExportTickets(mockService, dto)
+		assert.Equal(t, errors.ErrInternalServer, response.(sheet.Render)(&bytes.Buffer{}))
+	})
+
+	t.Run("should reject an unknown format", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.ExportTickets(mockService, &transfert.TicketExport{Format: aws.String("xls")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "ExportTickets", mock.Anything)
+	})
+
+	t.Run("should require a store to assign tickets", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.ExportTickets(mockService, &transfert.TicketExport{Format: aws.String(sheet.CSV), Count: aws.Int(10)})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "ExportTickets", mock.Anything)
+	})
+
+	t.Run("should reject a negative count", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.ExportTickets(mockService, &transfert.TicketExport{Format: aws.String(sheet.CSV), StoreID: storeID, Count: aws.Int(-1)})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "ExportTickets", mock.Anything)
+	})
+
+	t.Run("should return the error of the service", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.TicketExport{Format: aws.String(sheet.CSV), StoreID: storeID, Count: aws.Int(10)}
+		mockService.On("ExportTickets", dto).Return(nil, errors_domain_game.ErrTicketNotEnough)
+
+		statusCode, response := game.ExportTickets(mockService, dto)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTicketNotEnough, response)
+	})
+}
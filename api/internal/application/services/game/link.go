@@ -0,0 +1,77 @@
+package game
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+)
+
+// GetTicketQR draws the QR code of the signed claim link of a ticket
+// The format defaults to PNG and the size to qr.DefaultSize.
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoQR: *transfert.TicketQR the printed code of the ticket, the format and the size of the image
+//
+// Returns:
+// - int: the HTTP status
+// - any: the image on success, the error otherwise
+func GetTicketQR(service services.GameServiceInterface, dtoQR *transfert.TicketQR) (int, any) {
+	if dtoQR.Format == nil || *dtoQR.Format == "" {
+		dtoQR.Format = aws.String(qr.PNG)
+	}
+
+	if err := dtoQR.Check(data.Validator{
+		"token":  {validator.Required, validator.TicketCode},
+		"format": {validator.Required, validator.QRFormat},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if dtoQR.Size != nil && (*dtoQR.Size < 1 || *dtoQR.Size > qr.MaxSize) {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	link, err := service.SignTicketLink(&transfert.Ticket{Token: dtoQR.Token})
+	if err != nil {
+		return err.Code(), err
+	}
+
+	image, encodeErr := qr.Encode(*dtoQR.Format, link, aws.ToInt(dtoQR.Size))
+	if encodeErr != nil {
+		return errors.ErrInternalServer.Code(), errors.ErrInternalServer
+	}
+
+	return fiber.StatusOK, image
+}
+
+// ClaimLinkedTicket claims a ticket from its signed claim link
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoClaim: *transfert.Claim the printed code, the expiry and the signature of the link
+//
+// Returns:
+// - int: the HTTP status
+// - any: the claimed ticket on success, the error otherwise
+func ClaimLinkedTicket(service services.GameServiceInterface, dtoClaim *transfert.Claim) (int, any) {
+	if err := dtoClaim.Check(data.Validator{
+		"token":     {validator.Required, validator.TicketCode},
+		"expires":   {validator.Required},
+		"signature": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	ticket, err := service.ClaimLinkedTicket(dtoClaim)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, ticket
+}
@@ -0,0 +1,120 @@
+package game_test
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTicketQR(t *testing.T) {
+	generated, _ := token.Generate(12)
+	code := generated.PointerString()
+	link := "https://thetiptop.local/game/ticket/" + *code + "/claim?expires=1&signature=s"
+
+	t.Run("should draw a PNG by default", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("SignTicketLink", &transfert.Ticket{Token: code}).Return(link, nil)
+
+		statusCode, response := game.GetTicketQR(mockService, &transfert.TicketQR{Token: code, Size: aws.Int(64)})
+		assert.Equal(t, fiber.StatusOK, statusCode)
+
+		image, err := png.Decode(bytes.NewReader(response.([]byte)))
+		assert.NoError(t, err)
+		assert.Equal(t, 64, image.Bounds().Dx())
+	})
+
+	t.Run("should draw an SVG", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("SignTicketLink", &transfert.Ticket{Token: code}).Return(link, nil)
+
+		statusCode, response := game.GetTicketQR(mockService, &transfert.TicketQR{Token: code, Format: aws.String(qr.SVG)})
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.True(t, strings.HasPrefix(string(response.([]byte)), "<svg "))
+	})
+
+	t.Run("should refuse an invalid request", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.GetTicketQR(mockService, &transfert.TicketQR{Token: aws.String("000000000001")})
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+
+		statusCode, response := game.GetTicketQR(mockService, &transfert.TicketQR{Token: code, Format: aws.String("gif")})
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors.ErrValueIsNotQRFormat, response.(errors.Errors)["format"])
+
+		statusCode, response = game.GetTicketQR(mockService, &transfert.TicketQR{Token: code, Size: aws.Int(qr.MaxSize + 1)})
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors.ErrBadRequest, response)
+
+		mockService.AssertNotCalled(t, "SignTicketLink", mock.Anything)
+	})
+
+	t.Run("should return the error of the service", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("SignTicketLink", &transfert.Ticket{Token: code}).Return("", errors_domain_game.ErrTicketNotFound)
+
+		statusCode, response := game.GetTicketQR(mockService, &transfert.TicketQR{Token: code})
+		assert.Equal(t, http.StatusNotFound, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, response)
+	})
+}
+
+func TestClaimLinkedTicket(t *testing.T) {
+	generated, _ := token.Generate(12)
+	code := generated.PointerString()
+	dto := &transfert.Claim{Token: code, Expires: aws.String("1"), Signature: aws.String("s")}
+
+	t.Run("should claim the ticket", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("ClaimLinkedTicket", dto).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+
+		statusCode, response := game.ClaimLinkedTicket(mockService, dto)
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, "ticket-123", response.(*entities.Ticket).ID)
+	})
+
+	t.Run("should refuse a link without signature", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.ClaimLinkedTicket(mockService, &transfert.Claim{Token: code, Expires: aws.String("1")})
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "ClaimLinkedTicket", mock.Anything)
+	})
+
+	t.Run("should return the error of the service", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("ClaimLinkedTicket", dto).Return(nil, errors.ErrLinkIsExpired)
+
+		statusCode, response := game.ClaimLinkedTicket(mockService, dto)
+		assert.Equal(t, http.StatusGone, statusCode)
+		assert.Equal(t, errors.ErrLinkIsExpired, response)
+	})
+}
+
+func TestTicketLabel(t *testing.T) {
+	label := game.TicketLabel(&entities.Ticket{Token: "000000000018"})
+	assert.Equal(t, "000000000018", label.Code)
+	assert.Empty(t, label.Store)
+
+	// Le QR code porte un lien signé vers la réclamation du ticket
+	link, err := url.Parse(label.QR)
+	assert.NoError(t, err)
+	assert.Equal(t, "/game/ticket/000000000018/claim", link.Path)
+	assert.Nil(t, token.Links().Verify("000000000018", link.Query().Get("expires"), link.Query().Get("signature"), time.Now()))
+}
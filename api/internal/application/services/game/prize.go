@@ -0,0 +1,108 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+func CreatePrize(service services.PrizeServiceInterface, dtoPrize *transfert.Prize) (int, any) {
+	if err := dtoPrize.Check(data.Validator{
+		"code":       {validator.Required},
+		"label":      {validator.Required},
+		"value":      {validator.Required},
+		"percentage": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if !hasValidAmounts(dtoPrize) {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	prize, err := service.CreatePrize(dtoPrize)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, prize
+}
+
+func GetPrize(service services.PrizeServiceInterface, dtoPrize *transfert.Prize) (int, any) {
+	if err := dtoPrize.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	prize, err := service.GetPrize(dtoPrize)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, prize
+}
+
+func GetPrizes(service services.PrizeServiceInterface) (int, any) {
+	prizes, err := service.GetPrizes()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, prizes
+}
+
+func UpdatePrize(service services.PrizeServiceInterface, dtoPrize *transfert.Prize) (int, any) {
+	if err := dtoPrize.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if !hasValidAmounts(dtoPrize) {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	prize, err := service.UpdatePrize(dtoPrize)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, prize
+}
+
+func DeletePrize(service services.PrizeServiceInterface, dtoPrize *transfert.Prize) (int, any) {
+	if err := dtoPrize.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.DeletePrize(dtoPrize); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
+
+// hasValidAmounts checks the retail value and the share of tickets given for a prize
+//
+// Parameters:
+// - dtoPrize: *transfert.Prize the prize to check
+//
+// Returns:
+// - bool: false if the value is negative or the percentage outside [0, 100]
+func hasValidAmounts(dtoPrize *transfert.Prize) bool {
+	if dtoPrize.Value != nil && *dtoPrize.Value < 0 {
+		return false
+	}
+
+	if dtoPrize.Percentage != nil && (*dtoPrize.Percentage < 0 || *dtoPrize.Percentage > 100) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,170 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const prizeID = "123e4567-e89b-12d3-a456-426614174003"
+
+func TestCreatePrize(t *testing.T) {
+	dto := func() *transfert.Prize {
+		return &transfert.Prize{
+			Code:       aws.String("infuser"),
+			Label:      aws.String("Infuseur à thé"),
+			Value:      aws.Float64(8),
+			Percentage: aws.Int(60),
+		}
+	}
+
+	t.Run("should create the prize", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dtoPrize := dto()
+		expected := &entities.Prize{ID: prizeID}
+		mockService.On("CreatePrize", dtoPrize).Return(expected, nil)
+
+		statusCode, response := game.CreatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should require a code", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dtoPrize := dto()
+		dtoPrize.Code = nil
+
+		statusCode, _ := game.CreatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "CreatePrize")
+	})
+
+	t.Run("should reject a percentage above 100", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dtoPrize := dto()
+		dtoPrize.Percentage = aws.Int(101)
+
+		statusCode, _ := game.CreatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("should reject a negative value", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dtoPrize := dto()
+		dtoPrize.Value = aws.Float64(-1)
+
+		statusCode, _ := game.CreatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("should return the service error", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dtoPrize := dto()
+		mockService.On("CreatePrize", dtoPrize).Return(nil, errors_domain_game.ErrPrizeAlreadyExists)
+
+		statusCode, response := game.CreatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrPrizeAlreadyExists, response)
+	})
+}
+
+func TestGetPrize(t *testing.T) {
+	t.Run("should return the prize", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dto := &transfert.Prize{ID: aws.String(prizeID)}
+		expected := &entities.Prize{ID: prizeID}
+		mockService.On("GetPrize", dto).Return(expected, nil)
+
+		statusCode, response := game.GetPrize(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject an invalid id", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+
+		statusCode, _ := game.GetPrize(mockService, &transfert.Prize{ID: aws.String("1")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("should return the service error", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dto := &transfert.Prize{ID: aws.String(prizeID)}
+		mockService.On("GetPrize", dto).Return(nil, errors_domain_game.ErrPrizeNotFound)
+
+		statusCode, _ := game.GetPrize(mockService, dto)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestGetPrizes(t *testing.T) {
+	mockService := new(DomainPrizeService)
+	expected := []*entities.Prize{{ID: prizeID}}
+	mockService.On("GetPrizes").Return(expected, nil)
+
+	statusCode, response := game.GetPrizes(mockService)
+
+	assert.Equal(t, fiber.StatusOK, statusCode)
+	assert.Equal(t, expected, response)
+}
+
+func TestUpdatePrize(t *testing.T) {
+	t.Run("should update the prize", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dto := &transfert.Prize{ID: aws.String(prizeID), Percentage: aws.Int(50)}
+		expected := &entities.Prize{ID: prizeID}
+		mockService.On("UpdatePrize", dto).Return(expected, nil)
+
+		statusCode, response := game.UpdatePrize(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject a negative percentage", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+
+		statusCode, _ := game.UpdatePrize(mockService, &transfert.Prize{ID: aws.String(prizeID), Percentage: aws.Int(-5)})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "UpdatePrize")
+	})
+}
+
+func TestDeletePrize(t *testing.T) {
+	t.Run("should delete the prize", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dto := &transfert.Prize{ID: aws.String(prizeID)}
+		mockService.On("DeletePrize", dto).Return(nil)
+
+		statusCode, response := game.DeletePrize(mockService, dto)
+
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+	})
+
+	t.Run("should return the service error", func(t *testing.T) {
+		mockService := new(DomainPrizeService)
+		dto := &transfert.Prize{ID: aws.String(prizeID)}
+		mockService.On("DeletePrize", dto).Return(errors_domain_game.ErrPrizeInUse)
+
+		statusCode, _ := game.DeletePrize(mockService, dto)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
@@ -1,11 +1,13 @@
 package game_test
 
 import (
+	"iter"
 	"sync"
 
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -16,15 +18,18 @@ type DomainGameService struct {
 	mu sync.Mutex // Ensures thread safety when the mock is used in concurrent tests.
 }
 
-// GetRandomTicket simulates the GetRandomTicket method of the GameServiceInterface
+// IssueTicket simulates the IssueTicket method of the GameServiceInterface
 //
 // It uses testify's mock functionality to simulate return values and errors.
 //
+// Parameters:
+// - dtoIssuance: *game.Issuance - the caisse, the receipt and the amount of the purchase
+//
 // Returns:
-// - *entities.Ticket: the ticket returned by the service, if successful
+// - *entities.Ticket: the issued ticket, if successful
 // - errors.ErrorInterface: the error returned by the service, if any
-func (mgs *DomainGameService) GetRandomTicket() (*entities.Ticket, errors.ErrorInterface) {
-	args := mgs.Called()
+func (mgs *DomainGameService) IssueTicket(dtoIssuance *transfert.Issuance) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoIssuance)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(errors.ErrorInterface)
 	}
@@ -49,22 +54,58 @@ func (mgs *DomainGameService) UpdateTicket(dtoTicket *transfert.Ticket) (*entiti
 	return args.Get(0).(*entities.Ticket), nil
 }
 
+// RedeemTicket simulates the RedeemTicket method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoRedemption: *game.Redemption - the ticket and the caisse delivering the prize
+//
+// Returns:
+// - *entities.Ticket: the redeemed ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) RedeemTicket(dtoRedemption *transfert.Redemption) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoRedemption)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Ticket), nil
+}
+
+// ExportTickets simulates the ExportTickets method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoExport: *game.TicketExport - the store and the number of tickets to assign
+//
+// Returns:
+// - iter.Seq2[*entities.Ticket, errors.ErrorInterface]: the exported tickets, if successful
+// - errors.ErrorInterface: error, if any occurred during the operation
+func (mgs *DomainGameService) ExportTickets(dtoExport *transfert.TicketExport) (iter.Seq2[*entities.Ticket, errors.ErrorInterface], errors.ErrorInterface) {
+	args := mgs.Called(dtoExport)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(iter.Seq2[*entities.Ticket, errors.ErrorInterface]), nil
+}
+
 // GetTickets simulates the GetTickets method of the GameServiceInterface
 //
 // It uses testify's mock functionality to simulate return values and errors.
 //
 // Parameters:
-// - dtoTicket: *game.Ticket - the ticket to be updated
+// - list: *database.List - the requested page
 //
 // Returns:
-// - *entities.Ticket: the updated ticket, if successful
+// - *database.Page[*entities.Ticket]: the page of tickets, if successful
 // - errors.ErrorInterface: the error returned by the service, if any
-func (mgs *DomainGameService) GetTickets() ([]*entities.Ticket, errors.ErrorInterface) {
-	args := mgs.Called()
+func (mgs *DomainGameService) GetTickets(list *database.List) (*database.Page[*entities.Ticket], errors.ErrorInterface) {
+	args := mgs.Called(list)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(errors.ErrorInterface)
 	}
-	return args.Get(0).([]*entities.Ticket), nil
+	return args.Get(0).(*database.Page[*entities.Ticket]), nil
 }
 
 // GetTicketById simulates the GetTicketById method of the GameServiceInterface
@@ -84,3 +125,542 @@ func (mgs *DomainGameService) GetTicketById(dtoTicket *transfert.Ticket) (*entit
 	}
 	return args.Get(0).(*entities.Ticket), nil
 }
+
+// GetTicketHistory simulates the GetTicketHistory method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTicket: *game.Ticket - the ticket whose history is requested
+//
+// Returns:
+// - []*entities.TicketEvent: the events of the ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetTicketHistory(dtoTicket *transfert.Ticket) ([]*entities.TicketEvent, errors.ErrorInterface) {
+	args := mgs.Called(dtoTicket)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.TicketEvent), nil
+}
+
+// VoidTicket simulates the VoidTicket method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoVoid: *game.Void - the ticket to void and the reason
+//
+// Returns:
+// - *entities.Ticket: the voided ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) VoidTicket(dtoVoid *transfert.Void) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoVoid)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Ticket), nil
+}
+
+// ReissueTicket simulates the ReissueTicket method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoVoid: *game.Void - the ticket to reissue and the reason
+//
+// Returns:
+// - *entities.Ticket: the replacement, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) ReissueTicket(dtoVoid *transfert.Void) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoVoid)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Ticket), nil
+}
+
+// ClaimTicket simulates the ClaimTicket method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoClaim: *game.Claim - the printed code and the IP of the player
+//
+// Returns:
+// - *entities.Ticket: the claimed ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) ClaimTicket(dtoClaim *transfert.Claim) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoClaim)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Ticket), nil
+}
+
+// ClaimLinkedTicket simulates the ClaimLinkedTicket method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoClaim: *game.Claim - the printed code, the expiry and the signature of the link
+//
+// Returns:
+// - *entities.Ticket: the claimed ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) ClaimLinkedTicket(dtoClaim *transfert.Claim) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoClaim)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Ticket), nil
+}
+
+// SignTicketLink simulates the SignTicketLink method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTicket: *game.Ticket - the printed code of the ticket
+//
+// Returns:
+// - string: the signed claim link, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) SignTicketLink(dtoTicket *transfert.Ticket) (string, errors.ErrorInterface) {
+	args := mgs.Called(dtoTicket)
+	if args.Get(1) != nil {
+		return "", args.Get(1).(errors.ErrorInterface)
+	}
+	return args.String(0), nil
+}
+
+// GetClaimAttempts simulates the GetClaimAttempts method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoAttempt: *game.ClaimAttempt - the credential or the IP to review
+//
+// Returns:
+// - []*entities.ClaimAttempt: the failed attempts, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetClaimAttempts(dtoAttempt *transfert.ClaimAttempt) ([]*entities.ClaimAttempt, errors.ErrorInterface) {
+	args := mgs.Called(dtoAttempt)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.ClaimAttempt), nil
+}
+
+// GetPrizeStocks simulates the GetPrizeStocks method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoStock: *game.PrizeStock - the prize and the store to filter on
+//
+// Returns:
+// - []*entities.PrizeStock: the stocks, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetPrizeStocks(dtoStock *transfert.PrizeStock) ([]*entities.PrizeStock, errors.ErrorInterface) {
+	args := mgs.Called(dtoStock)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.PrizeStock), nil
+}
+
+// RestockPrize simulates the RestockPrize method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoStock: *game.PrizeStock - the delivery to record
+//
+// Returns:
+// - *entities.PrizeStock: the stock after the delivery, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) RestockPrize(dtoStock *transfert.PrizeStock) (*entities.PrizeStock, errors.ErrorInterface) {
+	args := mgs.Called(dtoStock)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.PrizeStock), nil
+}
+
+// TransferPrizeStock simulates the TransferPrizeStock method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTransfer: *game.StockTransfer - the transfer to record
+//
+// Returns:
+// - []*entities.PrizeStock: the source and destination stocks, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) TransferPrizeStock(dtoTransfer *transfert.StockTransfer) ([]*entities.PrizeStock, errors.ErrorInterface) {
+	args := mgs.Called(dtoTransfer)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.PrizeStock), nil
+}
+
+// OpenShift simulates the OpenShift method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoShift: *game.Shift - the caisse of the shift
+//
+// Returns:
+// - *entities.Shift: the opened shift, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) OpenShift(dtoShift *transfert.Shift) (*entities.Shift, errors.ErrorInterface) {
+	args := mgs.Called(dtoShift)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Shift), nil
+}
+
+// CloseShift simulates the CloseShift method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoShift: *game.Shift - the shift to close
+//
+// Returns:
+// - *entities.Shift: the closed shift with its Z-report, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) CloseShift(dtoShift *transfert.Shift) (*entities.Shift, errors.ErrorInterface) {
+	args := mgs.Called(dtoShift)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Shift), nil
+}
+
+// GetShifts simulates the GetShifts method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoShift: *game.Shift - the store and the caisse to filter on
+//
+// Returns:
+// - []*entities.Shift: the shifts, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetShifts(dtoShift *transfert.Shift) ([]*entities.Shift, errors.ErrorInterface) {
+	args := mgs.Called(dtoShift)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Shift), nil
+}
+
+// GetShiftReport simulates the GetShiftReport method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoShift: *game.Shift - the shift of the report
+//
+// Returns:
+// - *entities.Shift: the shift with its Z-report, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetShiftReport(dtoShift *transfert.Shift) (*entities.Shift, errors.ErrorInterface) {
+	args := mgs.Called(dtoShift)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Shift), nil
+}
+
+// SyncCaisse simulates the SyncCaisse method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoSync: *game.Sync - the caisse and its queued operations
+//
+// Returns:
+// - []*entities.SyncOperation: the outcome of each operation, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) SyncCaisse(dtoSync *transfert.Sync) ([]*entities.SyncOperation, errors.ErrorInterface) {
+	args := mgs.Called(dtoSync)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.SyncOperation), nil
+}
+
+// DomainDrawService is a mock implementation of the DrawServiceInterface
+// This mock is used to simulate the behavior of the draw service for testing purposes.
+type DomainDrawService struct {
+	mock.Mock
+}
+
+// CreateDraw simulates the CreateDraw method of the DrawServiceInterface
+//
+// Parameters:
+// - dtoDraw: *transfert.Draw - the draw to register
+//
+// Returns:
+// - *entities.Draw: the registered draw, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mds *DomainDrawService) CreateDraw(dtoDraw *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	args := mds.Called(dtoDraw)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// RunDraw simulates the RunDraw method of the DrawServiceInterface
+//
+// Parameters:
+// - dtoDraw: *transfert.Draw - the draw to run
+//
+// Returns:
+// - *entities.Draw: the draw with its winners, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mds *DomainDrawService) RunDraw(dtoDraw *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	args := mds.Called(dtoDraw)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// GetDraw simulates the GetDraw method of the DrawServiceInterface
+//
+// Parameters:
+// - dtoDraw: *transfert.Draw - the draw to read
+//
+// Returns:
+// - *entities.Draw: the draw, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mds *DomainDrawService) GetDraw(dtoDraw *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	args := mds.Called(dtoDraw)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// GetDraws simulates the GetDraws method of the DrawServiceInterface
+//
+// Returns:
+// - []*entities.Draw: the draws, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mds *DomainDrawService) GetDraws() ([]*entities.Draw, errors.ErrorInterface) {
+	args := mds.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Draw), nil
+}
+
+// DomainCampaignService is a mock implementation of the CampaignServiceInterface
+// This mock is used to simulate the behavior of the campaign service for testing purposes.
+type DomainCampaignService struct {
+	mock.Mock
+}
+
+// CreateCampaign simulates the CreateCampaign method of the CampaignServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *transfert.Campaign - the campaign to create
+//
+// Returns:
+// - *entities.Campaign: the created campaign, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mcs *DomainCampaignService) CreateCampaign(dtoCampaign *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	args := mcs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// GetCampaign simulates the GetCampaign method of the CampaignServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *transfert.Campaign - the campaign to read
+//
+// Returns:
+// - *entities.Campaign: the campaign, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mcs *DomainCampaignService) GetCampaign(dtoCampaign *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	args := mcs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// GetCampaigns simulates the GetCampaigns method of the CampaignServiceInterface
+//
+// Returns:
+// - []*entities.Campaign: the campaigns, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mcs *DomainCampaignService) GetCampaigns() ([]*entities.Campaign, errors.ErrorInterface) {
+	args := mcs.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Campaign), nil
+}
+
+// UpdateCampaign simulates the UpdateCampaign method of the CampaignServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *transfert.Campaign - the campaign to update
+//
+// Returns:
+// - *entities.Campaign: the updated campaign, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mcs *DomainCampaignService) UpdateCampaign(dtoCampaign *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	args := mcs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// DeleteCampaign simulates the DeleteCampaign method of the CampaignServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *transfert.Campaign - the campaign to delete
+//
+// Returns:
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mcs *DomainCampaignService) DeleteCampaign(dtoCampaign *transfert.Campaign) errors.ErrorInterface {
+	args := mcs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+// DomainPrizeService is a mock implementation of the PrizeServiceInterface
+// This mock is used to simulate the behavior of the prize service for testing purposes.
+type DomainPrizeService struct {
+	mock.Mock
+}
+
+// CreatePrize simulates the CreatePrize method of the PrizeServiceInterface
+//
+// Parameters:
+// - dtoPrize: *transfert.Prize - the prize to create
+//
+// Returns:
+// - *entities.Prize: the created prize, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mps *DomainPrizeService) CreatePrize(dtoPrize *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	args := mps.Called(dtoPrize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// GetPrize simulates the GetPrize method of the PrizeServiceInterface
+//
+// Parameters:
+// - dtoPrize: *transfert.Prize - the prize to read
+//
+// Returns:
+// - *entities.Prize: the prize, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mps *DomainPrizeService) GetPrize(dtoPrize *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	args := mps.Called(dtoPrize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// GetPrizes simulates the GetPrizes method of the PrizeServiceInterface
+//
+// Returns:
+// - []*entities.Prize: the prizes, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mps *DomainPrizeService) GetPrizes() ([]*entities.Prize, errors.ErrorInterface) {
+	args := mps.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Prize), nil
+}
+
+// UpdatePrize simulates the UpdatePrize method of the PrizeServiceInterface
+//
+// Parameters:
+// - dtoPrize: *transfert.Prize - the prize to update
+//
+// Returns:
+// - *entities.Prize: the updated prize, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mps *DomainPrizeService) UpdatePrize(dtoPrize *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	args := mps.Called(dtoPrize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// DeletePrize simulates the DeletePrize method of the PrizeServiceInterface
+//
+// Parameters:
+// - dtoPrize: *transfert.Prize - the prize to delete
+//
+// Returns:
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mps *DomainPrizeService) DeletePrize(dtoPrize *transfert.Prize) errors.ErrorInterface {
+	args := mps.Called(dtoPrize)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+// DomainStatisticsService is a mock implementation of the StatisticsServiceInterface
+// This mock is used to simulate the behavior of the statistics service for testing purposes.
+type DomainStatisticsService struct {
+	mock.Mock
+}
+
+// GetSeries simulates the GetSeries method of the StatisticsServiceInterface
+//
+// Parameters:
+// - dtoStatistics: *transfert.Statistics - the period and the filters
+//
+// Returns:
+// - []*entities.Statistic: the series, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mss *DomainStatisticsService) GetSeries(dtoStatistics *transfert.Statistics) ([]*entities.Statistic, errors.ErrorInterface) {
+	args := mss.Called(dtoStatistics)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Statistic), nil
+}
+
+// GetBreakdown simulates the GetBreakdown method of the StatisticsServiceInterface
+//
+// Parameters:
+// - dtoStatistics: *transfert.Statistics - the dimension, the period and the filters
+//
+// Returns:
+// - []*entities.Statistic: the breakdown, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mss *DomainStatisticsService) GetBreakdown(dtoStatistics *transfert.Statistics) ([]*entities.Statistic, errors.ErrorInterface) {
+	args := mss.Called(dtoStatistics)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Statistic), nil
+}
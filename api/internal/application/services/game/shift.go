@@ -0,0 +1,169 @@
+package game
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
+)
+
+// ReportJSON is the default format of a Z-report
+const ReportJSON = "json"
+
+// OpenShift validates the caisse and opens a shift of the current employee on it
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoShift: *transfert.Shift the caisse
+//
+// Returns:
+// - int: the HTTP status
+// - any: the opened shift on success, the error otherwise
+func OpenShift(service services.GameServiceInterface, dtoShift *transfert.Shift) (int, any) {
+	if err := dtoShift.Check(data.Validator{
+		"caisse_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	shift, err := service.OpenShift(dtoShift)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, shift
+}
+
+// CloseShift validates the shift and closes it
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoShift: *transfert.Shift the shift
+//
+// Returns:
+// - int: the HTTP status
+// - any: the closed shift with its Z-report on success, the error otherwise
+func CloseShift(service services.GameServiceInterface, dtoShift *transfert.Shift) (int, any) {
+	if err := dtoShift.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	shift, err := service.CloseShift(dtoShift)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, shift
+}
+
+// GetShifts validates the filters and lists the shifts
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoShift: *transfert.Shift the store and the caisse to filter on, both optional
+//
+// Returns:
+// - int: the HTTP status
+// - any: the shifts on success, the error otherwise
+func GetShifts(service services.GameServiceInterface, dtoShift *transfert.Shift) (int, any) {
+	mandatory := data.Validator{}
+
+	if dtoShift.StoreID != nil {
+		mandatory["store_id"] = []data.Control{validator.ID}
+	}
+
+	if dtoShift.CaisseID != nil {
+		mandatory["caisse_id"] = []data.Control{validator.ID}
+	}
+
+	if err := dtoShift.Check(mandatory); err != nil {
+		return err.Code(), err
+	}
+
+	shifts, err := service.GetShifts(dtoShift)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, shifts
+}
+
+// GetShiftReport validates the format and returns the Z-report of a closed shift
+// In CSV the response is the sheet.Render writing one row per prize, the total and one row per anomaly.
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoReport: *transfert.ShiftReport the shift and the format, JSON by default
+//
+// Returns:
+// - int: the HTTP status
+// - any: the shift or the sheet.Render on success, the error otherwise
+func GetShiftReport(service services.GameServiceInterface, dtoReport *transfert.ShiftReport) (int, any) {
+	if err := dtoReport.Check(data.Validator{
+		"shift_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	format := ReportJSON
+	if dtoReport.Format != nil {
+		format = *dtoReport.Format
+	}
+
+	if format != ReportJSON && format != sheet.CSV {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	shift, err := service.GetShiftReport(&transfert.Shift{ID: dtoReport.ShiftID})
+	if err != nil {
+		return err.Code(), err
+	}
+
+	if format == ReportJSON {
+		return fiber.StatusOK, shift
+	}
+
+	return fiber.StatusOK, sheet.Render(func(w io.Writer) error {
+		return sheet.WriteRows(w, ShiftRows(shift))
+	})
+}
+
+// ShiftRows describes the Z-report of a shift as a table
+//
+// Parameters:
+// - shift: *entities.Shift the closed shift with its lines and anomalies
+//
+// Returns:
+// - [][]string: the header, one row per prize, the total and one row per anomaly
+func ShiftRows(shift *entities.Shift) [][]string {
+	rows := [][]string{{"row", "prize_id", "issued", "redeemed", "voided", "amount", "ticket_id", "credential_id"}}
+
+	for _, line := range shift.Lines {
+		rows = append(rows, []string{"prize", orEmpty(line.PrizeID), strconv.Itoa(line.Issued), strconv.Itoa(line.Redeemed), strconv.Itoa(line.Voided), "", "", ""})
+	}
+
+	rows = append(rows, []string{"total", "", strconv.Itoa(shift.Issued), strconv.Itoa(shift.Redeemed), strconv.Itoa(shift.Voided), strconv.FormatFloat(shift.Amount, 'f', 2, 64), "", ""})
+
+	for _, anomaly := range shift.Anomalies {
+		rows = append(rows, []string{anomaly.Kind, "", "", "", "", "", anomaly.TicketID, orEmpty(anomaly.CredentialID)})
+	}
+
+	return rows
+}
+
+func orEmpty(str *string) string {
+	if str == nil {
+		return ""
+	}
+
+	return *str
+}
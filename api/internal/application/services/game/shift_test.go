@@ -0,0 +1,164 @@
+package game_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const shiftUUID = "123e4567-e89b-12d3-a456-426614174003"
+
+func TestOpenShift(t *testing.T) {
+	t.Run("should open a shift on the caisse", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Shift{CaisseID: aws.String(storeUUID)}
+		expected := &entities.Shift{ID: shiftUUID}
+		mockService.On("OpenShift", dto).Return(expected, nil)
+
+		statusCode, response := game.OpenShift(mockService, dto)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should require the caisse", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.OpenShift(mockService, &transfert.Shift{})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "OpenShift", mock.Anything)
+	})
+
+	t.Run("should return error when a shift is already open", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("OpenShift", mock.Anything).Return(nil, errors_domain_game.ErrShiftAlreadyOpen)
+
+		statusCode, response := game.OpenShift(mockService, &transfert.Shift{CaisseID: aws.String(storeUUID)})
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrShiftAlreadyOpen, response)
+	})
+}
+
+func TestCloseShift(t *testing.T) {
+	t.Run("should close the shift", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Shift{ID: aws.String(shiftUUID)}
+		expected := &entities.Shift{ID: shiftUUID}
+		mockService.On("CloseShift", dto).Return(expected, nil)
+
+		statusCode, response := game.CloseShift(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject a malformed shift", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.CloseShift(mockService, &transfert.Shift{ID: aws.String("shift")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "CloseShift", mock.Anything)
+	})
+}
+
+func TestGetShifts(t *testing.T) {
+	t.Run("should return the shifts", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Shift{StoreID: aws.String(storeUUID)}
+		expected := []*entities.Shift{{ID: shiftUUID}}
+		mockService.On("GetShifts", dto).Return(expected, nil)
+
+		statusCode, response := game.GetShifts(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject a malformed caisse", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.GetShifts(mockService, &transfert.Shift{CaisseID: aws.String("caisse")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetShifts", mock.Anything)
+	})
+}
+
+func TestGetShiftReport(t *testing.T) {
+	now := time.Now()
+	shift := &entities.Shift{
+		ID:       shiftUUID,
+		ClosedAt: &now,
+		Issued:   3,
+		Redeemed: 1,
+		Voided:   1,
+		Amount:   84.9,
+		Lines: entities.ShiftLines{
+			{PrizeID: aws.String(prizeUUID), Issued: 3, Redeemed: 1, Voided: 1},
+		},
+		Anomalies: entities.ShiftAnomalies{
+			{Kind: entities.ShiftAnomalyOtherEmployee, TicketID: "ticket-1", CredentialID: aws.String("employee-2")},
+		},
+	}
+
+	t.Run("should return the report as JSON by default", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("GetShiftReport", &transfert.Shift{ID: aws.String(shiftUUID)}).Return(shift, nil)
+
+		statusCode, response := game.GetShiftReport(mockService, &transfert.ShiftReport{ShiftID: aws.String(shiftUUID)})
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, shift, response)
+	})
+
+	t.Run("should render the report as CSV", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("GetShiftReport", mock.Anything).Return(shift, nil)
+
+		statusCode, response := game.GetShiftReport(mockService, &transfert.ShiftReport{ShiftID: aws.String(shiftUUID), Format: aws.String(sheet.CSV)})
+		assert.Equal(t, fiber.StatusOK, statusCode)
+
+		render, ok := response.(sheet.Render)
+		if assert.True(t, ok) {
+			out := &bytes.Buffer{}
+			assert.NoError(t, render(out))
+			assert.Equal(t, "row,prize_id,issued,redeemed,voided,amount,ticket_id,credential_id\n"+
+				"prize,"+prizeUUID+",3,1,1,,,\n"+
+				"total,,3,1,1,84.90,,\n"+
+				"other_employee,,,,,,ticket-1,employee-2\n", out.String())
+		}
+	})
+
+	t.Run("should reject an unknown format", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.GetShiftReport(mockService, &transfert.ShiftReport{ShiftID: aws.String(shiftUUID), Format: aws.String(sheet.PDF)})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetShiftReport", mock.Anything)
+	})
+
+	t.Run("should return error when the shift is still open", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("GetShiftReport", mock.Anything).Return(nil, errors_domain_game.ErrShiftNotClosed)
+
+		statusCode, response := game.GetShiftReport(mockService, &transfert.ShiftReport{ShiftID: aws.String(shiftUUID)})
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrShiftNotClosed, response)
+	})
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// GetStatisticsSeries validates the period and counts the tickets per day
+//
+// Parameters:
+// - service: services.StatisticsServiceInterface the statistics service
+// - dtoStatistics: *transfert.Statistics the period and the filters
+//
+// Returns:
+// - int: the HTTP status
+// - any: the series on success, the error otherwise
+func GetStatisticsSeries(service services.StatisticsServiceInterface, dtoStatistics *transfert.Statistics) (int, any) {
+	if err := checkStatistics(dtoStatistics, data.Validator{}); err != nil {
+		return err.Code(), err
+	}
+
+	series, err := service.GetSeries(dtoStatistics)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, series
+}
+
+// GetStatisticsBreakdown validates the dimension and the period and counts the tickets per value of the dimension
+//
+// Parameters:
+// - service: services.StatisticsServiceInterface the statistics service
+// - dtoStatistics: *transfert.Statistics the dimension, the period and the filters
+//
+// Returns:
+// - int: the HTTP status
+// - any: the breakdown on success, the error otherwise
+func GetStatisticsBreakdown(service services.StatisticsServiceInterface, dtoStatistics *transfert.Statistics) (int, any) {
+	if err := checkStatistics(dtoStatistics, data.Validator{
+		"by": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if !slices.Contains(entities.StatisticsDimensions, *dtoStatistics.By) {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	breakdown, err := service.GetBreakdown(dtoStatistics)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, breakdown
+}
+
+// checkStatistics validates the optional period and filters of the statistics
+// The period must be ordered and last at most services.StatisticsMaxDays.
+//
+// Parameters:
+// - dtoStatistics: *transfert.Statistics the statistics requested
+// - mandatory: data.Validator the controls of the other fields
+//
+// Returns:
+// - errors.ErrorInterface: an error if the statistics are invalid
+func checkStatistics(dtoStatistics *transfert.Statistics, mandatory data.Validator) errors.ErrorInterface {
+	if dtoStatistics.From != nil {
+		mandatory["from"] = []data.Control{validator.Date}
+	}
+
+	if dtoStatistics.To != nil {
+		mandatory["to"] = []data.Control{validator.Date}
+	}
+
+	if dtoStatistics.StoreID != nil {
+		mandatory["store_id"] = []data.Control{validator.ID}
+	}
+
+	if dtoStatistics.PrizeID != nil {
+		mandatory["prize_id"] = []data.Control{validator.ID}
+	}
+
+	if dtoStatistics.CampaignID != nil {
+		mandatory["campaign_id"] = []data.Control{validator.ID}
+	}
+
+	if err := dtoStatistics.Check(mandatory); err != nil {
+		return err
+	}
+
+	if dtoStatistics.From != nil && dtoStatistics.To != nil {
+		from, _ := time.Parse(time.DateOnly, *dtoStatistics.From)
+		to, _ := time.Parse(time.DateOnly, *dtoStatistics.To)
+
+		if to.Before(from) || to.Sub(from) >= services.StatisticsMaxDays*24*time.Hour {
+			return errors.ErrBadRequest
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStatisticsSeries(t *testing.T) {
+	t.Run("should return the series", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+		dto := &transfert.Statistics{From: aws.String("2024-11-01"), To: aws.String("2024-11-30")}
+		expected := []*entities.Statistic{{Key: "2024-11-01", Issued: 3}}
+		mockService.On("GetSeries", dto).Return(expected, nil)
+
+		statusCode, response := game.GetStatisticsSeries(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject a malformed day", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+
+		statusCode, _ := game.GetStatisticsSeries(mockService, &transfert.Statistics{From: aws.String("01/11/2024")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetSeries", mock.Anything)
+	})
+
+	t.Run("should reject a reversed period", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+
+		statusCode, _ := game.GetStatisticsSeries(mockService, &transfert.Statistics{From: aws.String("2024-11-30"), To: aws.String("2024-11-01")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetSeries", mock.Anything)
+	})
+
+	t.Run("should reject a period too long", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+
+		statusCode, _ := game.GetStatisticsSeries(mockService, &transfert.Statistics{From: aws.String("2023-01-01"), To: aws.String("2024-12-31")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetSeries", mock.Anything)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+		dto := &transfert.Statistics{}
+		mockService.On("GetSeries", dto).Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.GetStatisticsSeries(mockService, dto)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
+
+func TestGetStatisticsBreakdown(t *testing.T) {
+	t.Run("should return the breakdown", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+		dto := &transfert.Statistics{By: aws.String(entities.StatisticsByStore), PrizeID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+		expected := []*entities.Statistic{{Key: "store", Claimed: 1}}
+		mockService.On("GetBreakdown", dto).Return(expected, nil)
+
+		statusCode, response := game.GetStatisticsBreakdown(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should require a dimension", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+
+		statusCode, _ := game.GetStatisticsBreakdown(mockService, &transfert.Statistics{})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetBreakdown", mock.Anything)
+	})
+
+	t.Run("should reject an unknown dimension", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+
+		statusCode, _ := game.GetStatisticsBreakdown(mockService, &transfert.Statistics{By: aws.String("color")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetBreakdown", mock.Anything)
+	})
+
+	t.Run("should reject a malformed filter", func(t *testing.T) {
+		mockService := new(DomainStatisticsService)
+
+		statusCode, _ := game.GetStatisticsBreakdown(mockService, &transfert.Statistics{By: aws.String(entities.StatisticsByPrize), StoreID: aws.String("store")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetBreakdown", mock.Anything)
+	})
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// GetPrizeStocks validates the filters and lists the stocks of the prizes in the stores
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoStock: *transfert.PrizeStock the prize and the store to filter on, both optional
+//
+// Returns:
+// - int: the HTTP status
+// - any: the stocks on success, the error otherwise
+func GetPrizeStocks(service services.GameServiceInterface, dtoStock *transfert.PrizeStock) (int, any) {
+	mandatory := data.Validator{}
+
+	if dtoStock.PrizeID != nil {
+		mandatory["prize_id"] = []data.Control{validator.ID}
+	}
+
+	if dtoStock.StoreID != nil {
+		mandatory["store_id"] = []data.Control{validator.ID}
+	}
+
+	if err := dtoStock.Check(mandatory); err != nil {
+		return err.Code(), err
+	}
+
+	stocks, err := service.GetPrizeStocks(dtoStock)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, stocks
+}
+
+// RestockPrize validates and records a delivery of a prize to a store
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoStock: *transfert.PrizeStock the prize, the store, the delivered quantity and optionally the threshold
+//
+// Returns:
+// - int: the HTTP status
+// - any: the stock after the delivery on success, the error otherwise
+func RestockPrize(service services.GameServiceInterface, dtoStock *transfert.PrizeStock) (int, any) {
+	if err := dtoStock.Check(data.Validator{
+		"prize_id": {validator.Required, validator.ID},
+		"store_id": {validator.Required, validator.ID},
+		"quantity": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if *dtoStock.Quantity < 1 || (dtoStock.Threshold != nil && *dtoStock.Threshold < 0) {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	stock, err := service.RestockPrize(dtoStock)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, stock
+}
+
+// TransferPrizeStock validates and moves units of a prize from a store to another
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoTransfer: *transfert.StockTransfer the prize, the source and destination stores and the quantity
+//
+// Returns:
+// - int: the HTTP status
+// - any: the stocks of both stores on success, the error otherwise
+func TransferPrizeStock(service services.GameServiceInterface, dtoTransfer *transfert.StockTransfer) (int, any) {
+	if err := dtoTransfer.Check(data.Validator{
+		"prize_id":      {validator.Required, validator.ID},
+		"from_store_id": {validator.Required, validator.ID},
+		"to_store_id":   {validator.Required, validator.ID},
+		"quantity":      {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if *dtoTransfer.Quantity < 1 || *dtoTransfer.FromStoreID == *dtoTransfer.ToStoreID {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	stocks, err := service.TransferPrizeStock(dtoTransfer)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, stocks
+}
@@ -0,0 +1,140 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	prizeUUID = "123e4567-e89b-12d3-a456-426614174000"
+	storeUUID = "123e4567-e89b-12d3-a456-426614174001"
+	otherUUID = "123e4567-e89b-12d3-a456-426614174002"
+)
+
+func TestGetPrizeStocks(t *testing.T) {
+	t.Run("should return the stocks", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.PrizeStock{StoreID: aws.String(storeUUID)}
+		expected := []*entities.PrizeStock{{ID: "stock-1", StoreID: storeUUID}}
+		mockService.On("GetPrizeStocks", dto).Return(expected, nil)
+
+		statusCode, response := game.GetPrizeStocks(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject a malformed store", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.GetPrizeStocks(mockService, &transfert.PrizeStock{StoreID: aws.String("store")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetPrizeStocks", mock.Anything)
+	})
+}
+
+func TestRestockPrize(t *testing.T) {
+	t.Run("should restock a store", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.PrizeStock{PrizeID: aws.String(prizeUUID), StoreID: aws.String(storeUUID), Quantity: aws.Int(10)}
+		expected := &entities.PrizeStock{ID: "stock-1", Quantity: 10}
+		mockService.On("RestockPrize", dto).Return(expected, nil)
+
+		statusCode, response := game.RestockPrize(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject an empty delivery", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.RestockPrize(mockService, &transfert.PrizeStock{PrizeID: aws.String(prizeUUID), StoreID: aws.String(storeUUID), Quantity: aws.Int(0)})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "RestockPrize", mock.Anything)
+	})
+
+	t.Run("should reject a missing quantity", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.RestockPrize(mockService, &transfert.PrizeStock{PrizeID: aws.String(prizeUUID), StoreID: aws.String(storeUUID)})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "RestockPrize", mock.Anything)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.PrizeStock{PrizeID: aws.String(prizeUUID), StoreID: aws.String(storeUUID), Quantity: aws.Int(1)}
+		mockService.On("RestockPrize", dto).Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.RestockPrize(mockService, dto)
+
+		assert.Equal(t, errors.ErrUnauthorized.Code(), statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
+
+func TestTransferPrizeStock(t *testing.T) {
+	dto := func(to string, quantity int) *transfert.StockTransfer {
+		return &transfert.StockTransfer{
+			PrizeID:     aws.String(prizeUUID),
+			FromStoreID: aws.String(storeUUID),
+			ToStoreID:   aws.String(to),
+			Quantity:    aws.Int(quantity),
+		}
+	}
+
+	t.Run("should transfer the units", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		request := dto(otherUUID, 2)
+		expected := []*entities.PrizeStock{{StoreID: storeUUID}, {StoreID: otherUUID}}
+		mockService.On("TransferPrizeStock", request).Return(expected, nil)
+
+		statusCode, response := game.TransferPrizeStock(mockService, request)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject a transfer to the same store", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.TransferPrizeStock(mockService, dto(storeUUID, 2))
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "TransferPrizeStock", mock.Anything)
+	})
+
+	t.Run("should reject a negative quantity", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.TransferPrizeStock(mockService, dto(otherUUID, -1))
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "TransferPrizeStock", mock.Anything)
+	})
+
+	t.Run("should return the conflict of a source out of stock", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		request := dto(otherUUID, 20)
+		mockService.On("TransferPrizeStock", request).Return(nil, errors_domain_game.ErrPrizeOutOfStock)
+
+		statusCode, response := game.TransferPrizeStock(mockService, request)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrPrizeOutOfStock, response)
+	})
+}
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// SyncCaisse applies the issuances and redemptions queued by a caisse while it was offline
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoSync: *transfert.Sync the caisse and its queued operations
+//
+// Returns:
+// - int: the HTTP status
+// - any: the outcome of each operation on success, the error otherwise
+func SyncCaisse(service services.GameServiceInterface, dtoSync *transfert.Sync) (int, any) {
+	if err := dtoSync.Check(data.Validator{
+		"caisse_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	for _, operation := range dtoSync.Operations {
+		if operation == nil {
+			return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+		}
+
+		if err := operation.Check(data.Validator{
+			"id":          {validator.Required, validator.ID},
+			"type":        {validator.Required},
+			"occurred_at": {validator.Required, validator.Timestamp},
+		}); err != nil {
+			return err.Code(), err
+		}
+
+		switch *operation.Type {
+		case entities.SyncOperationIssue:
+			if err := operation.Check(data.Validator{
+				"receipt": {validator.Required},
+				"amount":  {validator.Required},
+			}); err != nil {
+				return err.Code(), err
+			}
+
+			if *operation.Amount <= 0 || *operation.Receipt == "" {
+				return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+			}
+		case entities.SyncOperationRedeem:
+			if err := operation.Check(data.Validator{
+				"ticket_id": {validator.Required, validator.ID},
+			}); err != nil {
+				return err.Code(), err
+			}
+		default:
+			return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+		}
+	}
+
+	results, err := service.SyncCaisse(dtoSync)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, results
+}
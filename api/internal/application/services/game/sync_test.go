@@ -0,0 +1,93 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncCaisse(t *testing.T) {
+	issue := func() *transfert.SyncOperation {
+		return &transfert.SyncOperation{
+			ID:         aws.String(prizeUUID),
+			Type:       aws.String(entities.SyncOperationIssue),
+			OccurredAt: aws.String("2024-03-01T10:00:00Z"),
+			Receipt:    aws.String("R-0001"),
+			Amount:     aws.Float64(54.9),
+		}
+	}
+
+	redeem := func() *transfert.SyncOperation {
+		return &transfert.SyncOperation{
+			ID:         aws.String(otherUUID),
+			Type:       aws.String(entities.SyncOperationRedeem),
+			OccurredAt: aws.String("2024-03-01T10:05:00Z"),
+			TicketID:   aws.String(shiftUUID),
+		}
+	}
+
+	t.Run("should apply the queued operations", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Sync{CaisseID: aws.String(storeUUID), Operations: []*transfert.SyncOperation{issue(), redeem()}}
+		expected := []*entities.SyncOperation{{ID: prizeUUID}, {ID: otherUUID}}
+		mockService.On("SyncCaisse", dto).Return(expected, nil)
+
+		statusCode, response := game.SyncCaisse(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should reject malformed operations", func(t *testing.T) {
+		malformed := map[string]func(*transfert.SyncOperation){
+			"missing id":        func(op *transfert.SyncOperation) { op.ID = nil },
+			"missing type":      func(op *transfert.SyncOperation) { op.Type = nil },
+			"unknown type":      func(op *transfert.SyncOperation) { op.Type = aws.String("void") },
+			"invalid timestamp": func(op *transfert.SyncOperation) { op.OccurredAt = aws.String("2024-03-01 10:00") },
+			"missing amount":    func(op *transfert.SyncOperation) { op.Amount = nil },
+			"negative amount":   func(op *transfert.SyncOperation) { op.Amount = aws.Float64(-1) },
+			"empty receipt":     func(op *transfert.SyncOperation) { op.Receipt = aws.String("") },
+			"redeem no ticket":  func(op *transfert.SyncOperation) { op.Type = aws.String(entities.SyncOperationRedeem) },
+		}
+
+		for name, alter := range malformed {
+			t.Run(name, func(t *testing.T) {
+				mockService := new(DomainGameService)
+				operation := issue()
+				alter(operation)
+
+				statusCode, _ := game.SyncCaisse(mockService, &transfert.Sync{CaisseID: aws.String(storeUUID), Operations: []*transfert.SyncOperation{operation}})
+
+				assert.Equal(t, http.StatusBadRequest, statusCode)
+				mockService.AssertNotCalled(t, "SyncCaisse", mock.Anything)
+			})
+		}
+	})
+
+	t.Run("should require the caisse", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.SyncCaisse(mockService, &transfert.Sync{Operations: []*transfert.SyncOperation{redeem()}})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "SyncCaisse", mock.Anything)
+	})
+
+	t.Run("should return error when the batch is too large", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("SyncCaisse", mock.Anything).Return(nil, errors_domain_game.ErrSyncTooManyOperations)
+
+		statusCode, response := game.SyncCaisse(mockService, &transfert.Sync{CaisseID: aws.String(storeUUID)})
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, statusCode)
+		assert.Equal(t, errors_domain_game.ErrSyncTooManyOperations, response)
+	})
+}
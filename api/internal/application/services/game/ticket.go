@@ -3,11 +3,36 @@ package game
 import (
 	"github.com/gofiber/fiber/v2"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 )
 
-func GetRandomTicket(service services.GameServiceInterface) (int, any) {
-	ticket, err := service.GetRandomTicket()
+// IssueTicket hands the next ticket of the pool over at a caisse for a purchase
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoIssuance: *transfert.Issuance the caisse, the receipt and the amount of the purchase
+//
+// Returns:
+// - int: the HTTP status
+// - any: the issued ticket on success, the error otherwise
+func IssueTicket(service services.GameServiceInterface, dtoIssuance *transfert.Issuance) (int, any) {
+	if err := dtoIssuance.Check(data.Validator{
+		"caisse_id": {validator.Required, validator.ID},
+		"receipt":   {validator.Required},
+		"amount":    {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if *dtoIssuance.Amount <= 0 || *dtoIssuance.Receipt == "" {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	ticket, err := service.IssueTicket(dtoIssuance)
 	if err != nil {
 		return err.Code(), err
 	}
@@ -15,8 +40,21 @@ func GetRandomTicket(service services.GameServiceInterface) (int, any) {
 	return fiber.StatusOK, ticket
 }
 
-func GetTickets(service services.GameServiceInterface) (int, any) {
-	tickets, err := service.GetTickets()
+// GetTickets lists a page of the tickets of the authenticated player
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - list: *database.List the page, the sort and the filters requested
+//
+// Returns:
+// - int: the HTTP status
+// - any: the page of tickets on success, the error otherwise
+func GetTickets(service services.GameServiceInterface, list *database.List) (int, any) {
+	if err := list.Check("status", "prize_id", "store_id", "campaign_id", "created_at", "claimed_at", "redeemed_at", "issued_at"); err != nil {
+		return err.Code(), err
+	}
+
+	tickets, err := service.GetTickets(list)
 	if err != nil {
 		return err.Code(), err
 	}
@@ -25,7 +63,14 @@ func GetTickets(service services.GameServiceInterface) (int, any) {
 }
 
 func UpdateTicket(service services.GameServiceInterface, dtoTicket *transfert.Ticket) (int, any) {
-	ticket, err := service.UpdateTicket(dtoTicket)
+	if err := dtoTicket.Check(data.Validator{
+		"token": {validator.Required, validator.TicketCode},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	// Only the printed code is kept, the ticket is claimed like through ClaimTicket
+	ticket, err := service.UpdateTicket(&transfert.Ticket{Token: dtoTicket.Token})
 
 	if err != nil {
 		return err.Code(), err
@@ -43,3 +88,128 @@ func GetTicketById(service services.GameServiceInterface, dtoTicket *transfert.T
 
 	return fiber.StatusOK, ticket
 }
+
+// GetTicketHistory lists the audit trail of a ticket, oldest event first
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoTicket: *transfert.Ticket the ticket whose history is requested
+//
+// Returns:
+// - int: the HTTP status
+// - any: the events of the ticket on success, the error otherwise
+func GetTicketHistory(service services.GameServiceInterface, dtoTicket *transfert.Ticket) (int, any) {
+	if err := dtoTicket.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	events, err := service.GetTicketHistory(dtoTicket)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, events
+}
+
+func RedeemTicket(service services.GameServiceInterface, dtoRedemption *transfert.Redemption) (int, any) {
+	if err := dtoRedemption.Check(data.Validator{
+		"ticket_id": {validator.Required, validator.ID},
+		"caisse_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	ticket, err := service.RedeemTicket(dtoRedemption)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, ticket
+}
+
+func ClaimTicket(service services.GameServiceInterface, dtoClaim *transfert.Claim) (int, any) {
+	if err := dtoClaim.Check(data.Validator{
+		"token": {validator.Required, validator.TicketCode},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	ticket, err := service.ClaimTicket(dtoClaim)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, ticket
+}
+
+func GetClaimAttempts(service services.GameServiceInterface, dtoAttempt *transfert.ClaimAttempt) (int, any) {
+	mandatory := data.Validator{}
+
+	if dtoAttempt.CredentialID != nil {
+		mandatory["credential_id"] = []data.Control{validator.ID}
+	}
+
+	if err := dtoAttempt.Check(mandatory); err != nil {
+		return err.Code(), err
+	}
+
+	attempts, err := service.GetClaimAttempts(dtoAttempt)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, attempts
+}
+
+// VoidTicket takes a damaged or misprinted ticket out of the game
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoVoid: *transfert.Void the ticket and the reason of the void
+//
+// Returns:
+// - int: the HTTP status
+// - any: the voided ticket on success, the error otherwise
+func VoidTicket(service services.GameServiceInterface, dtoVoid *transfert.Void) (int, any) {
+	if err := checkVoid(dtoVoid); err != nil {
+		return err.Code(), err
+	}
+
+	ticket, err := service.VoidTicket(dtoVoid)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, ticket
+}
+
+// ReissueTicket voids a ticket and replaces it under a fresh code
+//
+// Parameters:
+// - service: services.GameServiceInterface the game service
+// - dtoVoid: *transfert.Void the ticket and the reason of the void
+//
+// Returns:
+// - int: the HTTP status
+// - any: the replacement on success, the error otherwise
+func ReissueTicket(service services.GameServiceInterface, dtoVoid *transfert.Void) (int, any) {
+	if err := checkVoid(dtoVoid); err != nil {
+		return err.Code(), err
+	}
+
+	ticket, err := service.ReissueTicket(dtoVoid)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, ticket
+}
+
+func checkVoid(dtoVoid *transfert.Void) errors.ErrorInterface {
+	return dtoVoid.Check(data.Validator{
+		"ticket_id": {validator.Required, validator.ID},
+		"reason":    {validator.Required},
+	})
+}
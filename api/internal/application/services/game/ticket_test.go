@@ -9,97 +9,166 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/application/services/game"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetTickets(t *testing.T) {
 	t.Run("should return tickets successfully", func(t *testing.T) {
 		mockService := new(DomainGameService)
-		expectedTickets := []*entities.Ticket{}
-		mockService.On("GetTickets").Return(expectedTickets, nil)
+		expectedTickets := &database.Page[*entities.Ticket]{Items: []*entities.Ticket{}}
+		mockService.On("GetTickets", mock.Anything).Return(expectedTickets, nil)
 
-		statusCode, response := game.GetTickets(mockService)
+		statusCode, response := game.GetTickets(mockService, database.NewList(map[string]string{"sort": "-claimed_at", "filter[status]": "claimed"}))
 
 		assert.Equal(t, fiber.StatusOK, statusCode)
 		assert.Equal(t, expectedTickets, response)
-		mockService.AssertCalled(t, "GetTickets")
+		mockService.AssertCalled(t, "GetTickets", mock.Anything)
+	})
+
+	t.Run("should refuse a column that is not listed", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, response := game.GetTickets(mockService, database.NewList(map[string]string{"filter[credential_id]": "someone-else"}))
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Contains(t, response.(errors.Errors), "filter[credential_id]")
+		mockService.AssertNotCalled(t, "GetTickets", mock.Anything)
 	})
 
 	t.Run("should return error when service fails", func(t *testing.T) {
 		mockService := new(DomainGameService)
 		expectedError := errors.ErrBadRequest
-		mockService.On("GetTickets").Return(nil, expectedError)
+		mockService.On("GetTickets", mock.Anything).Return(nil, expectedError)
 
-		statusCode, response := game.GetTickets(mockService)
+		statusCode, response := game.GetTickets(mockService, database.NewList(nil))
 
 		assert.Equal(t, http.StatusBadRequest, statusCode)
 		assert.Error(t, response.(*errors.Error))
-		mockService.AssertCalled(t, "GetTickets")
+		mockService.AssertCalled(t, "GetTickets", mock.Anything)
 	})
 }
 
-func TestGetRandomTicket(t *testing.T) {
-	t.Run("should return ticket successfully", func(t *testing.T) {
+func TestIssueTicket(t *testing.T) {
+	dto := func() *transfert.Issuance {
+		return &transfert.Issuance{
+			CaisseID: aws.String("123e4567-e89b-12d3-a456-426614174001"),
+			Receipt:  aws.String("R-0001"),
+			Amount:   aws.Float64(54.9),
+		}
+	}
+
+	t.Run("should issue a ticket successfully", func(t *testing.T) {
 		mockService := new(DomainGameService)
-		expectedTicket := &entities.Ticket{}
-		mockService.On("GetRandomTicket").Return(expectedTicket, nil)
+		expectedTicket := &entities.Ticket{ID: "ticket-123"}
+		mockService.On("IssueTicket", dto()).Return(expectedTicket, nil)
 
-		statusCode, response := game.GetRandomTicket(mockService)
+		statusCode, response := game.IssueTicket(mockService, dto())
 
 		assert.Equal(t, fiber.StatusOK, statusCode)
 		assert.Equal(t, expectedTicket, response)
-		mockService.AssertCalled(t, "GetRandomTicket")
 	})
 
-	t.Run("should return error when service fails", func(t *testing.T) {
+	t.Run("should refuse an invalid caisse", func(t *testing.T) {
 		mockService := new(DomainGameService)
-		expectedError := errors.ErrBadRequest
-		mockService.On("GetRandomTicket").Return(nil, expectedError)
+		issuance := dto()
+		issuance.CaisseID = aws.String("caisse")
 
-		statusCode, response := game.GetRandomTicket(mockService)
+		statusCode, _ := game.IssueTicket(mockService, issuance)
 
 		assert.Equal(t, http.StatusBadRequest, statusCode)
-		assert.Error(t, response.(*errors.Error))
-		mockService.AssertCalled(t, "GetRandomTicket")
+		mockService.AssertNotCalled(t, "IssueTicket", mock.Anything)
+	})
+
+	t.Run("should refuse a missing or negative amount", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		issuance := dto()
+		issuance.Amount = nil
+
+		statusCode, _ := game.IssueTicket(mockService, issuance)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+
+		issuance.Amount = aws.Float64(-1)
+		statusCode, response := game.IssueTicket(mockService, issuance)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors.ErrBadRequest, response)
+
+		mockService.AssertNotCalled(t, "IssueTicket", mock.Anything)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("IssueTicket", dto()).Return(nil, errors_domain_game.ErrTicketAmountTooLow)
+
+		statusCode, response := game.IssueTicket(mockService, dto())
+
+		assert.Equal(t, http.StatusUnprocessableEntity, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTicketAmountTooLow, response)
 	})
 }
 
 func TestUpdateTicket(t *testing.T) {
-	t.Run("should update ticket successfully", func(t *testing.T) {
-		// Create a mock service
+	code, _ := token.Generate(12)
+
+	t.Run("should claim ticket successfully", func(t *testing.T) {
 		mockService := new(DomainGameService)
-		dtoTicket := &transfert.Ticket{}
-		updatedTicket := &entities.Ticket{ID: "1", Token: "updated-token"}
+		dtoTicket := &transfert.Ticket{Token: code.PointerString()}
+		claimedTicket := &entities.Ticket{ID: "1", Status: entities.TicketClaimed}
 
-		// Configure the mock to return the updated ticket
-		mockService.On("UpdateTicket", dtoTicket).Return(updatedTicket, nil)
+		mockService.On("UpdateTicket", dtoTicket).Return(claimedTicket, nil)
 
-		// Call the function under test
 		statusCode, response := game.UpdateTicket(mockService, dtoTicket)
 
-		// Assert the results
 		assert.Equal(t, fiber.StatusOK, statusCode)
-		assert.Equal(t, updatedTicket, response)
+		assert.Equal(t, claimedTicket, response)
 		mockService.AssertCalled(t, "UpdateTicket", dtoTicket)
 	})
 
-	t.Run("should return error when update fails", func(t *testing.T) {
-		// Create a mock service
+	t.Run("should only claim the ticket by its code", func(t *testing.T) {
 		mockService := new(DomainGameService)
-		dtoTicket := &transfert.Ticket{}
-		expectedError := errors.ErrBadRequest
+		dtoTicket := &transfert.Ticket{
+			ID:    aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			Token: code.PointerString(),
+		}
+		mockService.On("UpdateTicket", &transfert.Ticket{Token: dtoTicket.Token}).Return(&entities.Ticket{ID: *dtoTicket.ID}, nil)
 
-		// Configure the mock to return an error
-		mockService.On("UpdateTicket", dtoTicket).Return(nil, expectedError)
+		statusCode, _ := game.UpdateTicket(mockService, dtoTicket)
 
-		// Call the function under test
-		statusCode, response := game.UpdateTicket(mockService, dtoTicket)
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return error when token is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.UpdateTicket(mockService, &transfert.Ticket{ID: aws.String("123e4567-e89b-12d3-a456-426614174000")})
 
-		// Assert the results
 		assert.Equal(t, http.StatusBadRequest, statusCode)
-		assert.Equal(t, expectedError, response)
-		mockService.AssertCalled(t, "UpdateTicket", dtoTicket)
+		mockService.AssertNotCalled(t, "UpdateTicket")
+	})
+
+	t.Run("should reject a bad check digit before the service", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.UpdateTicket(mockService, &transfert.Ticket{Token: aws.String("000000000001")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "UpdateTicket")
+	})
+
+	t.Run("should return error when claims are locked", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{Token: code.PointerString()}
+		mockService.On("UpdateTicket", dtoTicket).Return(nil, errors_domain_game.ErrTicketClaimLocked)
+
+		statusCode, response := game.UpdateTicket(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusTooManyRequests, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTicketClaimLocked, response)
 	})
 }
 
@@ -130,3 +199,151 @@ func TestGetTicketById(t *testing.T) {
 		mockService.AssertCalled(t, "GetTicketById", dtoTicket)
 	})
 }
+
+func TestGetTicketHistory(t *testing.T) {
+	dtoTicket := &transfert.Ticket{ID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+
+	t.Run("should return the history of the ticket", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		events := []*entities.TicketEvent{{Action: entities.TicketEventIssued}, {Action: entities.TicketEventClaimed}}
+		mockService.On("GetTicketHistory", dtoTicket).Return(events, nil)
+
+		statusCode, response := game.GetTicketHistory(mockService, dtoTicket)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, events, response)
+	})
+
+	t.Run("should return error when the id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, response := game.GetTicketHistory(mockService, &transfert.Ticket{ID: aws.String("not-an-id")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(errors.ErrorInterface))
+		mockService.AssertNotCalled(t, "GetTicketHistory", mock.Anything)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("GetTicketHistory", dtoTicket).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		statusCode, response := game.GetTicketHistory(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, response)
+	})
+}
+
+func TestRedeemTicket(t *testing.T) {
+	dtoRedemption := &transfert.Redemption{
+		TicketID: aws.String("123e4567-e89b-12d3-a456-426614174000"),
+		CaisseID: aws.String("123e4567-e89b-12d3-a456-426614174001"),
+	}
+
+	t.Run("should redeem ticket successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		redeemedTicket := &entities.Ticket{ID: *dtoRedemption.TicketID, Status: entities.TicketRedeemed}
+		mockService.On("RedeemTicket", dtoRedemption).Return(redeemedTicket, nil)
+
+		statusCode, response := game.RedeemTicket(mockService, dtoRedemption)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, redeemedTicket, response)
+		mockService.AssertCalled(t, "RedeemTicket", dtoRedemption)
+	})
+
+	t.Run("should return error when caisse is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.RedeemTicket(mockService, &transfert.Redemption{TicketID: dtoRedemption.TicketID})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "RedeemTicket")
+	})
+
+	t.Run("should return error when redemption fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		expectedError := errors_domain_game.ErrTicketAlreadyRedeemed
+		mockService.On("RedeemTicket", dtoRedemption).Return(nil, expectedError)
+
+		statusCode, response := game.RedeemTicket(mockService, dtoRedemption)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, expectedError, response)
+	})
+}
+
+func TestVoidTicket(t *testing.T) {
+	dtoVoid := &transfert.Void{
+		TicketID: aws.String("123e4567-e89b-12d3-a456-426614174000"),
+		Reason:   aws.String("misprinted"),
+	}
+
+	t.Run("should void ticket successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		voided := &entities.Ticket{ID: *dtoVoid.TicketID, Status: entities.TicketVoided}
+		mockService.On("VoidTicket", dtoVoid).Return(voided, nil)
+
+		statusCode, response := game.VoidTicket(mockService, dtoVoid)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, voided, response)
+	})
+
+	t.Run("should return error when the reason is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.VoidTicket(mockService, &transfert.Void{TicketID: dtoVoid.TicketID})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "VoidTicket", mock.Anything)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("VoidTicket", dtoVoid).Return(nil, errors_domain_game.ErrTicketAlreadyRedeemed)
+
+		statusCode, response := game.VoidTicket(mockService, dtoVoid)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, response)
+	})
+}
+
+func TestReissueTicket(t *testing.T) {
+	dtoVoid := &transfert.Void{
+		TicketID: aws.String("123e4567-e89b-12d3-a456-426614174000"),
+		Reason:   aws.String("damaged"),
+	}
+
+	t.Run("should reissue ticket successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		replacement := &entities.Ticket{ID: "replacement", ReissuedFromID: dtoVoid.TicketID}
+		mockService.On("ReissueTicket", dtoVoid).Return(replacement, nil)
+
+		statusCode, response := game.ReissueTicket(mockService, dtoVoid)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, replacement, response)
+	})
+
+	t.Run("should return error when the ticket id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.ReissueTicket(mockService, &transfert.Void{TicketID: aws.String("invalid"), Reason: dtoVoid.Reason})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "ReissueTicket", mock.Anything)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("ReissueTicket", dtoVoid).Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.ReissueTicket(mockService, dtoVoid)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	serializer "github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+)
+
+// CreatePairing validates the caisse and generates the one-time pairing code of a new device
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoDevice: *transfert.Device the caisse and the label of the device
+//
+// Returns:
+// - int: the HTTP status
+// - any: the device waiting for its pairing on success, the error otherwise
+func CreatePairing(service services.StoreServiceInterface, dtoDevice *transfert.Device) (int, any) {
+	if err := dtoDevice.Check(data.Validator{
+		"caisse_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	device, err := service.CreatePairing(dtoDevice)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, device
+}
+
+// PairDevice validates the pairing code and hands the device its token
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoDevice: *transfert.Device the pairing code and the label of the device
+//
+// Returns:
+// - int: the HTTP status
+// - any: the device and its token on success, the error otherwise
+func PairDevice(service services.StoreServiceInterface, dtoDevice *transfert.Device) (int, any) {
+	if err := dtoDevice.Check(data.Validator{
+		"code": {validator.Required, validator.PairingCode},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	device, err := service.PairDevice(dtoDevice)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	accessToken, err := serializer.FromDevice(device.ID, map[string]any{
+		"caisse_id": device.CaisseID,
+		"store_id":  device.StoreID,
+	})
+
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, fiber.Map{
+		"device":       device,
+		"access_token": accessToken,
+	}
+}
+
+// GetDevices validates the store and lists its paired devices
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoDevice: *transfert.Device the store
+//
+// Returns:
+// - int: the HTTP status
+// - any: the devices on success, the error otherwise
+func GetDevices(service services.StoreServiceInterface, dtoDevice *transfert.Device) (int, any) {
+	if err := dtoDevice.Check(data.Validator{
+		"store_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	devices, err := service.GetDevices(dtoDevice)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, devices
+}
+
+// RevokeDevice validates the device and revokes its credential
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoDevice: *transfert.Device the device
+//
+// Returns:
+// - int: the HTTP status
+// - any: nil on success, the error otherwise
+func RevokeDevice(service services.StoreServiceInterface, dtoDevice *transfert.Device) (int, any) {
+	if err := dtoDevice.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.RevokeDevice(dtoDevice); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
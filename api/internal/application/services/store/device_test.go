@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/store"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestCreatePairing teste la fonction CreatePairing du package store
+func TestCreatePairing(t *testing.T) {
+	dto := &transfert.Device{CaisseID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+
+	t.Run("successful pairing code", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		expected := &entities.Device{ID: "device-123", CaisseID: dto.CaisseID}
+		mockService.On("CreatePairing", dto).Return(expected, nil)
+
+		statusCode, response := services.CreatePairing(mockService, dto)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("validation error - malformed caisse", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.CreatePairing(mockService, &transfert.Device{CaisseID: aws.String("caisse")})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "CreatePairing", mock.Anything)
+	})
+}
+
+// TestPairDevice teste la fonction PairDevice du package store
+func TestPairDevice(t *testing.T) {
+	assert.NoError(t, config.Load(aws.String("../../../../config.test.yml")))
+
+	code, err := token.Pairings().Generate()
+	assert.NoError(t, err)
+
+	dto := &transfert.Device{Code: code.PointerString()}
+
+	t.Run("successful pairing", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		device := &entities.Device{ID: "device-123", CaisseID: aws.String("caisse-123"), StoreID: aws.String("store-123")}
+		mockService.On("PairDevice", dto).Return(device, nil)
+
+		statusCode, response := services.PairDevice(mockService, dto)
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+
+		body := response.(fiber.Map)
+		assert.Equal(t, device, body["device"])
+
+		claims, err := jwt.TokenToClaims(body["access_token"].(string))
+		assert.Nil(t, err)
+		assert.Equal(t, "device-123", claims.ID)
+		assert.True(t, claims.IsDevice())
+	})
+
+	t.Run("validation error - malformed code", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.PairDevice(mockService, &transfert.Device{Code: aws.String("ABCDEFGH")})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "PairDevice", mock.Anything)
+	})
+
+	t.Run("service error - expired code", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		mockService.On("PairDevice", dto).Return(nil, errors_domain_store.ErrDevicePairingExpired)
+
+		statusCode, response := services.PairDevice(mockService, dto)
+
+		assert.Equal(t, 410, statusCode)
+		assert.Equal(t, errors_domain_store.ErrDevicePairingExpired, response)
+	})
+}
+
+// TestGetDevices teste la fonction GetDevices du package store
+func TestGetDevices(t *testing.T) {
+	dto := &transfert.Device{StoreID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+
+	t.Run("successful list", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		expected := []*entities.Device{{ID: "device-123", StoreID: dto.StoreID}}
+		mockService.On("GetDevices", dto).Return(expected, nil)
+
+		statusCode, response := services.GetDevices(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("validation error - missing store", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.GetDevices(mockService, &transfert.Device{})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "GetDevices", mock.Anything)
+	})
+}
+
+// TestRevokeDevice teste la fonction RevokeDevice du package store
+func TestRevokeDevice(t *testing.T) {
+	dto := &transfert.Device{ID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+
+	t.Run("successful revocation", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		mockService.On("RevokeDevice", dto).Return(nil)
+
+		statusCode, response := services.RevokeDevice(mockService, dto)
+
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+	})
+
+	t.Run("service error - already revoked", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		mockService.On("RevokeDevice", dto).Return(errors_domain_store.ErrDeviceRevoked)
+
+		statusCode, response := services.RevokeDevice(mockService, dto)
+
+		assert.Equal(t, 409, statusCode)
+		assert.Equal(t, errors_domain_store.ErrDeviceRevoked, response)
+	})
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// FindNearbyStores validates the position and lists the stores around it
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoNearby: *transfert.Nearby the position and optionally the radius in kilometers
+//
+// Returns:
+// - int: the HTTP status
+// - any: the stores on success, the error otherwise
+func FindNearbyStores(service services.StoreServiceInterface, dtoNearby *transfert.Nearby) (int, any) {
+	if err := dtoNearby.Check(data.Validator{
+		"lat": {validator.Required},
+		"lng": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if !isPosition(*dtoNearby.Latitude, *dtoNearby.Longitude) {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	if dtoNearby.Radius != nil && (*dtoNearby.Radius <= 0 || *dtoNearby.Radius > services.MaxRadius) {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	stores, err := service.FindNearbyStores(dtoNearby)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, stores
+}
+
+// UpdateStoreHours validates and replaces the weekly hours and the exceptions of a store
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoHours: *transfert.StoreHours the store, its weekly slots and its exceptions
+//
+// Returns:
+// - int: the HTTP status
+// - any: the store with its hours on success, the error otherwise
+func UpdateStoreHours(service services.StoreServiceInterface, dtoHours *transfert.StoreHours) (int, any) {
+	if err := dtoHours.Check(data.Validator{
+		"store_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	for _, opening := range dtoHours.Openings {
+		if err := opening.Check(data.Validator{
+			"weekday": {validator.Required},
+			"opens":   {validator.Required, validator.Time},
+			"closes":  {validator.Required, validator.Time},
+		}); err != nil {
+			return err.Code(), err
+		}
+
+		if *opening.Weekday < 0 || *opening.Weekday > 6 || *opening.Opens >= *opening.Closes {
+			return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+		}
+	}
+
+	days := make(map[string]bool)
+	for _, exception := range dtoHours.Exceptions {
+		mandatory := data.Validator{
+			"date": {validator.Required, validator.Date},
+		}
+
+		if exception.Opens != nil || exception.Closes != nil {
+			mandatory["opens"] = []data.Control{validator.Required, validator.Time}
+			mandatory["closes"] = []data.Control{validator.Required, validator.Time}
+		}
+
+		if err := exception.Check(mandatory); err != nil {
+			return err.Code(), err
+		}
+
+		if days[*exception.Date] || (exception.Opens != nil && *exception.Opens >= *exception.Closes) {
+			return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+		}
+
+		days[*exception.Date] = true
+	}
+
+	store, err := service.UpdateStoreHours(dtoHours)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, store
+}
@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/store"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestFindNearbyStores teste la fonction FindNearbyStores du package store
+func TestFindNearbyStores(t *testing.T) {
+	t.Run("successful search", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.Nearby{Latitude: aws.Float64(48.8566), Longitude: aws.Float64(2.3522), Radius: aws.Float64(5)}
+		expected := []*entities.NearbyStore{{Store: &entities.Store{ID: "store-1"}, Distance: 1.2, IsOpen: true}}
+		mockService.On("FindNearbyStores", dto).Return(expected, nil)
+
+		statusCode, response := services.FindNearbyStores(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	tests := []struct {
+		name string
+		dto  *transfert.Nearby
+	}{
+		{"validation error - missing longitude", &transfert.Nearby{Latitude: aws.Float64(48.8566)}},
+		{"validation error - latitude out of range", &transfert.Nearby{Latitude: aws.Float64(-91), Longitude: aws.Float64(2.3522)}},
+		{"validation error - longitude out of range", &transfert.Nearby{Latitude: aws.Float64(48.8566), Longitude: aws.Float64(181)}},
+		{"validation error - empty radius", &transfert.Nearby{Latitude: aws.Float64(48.8566), Longitude: aws.Float64(2.3522), Radius: aws.Float64(0)}},
+		{"validation error - radius too large", &transfert.Nearby{Latitude: aws.Float64(48.8566), Longitude: aws.Float64(2.3522), Radius: aws.Float64(500)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService, cleanup := setup()
+			defer cleanup()
+
+			statusCode, _ := services.FindNearbyStores(mockService, tt.dto)
+
+			assert.Equal(t, 400, statusCode)
+			mockService.AssertNotCalled(t, "FindNearbyStores", mock.Anything)
+		})
+	}
+}
+
+// TestUpdateStoreHours teste la fonction UpdateStoreHours du package store
+func TestUpdateStoreHours(t *testing.T) {
+	storeID := aws.String("123e4567-e89b-12d3-a456-426614174000")
+	opening := func(weekday int, opens, closes string) *transfert.Opening {
+		return &transfert.Opening{Weekday: aws.Int(weekday), Opens: aws.String(opens), Closes: aws.String(closes)}
+	}
+
+	t.Run("successful update", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.StoreHours{
+			StoreID:  storeID,
+			Openings: []*transfert.Opening{opening(1, "09:00", "12:00"), opening(1, "14:00", "18:00")},
+			Exceptions: []*transfert.OpeningException{
+				{Date: aws.String("2026-12-24"), Opens: aws.String("09:00"), Closes: aws.String("16:00")},
+				{Date: aws.String("2026-12-25"), Reason: aws.String("Christmas")},
+			},
+		}
+		expected := &entities.Store{ID: *storeID}
+		mockService.On("UpdateStoreHours", dto).Return(expected, nil)
+
+		statusCode, response := services.UpdateStoreHours(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("store not found", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.StoreHours{StoreID: storeID}
+		mockService.On("UpdateStoreHours", dto).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		statusCode, response := services.UpdateStoreHours(mockService, dto)
+
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound.Code(), statusCode)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, response)
+	})
+
+	tests := []struct {
+		name string
+		dto  *transfert.StoreHours
+	}{
+		{"validation error - invalid ID", &transfert.StoreHours{StoreID: aws.String("store")}},
+		{"validation error - unknown weekday", &transfert.StoreHours{StoreID: storeID, Openings: []*transfert.Opening{opening(7, "09:00", "18:00")}}},
+		{"validation error - malformed time", &transfert.StoreHours{StoreID: storeID, Openings: []*transfert.Opening{opening(1, "9h", "18:00")}}},
+		{"validation error - closes before it opens", &transfert.StoreHours{StoreID: storeID, Openings: []*transfert.Opening{opening(1, "18:00", "09:00")}}},
+		{"validation error - malformed date", &transfert.StoreHours{StoreID: storeID, Exceptions: []*transfert.OpeningException{{Date: aws.String("25/12/2026")}}}},
+		{"validation error - exception without closing time", &transfert.StoreHours{StoreID: storeID, Exceptions: []*transfert.OpeningException{{Date: aws.String("2026-12-24"), Opens: aws.String("09:00")}}}},
+		{"validation error - duplicated exception", &transfert.StoreHours{StoreID: storeID, Exceptions: []*transfert.OpeningException{{Date: aws.String("2026-12-25")}, {Date: aws.String("2026-12-25")}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService, cleanup := setup()
+			defer cleanup()
+
+			statusCode, _ := services.UpdateStoreHours(mockService, tt.dto)
+
+			assert.Equal(t, 400, statusCode)
+			mockService.AssertNotCalled(t, "UpdateStoreHours", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+// GetMemberships validates the store and lists the employees assigned to it
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoMembership: *transfert.Membership the store
+//
+// Returns:
+// - int: the HTTP status
+// - any: the memberships on success, the error otherwise
+func GetMemberships(service services.StoreServiceInterface, dtoMembership *transfert.Membership) (int, any) {
+	if err := dtoMembership.Check(data.Validator{
+		"store_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	memberships, err := service.GetMemberships(dtoMembership)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, memberships
+}
+
+// CreateMembership validates the store and the employee and assigns the employee to the store
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoMembership: *transfert.Membership the store and the credential of the employee
+//
+// Returns:
+// - int: the HTTP status
+// - any: the membership on success, the error otherwise
+func CreateMembership(service services.StoreServiceInterface, dtoMembership *transfert.Membership) (int, any) {
+	if err := dtoMembership.Check(data.Validator{
+		"store_id":      {validator.Required, validator.ID},
+		"credential_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	membership, err := service.CreateMembership(dtoMembership)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, membership
+}
+
+// DeleteMembership validates the store and the employee and removes the employee from the store
+//
+// Parameters:
+// - service: services.StoreServiceInterface the store service
+// - dtoMembership: *transfert.Membership the store and the credential of the employee
+//
+// Returns:
+// - int: the HTTP status
+// - any: nil on success, the error otherwise
+func DeleteMembership(service services.StoreServiceInterface, dtoMembership *transfert.Membership) (int, any) {
+	if err := dtoMembership.Check(data.Validator{
+		"store_id":      {validator.Required, validator.ID},
+		"credential_id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.DeleteMembership(dtoMembership); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
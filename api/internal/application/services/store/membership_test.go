@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/store"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestGetMemberships teste la fonction GetMemberships du package store
+func TestGetMemberships(t *testing.T) {
+	storeID := aws.String("123e4567-e89b-12d3-a456-426614174000")
+
+	t.Run("successful list", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.Membership{StoreID: storeID}
+		expected := []*entities.Membership{{StoreID: storeID, CredentialID: aws.String("employee-1")}}
+		mockService.On("GetMemberships", dto).Return(expected, nil)
+
+		statusCode, response := services.GetMemberships(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("validation error - malformed store", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.GetMemberships(mockService, &transfert.Membership{StoreID: aws.String("store")})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "GetMemberships", mock.Anything)
+	})
+}
+
+// TestCreateMembership teste la fonction CreateMembership du package store
+func TestCreateMembership(t *testing.T) {
+	dto := &transfert.Membership{
+		StoreID:      aws.String("123e4567-e89b-12d3-a456-426614174000"),
+		CredentialID: aws.String("123e4567-e89b-12d3-a456-426614174001"),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		expected := &entities.Membership{StoreID: dto.StoreID, CredentialID: dto.CredentialID}
+		mockService.On("CreateMembership", dto).Return(expected, nil)
+
+		statusCode, response := services.CreateMembership(mockService, dto)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("validation error - missing credential", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.CreateMembership(mockService, &transfert.Membership{StoreID: dto.StoreID})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "CreateMembership", mock.Anything)
+	})
+
+	t.Run("service error - already assigned", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		mockService.On("CreateMembership", dto).Return(nil, errors_domain_store.ErrMembershipAlreadyExists)
+
+		statusCode, response := services.CreateMembership(mockService, dto)
+
+		assert.Equal(t, 409, statusCode)
+		assert.Equal(t, errors_domain_store.ErrMembershipAlreadyExists, response)
+	})
+}
+
+// TestDeleteMembership teste la fonction DeleteMembership du package store
+func TestDeleteMembership(t *testing.T) {
+	dto := &transfert.Membership{
+		StoreID:      aws.String("123e4567-e89b-12d3-a456-426614174000"),
+		CredentialID: aws.String("123e4567-e89b-12d3-a456-426614174001"),
+	}
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		mockService.On("DeleteMembership", dto).Return(nil)
+
+		statusCode, response := services.DeleteMembership(mockService, dto)
+
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+	})
+
+	t.Run("service error - not assigned", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		mockService.On("DeleteMembership", dto).Return(errors_domain_store.ErrMembershipNotFound)
+
+		statusCode, response := services.DeleteMembership(mockService, dto)
+
+		assert.Equal(t, 404, statusCode)
+		assert.Equal(t, errors_domain_store.ErrMembershipNotFound, response)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -13,10 +14,10 @@ type MockStoreService struct {
 }
 
 // ListStores simule la méthode ListStores de StoreServiceInterface
-func (m *MockStoreService) ListStores() ([]*entities.Store, errors.ErrorInterface) {
-	args := m.Called()
+func (m *MockStoreService) ListStores(list *database.List) (*database.Page[*entities.Store], errors.ErrorInterface) {
+	args := m.Called(list)
 	if result := args.Get(0); result != nil {
-		return result.([]*entities.Store), nil
+		return result.(*database.Page[*entities.Store]), nil
 	}
 	return nil, args.Get(1).(errors.ErrorInterface)
 }
@@ -30,6 +31,51 @@ func (m *MockStoreService) GetStoreByID(dtoStore *transfert.Store) (*entities.St
 	return nil, args.Get(1).(errors.ErrorInterface)
 }
 
+// CreateStore simule la méthode CreateStore de StoreServiceInterface
+func (m *MockStoreService) CreateStore(dtoStore *transfert.Store) (*entities.Store, errors.ErrorInterface) {
+	args := m.Called(dtoStore)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.Store), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// UpdateStoreHours simule la méthode UpdateStoreHours de StoreServiceInterface
+func (m *MockStoreService) UpdateStoreHours(dtoHours *transfert.StoreHours) (*entities.Store, errors.ErrorInterface) {
+	args := m.Called(dtoHours)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.Store), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// FindNearbyStores simule la méthode FindNearbyStores de StoreServiceInterface
+func (m *MockStoreService) FindNearbyStores(dtoNearby *transfert.Nearby) ([]*entities.NearbyStore, errors.ErrorInterface) {
+	args := m.Called(dtoNearby)
+	if result := args.Get(0); result != nil {
+		return result.([]*entities.NearbyStore), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// UpdateStore simule la méthode UpdateStore de StoreServiceInterface
+func (m *MockStoreService) UpdateStore(dtoStore *transfert.Store) (*entities.Store, errors.ErrorInterface) {
+	args := m.Called(dtoStore)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.Store), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// DeleteStore simule la méthode DeleteStore de StoreServiceInterface
+func (m *MockStoreService) DeleteStore(dtoStore *transfert.Store) errors.ErrorInterface {
+	args := m.Called(dtoStore)
+	if err := args.Get(0); err != nil {
+		return err.(errors.ErrorInterface)
+	}
+	return nil
+}
+
 // GetCaisse simule la méthode GetCaisse de StoreServiceInterface
 func (m *MockStoreService) GetCaisse(dtoCaisse *transfert.Caisse) (*entities.Caisse, errors.ErrorInterface) {
 	args := m.Called(dtoCaisse)
@@ -67,6 +113,69 @@ func (m *MockStoreService) UpdateCaisse(dtoCaisse *transfert.Caisse) (*entities.
 	return nil, args.Get(1).(errors.ErrorInterface)
 }
 
+// GetMemberships simule la méthode GetMemberships de StoreServiceInterface
+func (m *MockStoreService) GetMemberships(dtoMembership *transfert.Membership) ([]*entities.Membership, errors.ErrorInterface) {
+	args := m.Called(dtoMembership)
+	if result := args.Get(0); result != nil {
+		return result.([]*entities.Membership), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// CreateMembership simule la méthode CreateMembership de StoreServiceInterface
+func (m *MockStoreService) CreateMembership(dtoMembership *transfert.Membership) (*entities.Membership, errors.ErrorInterface) {
+	args := m.Called(dtoMembership)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.Membership), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// DeleteMembership simule la méthode DeleteMembership de StoreServiceInterface
+func (m *MockStoreService) DeleteMembership(dtoMembership *transfert.Membership) errors.ErrorInterface {
+	args := m.Called(dtoMembership)
+	if err := args.Get(0); err != nil {
+		return err.(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreatePairing simule la méthode CreatePairing de StoreServiceInterface
+func (m *MockStoreService) CreatePairing(dtoDevice *transfert.Device) (*entities.Device, errors.ErrorInterface) {
+	args := m.Called(dtoDevice)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.Device), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// PairDevice simule la méthode PairDevice de StoreServiceInterface
+func (m *MockStoreService) PairDevice(dtoDevice *transfert.Device) (*entities.Device, errors.ErrorInterface) {
+	args := m.Called(dtoDevice)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.Device), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// GetDevices simule la méthode GetDevices de StoreServiceInterface
+func (m *MockStoreService) GetDevices(dtoDevice *transfert.Device) ([]*entities.Device, errors.ErrorInterface) {
+	args := m.Called(dtoDevice)
+	if result := args.Get(0); result != nil {
+		return result.([]*entities.Device), nil
+	}
+	return nil, args.Get(1).(errors.ErrorInterface)
+}
+
+// RevokeDevice simule la méthode RevokeDevice de StoreServiceInterface
+func (m *MockStoreService) RevokeDevice(dtoDevice *transfert.Device) errors.ErrorInterface {
+	args := m.Called(dtoDevice)
+	if err := args.Get(0); err != nil {
+		return err.(errors.ErrorInterface)
+	}
+	return nil
+}
+
 // setup initialise l'environnement de test en créant une instance du mock et en retournant une fonction de nettoyage
 //
 // Parameters:
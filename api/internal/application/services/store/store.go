@@ -1,20 +1,28 @@
 package services
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/application/validator"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/services"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 )
 
-func ListStores(service services.StoreServiceInterface) (int, any) {
-	credential, err := service.ListStores()
+func ListStores(service services.StoreServiceInterface, list *database.List) (int, any) {
+	if err := list.Check("label", "is_online", "created_at"); err != nil {
+		return err.Code(), err
+	}
+
+	stores, err := service.ListStores(list)
 	if err != nil {
 		return err.Code(), err
 	}
 
-	return fiber.StatusOK, credential
+	return fiber.StatusOK, stores
 }
 
 func GetStoreByID(service services.StoreServiceInterface, dtoStore *transfert.Store) (int, any) {
@@ -31,3 +39,94 @@ func GetStoreByID(service services.StoreServiceInterface, dtoStore *transfert.St
 
 	return fiber.StatusOK, store
 }
+
+func CreateStore(service services.StoreServiceInterface, dtoStore *transfert.Store) (int, any) {
+	if err := dtoStore.Check(data.Validator{
+		"label":     {validator.Required},
+		"is_online": {validator.Required, validator.IsBool},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if strings.TrimSpace(*dtoStore.Label) == "" {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	if err := checkLocation(dtoStore); err != nil {
+		return err.Code(), err
+	}
+
+	store, err := service.CreateStore(dtoStore)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, store
+}
+
+func UpdateStore(service services.StoreServiceInterface, dtoStore *transfert.Store) (int, any) {
+	mandatory := data.Validator{
+		"id": {validator.Required, validator.ID},
+	}
+
+	if dtoStore.IsOnline != nil {
+		mandatory["is_online"] = []data.Control{validator.IsBool}
+	}
+
+	if err := dtoStore.Check(mandatory); err != nil {
+		return err.Code(), err
+	}
+
+	if dtoStore.Label != nil && strings.TrimSpace(*dtoStore.Label) == "" {
+		return errors.ErrBadRequest.Code(), errors.ErrBadRequest
+	}
+
+	if err := checkLocation(dtoStore); err != nil {
+		return err.Code(), err
+	}
+
+	store, err := service.UpdateStore(dtoStore)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, store
+}
+
+func DeleteStore(service services.StoreServiceInterface, dtoStore *transfert.Store) (int, any) {
+	if err := dtoStore.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.DeleteStore(dtoStore); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
+
+// checkLocation validates the optional timezone and position of a store, both coordinates are set together
+func checkLocation(dtoStore *transfert.Store) errors.ErrorInterface {
+	if dtoStore.Timezone != nil {
+		if err := dtoStore.Check(data.Validator{"timezone": {validator.Timezone}}); err != nil {
+			return err
+		}
+	}
+
+	if (dtoStore.Latitude == nil) != (dtoStore.Longitude == nil) {
+		return errors.ErrBadRequest
+	}
+
+	if dtoStore.Latitude != nil && !isPosition(*dtoStore.Latitude, *dtoStore.Longitude) {
+		return errors.ErrBadRequest
+	}
+
+	return nil
+}
+
+// isPosition reports whether the coordinates are a valid position in degrees
+func isPosition(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
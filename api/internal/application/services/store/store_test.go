@@ -10,7 +10,9 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
 	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // TestListStores teste la fonction ListStores du package store
@@ -20,14 +22,14 @@ func TestListStores(t *testing.T) {
 		defer cleanup()
 
 		// Configuration du mock pour retourner une liste de magasins
-		expectedStores := []*entities.Store{
+		expectedStores := &database.Page[*entities.Store]{Items: []*entities.Store{
 			{ID: "store-123", Label: aws.String("Store One"), IsOnline: aws.Bool(true)},
 			{ID: "store-456", Label: aws.String("Store Two"), IsOnline: aws.Bool(false)},
-		}
-		mockService.On("ListStores").Return(expectedStores, nil)
+		}, Total: 2}
+		mockService.On("ListStores", mock.Anything).Return(expectedStores, nil)
 
 		// Appel de la fonction
-		statusCode, response := services.ListStores(mockService)
+		statusCode, response := services.ListStores(mockService, database.NewList(map[string]string{"sort": "label"}))
 
 		// Assertions
 		assert.Equal(t, fiber.StatusOK, statusCode)
@@ -40,16 +42,28 @@ func TestListStores(t *testing.T) {
 		defer cleanup()
 
 		// Configuration du mock pour retourner une erreur interne
-		mockService.On("ListStores").Return(nil, errors.ErrInternalServer)
+		mockService.On("ListStores", mock.Anything).Return(nil, errors.ErrInternalServer)
 
 		// Appel de la fonction
-		statusCode, response := services.ListStores(mockService)
+		statusCode, response := services.ListStores(mockService, database.NewList(nil))
 
 		// Assertions
 		assert.Equal(t, 500, statusCode)
 		assert.Equal(t, errors.ErrInternalServer, response)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("validation error - unknown sort", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		// Seules les colonnes publiques peuvent être triées
+		statusCode, _ := services.ListStores(mockService, database.NewList(map[string]string{"sort": "deleted_at"}))
+
+		// Assertions
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "ListStores", mock.Anything)
+	})
 }
 
 func TestGetStoreByID(t *testing.T) {
@@ -145,3 +159,150 @@ func TestGetStoreByID(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 }
+
+// TestCreateStore teste la fonction CreateStore du package store
+func TestCreateStore(t *testing.T) {
+	t.Run("successful creation", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.Store{Label: aws.String("Nice"), IsOnline: aws.Bool(false)}
+		expected := &entities.Store{ID: "store-123", Label: dto.Label, IsOnline: dto.IsOnline}
+		mockService.On("CreateStore", dto).Return(expected, nil)
+
+		statusCode, response := services.CreateStore(mockService, dto)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expected, response)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("validation error - missing kind", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.CreateStore(mockService, &transfert.Store{Label: aws.String("Nice")})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "CreateStore", mock.Anything)
+	})
+
+	t.Run("validation error - blank label", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.CreateStore(mockService, &transfert.Store{Label: aws.String("  "), IsOnline: aws.Bool(true)})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "CreateStore", mock.Anything)
+	})
+
+	t.Run("service error - label already used", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.Store{Label: aws.String("Nice"), IsOnline: aws.Bool(false)}
+		mockService.On("CreateStore", dto).Return(nil, errors_domain_store.ErrStoreAlreadyExists)
+
+		statusCode, response := services.CreateStore(mockService, dto)
+
+		assert.Equal(t, 409, statusCode)
+		assert.Equal(t, errors_domain_store.ErrStoreAlreadyExists, response)
+	})
+}
+
+// TestUpdateStore teste la fonction UpdateStore du package store
+func TestUpdateStore(t *testing.T) {
+	t.Run("successful update", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.Store{ID: aws.String("123e4567-e89b-12d3-a456-426614174000"), IsOnline: aws.Bool(true)}
+		expected := &entities.Store{ID: *dto.ID, IsOnline: dto.IsOnline}
+		mockService.On("UpdateStore", dto).Return(expected, nil)
+
+		statusCode, response := services.UpdateStore(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("validation error - invalid ID", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.UpdateStore(mockService, &transfert.Store{ID: aws.String("store")})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "UpdateStore", mock.Anything)
+	})
+
+	t.Run("validation error - blank label", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.UpdateStore(mockService, &transfert.Store{ID: aws.String("123e4567-e89b-12d3-a456-426614174000"), Label: aws.String("")})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "UpdateStore", mock.Anything)
+	})
+
+	t.Run("validation error - latitude without longitude", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.UpdateStore(mockService, &transfert.Store{ID: aws.String("123e4567-e89b-12d3-a456-426614174000"), Latitude: aws.Float64(48.85)})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "UpdateStore", mock.Anything)
+	})
+
+	t.Run("validation error - position out of range", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.UpdateStore(mockService, &transfert.Store{ID: aws.String("123e4567-e89b-12d3-a456-426614174000"), Latitude: aws.Float64(91), Longitude: aws.Float64(2.35)})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "UpdateStore", mock.Anything)
+	})
+
+	t.Run("validation error - unknown timezone", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		statusCode, _ := services.UpdateStore(mockService, &transfert.Store{ID: aws.String("123e4567-e89b-12d3-a456-426614174000"), Timezone: aws.String("Europe/Nowhere")})
+
+		assert.Equal(t, 400, statusCode)
+		mockService.AssertNotCalled(t, "UpdateStore", mock.Anything)
+	})
+}
+
+// TestDeleteStore teste la fonction DeleteStore du package store
+func TestDeleteStore(t *testing.T) {
+	t.Run("successful deletion", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.Store{ID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+		mockService.On("DeleteStore", dto).Return(nil)
+
+		statusCode, response := services.DeleteStore(mockService, dto)
+
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+	})
+
+	t.Run("service error - store not found", func(t *testing.T) {
+		mockService, cleanup := setup()
+		defer cleanup()
+
+		dto := &transfert.Store{ID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+		mockService.On("DeleteStore", dto).Return(errors_domain_store.ErrStoreNotFound)
+
+		statusCode, response := services.DeleteStore(mockService, dto)
+
+		assert.Equal(t, 404, statusCode)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, response)
+	})
+}
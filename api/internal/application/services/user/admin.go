@@ -0,0 +1,135 @@
+package services
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// ListUsers lists a page of the users for the admins
+//
+// Parameters:
+// - service: services.UserServiceInterface the user service
+// - list: *database.List the page, the sort and the filters requested
+//
+// Returns:
+// - int: the HTTP status
+// - any: the page of credentials on success, the error otherwise
+func ListUsers(service services.UserServiceInterface, list *database.List) (int, any) {
+	if err := list.Check("email", "role", "created_at"); err != nil {
+		return err.Code(), err
+	}
+
+	users, err := service.ListUsers(list)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, users
+}
+
+// PromoteUser moves a user one role up
+//
+// Parameters:
+// - service: services.UserServiceInterface the user service
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - int: the HTTP status
+// - any: the credential with its new role on success, the error otherwise
+func PromoteUser(service services.UserServiceInterface, dtoCredential *transfert.Credential) (int, any) {
+	return administrate(service.PromoteUser, dtoCredential)
+}
+
+// DemoteUser moves a user one role down
+//
+// Parameters:
+// - service: services.UserServiceInterface the user service
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - int: the HTTP status
+// - any: the credential with its new role on success, the error otherwise
+func DemoteUser(service services.UserServiceInterface, dtoCredential *transfert.Credential) (int, any) {
+	return administrate(service.DemoteUser, dtoCredential)
+}
+
+// DisableUser prevents a user from signing in
+//
+// Parameters:
+// - service: services.UserServiceInterface the user service
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - int: the HTTP status
+// - any: the disabled credential on success, the error otherwise
+func DisableUser(service services.UserServiceInterface, dtoCredential *transfert.Credential) (int, any) {
+	return administrate(service.DisableUser, dtoCredential)
+}
+
+// EnableUser allows a disabled user to sign in again
+//
+// Parameters:
+// - service: services.UserServiceInterface the user service
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - int: the HTTP status
+// - any: the enabled credential on success, the error otherwise
+func EnableUser(service services.UserServiceInterface, dtoCredential *transfert.Credential) (int, any) {
+	return administrate(service.EnableUser, dtoCredential)
+}
+
+// CreateAdmin registers an admin, or promotes the existing account of the email
+//
+// Parameters:
+// - service: services.UserServiceInterface the user service
+// - dtoCredential: *transfert.Credential the email and the password of the admin
+//
+// Returns:
+// - int: the HTTP status
+// - any: the credential of the admin on success, the error otherwise
+func CreateAdmin(service services.UserServiceInterface, dtoCredential *transfert.Credential) (int, any) {
+	if err := dtoCredential.Check(data.Validator{
+		"email":    {validator.Required, validator.Email},
+		"password": {validator.Required, validator.Password},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	credential, err := service.CreateAdmin(dtoCredential)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, credential
+}
+
+// administrate applies an admin action to the credential of a user
+//
+// Parameters:
+// - action: func(*transfert.Credential) (*entities.Credential, errors.ErrorInterface) the action of the user service
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - int: the HTTP status
+// - any: the updated credential on success, the error otherwise
+func administrate(action func(*transfert.Credential) (*entities.Credential, errors.ErrorInterface), dtoCredential *transfert.Credential) (int, any) {
+	if err := dtoCredential.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	credential, err := action(dtoCredential)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, credential
+}
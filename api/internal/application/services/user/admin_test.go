@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListUsers(t *testing.T) {
+	t.Run("should reject an unknown filter", func(t *testing.T) {
+		mockService := new(DomainUserService)
+
+		status, response := services.ListUsers(mockService, database.NewList(map[string]string{
+			"filter[password]": "secret",
+		}))
+
+		assert.Equal(t, fiber.StatusBadRequest, status)
+		assert.NotNil(t, response)
+		mockService.AssertNotCalled(t, "ListUsers", mock.Anything)
+	})
+
+	t.Run("should list the users", func(t *testing.T) {
+		mockService := new(DomainUserService)
+		list := database.NewList(map[string]string{"filter[role]": "admin"})
+		mockService.On("ListUsers", list).Return(&database.Page[*entities.Credential]{
+			Items: []*entities.Credential{{Role: security.ROLE_ADMIN}},
+			Total: 1,
+		}, nil)
+
+		status, response := services.ListUsers(mockService, list)
+
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Len(t, response.(*database.Page[*entities.Credential]).Items, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return the domain error", func(t *testing.T) {
+		mockService := new(DomainUserService)
+		mockService.On("ListUsers", mock.Anything).Return(nil, errors.ErrUnauthorized)
+
+		status, response := services.ListUsers(mockService, database.NewList(map[string]string{}))
+
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
+
+func TestAdministrateUser(t *testing.T) {
+	id := "42debee6-2063-4566-baf1-37a7bdd139ff"
+
+	actions := map[string]func(domain.UserServiceInterface, *transfert.Credential) (int, any){
+		"PromoteUser": services.PromoteUser,
+		"DemoteUser":  services.DemoteUser,
+		"DisableUser": services.DisableUser,
+		"EnableUser":  services.EnableUser,
+	}
+
+	for name, action := range actions {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should reject an invalid id", func(t *testing.T) {
+				mockService := new(DomainUserService)
+
+				status, response := action(mockService, &transfert.Credential{ID: aws.String("not-an-id")})
+
+				assert.Equal(t, fiber.StatusBadRequest, status)
+				assert.NotNil(t, response)
+				mockService.AssertNotCalled(t, name, mock.Anything)
+			})
+
+			t.Run("should return the credential", func(t *testing.T) {
+				mockService := new(DomainUserService)
+				mockService.On(name, &transfert.Credential{ID: &id}).Return(&entities.Credential{ID: id}, nil)
+
+				status, response := action(mockService, &transfert.Credential{ID: &id})
+
+				assert.Equal(t, fiber.StatusOK, status)
+				assert.Equal(t, id, response.(*entities.Credential).ID)
+				mockService.AssertExpectations(t)
+			})
+
+			t.Run("should return the domain error", func(t *testing.T) {
+				mockService := new(DomainUserService)
+				mockService.On(name, mock.Anything).Return(nil, errors_domain_user.ErrCredentialSelf)
+
+				status, response := action(mockService, &transfert.Credential{ID: &id})
+
+				assert.Equal(t, fiber.StatusConflict, status)
+				assert.Equal(t, errors_domain_user.ErrCredentialSelf, response)
+			})
+		})
+	}
+}
+
+func TestCreateAdmin(t *testing.T) {
+	email := "admin@thetiptop.fr"
+	password := "ValidP@ssw0rd"
+
+	t.Run("should reject a weak password", func(t *testing.T) {
+		mockService := new(DomainUserService)
+
+		status, response := services.CreateAdmin(mockService, &transfert.Credential{
+			Email:    &email,
+			Password: aws.String("short"),
+		})
+
+		assert.Equal(t, fiber.StatusBadRequest, status)
+		assert.NotNil(t, response)
+		mockService.AssertNotCalled(t, "CreateAdmin", mock.Anything)
+	})
+
+	t.Run("should create the admin", func(t *testing.T) {
+		mockService := new(DomainUserService)
+		mockService.On("CreateAdmin", mock.AnythingOfType("*transfert.Credential")).
+			Return(&entities.Credential{Email: &email, Role: security.ROLE_ADMIN}, nil)
+
+		status, response := services.CreateAdmin(mockService, &transfert.Credential{
+			Email:    &email,
+			Password: &password,
+		})
+
+		assert.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, security.ROLE_ADMIN, response.(*entities.Credential).Role)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return the domain error", func(t *testing.T) {
+		mockService := new(DomainUserService)
+		mockService.On("CreateAdmin", mock.Anything).Return(nil, errors_domain_user.ErrCredentialNotValid)
+
+		status, _ := services.CreateAdmin(mockService, &transfert.Credential{
+			Email:    &email,
+			Password: &password,
+		})
+
+		assert.Equal(t, errors_domain_user.ErrCredentialNotValid.Code(), status)
+	})
+}
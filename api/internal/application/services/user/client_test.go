@@ -154,7 +154,7 @@ func TestMailValidation(t *testing.T) {
 	// Chargement de la configuration
 	config.Load(aws.String("../../../config.test.yml"))
 
-	luhn := token.Generate(6)
+	luhn, _ := token.Generate(6)
 	email := "valid.email@example.com"
 
 	// Cas de validation réussie
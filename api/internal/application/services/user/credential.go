@@ -37,7 +37,7 @@ func UserAuth(service services.UserServiceInterface, credentialDTO *transfert.Cr
 	}
 }
 
-func UserAuthRenew(refresh *serializer.Token) (int, any) {
+func UserAuthRenew(service services.UserServiceInterface, refresh *serializer.Token) (int, any) {
 	var err errors.ErrorInterface = errors.ErrAuthInvalidToken
 	if refresh == nil {
 		return err.Code(), err
@@ -52,7 +52,15 @@ func UserAuthRenew(refresh *serializer.Token) (int, any) {
 		return err.Code(), err
 	}
 
-	accessToken, refreshToken, err := serializer.FromID(refresh.ID, refresh.Data)
+	// The credential is checked again, a disabled user cannot renew their tokens and a new role applies
+	credentialID, role, err := service.UserAuthRenew(&transfert.Credential{ID: &refresh.ID})
+	if err != nil {
+		return err.Code(), err
+	}
+
+	accessToken, refreshToken, err := serializer.FromID(*credentialID, map[string]any{
+		"role": role,
+	})
 	if err != nil {
 		return err.Code(), err
 	}
@@ -140,7 +140,7 @@ func TestUserAuthRenew(t *testing.T) {
 		t.Parallel()
 
 		// Null token
-		statusCode, response := services.UserAuthRenew(nil)
+		statusCode, response := services.UserAuthRenew(new(DomainUserService), nil)
 
 		assert.Equal(t, fiber.StatusBadRequest, statusCode)
 		errObj, ok := response.(*errors.Error)
@@ -158,7 +158,7 @@ func TestUserAuthRenew(t *testing.T) {
 			Type: jwt.ACCESS, // WRONG type
 		}
 
-		statusCode, response := services.UserAuthRenew(invalidToken)
+		statusCode, response := services.UserAuthRenew(new(DomainUserService), invalidToken)
 		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
 
 		errObj, ok := response.(*errors.Error)
@@ -177,7 +177,7 @@ func TestUserAuthRenew(t *testing.T) {
 			Exp:  time.Now().Add(-1 * time.Hour).Unix(),
 		}
 
-		statusCode, response := services.UserAuthRenew(expiredToken)
+		statusCode, response := services.UserAuthRenew(new(DomainUserService), expiredToken)
 		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
 
 		errObj, ok := response.(*errors.Error)
@@ -197,7 +197,10 @@ func TestUserAuthRenew(t *testing.T) {
 			Exp:  time.Now().Add(1 * time.Hour).Unix(),
 		}
 
-		statusCode, response := services.UserAuthRenew(validToken)
+		mockService := new(DomainUserService)
+		mockService.On("UserAuthRenew", &transfert.Credential{ID: &validToken.ID}).Return(&validToken.ID, security.ROLE_ADMIN, nil)
+
+		statusCode, response := services.UserAuthRenew(mockService, validToken)
 		assert.Equal(t, fiber.StatusOK, statusCode)
 		assert.NotNil(t, response)
 
@@ -206,7 +209,29 @@ func TestUserAuthRenew(t *testing.T) {
 		if ok {
 			assert.NotNil(t, respMap["access_token"])
 			assert.NotNil(t, respMap["refresh_token"])
+
+			// Le rôle du nouveau jeton est celui de l'identifiant, pas celui du jeton renouvelé
+			claims, err := jwt.TokenToClaims(respMap["access_token"].(string))
+			assert.Nil(t, err)
+			assert.Equal(t, string(security.ROLE_ADMIN), claims.Data["role"])
+		}
+	})
+
+	t.Run("disabled credential", func(t *testing.T) {
+		t.Parallel()
+
+		validToken := &jwt.Token{
+			Type: jwt.REFRESH,
+			ID:   "disabled-client-id",
+			Exp:  time.Now().Add(1 * time.Hour).Unix(),
 		}
+
+		mockService := new(DomainUserService)
+		mockService.On("UserAuthRenew", mock.Anything).Return(nil, "", errors_domain_user.ErrCredentialDisabled)
+
+		statusCode, response := services.UserAuthRenew(mockService, validToken)
+		assert.Equal(t, fiber.StatusForbidden, statusCode)
+		assert.Equal(t, errors_domain_user.ErrCredentialDisabled, response)
 	})
 }
 
@@ -221,7 +246,7 @@ func TestUserAuthRenew(t *testing.T) {
 func TestCredentialUpdate(t *testing.T) {
 	config.Load(aws.String("../../../config.test.yml"))
 
-	luhn := token.Generate(6)
+	luhn, _ := token.Generate(6)
 
 	t.Run("invalid token syntax", func(t *testing.T) {
 		t.Parallel()
@@ -7,6 +7,7 @@ import (
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -58,6 +59,15 @@ func (dcs *DomainUserService) UserAuth(obj *transfert.Credential) (*string, secu
 	return args.Get(0).(*string), args.Get(1).(security.Role), nil
 }
 
+func (dcs *DomainUserService) UserAuthRenew(obj *transfert.Credential) (*string, security.Role, errors.ErrorInterface) {
+	args := dcs.Called(obj)
+	if args.Get(0) == nil {
+		return nil, "", args.Get(2).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*string), args.Get(1).(security.Role), nil
+}
+
 func (dcs *DomainUserService) MailValidation(validation *transfert.Validation, credential *transfert.Credential) (*entities.Validation, errors.ErrorInterface) {
 	args := dcs.Called(validation, credential)
 	if args.Get(0) == nil {
@@ -149,3 +159,51 @@ func (dcs *DomainUserService) UpdateEmployee(dtoEmployee *transfert.Employee) (*
 	}
 	return args.Get(0).(*entities.Employee), nil
 }
+
+func (dcs *DomainUserService) ListUsers(list *database.List) (*database.Page[*entities.Credential], errors.ErrorInterface) {
+	args := dcs.Called(list)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*database.Page[*entities.Credential]), nil
+}
+
+func (dcs *DomainUserService) PromoteUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	args := dcs.Called(dtoCredential)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Credential), nil
+}
+
+func (dcs *DomainUserService) DemoteUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	args := dcs.Called(dtoCredential)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Credential), nil
+}
+
+func (dcs *DomainUserService) DisableUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	args := dcs.Called(dtoCredential)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Credential), nil
+}
+
+func (dcs *DomainUserService) EnableUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	args := dcs.Called(dtoCredential)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Credential), nil
+}
+
+func (dcs *DomainUserService) CreateAdmin(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	args := dcs.Called(dtoCredential)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Credential), nil
+}
@@ -0,0 +1,52 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Device struct {
+	ID        *string `json:"id" xml:"id" form:"id"`
+	Label     *string `json:"label" xml:"label" form:"label"`
+	Code      *string `json:"code" xml:"code" form:"code"`
+	CaisseID  *string `json:"caisse_id" xml:"caisse_id" form:"caisse_id"`
+	StoreID   *string `json:"store_id" xml:"store_id" form:"store_id"`
+	CreatedBy *string `json:"created_by" xml:"created_by" form:"created_by"`
+}
+
+func (c *Device) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":         c.ID,
+		"label":      c.Label,
+		"code":       c.Code,
+		"caisse_id":  c.CaisseID,
+		"store_id":   c.StoreID,
+		"created_by": c.CreatedBy,
+	})
+}
+
+func NewDevice(obj data.Object, mandatory data.Validator) (*Device, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Device{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
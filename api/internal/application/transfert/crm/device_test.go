@@ -0,0 +1,46 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func TestNewDevice(t *testing.T) {
+	mandatory := data.Validator{
+		"caisse_id": {validator.Required, validator.ID},
+	}
+
+	device, err := transfert.NewDevice(nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, device)
+
+	device, err = transfert.NewDevice(data.Object{}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, device)
+
+	device, err = transfert.NewDevice(data.Object{
+		"caisse_id": aws.String("387f3fb0-88a0-4e2f-bc82-529719e5ed21"),
+		"label":     aws.String("Comptoir"),
+	}, mandatory)
+	assert.NoError(t, err)
+	assert.Equal(t, "Comptoir", *device.Label)
+
+	device, err = transfert.NewDevice(data.Object{
+		"label": aws.String("Comptoir"),
+	}, mandatory)
+	assert.Error(t, err)
+	assert.Nil(t, device)
+}
+
+func TestDevice_Check(t *testing.T) {
+	device := &transfert.Device{Code: aws.String("code")}
+
+	assert.Error(t, device.Check(data.Validator{"code": {validator.PairingCode}}))
+	assert.NoError(t, device.Check(data.Validator{"label": {}}))
+}
@@ -0,0 +1,48 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Opening struct {
+	Weekday *int    `json:"weekday" xml:"weekday" form:"weekday"`
+	Opens   *string `json:"opens" xml:"opens" form:"opens"`
+	Closes  *string `json:"closes" xml:"closes" form:"closes"`
+}
+
+func (c *Opening) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"weekday": c.Weekday,
+		"opens":   c.Opens,
+		"closes":  c.Closes,
+	})
+}
+
+type OpeningException struct {
+	Date   *string `json:"date" xml:"date" form:"date"`
+	Opens  *string `json:"opens" xml:"opens" form:"opens"`
+	Closes *string `json:"closes" xml:"closes" form:"closes"`
+	Reason *string `json:"reason" xml:"reason" form:"reason"`
+}
+
+func (c *OpeningException) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"date":   c.Date,
+		"opens":  c.Opens,
+		"closes": c.Closes,
+		"reason": c.Reason,
+	})
+}
+
+type StoreHours struct {
+	StoreID    *string             `json:"store_id" xml:"store_id" form:"store_id"`
+	Openings   []*Opening          `json:"openings" xml:"openings" form:"openings"`
+	Exceptions []*OpeningException `json:"exceptions" xml:"exceptions" form:"exceptions"`
+}
+
+func (c *StoreHours) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"store_id": c.StoreID,
+	})
+}
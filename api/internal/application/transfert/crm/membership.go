@@ -0,0 +1,44 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Membership struct {
+	StoreID      *string `json:"store_id" xml:"store_id" form:"store_id"`
+	CredentialID *string `json:"credential_id" xml:"credential_id" form:"credential_id"`
+}
+
+func (c *Membership) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"store_id":      c.StoreID,
+		"credential_id": c.CredentialID,
+	})
+}
+
+func NewMembership(obj data.Object, mandatory data.Validator) (*Membership, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Membership{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
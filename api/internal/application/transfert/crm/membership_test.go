@@ -0,0 +1,47 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func TestNewMembership(t *testing.T) {
+	mandatory := data.Validator{
+		"store_id":      {validator.Required, validator.ID},
+		"credential_id": {validator.Required, validator.ID},
+	}
+
+	membership, err := transfert.NewMembership(nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, membership)
+
+	membership, err = transfert.NewMembership(data.Object{}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, membership)
+
+	membership, err = transfert.NewMembership(data.Object{
+		"store_id":      aws.String("387f3fb0-88a0-4e2f-bc82-529719e5ed21"),
+		"credential_id": aws.String("387f3fb0-88a0-4e2f-bc82-529719e5ed22"),
+	}, mandatory)
+	assert.NoError(t, err)
+	assert.Equal(t, "387f3fb0-88a0-4e2f-bc82-529719e5ed22", *membership.CredentialID)
+
+	membership, err = transfert.NewMembership(data.Object{
+		"store_id": aws.String("387f3fb0-88a0-4e2f-bc82-529719e5ed21"),
+	}, mandatory)
+	assert.Error(t, err)
+	assert.Nil(t, membership)
+}
+
+func TestMembership_Check(t *testing.T) {
+	membership := &transfert.Membership{StoreID: aws.String("store")}
+
+	assert.Error(t, membership.Check(data.Validator{"store_id": {validator.ID}}))
+	assert.NoError(t, membership.Check(data.Validator{"credential_id": {}}))
+}
@@ -0,0 +1,20 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Nearby struct {
+	Latitude  *float64 `json:"lat" xml:"lat" form:"lat" query:"lat"`
+	Longitude *float64 `json:"lng" xml:"lng" form:"lng" query:"lng"`
+	Radius    *float64 `json:"radius" xml:"radius" form:"radius" query:"radius"`
+}
+
+func (c *Nearby) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"lat":    c.Latitude,
+		"lng":    c.Longitude,
+		"radius": c.Radius,
+	})
+}
@@ -0,0 +1,18 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type PairingAttempt struct {
+	IP   *string `json:"ip" xml:"ip" form:"ip"`
+	Code *string `json:"code" xml:"code" form:"code"`
+}
+
+func (c *PairingAttempt) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"ip":   c.IP,
+		"code": c.Code,
+	})
+}
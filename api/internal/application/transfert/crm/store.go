@@ -6,9 +6,13 @@ import (
 )
 
 type Store struct {
-	ID       *string `json:"id" xml:"id" form:"id"`
-	Label    *string `json:"label" xml:"label" form:"label"`
-	IsOnline *bool   `json:"is_online" xml:"is_online" form:"is_online"`
+	ID        *string  `json:"id" xml:"id" form:"id"`
+	Label     *string  `json:"label" xml:"label" form:"label"`
+	IsOnline  *bool    `json:"is_online" xml:"is_online" form:"is_online"`
+	Address   *string  `json:"address" xml:"address" form:"address"`
+	Latitude  *float64 `json:"latitude" xml:"latitude" form:"latitude"`
+	Longitude *float64 `json:"longitude" xml:"longitude" form:"longitude"`
+	Timezone  *string  `json:"timezone" xml:"timezone" form:"timezone"`
 }
 
 func (c *Store) Check(validator data.Validator) errors.ErrorInterface {
@@ -16,6 +20,10 @@ func (c *Store) Check(validator data.Validator) errors.ErrorInterface {
 		"id":        c.ID,
 		"label":     c.Label,
 		"is_online": c.IsOnline,
+		"address":   c.Address,
+		"latitude":  c.Latitude,
+		"longitude": c.Longitude,
+		"timezone":  c.Timezone,
 	})
 }
 
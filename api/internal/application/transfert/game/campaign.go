@@ -0,0 +1,52 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Campaign struct {
+	ID         *string `json:"id" xml:"id" form:"id"`
+	Label      *string `json:"label" xml:"label" form:"label"`
+	Start      *string `json:"start" xml:"start" form:"start"`
+	End        *string `json:"end" xml:"end" form:"end"`
+	ClaimUntil *string `json:"claim_until" xml:"claim_until" form:"claim_until"`
+	Timezone   *string `json:"timezone" xml:"timezone" form:"timezone"`
+}
+
+func (c *Campaign) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":          c.ID,
+		"label":       c.Label,
+		"start":       c.Start,
+		"end":         c.End,
+		"claim_until": c.ClaimUntil,
+		"timezone":    c.Timezone,
+	})
+}
+
+func NewCampaign(obj data.Object, mandatory data.Validator) (*Campaign, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Campaign{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
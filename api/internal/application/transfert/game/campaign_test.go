@@ -0,0 +1,60 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCampaign(t *testing.T) {
+	mandatory := data.Validator{
+		"label":       {validator.Required},
+		"start":       {validator.Required, validator.DateTime},
+		"end":         {validator.Required, validator.DateTime},
+		"claim_until": {validator.Required, validator.DateTime},
+		"timezone":    {validator.Required, validator.Timezone},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		campaign, err := transfert.NewCampaign(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, campaign)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		campaign, err := transfert.NewCampaign(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, campaign)
+	})
+
+	t.Run("Valid campaign", func(t *testing.T) {
+		campaign, err := transfert.NewCampaign(data.Object{
+			"label":       aws.String("Ouverture Nice"),
+			"start":       aws.String("2024-11-01 00:00:00"),
+			"end":         aws.String("2024-11-30 23:59:59"),
+			"claim_until": aws.String("2024-12-30 23:59:59"),
+			"timezone":    aws.String("Europe/Paris"),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Europe/Paris", *campaign.Timezone)
+		assert.Nil(t, campaign.Check(mandatory))
+	})
+
+	t.Run("Invalid campaign - bad timezone", func(t *testing.T) {
+		campaign, err := transfert.NewCampaign(data.Object{
+			"label":       aws.String("Ouverture Nice"),
+			"start":       aws.String("2024-11-01 00:00:00"),
+			"end":         aws.String("2024-11-30 23:59:59"),
+			"claim_until": aws.String("2024-12-30 23:59:59"),
+			"timezone":    aws.String("Mars/Olympus"),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, campaign)
+	})
+}
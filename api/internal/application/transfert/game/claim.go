@@ -0,0 +1,66 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Claim struct {
+	Token     *string `json:"token" xml:"token" form:"token"`
+	Expires   *string `json:"expires" xml:"expires" form:"expires"`
+	Signature *string `json:"signature" xml:"signature" form:"signature"`
+	IP        *string `json:"-" xml:"-" form:"-"`
+}
+
+func (c *Claim) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"token":     c.Token,
+		"expires":   c.Expires,
+		"signature": c.Signature,
+		"ip":        c.IP,
+	})
+}
+
+func NewClaim(obj data.Object, mandatory data.Validator) (*Claim, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Claim{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+type ClaimAttempt struct {
+	ID           *string `json:"id" xml:"id" form:"id"`
+	CredentialID *string `json:"credential_id" xml:"credential_id" form:"credential_id"`
+	IP           *string `json:"ip" xml:"ip" form:"ip"`
+	Token        *string `json:"token" xml:"token" form:"token"`
+	Reason       *string `json:"reason" xml:"reason" form:"reason"`
+}
+
+func (c *ClaimAttempt) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":            c.ID,
+		"credential_id": c.CredentialID,
+		"ip":            c.IP,
+		"token":         c.Token,
+		"reason":        c.Reason,
+	})
+}
@@ -0,0 +1,55 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClaim(t *testing.T) {
+	mandatory := data.Validator{
+		"token": {validator.Required, validator.Luhn},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		claim, err := transfert.NewClaim(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, claim)
+	})
+
+	t.Run("Valid claim", func(t *testing.T) {
+		code, _ := token.Generate(12)
+		claim, err := transfert.NewClaim(data.Object{
+			"token": code.PointerString(),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, claim.Token)
+		assert.Nil(t, claim.Check(mandatory))
+	})
+
+	t.Run("Invalid claim - bad check digit", func(t *testing.T) {
+		claim, err := transfert.NewClaim(data.Object{
+			"token": aws.String("000000000001"),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, claim)
+	})
+
+	t.Run("IP is never read from the payload", func(t *testing.T) {
+		code, _ := token.Generate(12)
+		claim, err := transfert.NewClaim(data.Object{
+			"token": code.PointerString(),
+			"ip":    aws.String("127.0.0.1"),
+		}, nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, claim.IP)
+	})
+}
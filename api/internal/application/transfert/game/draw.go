@@ -0,0 +1,50 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Draw struct {
+	ID           *string `json:"id" xml:"id" form:"id"`
+	Commitment   *string `json:"commitment" xml:"commitment" form:"commitment"`
+	Seed         *string `json:"seed" xml:"seed" form:"seed"`
+	Count        *int    `json:"count" xml:"count" form:"count"`
+	CredentialID *string `json:"credential_id" xml:"credential_id" form:"credential_id"`
+}
+
+func (c *Draw) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":            c.ID,
+		"commitment":    c.Commitment,
+		"seed":          c.Seed,
+		"count":         c.Count,
+		"credential_id": c.CredentialID,
+	})
+}
+
+func NewDraw(obj data.Object, mandatory data.Validator) (*Draw, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Draw{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
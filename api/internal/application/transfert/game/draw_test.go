@@ -0,0 +1,51 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDraw(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		draw, err := transfert.NewDraw(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, draw)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		draw, err := transfert.NewDraw(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, draw)
+	})
+
+	t.Run("Valid draw", func(t *testing.T) {
+		draw, err := transfert.NewDraw(data.Object{
+			"commitment": aws.String("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"),
+			"count":      aws.Int(2),
+		}, data.Validator{
+			"commitment": {validator.Required, validator.SHA256},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, *draw.Count)
+		assert.NoError(t, draw.Check(data.Validator{
+			"commitment": {validator.Required, validator.SHA256},
+		}))
+	})
+
+	t.Run("Invalid draw - missing commitment", func(t *testing.T) {
+		draw, err := transfert.NewDraw(data.Object{
+			"seed": aws.String("seed"),
+		}, data.Validator{
+			"commitment": {validator.Required},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, draw)
+	})
+}
@@ -0,0 +1,46 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type TicketExport struct {
+	Format  *string `json:"format" xml:"format" form:"format"`
+	StoreID *string `json:"store_id" xml:"store_id" form:"store_id"`
+	Count   *int    `json:"count" xml:"count" form:"count"`
+}
+
+func (c *TicketExport) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"format":   c.Format,
+		"store_id": c.StoreID,
+		"count":    c.Count,
+	})
+}
+
+func NewTicketExport(obj data.Object, mandatory data.Validator) (*TicketExport, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &TicketExport{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
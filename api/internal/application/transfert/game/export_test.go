@@ -0,0 +1,51 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTicketExport(t *testing.T) {
+	mandatory := data.Validator{
+		"format":   {validator.Required, validator.SheetFormat},
+		"store_id": {validator.ID},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		export, err := transfert.NewTicketExport(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, export)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		export, err := transfert.NewTicketExport(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, export)
+	})
+
+	t.Run("Valid export", func(t *testing.T) {
+		export, err := transfert.NewTicketExport(data.Object{
+			"format":   aws.String("pdf"),
+			"store_id": aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			"count":    aws.Int(100),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100, *export.Count)
+		assert.Nil(t, export.Check(mandatory))
+	})
+
+	t.Run("Invalid export - unknown format", func(t *testing.T) {
+		export, err := transfert.NewTicketExport(data.Object{
+			"format": aws.String("xls"),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, export)
+	})
+}
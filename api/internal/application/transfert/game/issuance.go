@@ -0,0 +1,46 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Issuance struct {
+	CaisseID *string  `json:"caisse_id" xml:"caisse_id" form:"caisse_id"`
+	Receipt  *string  `json:"receipt" xml:"receipt" form:"receipt"`
+	Amount   *float64 `json:"amount" xml:"amount" form:"amount"`
+}
+
+func (c *Issuance) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"caisse_id": c.CaisseID,
+		"receipt":   c.Receipt,
+		"amount":    c.Amount,
+	})
+}
+
+func NewIssuance(obj data.Object, mandatory data.Validator) (*Issuance, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Issuance{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,53 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIssuance(t *testing.T) {
+	mandatory := data.Validator{
+		"caisse_id": {validator.Required, validator.ID},
+		"receipt":   {validator.Required},
+		"amount":    {validator.Required},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		issuance, err := transfert.NewIssuance(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, issuance)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		issuance, err := transfert.NewIssuance(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, issuance)
+	})
+
+	t.Run("Valid issuance", func(t *testing.T) {
+		issuance, err := transfert.NewIssuance(data.Object{
+			"caisse_id": aws.String("123e4567-e89b-12d3-a456-426614174001"),
+			"receipt":   aws.String("R-0001"),
+			"amount":    aws.Float64(54.9),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 54.9, *issuance.Amount)
+		assert.Nil(t, issuance.Check(mandatory))
+	})
+
+	t.Run("Invalid issuance - missing receipt", func(t *testing.T) {
+		issuance, err := transfert.NewIssuance(data.Object{
+			"caisse_id": aws.String("123e4567-e89b-12d3-a456-426614174001"),
+			"amount":    aws.Float64(54.9),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, issuance)
+	})
+}
@@ -0,0 +1,54 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Prize struct {
+	ID          *string  `json:"id" xml:"id" form:"id"`
+	Code        *string  `json:"code" xml:"code" form:"code"`
+	Label       *string  `json:"label" xml:"label" form:"label"`
+	Description *string  `json:"description" xml:"description" form:"description"`
+	Value       *float64 `json:"value" xml:"value" form:"value"`
+	Image       *string  `json:"image" xml:"image" form:"image"`
+	Percentage  *int     `json:"percentage" xml:"percentage" form:"percentage"`
+}
+
+func (c *Prize) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":          c.ID,
+		"code":        c.Code,
+		"label":       c.Label,
+		"description": c.Description,
+		"value":       c.Value,
+		"image":       c.Image,
+		"percentage":  c.Percentage,
+	})
+}
+
+func NewPrize(obj data.Object, mandatory data.Validator) (*Prize, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Prize{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,92 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type PrizeStock struct {
+	ID        *string `json:"id" xml:"id" form:"id"`
+	PrizeID   *string `json:"prize_id" xml:"prize_id" form:"prize_id"`
+	StoreID   *string `json:"store_id" xml:"store_id" form:"store_id"`
+	Quantity  *int    `json:"quantity" xml:"quantity" form:"quantity"`
+	Threshold *int    `json:"threshold" xml:"threshold" form:"threshold"`
+}
+
+func (c *PrizeStock) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":        c.ID,
+		"prize_id":  c.PrizeID,
+		"store_id":  c.StoreID,
+		"quantity":  c.Quantity,
+		"threshold": c.Threshold,
+	})
+}
+
+func NewPrizeStock(obj data.Object, mandatory data.Validator) (*PrizeStock, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &PrizeStock{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+type StockTransfer struct {
+	PrizeID     *string `json:"prize_id" xml:"prize_id" form:"prize_id"`
+	FromStoreID *string `json:"from_store_id" xml:"from_store_id" form:"from_store_id"`
+	ToStoreID   *string `json:"to_store_id" xml:"to_store_id" form:"to_store_id"`
+	Quantity    *int    `json:"quantity" xml:"quantity" form:"quantity"`
+}
+
+func (c *StockTransfer) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"prize_id":      c.PrizeID,
+		"from_store_id": c.FromStoreID,
+		"to_store_id":   c.ToStoreID,
+		"quantity":      c.Quantity,
+	})
+}
+
+func NewStockTransfer(obj data.Object, mandatory data.Validator) (*StockTransfer, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &StockTransfer{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,92 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPrizeStock(t *testing.T) {
+	mandatory := data.Validator{
+		"prize_id": {validator.Required, validator.ID},
+		"store_id": {validator.Required, validator.ID},
+		"quantity": {validator.Required},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		stock, err := transfert.NewPrizeStock(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, stock)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		stock, err := transfert.NewPrizeStock(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, stock)
+	})
+
+	t.Run("Valid stock", func(t *testing.T) {
+		stock, err := transfert.NewPrizeStock(data.Object{
+			"prize_id": aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			"store_id": aws.String("123e4567-e89b-12d3-a456-426614174001"),
+			"quantity": aws.Int(12),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 12, *stock.Quantity)
+		assert.Nil(t, stock.Check(mandatory))
+	})
+
+	t.Run("Invalid stock - missing store", func(t *testing.T) {
+		stock, err := transfert.NewPrizeStock(data.Object{
+			"prize_id": aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			"quantity": aws.Int(12),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, stock)
+	})
+}
+
+func TestNewStockTransfer(t *testing.T) {
+	mandatory := data.Validator{
+		"prize_id":      {validator.Required, validator.ID},
+		"from_store_id": {validator.Required, validator.ID},
+		"to_store_id":   {validator.Required, validator.ID},
+		"quantity":      {validator.Required},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		transfer, err := transfert.NewStockTransfer(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, transfer)
+	})
+
+	t.Run("Valid transfer", func(t *testing.T) {
+		transfer, err := transfert.NewStockTransfer(data.Object{
+			"prize_id":      aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			"from_store_id": aws.String("123e4567-e89b-12d3-a456-426614174001"),
+			"to_store_id":   aws.String("123e4567-e89b-12d3-a456-426614174002"),
+			"quantity":      aws.Int(3),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *transfer.Quantity)
+		assert.Nil(t, transfer.Check(mandatory))
+	})
+
+	t.Run("Invalid transfer - missing destination", func(t *testing.T) {
+		transfer, err := transfert.NewStockTransfer(data.Object{
+			"prize_id":      aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			"from_store_id": aws.String("123e4567-e89b-12d3-a456-426614174001"),
+			"quantity":      aws.Int(3),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, transfer)
+	})
+}
@@ -0,0 +1,57 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPrize(t *testing.T) {
+	mandatory := data.Validator{
+		"code":       {validator.Required},
+		"label":      {validator.Required},
+		"value":      {validator.Required},
+		"percentage": {validator.Required},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		prize, err := transfert.NewPrize(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, prize)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		prize, err := transfert.NewPrize(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, prize)
+	})
+
+	t.Run("Valid prize", func(t *testing.T) {
+		prize, err := transfert.NewPrize(data.Object{
+			"code":       aws.String("infuser"),
+			"label":      aws.String("Infuseur à thé"),
+			"value":      aws.Float64(8.5),
+			"percentage": aws.Int(60),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8.5, *prize.Value)
+		assert.Equal(t, 60, *prize.Percentage)
+		assert.Nil(t, prize.Check(mandatory))
+	})
+
+	t.Run("Invalid prize - missing percentage", func(t *testing.T) {
+		prize, err := transfert.NewPrize(data.Object{
+			"code":  aws.String("infuser"),
+			"label": aws.String("Infuseur à thé"),
+			"value": aws.Float64(8.5),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, prize)
+	})
+}
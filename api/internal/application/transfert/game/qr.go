@@ -0,0 +1,46 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type TicketQR struct {
+	Token  *string `json:"token" xml:"token" form:"token"`
+	Format *string `json:"format" xml:"format" form:"format"`
+	Size   *int    `json:"size" xml:"size" form:"size"`
+}
+
+func (c *TicketQR) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"token":  c.Token,
+		"format": c.Format,
+		"size":   c.Size,
+	})
+}
+
+func NewTicketQR(obj data.Object, mandatory data.Validator) (*TicketQR, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &TicketQR{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
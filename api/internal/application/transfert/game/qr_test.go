@@ -0,0 +1,52 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTicketQR(t *testing.T) {
+	mandatory := data.Validator{
+		"token":  {validator.Required, validator.Luhn},
+		"format": {validator.Required, validator.QRFormat},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		qr, err := transfert.NewTicketQR(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, qr)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		qr, err := transfert.NewTicketQR(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, qr)
+	})
+
+	t.Run("Valid QR code", func(t *testing.T) {
+		qr, err := transfert.NewTicketQR(data.Object{
+			"token":  aws.String("79927398713"),
+			"format": aws.String("svg"),
+			"size":   aws.Int(512),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 512, *qr.Size)
+		assert.Nil(t, qr.Check(mandatory))
+	})
+
+	t.Run("Invalid QR code - unknown format", func(t *testing.T) {
+		qr, err := transfert.NewTicketQR(data.Object{
+			"token":  aws.String("79927398713"),
+			"format": aws.String("gif"),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, qr)
+	})
+}
@@ -0,0 +1,44 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Redemption struct {
+	TicketID *string `json:"ticket_id" xml:"ticket_id" form:"ticket_id"`
+	CaisseID *string `json:"caisse_id" xml:"caisse_id" form:"caisse_id"`
+}
+
+func (c *Redemption) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"ticket_id": c.TicketID,
+		"caisse_id": c.CaisseID,
+	})
+}
+
+func NewRedemption(obj data.Object, mandatory data.Validator) (*Redemption, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Redemption{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
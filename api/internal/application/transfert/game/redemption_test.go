@@ -0,0 +1,50 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRedemption(t *testing.T) {
+	mandatory := data.Validator{
+		"ticket_id": {validator.Required, validator.ID},
+		"caisse_id": {validator.Required, validator.ID},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		redemption, err := transfert.NewRedemption(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, redemption)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		redemption, err := transfert.NewRedemption(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, redemption)
+	})
+
+	t.Run("Valid redemption", func(t *testing.T) {
+		redemption, err := transfert.NewRedemption(data.Object{
+			"ticket_id": aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			"caisse_id": aws.String("123e4567-e89b-12d3-a456-426614174001"),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123e4567-e89b-12d3-a456-426614174001", *redemption.CaisseID)
+		assert.Nil(t, redemption.Check(mandatory))
+	})
+
+	t.Run("Invalid redemption - missing caisse", func(t *testing.T) {
+		redemption, err := transfert.NewRedemption(data.Object{
+			"ticket_id": aws.String("123e4567-e89b-12d3-a456-426614174000"),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, redemption)
+	})
+}
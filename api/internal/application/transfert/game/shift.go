@@ -0,0 +1,60 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Shift struct {
+	ID       *string `json:"id" xml:"id" form:"id"`
+	CaisseID *string `json:"caisse_id" xml:"caisse_id" form:"caisse_id"`
+	StoreID  *string `json:"store_id" xml:"store_id" form:"store_id"`
+	OpenedBy *string `json:"opened_by" xml:"opened_by" form:"opened_by"`
+}
+
+func (c *Shift) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":        c.ID,
+		"caisse_id": c.CaisseID,
+		"store_id":  c.StoreID,
+		"opened_by": c.OpenedBy,
+	})
+}
+
+func NewShift(obj data.Object, mandatory data.Validator) (*Shift, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Shift{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+type ShiftReport struct {
+	ShiftID *string `json:"shift_id" xml:"shift_id" form:"shift_id"`
+	Format  *string `json:"format" xml:"format" form:"format"`
+}
+
+func (c *ShiftReport) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"shift_id": c.ShiftID,
+		"format":   c.Format,
+	})
+}
@@ -0,0 +1,57 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShift(t *testing.T) {
+	mandatory := data.Validator{
+		"caisse_id": {validator.Required, validator.ID},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		shift, err := transfert.NewShift(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, shift)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		shift, err := transfert.NewShift(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, shift)
+	})
+
+	t.Run("Valid shift", func(t *testing.T) {
+		shift, err := transfert.NewShift(data.Object{
+			"caisse_id": aws.String("123e4567-e89b-12d3-a456-426614174000"),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", *shift.CaisseID)
+		assert.Nil(t, shift.Check(mandatory))
+	})
+
+	t.Run("Invalid shift - malformed caisse", func(t *testing.T) {
+		shift, err := transfert.NewShift(data.Object{
+			"caisse_id": aws.String("caisse"),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, shift)
+	})
+}
+
+func TestShiftReport_Check(t *testing.T) {
+	mandatory := data.Validator{
+		"shift_id": {validator.Required, validator.ID},
+	}
+
+	assert.Nil(t, (&transfert.ShiftReport{ShiftID: aws.String("123e4567-e89b-12d3-a456-426614174000")}).Check(mandatory))
+	assert.NotNil(t, (&transfert.ShiftReport{Format: aws.String("csv")}).Check(mandatory))
+}
@@ -0,0 +1,52 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Statistics struct {
+	By         *string `json:"by" xml:"by" form:"by"`
+	From       *string `json:"from" xml:"from" form:"from"`
+	To         *string `json:"to" xml:"to" form:"to"`
+	StoreID    *string `json:"store_id" xml:"store_id" form:"store_id"`
+	PrizeID    *string `json:"prize_id" xml:"prize_id" form:"prize_id"`
+	CampaignID *string `json:"campaign_id" xml:"campaign_id" form:"campaign_id"`
+}
+
+func (c *Statistics) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"by":          c.By,
+		"from":        c.From,
+		"to":          c.To,
+		"store_id":    c.StoreID,
+		"prize_id":    c.PrizeID,
+		"campaign_id": c.CampaignID,
+	})
+}
+
+func NewStatistics(obj data.Object, mandatory data.Validator) (*Statistics, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Statistics{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
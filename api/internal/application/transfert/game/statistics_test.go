@@ -0,0 +1,54 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStatistics(t *testing.T) {
+	mandatory := data.Validator{
+		"by":   {validator.Required},
+		"from": {validator.Date},
+		"to":   {validator.Date},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		statistics, err := transfert.NewStatistics(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, statistics)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		statistics, err := transfert.NewStatistics(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, statistics)
+	})
+
+	t.Run("Valid statistics", func(t *testing.T) {
+		statistics, err := transfert.NewStatistics(data.Object{
+			"by":   aws.String("prize"),
+			"from": aws.String("2024-11-01"),
+			"to":   aws.String("2024-11-30"),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "prize", *statistics.By)
+		assert.Nil(t, statistics.Check(mandatory))
+	})
+
+	t.Run("Invalid statistics - malformed day", func(t *testing.T) {
+		statistics, err := transfert.NewStatistics(data.Object{
+			"by":   aws.String("prize"),
+			"from": aws.String("01/11/2024"),
+			"to":   aws.String("2024-11-30"),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, statistics)
+	})
+}
@@ -0,0 +1,48 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// SyncOperation is an issuance or a redemption queued by a caisse while offline
+// The ID is generated by the caisse and makes the upload idempotent.
+type SyncOperation struct {
+	ID           *string  `json:"id" xml:"id" form:"id"`
+	Type         *string  `json:"type" xml:"type" form:"type"`
+	OccurredAt   *string  `json:"occurred_at" xml:"occurred_at" form:"occurred_at"`
+	Receipt      *string  `json:"receipt" xml:"receipt" form:"receipt"`
+	Amount       *float64 `json:"amount" xml:"amount" form:"amount"`
+	TicketID     *string  `json:"ticket_id" xml:"ticket_id" form:"ticket_id"`
+	CaisseID     *string  `json:"caisse_id" xml:"caisse_id" form:"caisse_id"`
+	Status       *string  `json:"status" xml:"status" form:"status"`
+	Error        *string  `json:"error" xml:"error" form:"error"`
+	CredentialID *string  `json:"credential_id" xml:"credential_id" form:"credential_id"`
+}
+
+func (c *SyncOperation) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":            c.ID,
+		"type":          c.Type,
+		"occurred_at":   c.OccurredAt,
+		"receipt":       c.Receipt,
+		"amount":        c.Amount,
+		"ticket_id":     c.TicketID,
+		"caisse_id":     c.CaisseID,
+		"status":        c.Status,
+		"error":         c.Error,
+		"credential_id": c.CredentialID,
+	})
+}
+
+// Sync is the batch of operations uploaded by a caisse when it is back online
+type Sync struct {
+	CaisseID   *string          `json:"caisse_id" xml:"caisse_id" form:"caisse_id"`
+	Operations []*SyncOperation `json:"operations" xml:"operations" form:"operations"`
+}
+
+func (c *Sync) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"caisse_id": c.CaisseID,
+	})
+}
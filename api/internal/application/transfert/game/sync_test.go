@@ -0,0 +1,51 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync_Check(t *testing.T) {
+	mandatory := data.Validator{
+		"caisse_id": {validator.Required, validator.ID},
+	}
+
+	t.Run("Valid sync", func(t *testing.T) {
+		sync := &transfert.Sync{CaisseID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+		assert.Nil(t, sync.Check(mandatory))
+	})
+
+	t.Run("Invalid sync - missing caisse", func(t *testing.T) {
+		sync := &transfert.Sync{Operations: []*transfert.SyncOperation{{}}}
+		assert.NotNil(t, sync.Check(mandatory))
+	})
+}
+
+func TestSyncOperation_Check(t *testing.T) {
+	mandatory := data.Validator{
+		"id":          {validator.Required, validator.ID},
+		"occurred_at": {validator.Required, validator.Timestamp},
+	}
+
+	t.Run("Valid operation", func(t *testing.T) {
+		operation := &transfert.SyncOperation{
+			ID:         aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			Type:       aws.String("issue"),
+			OccurredAt: aws.String("2024-05-01T10:00:00+02:00"),
+		}
+		assert.Nil(t, operation.Check(mandatory))
+	})
+
+	t.Run("Invalid operation - local time without offset", func(t *testing.T) {
+		operation := &transfert.SyncOperation{
+			ID:         aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			OccurredAt: aws.String("2024-05-01 10:00:00"),
+		}
+		assert.NotNil(t, operation.Check(mandatory))
+	})
+}
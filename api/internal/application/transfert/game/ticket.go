@@ -7,17 +7,21 @@ import (
 
 type Ticket struct {
 	ID           *string `json:"id" xml:"id" form:"id"`
-	Prize        *string `json:"prize" xml:"prize" form:"prize"`
+	PrizeID      *string `json:"prize_id" xml:"prize_id" form:"prize_id"`
 	CredentialID *string `json:"credential_id" xml:"credential_id" form:"credential_id"`
 	Token        *string `json:"token" xml:"token" form:"token"`
+	CampaignID   *string `json:"campaign_id" xml:"campaign_id" form:"campaign_id"`
+	StoreID      *string `json:"store_id" xml:"store_id" form:"store_id"`
 }
 
-func (c *Ticket) Check(validator data.Validator) error {
+func (c *Ticket) Check(validator data.Validator) errors.ErrorInterface {
 	return validator.Check(data.Object{
 		"id":            c.ID,
-		"prize":         c.Prize,
+		"prize_id":      c.PrizeID,
 		"credential_id": c.CredentialID,
 		"token":         c.Token,
+		"campaign_id":   c.CampaignID,
+		"store_id":      c.StoreID,
 	})
 }
 
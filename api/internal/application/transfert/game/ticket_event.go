@@ -0,0 +1,28 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type TicketEvent struct {
+	ID           *string `json:"id" xml:"id" form:"id"`
+	TicketID     *string `json:"ticket_id" xml:"ticket_id" form:"ticket_id"`
+	Action       *string `json:"action" xml:"action" form:"action"`
+	CredentialID *string `json:"credential_id" xml:"credential_id" form:"credential_id"`
+	Role         *string `json:"role" xml:"role" form:"role"`
+	IP           *string `json:"ip" xml:"ip" form:"ip"`
+	UserAgent    *string `json:"user_agent" xml:"user_agent" form:"user_agent"`
+}
+
+func (c *TicketEvent) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":            c.ID,
+		"ticket_id":     c.TicketID,
+		"action":        c.Action,
+		"credential_id": c.CredentialID,
+		"role":          c.Role,
+		"ip":            c.IP,
+		"user_agent":    c.UserAgent,
+	})
+}
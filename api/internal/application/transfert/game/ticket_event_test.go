@@ -0,0 +1,27 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketEvent_Check(t *testing.T) {
+	mandatory := data.Validator{
+		"ticket_id": {validator.Required, validator.ID},
+	}
+
+	t.Run("Valid event", func(t *testing.T) {
+		event := &transfert.TicketEvent{TicketID: aws.String("123e4567-e89b-12d3-a456-426614174000")}
+		assert.Nil(t, event.Check(mandatory))
+	})
+
+	t.Run("Invalid event - malformed ticket", func(t *testing.T) {
+		event := &transfert.TicketEvent{TicketID: aws.String("ticket")}
+		assert.NotNil(t, event.Check(mandatory))
+	})
+}
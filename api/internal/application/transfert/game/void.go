@@ -0,0 +1,44 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Void struct {
+	TicketID *string `json:"ticket_id" xml:"ticket_id" form:"ticket_id"`
+	Reason   *string `json:"reason" xml:"reason" form:"reason"`
+}
+
+func (c *Void) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"ticket_id": c.TicketID,
+		"reason":    c.Reason,
+	})
+}
+
+func NewVoid(obj data.Object, mandatory data.Validator) (*Void, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Void{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
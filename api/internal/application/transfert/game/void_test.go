@@ -0,0 +1,50 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVoid(t *testing.T) {
+	mandatory := data.Validator{
+		"ticket_id": {validator.Required, validator.ID},
+		"reason":    {validator.Required},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		void, err := transfert.NewVoid(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, void)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		void, err := transfert.NewVoid(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, void)
+	})
+
+	t.Run("Valid void", func(t *testing.T) {
+		void, err := transfert.NewVoid(data.Object{
+			"ticket_id": aws.String("123e4567-e89b-12d3-a456-426614174000"),
+			"reason":    aws.String("misprinted"),
+		}, mandatory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "misprinted", *void.Reason)
+		assert.Nil(t, void.Check(mandatory))
+	})
+
+	t.Run("Invalid void - missing reason", func(t *testing.T) {
+		void, err := transfert.NewVoid(data.Object{
+			"ticket_id": aws.String("123e4567-e89b-12d3-a456-426614174000"),
+		}, mandatory)
+
+		assert.Error(t, err)
+		assert.Nil(t, void)
+	})
+}
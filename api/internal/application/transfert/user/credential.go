@@ -13,6 +13,7 @@ type Credential struct {
 
 func (c *Credential) Check(validator data.Validator) errors.ErrorInterface {
 	return validator.Check(data.Object{
+		"id":       c.ID,
 		"email":    c.Email,
 		"password": c.Password,
 	})
@@ -13,7 +13,7 @@ import (
 
 func TestNewValidation(t *testing.T) {
 
-	luhn := token.Generate(6)
+	luhn, _ := token.Generate(6)
 	fakeLuhn := token.NewLuhn("123456")
 
 	tests := []struct {
@@ -1,12 +1,17 @@
 package validator
 
 import (
+	"encoding/hex"
 	"net/mail"
 	"reflect"
+	"time"
+	_ "time/tzdata"
 	"unicode"
 
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
 )
 
 const (
@@ -55,6 +60,70 @@ func Luhn(value any, name string) errors.ErrorInterface {
 	return luhn.Validate()
 }
 
+// TicketCode verifies a ticket code was produced by the configured generator
+func TicketCode(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	return token.Tickets().Validate(token.Luhn(*str))
+}
+
+// PairingCode verifies a pairing code of a caisse device was produced by the pairing generator
+func PairingCode(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	return token.Pairings().Validate(token.Luhn(*str))
+}
+
+// SheetFormat verifies the value is a format of sheet, CSV or PDF
+func SheetFormat(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if *str != sheet.CSV && *str != sheet.PDF {
+		return errors.ErrValueIsNotSheetFormat
+	}
+
+	return nil
+}
+
+// QRFormat verifies the value is a format of QR code image, PNG or SVG
+func QRFormat(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if *str != qr.PNG && *str != qr.SVG {
+		return errors.ErrValueIsNotQRFormat
+	}
+
+	return nil
+}
+
 func ID(value any, name string) errors.ErrorInterface {
 	if err := Required(value, name); err != nil {
 		return err
@@ -73,6 +142,115 @@ func ID(value any, name string) errors.ErrorInterface {
 	return nil
 }
 
+func SHA256(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if len(*str) != 64 {
+		return errors.ErrValueIsNotSHA256
+	}
+
+	if _, err := hex.DecodeString(*str); err != nil {
+		return errors.ErrValueIsNotSHA256
+	}
+
+	return nil
+}
+
+func DateTime(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if _, err := time.Parse(time.DateTime, *str); err != nil {
+		return errors.ErrValueIsNotDate
+	}
+
+	return nil
+}
+
+// Timestamp verifies the value is an instant with its offset, formatted as RFC 3339
+func Timestamp(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if _, err := time.Parse(time.RFC3339, *str); err != nil {
+		return errors.ErrValueIsNotDate
+	}
+
+	return nil
+}
+
+// Date verifies the value is a day, formatted as 2006-01-02
+func Date(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if _, err := time.Parse(time.DateOnly, *str); err != nil {
+		return errors.ErrValueIsNotDate
+	}
+
+	return nil
+}
+
+// Time verifies the value is a time of day, formatted as 15:04
+func Time(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if _, err := time.Parse("15:04", *str); err != nil {
+		return errors.ErrValueIsNotTime
+	}
+
+	return nil
+}
+
+func Timezone(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if _, err := time.LoadLocation(*str); err != nil || *str == "" {
+		return errors.ErrValueIsNotTimezone
+	}
+
+	return nil
+}
+
 func Password(value any, name string) errors.ErrorInterface {
 	if err := Required(value, name); err != nil {
 		return err
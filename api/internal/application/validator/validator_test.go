@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -139,6 +140,165 @@ func TestLuhn(t *testing.T) {
 	}
 }
 
+func TestTicketCode(t *testing.T) {
+	defer token.Configure(nil)
+
+	assert.NoError(t, token.Configure(&token.Generator{Length: 10, Alphabet: token.Alphanumeric, Secret: "secret", Signature: 3}))
+	code, _ := token.Tickets().Generate()
+	unsigned, _ := token.Generate(10)
+
+	tests := []struct {
+		name    string
+		value   *string
+		wantErr bool
+	}{
+		{
+			name:    "Valid code",
+			value:   code.PointerString(),
+			wantErr: false,
+		},
+		{
+			name:    "Unsigned code",
+			value:   unsigned.PointerString(),
+			wantErr: true,
+		},
+		{
+			name:    "Empty code",
+			value:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.TicketCode(tt.value, "token")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPairingCode(t *testing.T) {
+	code, _ := token.Pairings().Generate()
+	ticket, _ := token.Generate(12)
+
+	tests := []struct {
+		name    string
+		value   *string
+		wantErr bool
+	}{
+		{
+			name:    "Valid code",
+			value:   code.PointerString(),
+			wantErr: false,
+		},
+		{
+			name:    "Ticket code",
+			value:   ticket.PointerString(),
+			wantErr: true,
+		},
+		{
+			name:    "Empty code",
+			value:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.PairingCode(tt.value, "code")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSheetFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   *string
+		wantErr bool
+	}{
+		{
+			name:    "CSV",
+			value:   aws.String("csv"),
+			wantErr: false,
+		},
+		{
+			name:    "PDF",
+			value:   aws.String("pdf"),
+			wantErr: false,
+		},
+		{
+			name:    "Unknown format",
+			value:   aws.String("xls"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty format",
+			value:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.SheetFormat(tt.value, "format")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQRFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   *string
+		wantErr bool
+	}{
+		{
+			name:    "PNG",
+			value:   aws.String("png"),
+			wantErr: false,
+		},
+		{
+			name:    "SVG",
+			value:   aws.String("svg"),
+			wantErr: false,
+		},
+		{
+			name:    "Unknown format",
+			value:   aws.String("gif"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty format",
+			value:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.QRFormat(tt.value, "format")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestID(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -174,6 +334,251 @@ func TestID(t *testing.T) {
 	}
 }
 
+func TestSHA256(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    *string
+		wantErr bool
+	}{
+		{
+			name:    "Valid hash",
+			hash:    aws.String("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"),
+			wantErr: false,
+		},
+		{
+			name:    "Invalid length",
+			hash:    aws.String("9f86d081884c7d659a2feaa0c55ad015"),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid characters",
+			hash:    aws.String("zf86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty hash",
+			hash:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.SHA256(tt.hash, "hash")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    *string
+		wantErr bool
+	}{
+		{
+			name:    "Valid date time",
+			date:    aws.String("2024-11-01 09:30:00"),
+			wantErr: false,
+		},
+		{
+			name:    "Date without time",
+			date:    aws.String("2024-11-01"),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date",
+			date:    aws.String("2024-13-01 09:30:00"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty date",
+			date:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.DateTime(tt.date, "date")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    *string
+		wantErr bool
+	}{
+		{
+			name:    "Valid date",
+			date:    aws.String("2024-11-01"),
+			wantErr: false,
+		},
+		{
+			name:    "Date with time",
+			date:    aws.String("2024-11-01 09:30:00"),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date",
+			date:    aws.String("2024-02-30"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty date",
+			date:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Date(tt.date, "date")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp *string
+		wantErr   bool
+	}{
+		{
+			name:      "Valid timestamp with offset",
+			timestamp: aws.String("2024-11-01T09:30:00+01:00"),
+			wantErr:   false,
+		},
+		{
+			name:      "Valid timestamp in UTC",
+			timestamp: aws.String("2024-11-01T08:30:00Z"),
+			wantErr:   false,
+		},
+		{
+			name:      "Timestamp without offset",
+			timestamp: aws.String("2024-11-01 09:30:00"),
+			wantErr:   true,
+		},
+		{
+			name:      "Empty timestamp",
+			timestamp: nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Timestamp(tt.timestamp, "timestamp")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		time    *string
+		wantErr bool
+	}{
+		{
+			name:    "Valid time",
+			time:    aws.String("09:30"),
+			wantErr: false,
+		},
+		{
+			name:    "Time with seconds",
+			time:    aws.String("09:30:00"),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid time",
+			time:    aws.String("25:00"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty time",
+			time:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Time(tt.time, "time")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone *string
+		wantErr  bool
+	}{
+		{
+			name:     "Valid timezone",
+			timezone: aws.String("Europe/Paris"),
+			wantErr:  false,
+		},
+		{
+			name:     "UTC",
+			timezone: aws.String("UTC"),
+			wantErr:  false,
+		},
+		{
+			name:     "Unknown timezone",
+			timezone: aws.String("Europe/Atlantis"),
+			wantErr:  true,
+		},
+		{
+			name:     "Blank timezone",
+			timezone: aws.String(""),
+			wantErr:  true,
+		},
+		{
+			name:     "Empty timezone",
+			timezone: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Timezone(tt.timezone, "timezone")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestIsBool(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -152,6 +152,56 @@ const docTemplate = `{
                 }
             }
         },
+        "/caisse/{id}/pairing": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Generate a one-time pairing code for a device of a caisse.",
+                "operationId": "jwt.Auth =\u003e store.CreatePairing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Caisse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label of the device",
+                        "name": "label",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Device waiting for its pairing, with its code"
+                    },
+                    "400": {
+                        "description": "Invalid input"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Caisse not found"
+                    }
+                }
+            }
+        },
         "/client": {
             "put": {
                 "security": [
@@ -373,6 +423,97 @@ const docTemplate = `{
                 "responses": {}
             }
         },
+        "/device/pair": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Exchange a pairing code for the token of the device.",
+                "operationId": "store.PairDevice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "One-time pairing code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label of the device",
+                        "name": "label",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Device paired, with its token"
+                    },
+                    "400": {
+                        "description": "Invalid code"
+                    },
+                    "404": {
+                        "description": "Unknown code"
+                    },
+                    "410": {
+                        "description": "Code expired or already used"
+                    },
+                    "429": {
+                        "description": "Too many failed pairings from this IP"
+                    }
+                }
+            }
+        },
+        "/device/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Revoke the credential of a device.",
+                "operationId": "jwt.Auth =\u003e store.RevokeDevice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Device revoked"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Device not found"
+                    },
+                    "409": {
+                        "description": "Device already revoked"
+                    }
+                }
+            }
+        },
         "/employee": {
             "put": {
                 "security": [
@@ -593,8 +734,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/game/random": {
-            "get": {
+        "/game/campaign": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
@@ -607,25 +748,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Game"
+                    "Campaign"
+                ],
+                "summary": "Create a campaign with its participation and redemption windows.",
+                "operationId": "jwt.Auth =\u003e game.CreateCampaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign label",
+                        "name": "label",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2025-01-01 00:00:00",
+                        "description": "Start of the participation window",
+                        "name": "start",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2025-01-31 23:59:59",
+                        "description": "End of the participation window",
+                        "name": "end",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2025-03-01 23:59:59",
+                        "description": "Deadline to collect the prizes",
+                        "name": "claim_until",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Europe/Paris",
+                        "description": "Timezone of the dates",
+                        "name": "timezone",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "Get a random ticket.",
-                "operationId": "jwt.Auth =\u003e game.GetTicket",
                 "responses": {
-                    "200": {
-                        "description": "Ticket details"
+                    "201": {
+                        "description": "Campaign created"
                     },
                     "400": {
                         "description": "Bad request"
                     },
                     "401": {
                         "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Campaign already exists"
                     }
                 }
             }
         },
-        "/game/ticket": {
-            "put": {
+        "/game/campaign/{id}": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
@@ -638,23 +823,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Game"
+                    "Campaign"
                 ],
-                "summary": "Update a ticket.",
-                "operationId": "jwt.Auth =\u003e game.UpdateTicket",
+                "summary": "Get a campaign by id.",
+                "operationId": "jwt.Auth =\u003e game.GetCampaign",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Ticket ID",
+                        "description": "Campaign ID",
                         "name": "id",
-                        "in": "formData",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ticket details"
+                        "description": "Campaign details"
                     },
                     "400": {
                         "description": "Bad request"
@@ -666,10 +851,8 @@ const docTemplate = `{
                         "description": "Not found"
                     }
                 }
-            }
-        },
-        "/game/ticket/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "Bearer": []
@@ -682,25 +865,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Game"
+                    "Campaign"
+                ],
+                "summary": "Update a campaign by id.",
+                "operationId": "jwt.Auth =\u003e game.UpdateCampaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campaign label",
+                        "name": "label",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the participation window",
+                        "name": "start",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the participation window",
+                        "name": "end",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deadline to collect the prizes",
+                        "name": "claim_until",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone of the dates",
+                        "name": "timezone",
+                        "in": "formData"
+                    }
                 ],
-                "summary": "Get ticket by id.",
-                "operationId": "jwt.Auth =\u003e game.GetTicketById",
                 "responses": {
                     "200": {
-                        "description": "Tickets details"
+                        "description": "Campaign updated"
                     },
                     "400": {
                         "description": "Bad request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
                     "404": {
                         "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Campaign already exists"
                     }
                 }
-            }
-        },
-        "/game/tickets": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
@@ -713,69 +940,2286 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Game"
+                    "Campaign"
+                ],
+                "summary": "Delete a campaign by id.",
+                "operationId": "jwt.Auth =\u003e game.DeleteCampaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List all tickets likend to the authenticated user.",
-                "operationId": "jwt.Auth =\u003e game.GetTickets",
                 "responses": {
-                    "200": {
-                        "description": "Tickets details"
+                    "204": {
+                        "description": "Campaign deleted"
                     },
                     "400": {
                         "description": "Bad request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
                     "404": {
                         "description": "Not found"
                     }
                 }
             }
         },
-        "/status/healthcheck": {
+        "/game/campaigns": {
             "get": {
-                "description": "get the status of server.",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
                 "consumes": [
-                    "*/*"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Status"
+                    "Campaign"
                 ],
-                "summary": "Show the status of server.",
-                "operationId": "status.HealthCheck",
+                "summary": "List all campaigns.",
+                "operationId": "jwt.Auth =\u003e game.GetCampaigns",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "Campaigns details"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
                     }
                 }
             }
         },
-        "/status/ip": {
+        "/game/claim/attempts": {
             "get": {
-                "description": "get the ip of user.",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
                 "consumes": [
-                    "*/*"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Status"
+                    "Game"
                 ],
-                "summary": "Show the ip of user.",
-                "operationId": "status.IP",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
+                "summary": "List the failed attempts to claim a ticket.",
+                "operationId": "jwt.Auth =\u003e game.GetClaimAttempts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Credential ID",
+                        "name": "credential_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IP address",
+                        "name": "ip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Failed attempts"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/draw": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Draw"
+                ],
+                "summary": "Register a draw with its published seed commitment.",
+                "operationId": "jwt.Auth =\u003e game.CreateDraw",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SHA-256 of the seed, hexadecimal",
+                        "name": "commitment",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Number of winners",
+                        "name": "count",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Draw registered"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Draw already exists"
+                    }
+                }
+            }
+        },
+        "/game/draw/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Draw"
+                ],
+                "summary": "Get a draw by id.",
+                "operationId": "jwt.Auth =\u003e game.GetDraw",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Draw ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draw details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Draw"
+                ],
+                "summary": "Reveal the seed of a draw and select the winners.",
+                "operationId": "jwt.Auth =\u003e game.RunDraw",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Draw ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seed matching the commitment",
+                        "name": "seed",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draw result"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Draw already done"
+                    },
+                    "422": {
+                        "description": "No entries"
+                    }
+                }
+            }
+        },
+        "/game/draws": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Draw"
+                ],
+                "summary": "List all draws.",
+                "operationId": "jwt.Auth =\u003e game.GetDraws",
+                "responses": {
+                    "200": {
+                        "description": "Draws details"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/prize": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "Add a prize to the catalog.",
+                "operationId": "jwt.Auth =\u003e game.CreatePrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "infuser",
+                        "description": "Prize code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prize label",
+                        "name": "label",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prize description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Retail value",
+                        "name": "value",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image URL",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Share of the tickets",
+                        "name": "percentage",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Prize created"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Prize already exists"
+                    }
+                }
+            }
+        },
+        "/game/prize/{id}": {
+            "get": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "Get a prize by id.",
+                "operationId": "game.GetPrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Prize details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "Update a prize by id.",
+                "operationId": "jwt.Auth =\u003e game.UpdatePrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prize code",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prize label",
+                        "name": "label",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prize description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Retail value",
+                        "name": "value",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image URL",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Share of the tickets",
+                        "name": "percentage",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Prize updated"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Prize already exists"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "Remove a prize from the catalog.",
+                "operationId": "jwt.Auth =\u003e game.DeletePrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Prize deleted"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Prize used by tickets"
+                    }
+                }
+            }
+        },
+        "/game/prizes": {
+            "get": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "List the prizes of the game.",
+                "operationId": "game.GetPrizes",
+                "responses": {
+                    "200": {
+                        "description": "Prizes details"
+                    }
+                }
+            }
+        },
+        "/game/shift": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Open a shift of the current employee on a caisse.",
+                "operationId": "jwt.Auth =\u003e game.OpenShift",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Caisse ID",
+                        "name": "caisse_id",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Opened shift"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Caisse not found"
+                    },
+                    "409": {
+                        "description": "A shift is already open on the caisse"
+                    }
+                }
+            }
+        },
+        "/game/shift/{id}/close": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Close a shift and compute its Z-report.",
+                "operationId": "jwt.Auth =\u003e game.CloseShift",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Closed shift with its Z-report"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Shift not found"
+                    },
+                    "409": {
+                        "description": "Shift already closed"
+                    }
+                }
+            }
+        },
+        "/game/shift/{id}/report": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "Get the Z-report of a closed shift.",
+                "operationId": "jwt.Auth =\u003e game.GetShiftReport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Format of the report",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Z-report"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Shift not found"
+                    },
+                    "409": {
+                        "description": "Shift still open"
+                    }
+                }
+            }
+        },
+        "/game/shifts": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "List the shifts of a store or a caisse, the latest first.",
+                "operationId": "jwt.Auth =\u003e game.GetShifts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Caisse ID",
+                        "name": "caisse_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of shifts"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/statistics/breakdown": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Count the tickets issued, claimed and redeemed per prize, per store or per day.",
+                "operationId": "jwt.Auth =\u003e game.GetStatisticsBreakdown",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "prize",
+                            "store"
+                        ],
+                        "type": "string",
+                        "description": "Dimension of the breakdown",
+                        "name": "by",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "First day of the period",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Last day of the period",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "prize_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Breakdown"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/statistics/series": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Count the tickets issued, claimed and redeemed per day.",
+                "operationId": "jwt.Auth =\u003e game.GetStatisticsSeries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "First day of the period",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Last day of the period",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "prize_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time series"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/stock": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock"
+                ],
+                "summary": "Record a delivery of a prize to a store.",
+                "operationId": "jwt.Auth =\u003e game.RestockPrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "prize_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivered units",
+                        "name": "quantity",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Available units triggering the low stock alert",
+                        "name": "threshold",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock after the delivery"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Prize or store not found"
+                    }
+                }
+            }
+        },
+        "/game/stock/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock"
+                ],
+                "summary": "Move available units of a prize from a store to another.",
+                "operationId": "jwt.Auth =\u003e game.TransferPrizeStock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "prize_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Source store ID",
+                        "name": "from_store_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Destination store ID",
+                        "name": "to_store_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Units to move",
+                        "name": "quantity",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stocks of the source and destination stores"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Stock or store not found"
+                    },
+                    "409": {
+                        "description": "Not enough available units in the source store"
+                    }
+                }
+            }
+        },
+        "/game/stocks": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock"
+                ],
+                "summary": "List the stock of the prizes in the stores.",
+                "operationId": "jwt.Auth =\u003e game.GetPrizeStocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "prize_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of stocks"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/sync": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Upload the issuances and redemptions queued by a caisse while it was offline.",
+                "operationId": "jwt.Auth =\u003e game.SyncCaisse",
+                "parameters": [
+                    {
+                        "description": "Caisse ID in caisse_id, operations with id, type (issue or redeem), occurred_at (RFC 3339), receipt and amount or ticket_id",
+                        "name": "sync",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Outcome of each operation, applied or conflict"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Caisse not found"
+                    },
+                    "413": {
+                        "description": "Too many operations"
+                    }
+                }
+            }
+        },
+        "/game/ticket": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Claim a ticket with its printed code, kept for the older clients.",
+                "operationId": "jwt.Auth =\u003e game.UpdateTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Printed code of the ticket",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Campaign not started"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "410": {
+                        "description": "Campaign ended"
+                    },
+                    "429": {
+                        "description": "Too many failed attempts"
+                    }
+                }
+            }
+        },
+        "/game/ticket/claim": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Claim a ticket with its printed code.",
+                "operationId": "jwt.Auth =\u003e game.ClaimTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Printed code of the ticket",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Campaign not started"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "410": {
+                        "description": "Campaign ended"
+                    },
+                    "429": {
+                        "description": "Too many failed attempts"
+                    }
+                }
+            }
+        },
+        "/game/ticket/issue": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Issue the next ticket of the pool at a caisse for a purchase.",
+                "operationId": "jwt.Auth =\u003e game.IssueTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Caisse ID",
+                        "name": "caisse_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Receipt number of the purchase",
+                        "name": "receipt",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount of the purchase",
+                        "name": "amount",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Caisse not found"
+                    },
+                    "409": {
+                        "description": "Receipt already rewarded or no ticket left"
+                    },
+                    "422": {
+                        "description": "Amount under the minimum"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Get ticket by id.",
+                "operationId": "jwt.Auth =\u003e game.GetTicketById",
+                "responses": {
+                    "200": {
+                        "description": "Tickets details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "List the audit trail of a ticket.",
+                "operationId": "jwt.Auth =\u003e game.GetTicketHistory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket events, oldest first"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}/redeem": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Redeem the prize of a claimed ticket at a caisse.",
+                "operationId": "jwt.Auth =\u003e game.RedeemTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Caisse ID",
+                        "name": "caisse_id",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Ticket not claimed, already redeemed or prize out of stock in the store"
+                    },
+                    "410": {
+                        "description": "Ticket expired or voided"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}/reissue": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Void a ticket and replace it under a fresh code with the same prize.",
+                "operationId": "jwt.Auth =\u003e game.ReissueTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason of the void",
+                        "name": "reason",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Replacement ticket"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Ticket already redeemed or no fresh code left"
+                    },
+                    "410": {
+                        "description": "Ticket expired or already voided"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}/void": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Void a damaged or misprinted ticket, its record is kept.",
+                "operationId": "jwt.Auth =\u003e game.VoidTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason of the void",
+                        "name": "reason",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Voided ticket"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Ticket already redeemed"
+                    },
+                    "410": {
+                        "description": "Ticket expired or already voided"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{token}/claim": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Claim a ticket from its signed claim link.",
+                "operationId": "jwt.Auth =\u003e game.ClaimLinkedTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Printed code of the ticket",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expiry of the link",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of the link",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forged link or campaign not started"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "410": {
+                        "description": "Expired link or campaign ended"
+                    },
+                    "429": {
+                        "description": "Too many failed attempts"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{token}/qr": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Draw the QR code of the signed claim link of a ticket.",
+                "operationId": "jwt.Auth =\u003e game.GetTicketQR",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Printed code of the ticket",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "description": "Format of the image",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Side of the image in pixels",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/tickets": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "List a page of the tickets likend to the authenticated user.",
+                "operationId": "jwt.Auth =\u003e game.GetTickets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-created_at",
+                        "description": "Columns to sort by, prefixed by - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status of the tickets",
+                        "name": "filter[status]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of tickets"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/tickets/export": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "text/csv",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Export the tickets to print, after assigning new tickets to a store.",
+                "operationId": "jwt.Auth =\u003e game.ExportTickets",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Format of the sheet",
+                        "name": "format",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of new tickets assigned to the store",
+                        "name": "count",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sheet of tickets",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Not enough tickets"
+                    }
+                }
+            }
+        },
+        "/status/healthcheck": {
+            "get": {
+                "description": "get the status of server.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Status"
+                ],
+                "summary": "Show the status of server.",
+                "operationId": "status.HealthCheck",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/status/ip": {
+            "get": {
+                "description": "get the ip of user.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Status"
+                ],
+                "summary": "Show the ip of user.",
+                "operationId": "status.IP",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
         "/store": {
             "get": {
-                "consumes": [
-                    "multipart/form-data"
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "List a page of the stores.",
+                "operationId": "store.List",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "label",
+                        "description": "Columns to sort by, prefixed by - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Online stores only",
+                        "name": "filter[is_online]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "page of stores"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Create a store.",
+                "operationId": "jwt.Auth =\u003e store.CreateStore",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Label of the store",
+                        "name": "label",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Online store",
+                        "name": "is_online",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postal address of the store",
+                        "name": "address",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the store in degrees",
+                        "name": "latitude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the store in degrees",
+                        "name": "longitude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Europe/Paris",
+                        "description": "IANA timezone of the store",
+                        "name": "timezone",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Store created"
+                    },
+                    "400": {
+                        "description": "Invalid input"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Label already used"
+                    }
+                }
+            }
+        },
+        "/store/nearby": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "List the stores around a position, the closest first.",
+                "operationId": "store.FindNearbyStores",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude in degrees",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude in degrees",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in kilometers, 10 by default and 100 at most",
+                        "name": "radius",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stores with their distance and whether they are open"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    }
+                }
+            }
+        },
+        "/store/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Get caisse by store",
+                "operationId": "store.GetStoreByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of caisse"
+                    },
+                    "400": {
+                        "description": "Invalid store"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Update the label, the kind or the location of a store.",
+                "operationId": "jwt.Auth =\u003e store.UpdateStore",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label of the store",
+                        "name": "label",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Online store",
+                        "name": "is_online",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postal address of the store",
+                        "name": "address",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the store in degrees",
+                        "name": "latitude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the store in degrees",
+                        "name": "longitude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Europe/Paris",
+                        "description": "IANA timezone of the store",
+                        "name": "timezone",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store updated"
+                    },
+                    "400": {
+                        "description": "Invalid input"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Store not found"
+                    },
+                    "409": {
+                        "description": "Label already used"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Close a store and its caisses.",
+                "operationId": "jwt.Auth =\u003e store.DeleteStore",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Store deleted"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Store not found"
+                    }
+                }
+            }
+        },
+        "/store/{id}/devices": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "List the paired devices of a store.",
+                "operationId": "jwt.Auth =\u003e store.GetDevices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Devices of the store"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Store not found"
+                    }
+                }
+            }
+        },
+        "/store/{id}/employee": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Assign an employee to a store.",
+                "operationId": "jwt.Auth =\u003e store.CreateMembership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Credential ID of the employee",
+                        "name": "credential_id",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Employee assigned"
+                    },
+                    "400": {
+                        "description": "Invalid input"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Store not found"
+                    },
+                    "409": {
+                        "description": "Employee already assigned"
+                    }
+                }
+            }
+        },
+        "/store/{id}/employee/{credential_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Remove an employee from a store.",
+                "operationId": "jwt.Auth =\u003e store.DeleteMembership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Credential ID of the employee",
+                        "name": "credential_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Employee removed"
+                    },
+                    "400": {
+                        "description": "Invalid input"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Employee not assigned to the store"
+                    }
+                }
+            }
+        },
+        "/store/{id}/employees": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
                 "produces": [
                     "application/json"
@@ -783,44 +3227,83 @@ const docTemplate = `{
                 "tags": [
                     "Store"
                 ],
-                "summary": "List all store.",
-                "operationId": "store.List",
+                "summary": "List the employees assigned to a store.",
+                "operationId": "jwt.Auth =\u003e store.GetMemberships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "list of store"
+                        "description": "Memberships of the store"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Store not found"
                     }
                 }
             }
         },
-        "/store/{id}": {
-            "get": {
+        "/store/{id}/hours": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Store"
                 ],
-                "summary": "Get caisse by store",
-                "operationId": "store.GetStoreByID",
+                "summary": "Replace the weekly opening hours and the exceptions of a store.",
+                "operationId": "jwt.Auth =\u003e store.UpdateStoreHours",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
                         "description": "Store ID",
-                        "name": "store_id",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Weekly slots in openings, dated exceptions in exceptions",
+                        "name": "hours",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of caisse"
+                        "description": "Store with its hours"
                     },
                     "400": {
-                        "description": "Invalid store"
+                        "description": "Invalid input"
                     },
-                    "500": {
-                        "description": "Internal server error"
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Store not found"
                     }
                 }
             }
@@ -903,6 +3386,9 @@ const docTemplate = `{
                     "401": {
                         "description": "Token expired"
                     },
+                    "403": {
+                        "description": "Credential disabled"
+                    },
                     "500": {
                         "description": "Internal server error"
                     }
@@ -1066,6 +3552,254 @@ const docTemplate = `{
                 ],
                 "responses": {}
             }
+        },
+        "/user/{id}/demote": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Demote a user: an admin becomes an employee, an employee becomes a client.",
+                "operationId": "jwt.Auth =\u003e user.DemoteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Credential ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User demoted"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Credential not found"
+                    },
+                    "409": {
+                        "description": "User already client or self"
+                    }
+                }
+            }
+        },
+        "/user/{id}/disable": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Disable a user, who can no longer sign in nor renew their tokens.",
+                "operationId": "jwt.Auth =\u003e user.DisableUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Credential ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User disabled"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Credential not found"
+                    },
+                    "409": {
+                        "description": "User already disabled or self"
+                    }
+                }
+            }
+        },
+        "/user/{id}/enable": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Enable a disabled user again.",
+                "operationId": "jwt.Auth =\u003e user.EnableUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Credential ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User enabled"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Credential not found"
+                    },
+                    "409": {
+                        "description": "User not disabled or self"
+                    }
+                }
+            }
+        },
+        "/user/{id}/promote": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Promote a user: a client becomes an employee, an employee becomes an admin.",
+                "operationId": "jwt.Auth =\u003e user.PromoteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Credential ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User promoted"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Credential not found"
+                    },
+                    "409": {
+                        "description": "User already admin or self"
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List a page of the clients, employees and admins.",
+                "operationId": "jwt.Auth =\u003e user.ListUsers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "email",
+                        "description": "Columns to sort by, prefixed by - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "client",
+                            "employee",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Role of the users",
+                        "name": "filter[role]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email address of the user",
+                        "name": "filter[email]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of users"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
         }
     },
     "definitions": {
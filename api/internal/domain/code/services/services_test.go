@@ -62,6 +62,29 @@ func (m *PermissionMock) GetCredentialID() *string {
 	return args.Get(0).(*string)
 }
 
+func (m *PermissionMock) GetRole() security.Role {
+	args := m.Called()
+	return args.Get(0).(security.Role)
+}
+
+func (m *PermissionMock) GetIP() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
+func (m *PermissionMock) GetUserAgent() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
 func (m *PermissionMock) IsGrantedByRoles(roles ...security.Role) bool {
 	args := m.Called(roles)
 	return args.Bool(0)
@@ -0,0 +1,166 @@
+package entities
+
+import (
+	"time"
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+const (
+	// CampaignDefaultTimezone is used when a campaign does not define its timezone
+	CampaignDefaultTimezone = "UTC"
+)
+
+type Campaign struct {
+	// Gorm model
+	ID        string          `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time       `json:"-"`
+	UpdatedAt time.Time       `json:"-"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Additional fields
+	Label      *string   `gorm:"type:varchar(255);uniqueIndex" json:"label"`
+	StartAt    time.Time `gorm:"index" json:"start"`
+	EndAt      time.Time `gorm:"index" json:"end"`
+	ClaimUntil time.Time `json:"claim_until"`
+	Timezone   string    `gorm:"type:varchar(64)" json:"timezone"`
+}
+
+func CreateCampaign(obj *transfert.Campaign) *Campaign {
+	c := &Campaign{
+		Timezone: CampaignDefaultTimezone,
+	}
+
+	if obj.ID != nil {
+		c.ID = *obj.ID
+	}
+
+	c.Update(obj)
+
+	return c
+}
+
+// Update applies the fields set in the transfer object to the campaign
+// Dates are wall-clock times (time.DateTime layout) read in the timezone of the campaign,
+// a new timezone only applies to the dates given alongside it.
+//
+// Parameters:
+// - obj: *transfert.Campaign the fields to apply
+func (campaign *Campaign) Update(obj *transfert.Campaign) {
+	if obj.Label != nil {
+		campaign.Label = obj.Label
+	}
+
+	if obj.Timezone != nil && *obj.Timezone != "" {
+		campaign.Timezone = *obj.Timezone
+	}
+
+	location := campaign.Location()
+
+	if start, ok := parseCampaignTime(obj.Start, location); ok {
+		campaign.StartAt = start
+	}
+
+	if end, ok := parseCampaignTime(obj.End, location); ok {
+		campaign.EndAt = end
+	}
+
+	if claimUntil, ok := parseCampaignTime(obj.ClaimUntil, location); ok {
+		campaign.ClaimUntil = claimUntil
+	}
+}
+
+func (campaign *Campaign) IsPublic() bool {
+	return true
+}
+
+func (campaign *Campaign) GetOwnerID() string {
+	return ""
+}
+
+// Location returns the timezone of the campaign, UTC if it is unknown
+//
+// Returns:
+// - *time.Location: the location used to read and display the dates
+func (campaign *Campaign) Location() *time.Location {
+	location, err := time.LoadLocation(campaign.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// IsValid checks that the windows are consistent
+// The participation window must not be empty and the redemption deadline cannot precede its end.
+//
+// Returns:
+// - bool: true if start < end <= claim until
+func (campaign *Campaign) IsValid() bool {
+	return campaign.StartAt.Before(campaign.EndAt) && !campaign.ClaimUntil.Before(campaign.EndAt)
+}
+
+// IsStarted reports whether the participation window is open or past
+//
+// Parameters:
+// - now: time.Time the reference time
+//
+// Returns:
+// - bool: true if now is after the start of the campaign
+func (campaign *Campaign) IsStarted(now time.Time) bool {
+	return !now.Before(campaign.StartAt)
+}
+
+// IsEnded reports whether the participation window is closed
+//
+// Parameters:
+// - now: time.Time the reference time
+//
+// Returns:
+// - bool: true if tickets can no longer be claimed
+func (campaign *Campaign) IsEnded(now time.Time) bool {
+	return now.After(campaign.EndAt)
+}
+
+// IsClaimExpired reports whether the winners can no longer collect their prize
+//
+// Parameters:
+// - now: time.Time the reference time
+//
+// Returns:
+// - bool: true if tickets can no longer be redeemed
+func (campaign *Campaign) IsClaimExpired(now time.Time) bool {
+	return now.After(campaign.ClaimUntil)
+}
+
+func (campaign *Campaign) BeforeUpdate(tx *gorm.DB) error {
+	campaign.UpdatedAt = time.Now()
+	return nil
+}
+
+func (campaign *Campaign) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	campaign.ID = id.String()
+
+	return nil
+}
+
+func parseCampaignTime(value *string, location *time.Location) (time.Time, bool) {
+	if value == nil {
+		return time.Time{}, false
+	}
+
+	t, err := time.ParseInLocation(time.DateTime, *value, location)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
@@ -0,0 +1,130 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCampaign(t *testing.T) {
+	t.Run("with timezone", func(t *testing.T) {
+		input := &transfert.Campaign{
+			ID:         aws.String(uuid.New().String()),
+			Label:      aws.String("Ouverture Nice"),
+			Start:      aws.String("2024-11-01 00:00:00"),
+			End:        aws.String("2024-11-30 23:59:59"),
+			ClaimUntil: aws.String("2024-12-30 23:59:59"),
+			Timezone:   aws.String("Europe/Paris"),
+		}
+
+		campaign := entities.CreateCampaign(input)
+
+		paris, err := time.LoadLocation("Europe/Paris")
+		assert.Nil(t, err)
+
+		assert.Equal(t, *input.ID, campaign.ID)
+		assert.Equal(t, *input.Label, *campaign.Label)
+		assert.Equal(t, "Europe/Paris", campaign.Timezone)
+		assert.True(t, campaign.StartAt.Equal(time.Date(2024, 11, 1, 0, 0, 0, 0, paris)))
+		assert.True(t, campaign.StartAt.Equal(time.Date(2024, 10, 31, 23, 0, 0, 0, time.UTC)))
+		assert.True(t, campaign.ClaimUntil.Equal(time.Date(2024, 12, 30, 23, 59, 59, 0, paris)))
+		assert.True(t, campaign.IsValid())
+	})
+
+	t.Run("without timezone", func(t *testing.T) {
+		campaign := entities.CreateCampaign(&transfert.Campaign{
+			Start: aws.String("2024-11-01 00:00:00"),
+		})
+
+		assert.Empty(t, campaign.ID)
+		assert.Equal(t, entities.CampaignDefaultTimezone, campaign.Timezone)
+		assert.True(t, campaign.StartAt.Equal(time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("with invalid dates", func(t *testing.T) {
+		campaign := entities.CreateCampaign(&transfert.Campaign{
+			Start: aws.String("tomorrow"),
+		})
+
+		assert.True(t, campaign.StartAt.IsZero())
+		assert.False(t, campaign.IsValid())
+	})
+}
+
+func TestCampaign_Update(t *testing.T) {
+	campaign := entities.CreateCampaign(&transfert.Campaign{
+		Label:      aws.String("Ouverture Nice"),
+		Start:      aws.String("2024-11-01 00:00:00"),
+		End:        aws.String("2024-11-30 23:59:59"),
+		ClaimUntil: aws.String("2024-12-30 23:59:59"),
+	})
+	start := campaign.StartAt
+
+	campaign.Update(&transfert.Campaign{
+		Timezone: aws.String("Europe/Paris"),
+		End:      aws.String("2024-12-15 23:59:59"),
+	})
+
+	assert.Equal(t, "Ouverture Nice", *campaign.Label)
+	assert.Equal(t, "Europe/Paris", campaign.Timezone)
+	assert.True(t, campaign.StartAt.Equal(start))
+	assert.Equal(t, campaign.Location(), campaign.EndAt.Location())
+	assert.True(t, campaign.IsValid())
+
+	campaign.Update(&transfert.Campaign{Timezone: aws.String("")})
+	assert.Equal(t, "Europe/Paris", campaign.Timezone)
+}
+
+func TestCampaign_IsValid(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, (&entities.Campaign{StartAt: now, EndAt: now.Add(time.Hour), ClaimUntil: now.Add(time.Hour)}).IsValid())
+	assert.False(t, (&entities.Campaign{StartAt: now, EndAt: now, ClaimUntil: now.Add(time.Hour)}).IsValid())
+	assert.False(t, (&entities.Campaign{StartAt: now, EndAt: now.Add(time.Hour), ClaimUntil: now}).IsValid())
+}
+
+func TestCampaign_Windows(t *testing.T) {
+	now := time.Now()
+	campaign := &entities.Campaign{
+		StartAt:    now.Add(-time.Hour),
+		EndAt:      now.Add(time.Hour),
+		ClaimUntil: now.Add(2 * time.Hour),
+	}
+
+	assert.False(t, campaign.IsStarted(now.Add(-2*time.Hour)))
+	assert.True(t, campaign.IsStarted(now))
+	assert.False(t, campaign.IsEnded(now))
+	assert.True(t, campaign.IsEnded(now.Add(90*time.Minute)))
+	assert.False(t, campaign.IsClaimExpired(now.Add(90*time.Minute)))
+	assert.True(t, campaign.IsClaimExpired(now.Add(3*time.Hour)))
+}
+
+func TestCampaign_Location(t *testing.T) {
+	assert.Equal(t, "Europe/Paris", (&entities.Campaign{Timezone: "Europe/Paris"}).Location().String())
+	assert.Equal(t, time.UTC, (&entities.Campaign{Timezone: "Nowhere/Land"}).Location())
+}
+
+func TestCampaign_IsPublic(t *testing.T) {
+	campaign := &entities.Campaign{}
+	assert.True(t, campaign.IsPublic())
+	assert.Equal(t, "", campaign.GetOwnerID())
+}
+
+func TestCampaign_BeforeCreate(t *testing.T) {
+	campaign := &entities.Campaign{}
+	assert.Nil(t, campaign.BeforeCreate(nil))
+	assert.NotEmpty(t, campaign.ID)
+}
+
+func TestCampaign_BeforeUpdate(t *testing.T) {
+	campaign := &entities.Campaign{UpdatedAt: time.Now().Add(-time.Hour)}
+	oldTime := campaign.UpdatedAt
+
+	assert.Nil(t, campaign.BeforeUpdate(nil))
+	assert.True(t, campaign.UpdatedAt.After(oldTime))
+}
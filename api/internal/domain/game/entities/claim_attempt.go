@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+const (
+	// ClaimAttemptWindow is the period during which the failed attempts are taken into account
+	ClaimAttemptWindow = 24 * time.Hour
+
+	ClaimFailureNotFound    = "not_found"
+	ClaimFailureUnavailable = "unavailable"
+)
+
+// ClaimLockout locks the claims for a duration once a number of failures is reached
+type ClaimLockout struct {
+	Failures int
+	Duration time.Duration
+}
+
+// ClaimLockouts are the escalating lockouts, sorted by number of failures
+var ClaimLockouts = []ClaimLockout{
+	{Failures: 5, Duration: time.Minute},
+	{Failures: 10, Duration: 15 * time.Minute},
+	{Failures: 20, Duration: ClaimAttemptWindow},
+}
+
+type ClaimAttempt struct {
+	// Gorm model
+	ID        string          `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time       `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time       `json:"-"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Additional fields
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"`
+	IP           *string `gorm:"type:varchar(45);index" json:"ip"`
+	Token        *string `gorm:"type:varchar(32)" json:"token"`
+	Reason       *string `gorm:"type:varchar(16)" json:"reason"`
+}
+
+func CreateClaimAttempt(obj *transfert.ClaimAttempt) *ClaimAttempt {
+	a := &ClaimAttempt{
+		CredentialID: obj.CredentialID,
+		IP:           obj.IP,
+		Token:        obj.Token,
+		Reason:       obj.Reason,
+	}
+
+	if obj.ID != nil {
+		a.ID = *obj.ID
+	}
+
+	return a
+}
+
+func (attempt *ClaimAttempt) IsPublic() bool {
+	return false
+}
+
+func (attempt *ClaimAttempt) GetOwnerID() string {
+	if attempt.CredentialID == nil {
+		return ""
+	}
+
+	return *attempt.CredentialID
+}
+
+func (attempt *ClaimAttempt) BeforeUpdate(tx *gorm.DB) error {
+	attempt.UpdatedAt = time.Now()
+	return nil
+}
+
+func (attempt *ClaimAttempt) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	attempt.ID = id.String()
+
+	return nil
+}
+
+// ClaimLockedUntil computes the end of the lockout caused by the failed attempts
+// The lockout starts at the last failure and lasts according to the number of failures in the window.
+//
+// Parameters:
+// - attempts: []*ClaimAttempt the failed attempts of a credential or an IP
+// - now: time.Time the reference time
+//
+// Returns:
+// - time.Time: the end of the lockout, zero if the claims are not locked
+func ClaimLockedUntil(attempts []*ClaimAttempt, now time.Time) time.Time {
+	var failures int
+	var last time.Time
+
+	for _, attempt := range attempts {
+		if now.Sub(attempt.CreatedAt) > ClaimAttemptWindow {
+			continue
+		}
+
+		failures++
+		if attempt.CreatedAt.After(last) {
+			last = attempt.CreatedAt
+		}
+	}
+
+	var duration time.Duration
+	for _, lockout := range ClaimLockouts {
+		if failures >= lockout.Failures {
+			duration = lockout.Duration
+		}
+	}
+
+	if duration == 0 {
+		return time.Time{}
+	}
+
+	return last.Add(duration)
+}
@@ -0,0 +1,63 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateClaimAttempt(t *testing.T) {
+	input := &transfert.ClaimAttempt{
+		ID:           aws.String("attempt-1"),
+		CredentialID: aws.String("client-123"),
+		IP:           aws.String("203.0.113.7"),
+		Token:        aws.String("123456789012"),
+		Reason:       aws.String(entities.ClaimFailureNotFound),
+	}
+
+	attempt := entities.CreateClaimAttempt(input)
+
+	assert.Equal(t, "attempt-1", attempt.ID)
+	assert.Equal(t, input.IP, attempt.IP)
+	assert.Equal(t, input.Token, attempt.Token)
+	assert.Equal(t, input.Reason, attempt.Reason)
+	assert.Equal(t, "client-123", attempt.GetOwnerID())
+	assert.False(t, attempt.IsPublic())
+}
+
+func TestClaimLockedUntil(t *testing.T) {
+	now := time.Now()
+
+	attempts := func(count int, at time.Time) []*entities.ClaimAttempt {
+		list := make([]*entities.ClaimAttempt, count)
+		for i := range list {
+			list[i] = &entities.ClaimAttempt{CreatedAt: at}
+		}
+		return list
+	}
+
+	t.Run("no lockout under the first threshold", func(t *testing.T) {
+		assert.True(t, entities.ClaimLockedUntil(attempts(4, now), now).IsZero())
+		assert.True(t, entities.ClaimLockedUntil(nil, now).IsZero())
+	})
+
+	t.Run("escalating lockouts", func(t *testing.T) {
+		assert.Equal(t, now.Add(time.Minute), entities.ClaimLockedUntil(attempts(5, now), now))
+		assert.Equal(t, now.Add(15*time.Minute), entities.ClaimLockedUntil(attempts(10, now), now))
+		assert.Equal(t, now.Add(24*time.Hour), entities.ClaimLockedUntil(attempts(25, now), now))
+	})
+
+	t.Run("lockout starts at the last failure", func(t *testing.T) {
+		list := append(attempts(4, now.Add(-time.Hour)), &entities.ClaimAttempt{CreatedAt: now.Add(-time.Minute)})
+		assert.Equal(t, now, entities.ClaimLockedUntil(list, now))
+	})
+
+	t.Run("failures outside the window are ignored", func(t *testing.T) {
+		list := append(attempts(20, now.Add(-25*time.Hour)), attempts(4, now)...)
+		assert.True(t, entities.ClaimLockedUntil(list, now).IsZero())
+	})
+}
@@ -0,0 +1,257 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+type Draw struct {
+	// Gorm model
+	ID        string          `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"-"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Additional fields
+	Commitment   *string    `gorm:"type:varchar(64);uniqueIndex" json:"commitment"`
+	Seed         *string    `gorm:"type:varchar(255)" json:"seed"`
+	Count        int        `gorm:"type:int" json:"count"`
+	Entries      int        `gorm:"type:int" json:"entries"`
+	EntriesHash  *string    `gorm:"type:varchar(64)" json:"entries_hash"`
+	CredentialID *string    `gorm:"type:varchar(36);index" json:"credential_id"`
+	DrawnAt      *time.Time `json:"drawn_at"`
+
+	Winners Winners `gorm:"foreignKey:DrawID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"winners"`
+}
+
+func CreateDraw(obj *transfert.Draw) *Draw {
+	d := &Draw{
+		Commitment:   obj.Commitment,
+		Seed:         obj.Seed,
+		CredentialID: obj.CredentialID,
+		Count:        1,
+	}
+
+	if obj.Count != nil {
+		d.Count = *obj.Count
+	}
+
+	if obj.ID != nil {
+		d.ID = *obj.ID
+	}
+
+	return d
+}
+
+func (draw *Draw) IsPublic() bool {
+	return false
+}
+
+func (draw *Draw) GetOwnerID() string {
+	if draw.CredentialID == nil {
+		return ""
+	}
+
+	return *draw.CredentialID
+}
+
+// IsDrawn reports whether the seed has already been revealed and the winners selected
+//
+// Returns:
+// - bool: true if the draw is done
+func (draw *Draw) IsDrawn() bool {
+	return draw.DrawnAt != nil
+}
+
+// Verify checks that the given seed matches the published commitment
+// The commitment is the hexadecimal SHA-256 of the seed.
+//
+// Parameters:
+// - seed: string the revealed seed
+//
+// Returns:
+// - bool: true if sha256(seed) equals the commitment
+func (draw *Draw) Verify(seed string) bool {
+	if draw.Commitment == nil {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(seed))
+	expected, err := hex.DecodeString(strings.ToLower(*draw.Commitment))
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(sum[:], expected)
+}
+
+func (draw *Draw) BeforeUpdate(tx *gorm.DB) error {
+	draw.UpdatedAt = time.Now()
+
+	for _, winner := range draw.Winners {
+		winner.DrawID = &draw.ID
+	}
+
+	return nil
+}
+
+func (draw *Draw) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	draw.ID = id.String()
+
+	return nil
+}
+
+func (draw *Draw) AfterFind(tx *gorm.DB) error {
+	if err := tx.Model(draw).Association("Winners").Find(&draw.Winners); err != nil {
+		return err
+	}
+
+	sort.Slice(draw.Winners, func(i, j int) bool {
+		return draw.Winners[i].Position < draw.Winners[j].Position
+	})
+
+	return nil
+}
+
+type Winners []*Winner
+
+type Winner struct {
+	// Gorm model
+	ID        string          `gorm:"type:varchar(36);primaryKey;" json:"-"`
+	CreatedAt time.Time       `json:"-"`
+	UpdatedAt time.Time       `json:"-"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Additional fields
+	DrawID       *string `gorm:"type:varchar(36);index" json:"-"`
+	Position     int     `gorm:"type:int" json:"position"`
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"`
+}
+
+func (winner *Winner) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	winner.ID = id.String()
+
+	return nil
+}
+
+// DrawEntries normalizes a list of participants before a draw
+// Duplicates are removed and the list is sorted so that anyone holding the
+// same participants obtains the same entry list, whatever the database order.
+//
+// Parameters:
+// - ids: []string the credential IDs of the participants
+//
+// Returns:
+// - []string: the sorted and deduplicated entry list
+func DrawEntries(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	entries := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		entries = append(entries, id)
+	}
+
+	sort.Strings(entries)
+
+	return entries
+}
+
+// DrawEntriesHash computes the fingerprint of an entry list
+// The hash is the hexadecimal SHA-256 of the entries joined by a line feed.
+//
+// Parameters:
+// - entries: []string the normalized entry list
+//
+// Returns:
+// - string: the hexadecimal hash
+func DrawEntriesHash(entries []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// DrawWinners selects the winners from the entries using the revealed seed
+// The selection is a partial Fisher-Yates shuffle driven by HMAC-SHA256(seed, hash || counter),
+// so it can be replayed offline by anyone holding the seed and the entry list.
+//
+// Parameters:
+// - seed: string the revealed seed
+// - entries: []string the normalized entry list
+// - count: int the number of winners to select
+//
+// Returns:
+// - []string: the winners in order of selection
+func DrawWinners(seed string, entries []string, count int) []string {
+	pool := make([]string, len(entries))
+	copy(pool, entries)
+
+	if count > len(pool) {
+		count = len(pool)
+	}
+
+	stream := &drawStream{
+		mac:     hmac.New(sha256.New, []byte(seed)),
+		entries: DrawEntriesHash(entries),
+	}
+
+	for i := 0; i < count; i++ {
+		j := i + int(stream.uniform(uint64(len(pool)-i)))
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+
+	return pool[:count]
+}
+
+// drawStream is a deterministic source of random numbers derived from the seed
+type drawStream struct {
+	mac     hash.Hash
+	entries string
+	counter uint64
+}
+
+// next returns the next 64 bits of the stream
+func (s *drawStream) next() uint64 {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], s.counter)
+	s.counter++
+
+	s.mac.Reset()
+	s.mac.Write([]byte(s.entries))
+	s.mac.Write(counter[:])
+
+	return binary.BigEndian.Uint64(s.mac.Sum(nil)[:8])
+}
+
+// uniform returns an unbiased number in [0, n) using rejection sampling
+func (s *drawStream) uniform(n uint64) uint64 {
+	limit := ^uint64(0) - (^uint64(0) % n)
+	for {
+		if v := s.next(); v < limit {
+			return v % n
+		}
+	}
+}
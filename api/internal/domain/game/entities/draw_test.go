@@ -0,0 +1,166 @@
+package entities_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func commit(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestCreateDraw(t *testing.T) {
+	t.Run("with count", func(t *testing.T) {
+		input := &transfert.Draw{
+			ID:           aws.String(uuid.New().String()),
+			Commitment:   aws.String(commit("seed")),
+			Count:        aws.Int(3),
+			CredentialID: aws.String(uuid.New().String()),
+		}
+
+		draw := entities.CreateDraw(input)
+
+		assert.Equal(t, *input.ID, draw.ID)
+		assert.Equal(t, *input.Commitment, *draw.Commitment)
+		assert.Equal(t, *input.CredentialID, draw.GetOwnerID())
+		assert.Equal(t, 3, draw.Count)
+		assert.Nil(t, draw.Seed)
+		assert.False(t, draw.IsDrawn())
+	})
+
+	t.Run("without count", func(t *testing.T) {
+		draw := entities.CreateDraw(&transfert.Draw{})
+
+		assert.Empty(t, draw.ID)
+		assert.Equal(t, 1, draw.Count)
+		assert.Equal(t, "", draw.GetOwnerID())
+	})
+}
+
+func TestDraw_IsPublic(t *testing.T) {
+	draw := &entities.Draw{}
+	assert.False(t, draw.IsPublic())
+}
+
+func TestDraw_Verify(t *testing.T) {
+	draw := &entities.Draw{Commitment: aws.String(commit("my-secret-seed"))}
+
+	assert.True(t, draw.Verify("my-secret-seed"))
+	assert.False(t, draw.Verify("another-seed"))
+
+	draw.Commitment = aws.String("not-an-hexadecimal-value")
+	assert.False(t, draw.Verify("my-secret-seed"))
+
+	draw.Commitment = nil
+	assert.False(t, draw.Verify("my-secret-seed"))
+}
+
+func TestDrawEntries(t *testing.T) {
+	entries := entities.DrawEntries([]string{"c", "a", "b", "a", "", "c"})
+	assert.Equal(t, []string{"a", "b", "c"}, entries)
+}
+
+func TestDrawEntriesHash(t *testing.T) {
+	assert.Equal(t, commit("a\nb\nc"), entities.DrawEntriesHash([]string{"a", "b", "c"}))
+	assert.NotEqual(t, entities.DrawEntriesHash([]string{"a", "b"}), entities.DrawEntriesHash([]string{"a", "b", "c"}))
+}
+
+func TestDrawWinners(t *testing.T) {
+	entries := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		entries = append(entries, fmt.Sprintf("client-%03d", i))
+	}
+
+	t.Run("is deterministic", func(t *testing.T) {
+		first := entities.DrawWinners("seed", entries, 5)
+		second := entities.DrawWinners("seed", entries, 5)
+
+		assert.Len(t, first, 5)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("depends on the seed", func(t *testing.T) {
+		assert.NotEqual(t, entities.DrawWinners("seed-1", entries, 5), entities.DrawWinners("seed-2", entries, 5))
+	})
+
+	t.Run("selects distinct entries", func(t *testing.T) {
+		winners := entities.DrawWinners("seed", entries, len(entries))
+		seen := map[string]bool{}
+		for _, winner := range winners {
+			assert.False(t, seen[winner])
+			seen[winner] = true
+		}
+		assert.Len(t, seen, len(entries))
+	})
+
+	t.Run("does not alter the entries", func(t *testing.T) {
+		copied := append([]string{}, entries...)
+		entities.DrawWinners("seed", entries, 10)
+		assert.Equal(t, copied, entries)
+	})
+
+	t.Run("caps the count to the entries", func(t *testing.T) {
+		assert.Len(t, entities.DrawWinners("seed", []string{"a", "b"}, 5), 2)
+		assert.Empty(t, entities.DrawWinners("seed", []string{}, 1))
+	})
+}
+
+func TestDraw_BeforeCreate(t *testing.T) {
+	draw := &entities.Draw{}
+	assert.Nil(t, draw.BeforeCreate(nil))
+	assert.NotEmpty(t, draw.ID)
+
+	winner := &entities.Winner{}
+	assert.Nil(t, winner.BeforeCreate(nil))
+	assert.NotEmpty(t, winner.ID)
+}
+
+func TestDraw_BeforeUpdate(t *testing.T) {
+	draw := &entities.Draw{
+		ID:        uuid.New().String(),
+		UpdatedAt: time.Now().Add(-time.Hour),
+		Winners:   entities.Winners{{Position: 1}},
+	}
+	oldTime := draw.UpdatedAt
+
+	assert.Nil(t, draw.BeforeUpdate(nil))
+	assert.True(t, draw.UpdatedAt.After(oldTime))
+	assert.Equal(t, draw.ID, *draw.Winners[0].DrawID)
+}
+
+func TestDraw_AfterFind(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.Nil(t, err)
+	assert.Nil(t, db.AutoMigrate(&entities.Draw{}, &entities.Winner{}))
+
+	now := time.Now()
+	draw := &entities.Draw{
+		Commitment: aws.String(commit("seed")),
+		Count:      2,
+		DrawnAt:    &now,
+		Winners: entities.Winners{
+			{Position: 2, CredentialID: aws.String("b")},
+			{Position: 1, CredentialID: aws.String("a")},
+		},
+	}
+	assert.Nil(t, db.Create(draw).Error)
+
+	var fetched entities.Draw
+	assert.Nil(t, db.First(&fetched, "id = ?", draw.ID).Error)
+	assert.Len(t, fetched.Winners, 2)
+	assert.Equal(t, 1, fetched.Winners[0].Position)
+	assert.Equal(t, "a", *fetched.Winners[0].CredentialID)
+	assert.True(t, fetched.IsDrawn())
+}
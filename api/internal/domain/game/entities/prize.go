@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+type Prize struct {
+	// Gorm model
+	ID        string          `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time       `json:"-"`
+	UpdatedAt time.Time       `json:"-"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Additional fields
+	Code        *string `gorm:"type:varchar(64);uniqueIndex" json:"code"`
+	Label       *string `gorm:"type:varchar(255)" json:"label"`
+	Description *string `gorm:"type:text" json:"description"`
+	Value       float64 `json:"value"`
+	Image       *string `gorm:"type:varchar(255)" json:"image"`
+	Percentage  int     `json:"percentage"`
+}
+
+func CreatePrize(obj *transfert.Prize) *Prize {
+	p := &Prize{}
+
+	if obj.ID != nil {
+		p.ID = *obj.ID
+	}
+
+	p.Update(obj)
+
+	return p
+}
+
+// Update applies the fields set in the transfer object to the prize
+//
+// Parameters:
+// - obj: *transfert.Prize the fields to apply
+func (prize *Prize) Update(obj *transfert.Prize) {
+	if obj.Code != nil {
+		prize.Code = obj.Code
+	}
+
+	if obj.Label != nil {
+		prize.Label = obj.Label
+	}
+
+	if obj.Description != nil {
+		prize.Description = obj.Description
+	}
+
+	if obj.Value != nil {
+		prize.Value = *obj.Value
+	}
+
+	if obj.Image != nil {
+		prize.Image = obj.Image
+	}
+
+	if obj.Percentage != nil {
+		prize.Percentage = *obj.Percentage
+	}
+}
+
+func (prize *Prize) IsPublic() bool {
+	return true
+}
+
+func (prize *Prize) GetOwnerID() string {
+	return ""
+}
+
+func (prize *Prize) BeforeUpdate(tx *gorm.DB) error {
+	prize.UpdatedAt = time.Now()
+	return nil
+}
+
+func (prize *Prize) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	prize.ID = id.String()
+
+	return nil
+}
+
+// IsDispatchValid checks that the prizes share all the tickets
+//
+// Parameters:
+// - prizes: []*Prize the prizes of the game
+//
+// Returns:
+// - bool: true if the percentages are positive and sum to 100
+func IsDispatchValid(prizes []*Prize) bool {
+	total := 0
+	for _, prize := range prizes {
+		if prize.Percentage < 0 {
+			return false
+		}
+
+		total += prize.Percentage
+	}
+
+	return total == 100
+}
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+// PrizeStock is the inventory of a prize in a store
+// Reserved counts the units promised to claimed tickets which are not redeemed yet.
+type PrizeStock struct {
+	// Gorm model
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Additional fields
+	PrizeID   string     `gorm:"type:varchar(36);uniqueIndex:idx_prize_store;not null" json:"prize_id"`
+	StoreID   string     `gorm:"type:varchar(36);uniqueIndex:idx_prize_store;not null" json:"store_id"`
+	Quantity  int        `json:"quantity"`
+	Reserved  int        `json:"reserved"`
+	Threshold int        `json:"threshold"`
+	AlertedAt *time.Time `json:"alerted_at"`
+}
+
+func CreatePrizeStock(obj *transfert.PrizeStock) *PrizeStock {
+	stock := &PrizeStock{}
+
+	if obj.ID != nil {
+		stock.ID = *obj.ID
+	}
+
+	if obj.PrizeID != nil {
+		stock.PrizeID = *obj.PrizeID
+	}
+
+	if obj.StoreID != nil {
+		stock.StoreID = *obj.StoreID
+	}
+
+	if obj.Quantity != nil {
+		stock.Quantity = *obj.Quantity
+	}
+
+	if obj.Threshold != nil {
+		stock.Threshold = *obj.Threshold
+	}
+
+	return stock
+}
+
+// Available returns the units of the store which are not promised to a claimed ticket
+//
+// Returns:
+// - int: the units that can still be reserved or handed over
+func (stock *PrizeStock) Available() int {
+	return stock.Quantity - stock.Reserved
+}
+
+// IsLow checks that the available units reached the alert threshold of the store
+//
+// Returns:
+// - bool: true if the stock must be refilled
+func (stock *PrizeStock) IsLow() bool {
+	return stock.Available() <= stock.Threshold
+}
+
+func (stock *PrizeStock) IsPublic() bool {
+	return false
+}
+
+func (stock *PrizeStock) GetOwnerID() string {
+	return ""
+}
+
+func (stock *PrizeStock) BeforeCreate(tx *gorm.DB) error {
+	if stock.ID != "" {
+		return nil
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	stock.ID = id.String()
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePrizeStock(t *testing.T) {
+	stock := entities.CreatePrizeStock(&transfert.PrizeStock{
+		ID:        aws.String("stock-id"),
+		PrizeID:   aws.String("prize-id"),
+		StoreID:   aws.String("store-id"),
+		Quantity:  aws.Int(10),
+		Threshold: aws.Int(3),
+	})
+
+	assert.Equal(t, "stock-id", stock.ID)
+	assert.Equal(t, "prize-id", stock.PrizeID)
+	assert.Equal(t, "store-id", stock.StoreID)
+	assert.Equal(t, 10, stock.Quantity)
+	assert.Equal(t, 3, stock.Threshold)
+	assert.False(t, stock.IsPublic())
+	assert.Empty(t, stock.GetOwnerID())
+}
+
+func TestPrizeStockAvailable(t *testing.T) {
+	stock := &entities.PrizeStock{Quantity: 10, Reserved: 6, Threshold: 3}
+	assert.Equal(t, 4, stock.Available())
+	assert.False(t, stock.IsLow())
+
+	// Les lots réservés par des tickets réclamés ne sont plus disponibles
+	stock.Reserved = 7
+	assert.True(t, stock.IsLow())
+}
+
+func TestPrizeStockBeforeCreate(t *testing.T) {
+	stock := &entities.PrizeStock{}
+	assert.Nil(t, stock.BeforeCreate(nil))
+	assert.NotEmpty(t, stock.ID)
+
+	stock = &entities.PrizeStock{ID: "kept"}
+	assert.Nil(t, stock.BeforeCreate(nil))
+	assert.Equal(t, "kept", stock.ID)
+}
@@ -0,0 +1,59 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePrize(t *testing.T) {
+	input := &transfert.Prize{
+		ID:          aws.String(uuid.New().String()),
+		Code:        aws.String("infuser"),
+		Label:       aws.String("Infuseur à thé"),
+		Description: aws.String("Un infuseur en inox"),
+		Value:       aws.Float64(8.5),
+		Image:       aws.String("https://cdn.thetiptop.local/infuser.png"),
+		Percentage:  aws.Int(60),
+	}
+
+	prize := entities.CreatePrize(input)
+
+	assert.Equal(t, *input.ID, prize.ID)
+	assert.Equal(t, *input.Code, *prize.Code)
+	assert.Equal(t, *input.Label, *prize.Label)
+	assert.Equal(t, *input.Description, *prize.Description)
+	assert.Equal(t, 8.5, prize.Value)
+	assert.Equal(t, *input.Image, *prize.Image)
+	assert.Equal(t, 60, prize.Percentage)
+	assert.True(t, prize.IsPublic())
+	assert.Empty(t, prize.GetOwnerID())
+}
+
+func TestPrizeUpdate(t *testing.T) {
+	prize := entities.CreatePrize(&transfert.Prize{
+		Code:       aws.String("infuser"),
+		Label:      aws.String("Infuseur à thé"),
+		Value:      aws.Float64(8),
+		Percentage: aws.Int(60),
+	})
+
+	prize.Update(&transfert.Prize{Percentage: aws.Int(50)})
+
+	assert.Equal(t, "infuser", *prize.Code)
+	assert.Equal(t, "Infuseur à thé", *prize.Label)
+	assert.Equal(t, float64(8), prize.Value)
+	assert.Equal(t, 50, prize.Percentage)
+}
+
+func TestIsDispatchValid(t *testing.T) {
+	assert.True(t, entities.IsDispatchValid([]*entities.Prize{{Percentage: 60}, {Percentage: 40}}))
+	assert.True(t, entities.IsDispatchValid([]*entities.Prize{{Percentage: 100}, {Percentage: 0}}))
+	assert.False(t, entities.IsDispatchValid([]*entities.Prize{{Percentage: 60}, {Percentage: 30}}))
+	assert.False(t, entities.IsDispatchValid([]*entities.Prize{{Percentage: 110}, {Percentage: -10}}))
+	assert.False(t, entities.IsDispatchValid(nil))
+}
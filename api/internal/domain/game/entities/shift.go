@@ -0,0 +1,236 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+// Anomalies noted in the Z-report of a shift
+const (
+	// ShiftAnomalyOtherEmployee is a ticket issued or redeemed by another employee than the one running the caisse
+	ShiftAnomalyOtherEmployee = "other_employee"
+	// ShiftAnomalyIssuedAndRedeemed is a ticket issued and redeemed during the same shift
+	ShiftAnomalyIssuedAndRedeemed = "issued_and_redeemed"
+)
+
+// Shift is the period during which an employee runs a caisse
+// The tickets issued and redeemed at the caisse meanwhile are attributed to it. Closing the shift
+// freezes its Z-report: the totals, the counts per prize and the anomalies.
+type Shift struct {
+	// Gorm model
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Additional fields
+	CaisseID *string    `gorm:"type:varchar(36);index" json:"caisse_id"`
+	StoreID  *string    `gorm:"type:varchar(36);index" json:"store_id"`
+	OpenedBy *string    `gorm:"type:varchar(36);index" json:"opened_by"`
+	OpenedAt time.Time  `json:"opened_at"`
+	ClosedBy *string    `gorm:"type:varchar(36)" json:"closed_by"`
+	ClosedAt *time.Time `gorm:"index" json:"closed_at"`
+
+	// Z-report
+	Issued    int            `json:"issued"`
+	Redeemed  int            `json:"redeemed"`
+	Voided    int            `json:"voided"`
+	Amount    float64        `json:"amount"`
+	Lines     ShiftLines     `gorm:"foreignKey:ShiftID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines,omitempty"`
+	Anomalies ShiftAnomalies `gorm:"foreignKey:ShiftID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"anomalies,omitempty"`
+}
+
+type ShiftLines []*ShiftLine
+
+// ShiftLine counts the tickets of a prize in the Z-report of a shift
+type ShiftLine struct {
+	ID      string  `gorm:"type:varchar(36);primaryKey;" json:"-"`
+	ShiftID *string `gorm:"type:varchar(36);index" json:"-"`
+
+	PrizeID  *string `gorm:"type:varchar(36)" json:"prize_id"`
+	Issued   int     `json:"issued"`
+	Redeemed int     `json:"redeemed"`
+	Voided   int     `json:"voided"`
+}
+
+type ShiftAnomalies []*ShiftAnomaly
+
+// ShiftAnomaly is a ticket of the shift which a manager should look into
+type ShiftAnomaly struct {
+	ID      string  `gorm:"type:varchar(36);primaryKey;" json:"-"`
+	ShiftID *string `gorm:"type:varchar(36);index" json:"-"`
+
+	Kind         string  `gorm:"type:varchar(32)" json:"kind"`
+	TicketID     string  `gorm:"type:varchar(36)" json:"ticket_id"`
+	CredentialID *string `gorm:"type:varchar(36)" json:"credential_id"`
+}
+
+func CreateShift(obj *transfert.Shift) *Shift {
+	s := &Shift{
+		CaisseID: obj.CaisseID,
+		StoreID:  obj.StoreID,
+		OpenedBy: obj.OpenedBy,
+		OpenedAt: time.Now(),
+	}
+
+	if obj.ID != nil {
+		s.ID = *obj.ID
+	}
+
+	return s
+}
+
+func (shift *Shift) IsPublic() bool {
+	return false
+}
+
+func (shift *Shift) GetOwnerID() string {
+	if shift.OpenedBy == nil {
+		return ""
+	}
+
+	return *shift.OpenedBy
+}
+
+// IsOpen reports whether tickets are still attributed to the shift
+//
+// Returns:
+// - bool: true until the shift is closed
+func (shift *Shift) IsOpen() bool {
+	return shift.ClosedAt == nil
+}
+
+// Close ends the shift and computes its Z-report
+// Voids count the tickets issued during the shift which were voided since.
+//
+// Parameters:
+// - employeeID: *string the credential of the employee closing the shift
+// - issued: []*Ticket the tickets issued during the shift
+// - redeemed: []*Ticket the tickets redeemed during the shift
+//
+// Returns:
+// - bool: false if the shift is already closed
+func (shift *Shift) Close(employeeID *string, issued, redeemed []*Ticket) bool {
+	if !shift.IsOpen() {
+		return false
+	}
+
+	now := time.Now()
+	shift.ClosedAt = &now
+	shift.ClosedBy = employeeID
+	shift.Issued, shift.Redeemed, shift.Voided, shift.Amount = 0, 0, 0, 0
+	shift.Anomalies = ShiftAnomalies{}
+
+	lines := map[string]*ShiftLine{}
+	line := func(ticket *Ticket) *ShiftLine {
+		key := ""
+		if ticket.PrizeID != nil {
+			key = *ticket.PrizeID
+		}
+
+		if lines[key] == nil {
+			lines[key] = &ShiftLine{ShiftID: &shift.ID, PrizeID: ticket.PrizeID}
+		}
+
+		return lines[key]
+	}
+
+	for _, ticket := range issued {
+		line(ticket).Issued++
+		shift.Issued++
+
+		if ticket.Amount != nil {
+			shift.Amount += *ticket.Amount
+		}
+
+		if ticket.VoidedAt != nil {
+			line(ticket).Voided++
+			shift.Voided++
+		}
+
+		if !shift.isOpenedBy(ticket.IssuedBy) {
+			shift.flag(ShiftAnomalyOtherEmployee, ticket.ID, ticket.IssuedBy)
+		}
+	}
+
+	for _, ticket := range redeemed {
+		line(ticket).Redeemed++
+		shift.Redeemed++
+
+		if !shift.isOpenedBy(ticket.RedeemedBy) {
+			shift.flag(ShiftAnomalyOtherEmployee, ticket.ID, ticket.RedeemedBy)
+		}
+
+		if ticket.ShiftID != nil && *ticket.ShiftID == shift.ID {
+			shift.flag(ShiftAnomalyIssuedAndRedeemed, ticket.ID, ticket.RedeemedBy)
+		}
+	}
+
+	shift.Lines = ShiftLines{}
+	for _, line := range lines {
+		shift.Lines = append(shift.Lines, line)
+	}
+
+	sort.Slice(shift.Lines, func(i, j int) bool {
+		return shift.Lines[i].prize() < shift.Lines[j].prize()
+	})
+
+	return true
+}
+
+func (shift *Shift) isOpenedBy(credentialID *string) bool {
+	return shift.OpenedBy != nil && credentialID != nil && *shift.OpenedBy == *credentialID
+}
+
+func (shift *Shift) flag(kind, ticketID string, credentialID *string) {
+	shift.Anomalies = append(shift.Anomalies, &ShiftAnomaly{
+		ShiftID:      &shift.ID,
+		Kind:         kind,
+		TicketID:     ticketID,
+		CredentialID: credentialID,
+	})
+}
+
+func (line *ShiftLine) prize() string {
+	if line.PrizeID == nil {
+		return ""
+	}
+
+	return *line.PrizeID
+}
+
+func (shift *Shift) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	shift.ID = id.String()
+
+	return nil
+}
+
+func (line *ShiftLine) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	line.ID = id.String()
+
+	return nil
+}
+
+func (anomaly *ShiftAnomaly) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	anomaly.ID = id.String()
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateShift(t *testing.T) {
+	input := &transfert.Shift{
+		ID:       aws.String("shift-1"),
+		CaisseID: aws.String("caisse-1"),
+		StoreID:  aws.String("store-1"),
+		OpenedBy: aws.String("employee-1"),
+	}
+
+	shift := entities.CreateShift(input)
+
+	assert.Equal(t, "shift-1", shift.ID)
+	assert.Equal(t, input.CaisseID, shift.CaisseID)
+	assert.Equal(t, input.StoreID, shift.StoreID)
+	assert.Equal(t, "employee-1", shift.GetOwnerID())
+	assert.False(t, shift.OpenedAt.IsZero())
+	assert.True(t, shift.IsOpen())
+	assert.False(t, shift.IsPublic())
+
+	assert.Equal(t, "", entities.CreateShift(&transfert.Shift{}).GetOwnerID())
+}
+
+func TestShift_Close(t *testing.T) {
+	employee := aws.String("employee-1")
+	other := aws.String("employee-2")
+	now := time.Now()
+
+	t.Run("computes the Z-report", func(t *testing.T) {
+		shift := &entities.Shift{ID: "shift-1", OpenedBy: employee}
+
+		issued := []*entities.Ticket{
+			{ID: "ticket-1", PrizeID: aws.String("prize-b"), IssuedBy: employee, Amount: aws.Float64(20), ShiftID: aws.String("shift-1")},
+			{ID: "ticket-2", PrizeID: aws.String("prize-a"), IssuedBy: employee, Amount: aws.Float64(30.5), ShiftID: aws.String("shift-1"), VoidedAt: &now},
+			{ID: "ticket-3", PrizeID: aws.String("prize-b"), IssuedBy: other, ShiftID: aws.String("shift-1")},
+		}
+
+		redeemed := []*entities.Ticket{
+			{ID: "ticket-1", PrizeID: aws.String("prize-b"), RedeemedBy: employee, ShiftID: aws.String("shift-1")},
+			{ID: "ticket-4", PrizeID: aws.String("prize-a"), RedeemedBy: other, ShiftID: aws.String("shift-0")},
+		}
+
+		assert.True(t, shift.Close(employee, issued, redeemed))
+		assert.False(t, shift.IsOpen())
+		assert.Equal(t, employee, shift.ClosedBy)
+
+		assert.Equal(t, 3, shift.Issued)
+		assert.Equal(t, 2, shift.Redeemed)
+		assert.Equal(t, 1, shift.Voided)
+		assert.Equal(t, 50.5, shift.Amount)
+
+		if assert.Len(t, shift.Lines, 2) {
+			assert.Equal(t, "prize-a", *shift.Lines[0].PrizeID)
+			assert.Equal(t, 1, shift.Lines[0].Issued)
+			assert.Equal(t, 1, shift.Lines[0].Redeemed)
+			assert.Equal(t, 1, shift.Lines[0].Voided)
+
+			assert.Equal(t, "prize-b", *shift.Lines[1].PrizeID)
+			assert.Equal(t, 2, shift.Lines[1].Issued)
+			assert.Equal(t, 1, shift.Lines[1].Redeemed)
+			assert.Equal(t, 0, shift.Lines[1].Voided)
+		}
+
+		if assert.Len(t, shift.Anomalies, 3) {
+			assert.Equal(t, entities.ShiftAnomalyOtherEmployee, shift.Anomalies[0].Kind)
+			assert.Equal(t, "ticket-3", shift.Anomalies[0].TicketID)
+			assert.Equal(t, other, shift.Anomalies[0].CredentialID)
+
+			assert.Equal(t, entities.ShiftAnomalyIssuedAndRedeemed, shift.Anomalies[1].Kind)
+			assert.Equal(t, "ticket-1", shift.Anomalies[1].TicketID)
+
+			assert.Equal(t, entities.ShiftAnomalyOtherEmployee, shift.Anomalies[2].Kind)
+			assert.Equal(t, "ticket-4", shift.Anomalies[2].TicketID)
+		}
+	})
+
+	t.Run("empty shift", func(t *testing.T) {
+		shift := &entities.Shift{ID: "shift-1", OpenedBy: employee}
+
+		assert.True(t, shift.Close(other, nil, nil))
+		assert.Equal(t, other, shift.ClosedBy)
+		assert.Equal(t, 0, shift.Issued)
+		assert.Empty(t, shift.Lines)
+		assert.Empty(t, shift.Anomalies)
+	})
+
+	t.Run("already closed", func(t *testing.T) {
+		shift := &entities.Shift{ID: "shift-1", OpenedBy: employee, ClosedAt: &now, Issued: 4}
+
+		assert.False(t, shift.Close(employee, []*entities.Ticket{{ID: "ticket-1"}}, nil))
+		assert.Equal(t, 4, shift.Issued)
+		assert.Equal(t, &now, shift.ClosedAt)
+	})
+}
+
+func TestShift_BeforeCreate(t *testing.T) {
+	shift := &entities.Shift{}
+	line := &entities.ShiftLine{}
+	anomaly := &entities.ShiftAnomaly{}
+
+	assert.Nil(t, shift.BeforeCreate(nil))
+	assert.Nil(t, line.BeforeCreate(nil))
+	assert.Nil(t, anomaly.BeforeCreate(nil))
+
+	assert.Len(t, shift.ID, 36)
+	assert.Len(t, line.ID, 36)
+	assert.Len(t, anomaly.ID, 36)
+}
@@ -0,0 +1,27 @@
+package entities
+
+// Dimensions of the statistics
+const (
+	StatisticsByDay   = "day"
+	StatisticsByPrize = "prize"
+	StatisticsByStore = "store"
+)
+
+// StatisticsDimensions are the dimensions a breakdown can be made by
+var StatisticsDimensions = []string{StatisticsByDay, StatisticsByPrize, StatisticsByStore}
+
+// Aggregate is a group of tickets counted by the database
+type Aggregate struct {
+	Bucket string  `json:"bucket"`
+	Count  int     `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+// Statistic sums up the lifecycle of the tickets of a day, a prize or a store
+type Statistic struct {
+	Key      string  `json:"key"`
+	Issued   int     `json:"issued"`
+	Claimed  int     `json:"claimed"`
+	Redeemed int     `json:"redeemed"`
+	Amount   float64 `json:"amount"`
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+)
+
+// Types of the operations uploaded by an offline caisse
+const (
+	SyncOperationIssue  = "issue"
+	SyncOperationRedeem = "redeem"
+)
+
+// Outcomes of the operations uploaded by an offline caisse
+const (
+	SyncOperationApplied  = "applied"
+	SyncOperationConflict = "conflict"
+)
+
+// SyncOperation is the outcome of an operation uploaded by an offline caisse
+// Its ID is the idempotency ID generated by the caisse: an operation uploaded again is not applied twice,
+// the recorded outcome is returned instead.
+type SyncOperation struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"synced_at"`
+
+	// Additional fields
+	Type         string    `gorm:"type:varchar(16)" json:"type"`
+	Status       string    `gorm:"type:varchar(16);index" json:"status"`
+	Error        *string   `gorm:"type:varchar(64)" json:"error,omitempty"`
+	OccurredAt   time.Time `gorm:"index" json:"occurred_at"`
+	CaisseID     *string   `gorm:"type:varchar(36);index" json:"caisse_id"`
+	TicketID     *string   `gorm:"type:varchar(36);index" json:"ticket_id,omitempty"`
+	CredentialID *string   `gorm:"type:varchar(36)" json:"credential_id"`
+
+	// Replayed tells the caisse the operation had already been uploaded
+	Replayed bool `gorm:"-" json:"replayed"`
+}
+
+// IsApplied reports whether the operation changed the ticket
+func (operation *SyncOperation) IsApplied() bool {
+	return operation.Status == SyncOperationApplied
+}
+
+func (operation *SyncOperation) IsPublic() bool {
+	return false
+}
+
+func (operation *SyncOperation) GetOwnerID() string {
+	if operation.CredentialID == nil {
+		return ""
+	}
+
+	return *operation.CredentialID
+}
+
+func CreateSyncOperation(obj *transfert.SyncOperation) *SyncOperation {
+	o := &SyncOperation{
+		Error:        obj.Error,
+		CaisseID:     obj.CaisseID,
+		TicketID:     obj.TicketID,
+		CredentialID: obj.CredentialID,
+	}
+
+	if obj.ID != nil {
+		o.ID = *obj.ID
+	}
+
+	if obj.Type != nil {
+		o.Type = *obj.Type
+	}
+
+	if obj.Status != nil {
+		o.Status = *obj.Status
+	}
+
+	if obj.OccurredAt != nil {
+		if occurredAt, err := time.Parse(time.RFC3339, *obj.OccurredAt); err == nil {
+			o.OccurredAt = occurredAt
+		}
+	}
+
+	return o
+}
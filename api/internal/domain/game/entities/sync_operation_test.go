@@ -0,0 +1,41 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSyncOperation(t *testing.T) {
+	input := &transfert.SyncOperation{
+		ID:           aws.String("operation-1"),
+		Type:         aws.String(entities.SyncOperationRedeem),
+		OccurredAt:   aws.String("2024-03-01T10:30:00Z"),
+		CaisseID:     aws.String("caisse-1"),
+		TicketID:     aws.String("ticket-1"),
+		CredentialID: aws.String("employee-1"),
+		Status:       aws.String(entities.SyncOperationApplied),
+	}
+
+	operation := entities.CreateSyncOperation(input)
+
+	assert.Equal(t, "operation-1", operation.ID)
+	assert.Equal(t, entities.SyncOperationRedeem, operation.Type)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC), operation.OccurredAt.UTC())
+	assert.Equal(t, input.CaisseID, operation.CaisseID)
+	assert.Equal(t, input.TicketID, operation.TicketID)
+	assert.Equal(t, "employee-1", operation.GetOwnerID())
+	assert.Nil(t, operation.Error)
+	assert.True(t, operation.IsApplied())
+	assert.False(t, operation.IsPublic())
+	assert.False(t, operation.Replayed)
+
+	empty := entities.CreateSyncOperation(&transfert.SyncOperation{OccurredAt: aws.String("yesterday")})
+	assert.Equal(t, "", empty.GetOwnerID())
+	assert.True(t, empty.OccurredAt.IsZero())
+	assert.False(t, empty.IsApplied())
+}
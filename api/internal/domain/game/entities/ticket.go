@@ -1,7 +1,9 @@
 package entities
 
 import (
+	"math/rand/v2"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
@@ -19,14 +21,60 @@ type Ticket struct {
 	// Additional fields
 	CredentialID *string    `gorm:"type:varchar(36);index" json:"credential_id"`
 	Token        token.Luhn `gorm:"type:varchar(16);uniqueIndex" json:"token"`
-	Prize        *string    `gorm:"type:varchar(36);index" json:"prize"`
+	PrizeID      *string    `gorm:"type:varchar(36);index" json:"prize_id"`
+	Prize        *Prize     `gorm:"foreignKey:PrizeID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"prize,omitempty"`
+
+	// Redemption
+	Status           TicketStatus `gorm:"type:varchar(16);index" json:"status"`
+	ClaimedAt        *time.Time   `json:"claimed_at"`
+	RedeemedAt       *time.Time   `json:"redeemed_at"`
+	RedeemedCaisseID *string      `gorm:"type:varchar(36);index" json:"redeemed_caisse_id"`
+	RedeemedBy       *string      `gorm:"type:varchar(36);index" json:"redeemed_by"`
+	RedeemedShiftID  *string      `gorm:"type:varchar(36);index" json:"redeemed_shift_id,omitempty"`
+
+	// Campaign
+	CampaignID *string   `gorm:"type:varchar(36);index" json:"campaign_id"`
+	Campaign   *Campaign `gorm:"foreignKey:CampaignID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+
+	// Issuance
+	Sequence *int64 `gorm:"uniqueIndex" json:"-"`
+
+	// Distribution, a receipt gets a single ticket per store
+	StoreID  *string    `gorm:"type:varchar(36);index;uniqueIndex:idx_tickets_store_receipt,priority:1" json:"store_id"`
+	CaisseID *string    `gorm:"type:varchar(36);index" json:"caisse_id"`
+	Receipt  *string    `gorm:"type:varchar(64);uniqueIndex:idx_tickets_store_receipt,priority:2" json:"receipt"`
+	Amount   *float64   `json:"amount"`
+	IssuedAt *time.Time `json:"issued_at"`
+	IssuedBy *string    `gorm:"type:varchar(36);index" json:"issued_by"`
+	ShiftID  *string    `gorm:"type:varchar(36);index" json:"shift_id,omitempty"`
+
+	// Void
+	VoidedAt       *time.Time `gorm:"index" json:"voided_at,omitempty"`
+	VoidedBy       *string    `gorm:"type:varchar(36)" json:"voided_by,omitempty"`
+	VoidReason     *string    `gorm:"type:varchar(255)" json:"void_reason,omitempty"`
+	ReissuedFromID *string    `gorm:"type:varchar(36);index" json:"reissued_from_id,omitempty"`
+
+	// Stock
+	ReservedStoreID *string `gorm:"type:varchar(36);index" json:"reserved_store_id,omitempty"`
+}
+
+// RandomTicketSequence returns a random position in the issuance sequence
+// Tickets get a random position when they are created, ordering them by position shuffles them
+// whatever the order and the prize they were generated with.
+//
+// Returns:
+// - int64: a non-negative position
+func RandomTicketSequence() int64 {
+	return rand.Int64()
 }
 
 func CreateTicket(obj *transfert.Ticket) *Ticket {
 	t := &Ticket{
 		CredentialID: obj.CredentialID,
-		Prize:        obj.Prize,
+		PrizeID:      obj.PrizeID,
 		Token:        token.NewLuhnP(obj.Token),
+		CampaignID:   obj.CampaignID,
+		StoreID:      obj.StoreID,
 	}
 
 	if obj.ID != nil {
@@ -48,6 +96,160 @@ func (ticket *Ticket) GetOwnerID() string {
 	return *ticket.CredentialID
 }
 
+// GetStatus returns the lifecycle state of the ticket
+// Tickets stored before the state machine existed have no status, it is deduced from the claim.
+//
+// Returns:
+// - TicketStatus: the current state
+func (ticket *Ticket) GetStatus() TicketStatus {
+	if ticket.Status != "" {
+		return ticket.Status
+	}
+
+	if ticket.CredentialID != nil {
+		return TicketClaimed
+	}
+
+	return TicketUnclaimed
+}
+
+// Claim links the ticket to the credential of the player
+//
+// Parameters:
+// - credentialID: *string the credential claiming the ticket
+//
+// Returns:
+// - bool: false if the ticket cannot be claimed from its current state
+func (ticket *Ticket) Claim(credentialID *string) bool {
+	if !ticket.GetStatus().CanTransitionTo(TicketClaimed) {
+		return false
+	}
+
+	now := time.Now()
+	ticket.CredentialID = credentialID
+	ticket.Status = TicketClaimed
+	ticket.ClaimedAt = &now
+
+	return true
+}
+
+// Issue hands the ticket over at a caisse for a purchase
+// Only a ticket still in the pool, neither claimed nor sent to a store, can be issued.
+//
+// Parameters:
+// - caisseID: *string the caisse issuing the ticket
+// - storeID: *string the store of the caisse
+// - receipt: *string the receipt number of the purchase
+// - amount: *float64 the amount of the purchase
+// - employeeID: *string the credential of the employee issuing the ticket
+//
+// Returns:
+// - bool: false if the ticket is not in the pool anymore
+func (ticket *Ticket) Issue(caisseID, storeID, receipt *string, amount *float64, employeeID *string) bool {
+	if ticket.GetStatus() != TicketUnclaimed || ticket.StoreID != nil || ticket.IssuedAt != nil {
+		return false
+	}
+
+	now := time.Now()
+	ticket.CaisseID = caisseID
+	ticket.StoreID = storeID
+	ticket.Receipt = receipt
+	ticket.Amount = amount
+	ticket.IssuedAt = &now
+	ticket.IssuedBy = employeeID
+
+	return true
+}
+
+// Redeem marks the prize of the ticket as handed over at a caisse
+//
+// Parameters:
+// - caisseID: *string the caisse delivering the prize
+// - employeeID: *string the credential of the employee delivering the prize
+//
+// Returns:
+// - bool: false if the ticket cannot be redeemed from its current state
+func (ticket *Ticket) Redeem(caisseID, employeeID *string) bool {
+	if !ticket.GetStatus().CanTransitionTo(TicketRedeemed) {
+		return false
+	}
+
+	now := time.Now()
+	ticket.Status = TicketRedeemed
+	ticket.RedeemedAt = &now
+	ticket.RedeemedCaisseID = caisseID
+	ticket.RedeemedBy = employeeID
+
+	return true
+}
+
+// Expire marks a claimed ticket whose prize was not collected in time
+//
+// Returns:
+// - bool: false if the ticket cannot expire from its current state
+func (ticket *Ticket) Expire() bool {
+	if !ticket.GetStatus().CanTransitionTo(TicketExpired) {
+		return false
+	}
+
+	ticket.Status = TicketExpired
+
+	return true
+}
+
+// Void takes the ticket out of the game, its record is kept with the reason
+// A redeemed or expired ticket is final and cannot be voided anymore.
+//
+// Parameters:
+// - reason: *string why the ticket is voided
+// - adminID: *string the credential of the administrator voiding the ticket
+//
+// Returns:
+// - bool: false if the ticket cannot be voided from its current state
+func (ticket *Ticket) Void(reason, adminID *string) bool {
+	if !ticket.GetStatus().CanTransitionTo(TicketVoided) {
+		return false
+	}
+
+	// The reason is free text typed by the administrator
+	if reason != nil && utf8.RuneCountInString(*reason) > 255 {
+		truncated := string([]rune(*reason)[:255])
+		reason = &truncated
+	}
+
+	now := time.Now()
+	ticket.Status = TicketVoided
+	ticket.VoidedAt = &now
+	ticket.VoidedBy = adminID
+	ticket.VoidReason = reason
+
+	return true
+}
+
+// Reissue builds the ticket replacing a voided one under a fresh code
+// The replacement carries over the prize, the campaign and the distribution of the voided ticket,
+// so the purchase keeps its ticket and the prize keeps its share of the game. It starts unclaimed.
+//
+// Parameters:
+// - code: token.Luhn the code of the replacement
+//
+// Returns:
+// - *Ticket: the replacement, not stored yet
+func (ticket *Ticket) Reissue(code token.Luhn) *Ticket {
+	return &Ticket{
+		Token:          code,
+		PrizeID:        ticket.PrizeID,
+		CampaignID:     ticket.CampaignID,
+		StoreID:        ticket.StoreID,
+		CaisseID:       ticket.CaisseID,
+		Receipt:        ticket.Receipt,
+		Amount:         ticket.Amount,
+		IssuedAt:       ticket.IssuedAt,
+		IssuedBy:       ticket.IssuedBy,
+		ReissuedFromID: &ticket.ID,
+	}
+}
+
 func (ticket *Ticket) BeforeUpdate(tx *gorm.DB) error {
 	ticket.UpdatedAt = time.Now()
 	return nil
@@ -60,6 +262,12 @@ func (ticket *Ticket) BeforeCreate(tx *gorm.DB) error {
 	}
 
 	ticket.ID = id.String()
+	ticket.Status = ticket.GetStatus()
+
+	if ticket.Sequence == nil {
+		sequence := RandomTicketSequence()
+		ticket.Sequence = &sequence
+	}
 
 	return nil
 }
@@ -0,0 +1,47 @@
+package entities
+
+// TicketStatus defines the lifecycle state of a ticket
+type TicketStatus string
+
+const (
+	TicketUnclaimed TicketStatus = "unclaimed"
+	TicketClaimed   TicketStatus = "claimed"
+	TicketRedeemed  TicketStatus = "redeemed"
+	TicketExpired   TicketStatus = "expired"
+	TicketVoided    TicketStatus = "voided"
+)
+
+// ticketTransitions lists the states reachable from each state, final states have none
+var ticketTransitions = map[TicketStatus][]TicketStatus{
+	TicketUnclaimed: {TicketClaimed, TicketVoided},
+	TicketClaimed:   {TicketRedeemed, TicketExpired, TicketVoided},
+}
+
+func (s TicketStatus) String() string {
+	return string(s)
+}
+
+// CanTransitionTo checks if the state machine allows moving to the next state
+//
+// Parameters:
+// - next: TicketStatus the wanted state
+//
+// Returns:
+// - bool: true if the transition is allowed
+func (s TicketStatus) CanTransitionTo(next TicketStatus) bool {
+	for _, allowed := range ticketTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsFinal checks if no transition can leave the state
+//
+// Returns:
+// - bool: true if the state is final
+func (s TicketStatus) IsFinal() bool {
+	return len(ticketTransitions[s]) == 0
+}
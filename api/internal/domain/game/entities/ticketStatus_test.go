@@ -0,0 +1,35 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, entities.TicketUnclaimed.CanTransitionTo(entities.TicketClaimed))
+	assert.True(t, entities.TicketUnclaimed.CanTransitionTo(entities.TicketVoided))
+	assert.False(t, entities.TicketUnclaimed.CanTransitionTo(entities.TicketRedeemed))
+
+	assert.True(t, entities.TicketClaimed.CanTransitionTo(entities.TicketRedeemed))
+	assert.True(t, entities.TicketClaimed.CanTransitionTo(entities.TicketExpired))
+	assert.True(t, entities.TicketClaimed.CanTransitionTo(entities.TicketVoided))
+	assert.False(t, entities.TicketClaimed.CanTransitionTo(entities.TicketClaimed))
+
+	assert.False(t, entities.TicketRedeemed.CanTransitionTo(entities.TicketClaimed))
+	assert.False(t, entities.TicketExpired.CanTransitionTo(entities.TicketRedeemed))
+	assert.False(t, entities.TicketVoided.CanTransitionTo(entities.TicketClaimed))
+}
+
+func TestTicketStatus_IsFinal(t *testing.T) {
+	assert.False(t, entities.TicketUnclaimed.IsFinal())
+	assert.False(t, entities.TicketClaimed.IsFinal())
+	assert.True(t, entities.TicketRedeemed.IsFinal())
+	assert.True(t, entities.TicketExpired.IsFinal())
+	assert.True(t, entities.TicketVoided.IsFinal())
+}
+
+func TestTicketStatus_String(t *testing.T) {
+	assert.Equal(t, "redeemed", entities.TicketRedeemed.String())
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+// Actions recorded in the history of a ticket
+const (
+	TicketEventIssued   = "issued"
+	TicketEventClaimed  = "claimed"
+	TicketEventRedeemed = "redeemed"
+	TicketEventExpired  = "expired"
+	TicketEventVoided   = "voided"
+	TicketEventReissued = "reissued"
+	TicketEventViewed   = "viewed"
+)
+
+// ErrTicketEventAppendOnly is returned by the database when an event is changed after being recorded
+var ErrTicketEventAppendOnly = errors.New("ticket events are append-only")
+
+// TicketEvent is an entry of the audit trail of a ticket
+// The events are only ever appended, they have no soft delete and cannot be updated.
+type TicketEvent struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	// Additional fields
+	TicketID     string  `gorm:"type:varchar(36);index;not null" json:"ticket_id"`
+	Action       string  `gorm:"type:varchar(16);index" json:"action"`
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"`
+	Role         *string `gorm:"type:varchar(16)" json:"role"`
+	IP           *string `gorm:"type:varchar(45)" json:"ip"`
+	UserAgent    *string `gorm:"type:varchar(255)" json:"user_agent"`
+}
+
+func CreateTicketEvent(obj *transfert.TicketEvent) *TicketEvent {
+	e := &TicketEvent{
+		CredentialID: obj.CredentialID,
+		Role:         obj.Role,
+		IP:           obj.IP,
+		UserAgent:    obj.UserAgent,
+	}
+
+	if obj.ID != nil {
+		e.ID = *obj.ID
+	}
+
+	if obj.TicketID != nil {
+		e.TicketID = *obj.TicketID
+	}
+
+	if obj.Action != nil {
+		e.Action = *obj.Action
+	}
+
+	// The user agent is free text sent by the client
+	if e.UserAgent != nil && len(*e.UserAgent) > 255 {
+		userAgent := (*e.UserAgent)[:255]
+		e.UserAgent = &userAgent
+	}
+
+	return e
+}
+
+func (event *TicketEvent) IsPublic() bool {
+	return false
+}
+
+func (event *TicketEvent) GetOwnerID() string {
+	if event.CredentialID == nil {
+		return ""
+	}
+
+	return *event.CredentialID
+}
+
+func (event *TicketEvent) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	event.ID = id.String()
+
+	return nil
+}
+
+func (event *TicketEvent) BeforeUpdate(tx *gorm.DB) error {
+	return ErrTicketEventAppendOnly
+}
+
+func (event *TicketEvent) BeforeDelete(tx *gorm.DB) error {
+	return ErrTicketEventAppendOnly
+}
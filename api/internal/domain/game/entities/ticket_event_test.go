@@ -0,0 +1,42 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTicketEvent(t *testing.T) {
+	input := &transfert.TicketEvent{
+		ID:           aws.String("event-1"),
+		TicketID:     aws.String("ticket-1"),
+		Action:       aws.String(entities.TicketEventClaimed),
+		CredentialID: aws.String("client-123"),
+		Role:         aws.String("client"),
+		IP:           aws.String("203.0.113.7"),
+		UserAgent:    aws.String(strings.Repeat("a", 300)),
+	}
+
+	event := entities.CreateTicketEvent(input)
+
+	assert.Equal(t, "event-1", event.ID)
+	assert.Equal(t, "ticket-1", event.TicketID)
+	assert.Equal(t, entities.TicketEventClaimed, event.Action)
+	assert.Equal(t, input.IP, event.IP)
+	assert.Len(t, *event.UserAgent, 255)
+	assert.Equal(t, "client-123", event.GetOwnerID())
+	assert.False(t, event.IsPublic())
+}
+
+func TestTicketEvent_AppendOnly(t *testing.T) {
+	event := &entities.TicketEvent{}
+
+	assert.Nil(t, event.BeforeCreate(nil))
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, entities.ErrTicketEventAppendOnly, event.BeforeUpdate(nil))
+	assert.Equal(t, entities.ErrTicketEventAppendOnly, event.BeforeDelete(nil))
+}
@@ -1,8 +1,10 @@
 package entities_test
 
 import (
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/uuid"
@@ -19,7 +21,7 @@ func TestCreateTicket(t *testing.T) {
 	input := &transfert.Ticket{
 		ID:           aws.String(uuid.New().String()),
 		CredentialID: aws.String(uuid.New().String()),
-		Prize:        aws.String("PrizeA"),
+		PrizeID:      aws.String("PrizeA"),
 		Token:        aws.String("123456"),
 	}
 
@@ -28,7 +30,7 @@ func TestCreateTicket(t *testing.T) {
 	assert.NotNil(t, ticket)
 	assert.Equal(t, *input.ID, ticket.ID)
 	assert.Equal(t, *input.CredentialID, *ticket.CredentialID)
-	assert.Equal(t, *input.Prize, *ticket.Prize)
+	assert.Equal(t, *input.PrizeID, *ticket.PrizeID)
 	assert.Equal(t, token.Luhn("123456"), ticket.Token)
 }
 
@@ -37,7 +39,7 @@ func TestCreateTicketWithNilFields(t *testing.T) {
 	input := &transfert.Ticket{
 		ID:           nil,
 		CredentialID: nil,
-		Prize:        nil,
+		PrizeID:      nil,
 		Token:        aws.String("123456"),
 	}
 
@@ -46,7 +48,7 @@ func TestCreateTicketWithNilFields(t *testing.T) {
 	assert.NotNil(t, ticket)
 	assert.Empty(t, ticket.ID)
 	assert.Nil(t, ticket.CredentialID)
-	assert.Nil(t, ticket.Prize)
+	assert.Nil(t, ticket.PrizeID)
 	assert.Equal(t, token.Luhn("123456"), ticket.Token)
 }
 
@@ -78,6 +80,169 @@ func TestTicket_BeforeCreate(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.NotEmpty(t, ticket.ID)
+	assert.Equal(t, entities.TicketUnclaimed, ticket.Status)
+	assert.NotNil(t, ticket.Sequence)
+	assert.GreaterOrEqual(t, *ticket.Sequence, int64(0))
+
+	claimed := &entities.Ticket{CredentialID: aws.String(uuid.New().String())}
+	assert.Nil(t, claimed.BeforeCreate(nil))
+	assert.Equal(t, entities.TicketClaimed, claimed.Status)
+
+	sequence := int64(42)
+	sequenced := &entities.Ticket{Sequence: &sequence}
+	assert.Nil(t, sequenced.BeforeCreate(nil))
+	assert.Equal(t, int64(42), *sequenced.Sequence)
+}
+
+func TestTicket_GetStatus(t *testing.T) {
+	assert.Equal(t, entities.TicketUnclaimed, (&entities.Ticket{}).GetStatus())
+	assert.Equal(t, entities.TicketClaimed, (&entities.Ticket{CredentialID: aws.String("client")}).GetStatus())
+	assert.Equal(t, entities.TicketVoided, (&entities.Ticket{Status: entities.TicketVoided}).GetStatus())
+}
+
+func TestTicket_Claim(t *testing.T) {
+	credentialID := aws.String(uuid.New().String())
+
+	t.Run("unclaimed ticket", func(t *testing.T) {
+		ticket := &entities.Ticket{}
+		assert.True(t, ticket.Claim(credentialID))
+		assert.Equal(t, credentialID, ticket.CredentialID)
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+	})
+
+	t.Run("already claimed ticket", func(t *testing.T) {
+		ticket := &entities.Ticket{CredentialID: aws.String("other")}
+		assert.False(t, ticket.Claim(credentialID))
+		assert.Equal(t, "other", *ticket.CredentialID)
+	})
+
+	t.Run("voided ticket", func(t *testing.T) {
+		ticket := &entities.Ticket{Status: entities.TicketVoided}
+		assert.False(t, ticket.Claim(credentialID))
+		assert.Nil(t, ticket.CredentialID)
+	})
+}
+
+func TestTicket_Issue(t *testing.T) {
+	caisseID := aws.String(uuid.New().String())
+	storeID := aws.String(uuid.New().String())
+	employeeID := aws.String(uuid.New().String())
+
+	t.Run("ticket of the pool", func(t *testing.T) {
+		ticket := &entities.Ticket{}
+		assert.True(t, ticket.Issue(caisseID, storeID, aws.String("R-0001"), aws.Float64(54.9), employeeID))
+		assert.Equal(t, caisseID, ticket.CaisseID)
+		assert.Equal(t, storeID, ticket.StoreID)
+		assert.Equal(t, "R-0001", *ticket.Receipt)
+		assert.Equal(t, 54.9, *ticket.Amount)
+		assert.Equal(t, employeeID, ticket.IssuedBy)
+		assert.NotNil(t, ticket.IssuedAt)
+		assert.Equal(t, entities.TicketUnclaimed, ticket.GetStatus())
+	})
+
+	t.Run("issued twice", func(t *testing.T) {
+		ticket := &entities.Ticket{}
+		assert.True(t, ticket.Issue(caisseID, storeID, aws.String("R-0001"), aws.Float64(54.9), employeeID))
+		assert.False(t, ticket.Issue(aws.String("other"), storeID, aws.String("R-0002"), aws.Float64(60), employeeID))
+		assert.Equal(t, caisseID, ticket.CaisseID)
+	})
+
+	t.Run("printed or claimed ticket", func(t *testing.T) {
+		printed := &entities.Ticket{StoreID: storeID}
+		assert.False(t, printed.Issue(caisseID, storeID, aws.String("R-0001"), aws.Float64(54.9), employeeID))
+		assert.Nil(t, printed.IssuedAt)
+
+		claimed := &entities.Ticket{CredentialID: aws.String("client"), Status: entities.TicketClaimed}
+		assert.False(t, claimed.Issue(caisseID, storeID, aws.String("R-0001"), aws.Float64(54.9), employeeID))
+		assert.Nil(t, claimed.IssuedAt)
+	})
+}
+
+func TestTicket_Redeem(t *testing.T) {
+	caisseID := aws.String(uuid.New().String())
+	employeeID := aws.String(uuid.New().String())
+
+	t.Run("claimed ticket", func(t *testing.T) {
+		ticket := &entities.Ticket{CredentialID: aws.String("client"), Status: entities.TicketClaimed}
+		assert.True(t, ticket.Redeem(caisseID, employeeID))
+		assert.Equal(t, entities.TicketRedeemed, ticket.Status)
+		assert.Equal(t, caisseID, ticket.RedeemedCaisseID)
+		assert.Equal(t, employeeID, ticket.RedeemedBy)
+		assert.NotNil(t, ticket.RedeemedAt)
+	})
+
+	t.Run("redeemed twice", func(t *testing.T) {
+		ticket := &entities.Ticket{CredentialID: aws.String("client"), Status: entities.TicketClaimed}
+		assert.True(t, ticket.Redeem(caisseID, employeeID))
+		redeemedAt := ticket.RedeemedAt
+		assert.False(t, ticket.Redeem(aws.String("other"), employeeID))
+		assert.Equal(t, redeemedAt, ticket.RedeemedAt)
+		assert.Equal(t, caisseID, ticket.RedeemedCaisseID)
+	})
+
+	t.Run("unclaimed ticket", func(t *testing.T) {
+		ticket := &entities.Ticket{}
+		assert.False(t, ticket.Redeem(caisseID, employeeID))
+		assert.Nil(t, ticket.RedeemedAt)
+	})
+}
+
+func TestTicket_Void(t *testing.T) {
+	adminID := aws.String(uuid.New().String())
+	reason := aws.String("misprinted")
+
+	t.Run("unclaimed ticket", func(t *testing.T) {
+		ticket := &entities.Ticket{}
+		assert.True(t, ticket.Void(reason, adminID))
+		assert.Equal(t, entities.TicketVoided, ticket.Status)
+		assert.Equal(t, reason, ticket.VoidReason)
+		assert.Equal(t, adminID, ticket.VoidedBy)
+		assert.NotNil(t, ticket.VoidedAt)
+	})
+
+	t.Run("claimed ticket", func(t *testing.T) {
+		ticket := &entities.Ticket{CredentialID: aws.String("client"), Status: entities.TicketClaimed}
+		assert.True(t, ticket.Void(reason, adminID))
+		assert.Equal(t, entities.TicketVoided, ticket.Status)
+	})
+
+	t.Run("final ticket", func(t *testing.T) {
+		for _, status := range []entities.TicketStatus{entities.TicketRedeemed, entities.TicketExpired, entities.TicketVoided} {
+			ticket := &entities.Ticket{Status: status}
+			assert.False(t, ticket.Void(reason, adminID))
+			assert.Nil(t, ticket.VoidedAt)
+			assert.Equal(t, status, ticket.Status)
+		}
+	})
+
+	t.Run("long reason", func(t *testing.T) {
+		ticket := &entities.Ticket{}
+		assert.True(t, ticket.Void(aws.String(strings.Repeat("é", 300)), adminID))
+		assert.Equal(t, 255, utf8.RuneCountInString(*ticket.VoidReason))
+	})
+}
+
+func TestTicket_Reissue(t *testing.T) {
+	ticket := &entities.Ticket{
+		ID:           "voided",
+		Token:        token.Luhn("000000000018"),
+		CredentialID: aws.String("client"),
+		PrizeID:      aws.String("prize"),
+		CampaignID:   aws.String("campaign"),
+		StoreID:      aws.String("store"),
+		Receipt:      aws.String("R-0001"),
+		Status:       entities.TicketVoided,
+	}
+
+	replacement := ticket.Reissue(token.Luhn("000000000026"))
+	assert.Equal(t, token.Luhn("000000000026"), replacement.Token)
+	assert.Equal(t, ticket.PrizeID, replacement.PrizeID)
+	assert.Equal(t, ticket.CampaignID, replacement.CampaignID)
+	assert.Equal(t, ticket.StoreID, replacement.StoreID)
+	assert.Equal(t, ticket.Receipt, replacement.Receipt)
+	assert.Equal(t, "voided", *replacement.ReissuedFromID)
+	assert.Nil(t, replacement.CredentialID)
+	assert.Equal(t, entities.TicketUnclaimed, replacement.GetStatus())
 }
 
 func TestTicket_BeforeUpdate(t *testing.T) {
@@ -112,3 +277,16 @@ func TestTicket_AfterFind(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, ticket.ID, fetchedTicket.ID)
 }
+
+func TestTicket_Expire(t *testing.T) {
+	claimed := &entities.Ticket{CredentialID: aws.String("client"), Status: entities.TicketClaimed}
+	assert.True(t, claimed.Expire())
+	assert.Equal(t, entities.TicketExpired, claimed.Status)
+
+	unclaimed := &entities.Ticket{}
+	assert.False(t, unclaimed.Expire())
+	assert.Equal(t, entities.TicketUnclaimed, unclaimed.GetStatus())
+
+	redeemed := &entities.Ticket{Status: entities.TicketRedeemed}
+	assert.False(t, redeemed.Expire())
+}
@@ -8,5 +8,55 @@ import (
 
 var (
 	// Ticket errors
-	ErrTicketNotFound = errors.New(http.StatusNotFound, "ticket.not_found")
+	ErrTicketNotFound        = errors.New(http.StatusNotFound, "ticket.not_found")
+	ErrTicketNotClaimed      = errors.New(http.StatusConflict, "ticket.not_claimed")
+	ErrTicketAlreadyClaimed  = errors.New(http.StatusConflict, "ticket.already_claimed")
+	ErrTicketAlreadyRedeemed = errors.New(http.StatusConflict, "ticket.already_redeemed")
+	ErrTicketExpired         = errors.New(http.StatusGone, "ticket.expired")
+	ErrTicketVoided          = errors.New(http.StatusGone, "ticket.voided")
+	ErrTicketClaimLocked     = errors.New(http.StatusTooManyRequests, "ticket.claim_locked")
+	ErrTicketNotEnough       = errors.New(http.StatusConflict, "ticket.not_enough")
+	ErrTicketAlreadyIssued   = errors.New(http.StatusConflict, "ticket.already_issued")
+	ErrTicketReceiptUsed     = errors.New(http.StatusConflict, "ticket.receipt_used")
+	ErrTicketAmountTooLow    = errors.New(http.StatusUnprocessableEntity, "ticket.amount_too_low")
+	ErrTicketCodesExhausted  = errors.New(http.StatusConflict, "ticket.codes_exhausted")
+
+	// Campaign errors
+	ErrCampaignNotFound      = errors.New(http.StatusNotFound, "campaign.not_found")
+	ErrCampaignAlreadyExists = errors.New(http.StatusConflict, "campaign.already_exists")
+	ErrCampaignInvalidWindow = errors.New(http.StatusBadRequest, "campaign.invalid_window")
+	ErrCampaignNotStarted    = errors.New(http.StatusForbidden, "campaign.not_started")
+	ErrCampaignEnded         = errors.New(http.StatusGone, "campaign.ended")
+	ErrCampaignClaimExpired  = errors.New(http.StatusGone, "campaign.claim_expired")
+
+	// Prize errors
+	ErrPrizeNotFound        = errors.New(http.StatusNotFound, "prize.not_found")
+	ErrPrizeAlreadyExists   = errors.New(http.StatusConflict, "prize.already_exists")
+	ErrPrizeInUse           = errors.New(http.StatusConflict, "prize.in_use")
+	ErrPrizeInvalidDispatch = errors.New(http.StatusBadRequest, "prize.invalid_dispatch")
+
+	// Stock errors
+	ErrPrizeStockNotFound = errors.New(http.StatusNotFound, "prize_stock.not_found")
+	ErrPrizeOutOfStock    = errors.New(http.StatusConflict, "prize_stock.out_of_stock")
+
+	// Draw errors
+	ErrDrawNotFound           = errors.New(http.StatusNotFound, "draw.not_found")
+	ErrDrawAlreadyExists      = errors.New(http.StatusConflict, "draw.already_exists")
+	ErrDrawAlreadyDone        = errors.New(http.StatusConflict, "draw.already_done")
+	ErrDrawCommitmentMismatch = errors.New(http.StatusBadRequest, "draw.commitment_mismatch")
+	ErrDrawNoEntries          = errors.New(http.StatusUnprocessableEntity, "draw.no_entries")
+
+	// Shift errors
+	ErrShiftNotFound    = errors.New(http.StatusNotFound, "shift.not_found")
+	ErrShiftAlreadyOpen = errors.New(http.StatusConflict, "shift.already_open")
+	ErrShiftClosed      = errors.New(http.StatusConflict, "shift.closed")
+	ErrShiftNotClosed   = errors.New(http.StatusConflict, "shift.not_closed")
+
+	// Sync errors
+	ErrSyncOperationNotFound = errors.New(http.StatusNotFound, "sync.operation_not_found")
+	ErrSyncOperationRecorded = errors.New(http.StatusConflict, "sync.operation_recorded")
+	ErrSyncTooManyOperations = errors.New(http.StatusRequestEntityTooLarge, "sync.too_many_operations")
+	ErrSyncUnknownOperation  = errors.New(http.StatusBadRequest, "sync.unknown_operation")
+	ErrSyncOperationMismatch = errors.New(http.StatusConflict, "sync.operation_mismatch")
+	ErrSyncOperationInFuture = errors.New(http.StatusBadRequest, "sync.operation_in_future")
 )
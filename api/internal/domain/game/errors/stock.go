@@ -0,0 +1,61 @@
+package errors_domain_game
+
+import (
+	"encoding/json"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+var _ errors.ErrorInterface = (*OutOfStock)(nil)
+
+// AvailableStore is a store where a prize can still be collected
+type AvailableStore struct {
+	StoreID   string  `json:"store_id"`
+	Label     *string `json:"label,omitempty"`
+	Available int     `json:"available"`
+}
+
+// OutOfStock is returned when the store of the caisse has no unit of the prize left
+// It lists the stores where the player can collect the prize instead.
+type OutOfStock struct {
+	Stores []*AvailableStore
+}
+
+// NewOutOfStock returns an out of stock error listing the stores that still have the prize
+//
+// Parameters:
+// - stores: []*AvailableStore the stores with available units
+//
+// Returns:
+// - *OutOfStock: the error
+func NewOutOfStock(stores []*AvailableStore) *OutOfStock {
+	if stores == nil {
+		stores = []*AvailableStore{}
+	}
+
+	return &OutOfStock{stores}
+}
+
+func (e *OutOfStock) Error() string {
+	return ErrPrizeOutOfStock.Error()
+}
+
+func (e *OutOfStock) Code() int {
+	return ErrPrizeOutOfStock.Code()
+}
+
+func (e *OutOfStock) Log(err error) *errors.Error {
+	return ErrPrizeOutOfStock.Log(err)
+}
+
+func (e *OutOfStock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code    int               `json:"code"`
+		Message string            `json:"message"`
+		Stores  []*AvailableStore `json:"stores"`
+	}{
+		Code:    e.Code(),
+		Message: e.Error(),
+		Stores:  e.Stores,
+	})
+}
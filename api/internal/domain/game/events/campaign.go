@@ -0,0 +1,54 @@
+package events
+
+import (
+	"fmt"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+)
+
+// SyncCampaign ensures the campaign described in the configuration exists in the database
+// The campaign is matched by label, created if missing and updated otherwise,
+// then the tickets without campaign are attached to it.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface the game repository
+// - desired: *transfert.Campaign the campaign read from the configuration
+//
+// Returns:
+// - *entities.Campaign: the synchronized campaign, nil if none is configured
+// - error: an error if the campaign is invalid or cannot be saved
+func SyncCampaign(repo repositories.GameRepositoryInterface, desired *transfert.Campaign) (*entities.Campaign, error) {
+	if desired == nil || desired.Label == nil || *desired.Label == "" {
+		return nil, nil
+	}
+
+	if !entities.CreateCampaign(desired).IsValid() {
+		return nil, fmt.Errorf("invalid windows for campaign %s: %w", *desired.Label, errors_domain_game.ErrCampaignInvalidWindow)
+	}
+
+	campaign, err := repo.ReadCampaign(&transfert.Campaign{Label: desired.Label})
+	switch err {
+	case nil:
+		campaign.Update(desired)
+		if err := repo.UpdateCampaign(campaign); err != nil {
+			return nil, fmt.Errorf("failed to update campaign %s: %w", *desired.Label, err)
+		}
+	case errors_domain_game.ErrCampaignNotFound:
+		if campaign, err = repo.CreateCampaign(desired); err != nil {
+			return nil, fmt.Errorf("failed to create campaign %s: %w", *desired.Label, err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to read campaign %s: %w", *desired.Label, err)
+	}
+
+	if err := repo.AttachTicketsToCampaign(campaign); err != nil {
+		return nil, fmt.Errorf("failed to attach tickets to campaign %s: %w", *desired.Label, err)
+	}
+
+	fmt.Printf("Campaign %s is ready\n", *desired.Label)
+
+	return campaign, nil
+}
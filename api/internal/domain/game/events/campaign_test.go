@@ -0,0 +1,92 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func desiredCampaign() *transfert.Campaign {
+	return &transfert.Campaign{
+		Label:      aws.String("Ouverture Nice"),
+		Start:      aws.String("2024-11-01 00:00:00"),
+		End:        aws.String("2024-11-30 23:59:59"),
+		ClaimUntil: aws.String("2024-12-30 23:59:59"),
+		Timezone:   aws.String("Europe/Paris"),
+	}
+}
+
+func TestSyncCampaign(t *testing.T) {
+	t.Run("creates a missing campaign", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		desired := desiredCampaign()
+		created := entities.CreateCampaign(desired)
+
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: desired.Label}, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+		mockRepo.On("CreateCampaign", desired, mock.Anything).Return(created, nil)
+		mockRepo.On("AttachTicketsToCampaign", created, mock.Anything).Return(nil)
+
+		campaign, err := events.SyncCampaign(mockRepo, desired)
+		assert.NoError(t, err)
+		assert.Equal(t, created, campaign)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("updates an existing campaign", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		desired := desiredCampaign()
+		existing := &entities.Campaign{ID: "campaign-id", Label: desired.Label, Timezone: "UTC"}
+
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: desired.Label}, mock.Anything).Return(existing, nil)
+		mockRepo.On("UpdateCampaign", existing, mock.Anything).Return(nil)
+		mockRepo.On("AttachTicketsToCampaign", existing, mock.Anything).Return(nil)
+
+		campaign, err := events.SyncCampaign(mockRepo, desired)
+		assert.NoError(t, err)
+		assert.Equal(t, "campaign-id", campaign.ID)
+		assert.Equal(t, "Europe/Paris", campaign.Timezone)
+		assert.True(t, campaign.IsValid())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("does nothing without label", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		campaign, err := events.SyncCampaign(mockRepo, &transfert.Campaign{Label: aws.String("")})
+		assert.NoError(t, err)
+		assert.Nil(t, campaign)
+
+		campaign, err = events.SyncCampaign(mockRepo, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, campaign)
+		mockRepo.AssertNotCalled(t, "ReadCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects inconsistent windows", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		desired := desiredCampaign()
+		desired.End = aws.String("2024-10-01 00:00:00")
+
+		campaign, err := events.SyncCampaign(mockRepo, desired)
+		assert.Nil(t, campaign)
+		assert.ErrorIs(t, err, errors_domain_game.ErrCampaignInvalidWindow)
+	})
+
+	t.Run("fails when the database fails", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		desired := desiredCampaign()
+
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: desired.Label}, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		campaign, err := events.SyncCampaign(mockRepo, desired)
+		assert.Nil(t, campaign)
+		assert.ErrorIs(t, err, errors.ErrInternalServer)
+	})
+}
@@ -3,63 +3,127 @@ package events
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"github.com/schollz/progressbar/v3"
 )
 
-func HydrateDBWithTickets(repo repositories.GameRepositoryInterface, require int, dispatch map[string]int) {
-	tokenMap := loadExistingTokens(repo)
-	existingCounts := countExistingTickets(repo, dispatch)
+const (
+	// DefaultTicketChunkSize is the number of tickets generated and inserted at once
+	DefaultTicketChunkSize = 1000
+)
 
-	totalExisting := calculateTotalExisting(existingCounts)
-	if totalExisting >= require {
-		fmt.Printf("%d tickets are already ready\n", totalExisting)
-		return
+// HydrateDBWithTickets generates the missing tickets of the game
+// The dispatch gives the percentage of tickets for each prize ID. Tickets are generated in chunks
+// mixing the prizes in proportion of what each of them misses, each chunk is inserted in a single
+// transaction and acts as a checkpoint: an interrupted run resumes from the tickets already stored.
+// Voided tickets are out of the game and do not count, the next run generates their replacement.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface the game repository
+// - require: int the total number of tickets
+// - dispatch: map[string]int the percentage of tickets for each prize ID
+// - chunkSize: int the number of tickets inserted at once, DefaultTicketChunkSize if not positive
+//
+// Returns:
+// - error: an error if the tickets cannot be generated
+func HydrateDBWithTickets(repo repositories.GameRepositoryInterface, require int, dispatch map[string]int, chunkSize int) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultTicketChunkSize
+	}
+
+	if err := sequenceLegacyTickets(repo, chunkSize); err != nil {
+		return err
+	}
+
+	existingCounts, err := countExistingTickets(repo, dispatch)
+	if err != nil {
+		return err
 	}
 
-	remaining := require - totalExisting
+	totalExisting := calculateTotal(existingCounts)
 	ticketsPerPrize := calculateTicketsPerPrize(require, dispatch, existingCounts)
-	bar := initializeProgressBar(require, totalExisting)
+	remaining := calculateTotal(ticketsPerPrize)
+
+	if remaining == 0 {
+		fmt.Printf("%d tickets are already ready\n", totalExisting)
+		return nil
+	}
 
-	generateAndInsertTickets(repo, ticketsPerPrize, remaining, tokenMap, bar)
-	fmt.Printf("\n%d tickets are ready\n", require)
+	bar := initializeProgressBar(totalExisting+remaining, totalExisting)
+
+	if err := generateAndInsertTickets(repo, ticketsPerPrize, chunkSize, bar); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d tickets are ready\n", totalExisting+remaining)
+
+	return nil
 }
 
-func loadExistingTokens(repo repositories.GameRepositoryInterface) map[string]bool {
-	tokenMap := make(map[string]bool)
-	existingTokens, err := repo.ReadTickets(&transfert.Ticket{})
-	if err != nil {
-		panic(fmt.Sprintf("Failed to fetch existing tokens: %v", err))
+// sequenceLegacyTickets gives a position in the issuance sequence to the tickets stored before it existed
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface the game repository
+// - chunkSize: int the number of tickets read at once
+//
+// Returns:
+// - error: an error if the tickets cannot be updated
+func sequenceLegacyTickets(repo repositories.GameRepositoryInterface, chunkSize int) error {
+	sequenced := 0
+
+	for {
+		tickets, err := repo.ReadTickets(&transfert.Ticket{}, database.Where("sequence IS NULL"), database.Limit(chunkSize))
+		if err != nil {
+			return fmt.Errorf("failed to read unsequenced tickets: %w", err)
+		}
+
+		if len(tickets) == 0 {
+			break
+		}
+
+		for _, ticket := range tickets {
+			sequence := entities.RandomTicketSequence()
+			ticket.Sequence = &sequence
+			if err := repo.UpdateTicket(ticket); err != nil {
+				return fmt.Errorf("failed to sequence ticket %s: %w", ticket.ID, err)
+			}
+		}
+
+		sequenced += len(tickets)
 	}
 
-	for _, existingToken := range existingTokens {
-		tokenMap[existingToken.Token.String()] = true
+	if sequenced > 0 {
+		fmt.Printf("%d existing tickets sequenced\n", sequenced)
 	}
-	fmt.Printf("Loaded %d existing tokens from the database\n", len(tokenMap))
-	return tokenMap
+
+	return nil
 }
 
-func countExistingTickets(repo repositories.GameRepositoryInterface, dispatch map[string]int) map[string]int {
+// countExistingTickets counts the tickets of each prize still in the game, voided tickets left aside
+func countExistingTickets(repo repositories.GameRepositoryInterface, dispatch map[string]int) (map[string]int, error) {
 	existingCounts := make(map[string]int)
 	for prize := range dispatch {
 		count, err := repo.CountTicket(&transfert.Ticket{
-			Prize: aws.String(prize),
-		})
+			PrizeID: aws.String(prize),
+		}, database.Where("voided_at IS NULL"))
 		if err != nil {
-			panic(fmt.Sprintf("Failed to count tickets for %s: %v", prize, err))
+			return nil, fmt.Errorf("failed to count tickets for %s: %w", prize, err)
 		}
 		existingCounts[prize] = count
 	}
-	return existingCounts
+	return existingCounts, nil
 }
 
-func calculateTotalExisting(existingCounts map[string]int) int {
+func calculateTotal(counts map[string]int) int {
 	total := 0
-	for _, count := range existingCounts {
+	for _, count := range counts {
 		total += count
 	}
 	return total
@@ -69,12 +133,132 @@ func calculateTicketsPerPrize(require int, dispatch map[string]int, existingCoun
 	ticketsPerPrize := make(map[string]int)
 	for prize, percent := range dispatch {
 		expected := int(math.Round(float64(require) * float64(percent) / 100.0))
-		ticketsPerPrize[prize] = expected - existingCounts[prize]
+		ticketsPerPrize[prize] = max(expected-existingCounts[prize], 0)
 		fmt.Println(ticketsPerPrize[prize], "tickets for", prize, existingCounts[prize], "already exist")
 	}
 	return ticketsPerPrize
 }
 
+// splitChunk takes the next chunk out of the missing tickets
+// Each prize gets a share of the chunk proportional to what it misses, so that any number of
+// inserted chunks respects the dispatch.
+//
+// Parameters:
+// - missing: map[string]int the tickets still missing for each prize ID, updated in place
+// - size: int the size of the chunk
+//
+// Returns:
+// - map[string]int: the number of tickets of the chunk for each prize ID
+func splitChunk(missing map[string]int, size int) map[string]int {
+	total := calculateTotal(missing)
+	prizes := make([]string, 0, len(missing))
+	for prize := range missing {
+		prizes = append(prizes, prize)
+	}
+
+	chunk := make(map[string]int, len(missing))
+
+	if total <= size {
+		for _, prize := range prizes {
+			chunk[prize] = missing[prize]
+			missing[prize] = 0
+		}
+
+		return chunk
+	}
+
+	// The seats left by the rounding go to the largest remainders
+	sort.Slice(prizes, func(i, j int) bool {
+		ri, rj := missing[prizes[i]]*size%total, missing[prizes[j]]*size%total
+		if ri != rj {
+			return ri > rj
+		}
+		return prizes[i] < prizes[j]
+	})
+
+	allocated := 0
+	for _, prize := range prizes {
+		chunk[prize] = missing[prize] * size / total
+		allocated += chunk[prize]
+	}
+
+	for i := 0; allocated < size; i++ {
+		if prize := prizes[i%len(prizes)]; chunk[prize] < missing[prize] {
+			chunk[prize]++
+			allocated++
+		}
+	}
+
+	for prize, count := range chunk {
+		missing[prize] -= count
+	}
+
+	return chunk
+}
+
+// assignUniqueTokens gives each ticket of a chunk a token that no other ticket uses
+// Only the tokens of the chunk are looked up, the existing tokens are never loaded in memory.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface the game repository
+// - tickets: []*transfert.Ticket the tickets of the chunk
+//
+// Returns:
+// - error: an error if the existing tokens cannot be read
+func assignUniqueTokens(repo repositories.GameRepositoryInterface, tickets []*transfert.Ticket) error {
+	used := make(map[string]bool, len(tickets))
+	pending := tickets
+
+	for len(pending) > 0 {
+		tokens := make([]string, 0, len(pending))
+		for _, ticket := range pending {
+			code, err := generateCode(used)
+			if err != nil {
+				return fmt.Errorf("failed to generate token: %w", err)
+			}
+
+			used[code] = true
+			ticket.Token = aws.String(code)
+			tokens = append(tokens, code)
+		}
+
+		existing, err := repo.ReadTickets(&transfert.Ticket{}, database.Where("token IN ?", tokens))
+		if err != nil {
+			return fmt.Errorf("failed to check existing tokens: %w", err)
+		}
+
+		taken := make(map[string]bool, len(existing))
+		for _, ticket := range existing {
+			taken[ticket.Token.String()] = true
+		}
+
+		collisions := []*transfert.Ticket{}
+		for _, ticket := range pending {
+			if taken[*ticket.Token] {
+				collisions = append(collisions, ticket)
+			}
+		}
+
+		pending = collisions
+	}
+
+	return nil
+}
+
+// generateCode draws a ticket code with the configured generator, skipping the codes already drawn
+func generateCode(used map[string]bool) (string, error) {
+	for {
+		code, err := token.Tickets().Generate()
+		if err != nil {
+			return "", err
+		}
+
+		if !used[code.String()] {
+			return code.String(), nil
+		}
+	}
+}
+
 func initializeProgressBar(require, totalExisting int) *progressbar.ProgressBar {
 	bar := progressbar.NewOptions(require,
 		progressbar.OptionSetDescription("Inserting tickets..."),
@@ -87,36 +271,34 @@ func initializeProgressBar(require, totalExisting int) *progressbar.ProgressBar
 	return bar
 }
 
-func generateAndInsertTickets(repo repositories.GameRepositoryInterface, ticketsPerPrize map[string]int, remaining int, tokenMap map[string]bool, bar *progressbar.ProgressBar) {
-	modulo := 1000
-	generateUniqueToken := func() *string {
-		for {
-			token := token.Generate(12).PointerString()
-			if !tokenMap[*token] {
-				tokenMap[*token] = true
-				return token
-			}
+func generateAndInsertTickets(repo repositories.GameRepositoryInterface, ticketsPerPrize map[string]int, chunkSize int, bar *progressbar.ProgressBar) error {
+	missing := make(map[string]int, len(ticketsPerPrize))
+	for prize, count := range ticketsPerPrize {
+		if count > 0 {
+			missing[prize] = count
 		}
 	}
 
-	for prize, numTickets := range ticketsPerPrize {
-		if numTickets <= 0 {
-			continue
+	for calculateTotal(missing) > 0 {
+		tickets := make([]*transfert.Ticket, 0, chunkSize)
+		for prize, count := range splitChunk(missing, chunkSize) {
+			for range count {
+				tickets = append(tickets, &transfert.Ticket{
+					PrizeID: aws.String(prize),
+				})
+			}
 		}
-		tickets := []*transfert.Ticket{}
-		for i := 0; i < numTickets; i++ {
-			tickets = append(tickets, &transfert.Ticket{
-				Prize: aws.String(prize),
-				Token: generateUniqueToken(),
-			})
 
-			if len(tickets) >= modulo || i == numTickets-1 {
-				if err := repo.CreateTickets(tickets); err != nil {
-					panic(fmt.Sprintf("Failed to insert tickets for %s: %v", prize, err))
-				}
-				bar.Add(len(tickets))
-				tickets = []*transfert.Ticket{}
-			}
+		if err := assignUniqueTokens(repo, tickets); err != nil {
+			return err
 		}
+
+		if err := repo.CreateTickets(tickets); err != nil {
+			return fmt.Errorf("failed to insert tickets: %w", err)
+		}
+
+		bar.Add(len(tickets))
 	}
+
+	return nil
 }
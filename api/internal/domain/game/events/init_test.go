@@ -3,11 +3,17 @@ package events_test
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
@@ -54,6 +60,16 @@ func (m *MockGameRepository) ReadTickets(obj *transfert.Ticket, options ...datab
 	return args.Get(0).([]*entities.Ticket), nil
 }
 
+// PaginateTickets simule la lecture d'une page de tickets
+func (m *MockGameRepository) PaginateTickets(obj *transfert.Ticket, list *database.List, options ...database.Option) (*database.Page[*entities.Ticket], errors.ErrorInterface) {
+	args := m.Called(obj, list, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*database.Page[*entities.Ticket]), nil
+}
+
 // UpdateTicket simule la mise à jour d'un ticket
 func (m *MockGameRepository) UpdateTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
 	args := m.Called(entity, options)
@@ -63,6 +79,45 @@ func (m *MockGameRepository) UpdateTicket(entity *entities.Ticket, options ...da
 	return nil
 }
 
+// ClaimTicket simule la réclamation conditionnelle d'un ticket
+func (m *MockGameRepository) ClaimTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// RedeemTicket simule la remise conditionnelle d'un ticket.
+func (m *MockGameRepository) RedeemTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReserveTicket simule l'enregistrement de la boutique qui réserve le lot d'un ticket
+func (m *MockGameRepository) ReserveTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ExpireTicket simule l'expiration conditionnelle d'un ticket
+func (m *MockGameRepository) ExpireTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
 // DeleteTicket simule la suppression d'un ticket
 func (m *MockGameRepository) DeleteTicket(obj *transfert.Ticket, options ...database.Option) errors.ErrorInterface {
 	args := m.Called(obj, options)
@@ -81,46 +136,619 @@ func (m *MockGameRepository) CountTicket(obj *transfert.Ticket, options ...datab
 	return args.Int(0), nil
 }
 
+// IssueTicket simule la remise d'un ticket en caisse
+func (m *MockGameRepository) IssueTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// VoidTicket simule l'annulation d'un ticket
+func (m *MockGameRepository) VoidTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// ReissueTicket simule l'annulation d'un ticket et la création de son remplaçant
+func (m *MockGameRepository) ReissueTicket(voided, replacement *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(voided, replacement, options)
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// Transaction simule une transaction en exécutant les opérations sur le mock lui-même
+func (m *MockGameRepository) Transaction(fn func(repo repositories.GameRepositoryInterface) errors.ErrorInterface) errors.ErrorInterface {
+	return fn(m)
+}
+
+// AssignTicketsToStore simule l'attribution de tickets à une boutique
+func (m *MockGameRepository) AssignTicketsToStore(ids []string, storeID string, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(ids, storeID, options)
+	if args.Error(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Int(0), nil
+}
+
+// AggregateTickets simule le comptage des tickets par groupe
+func (m *MockGameRepository) AggregateTickets(obj *transfert.Ticket, options ...database.Option) ([]*entities.Aggregate, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Aggregate), nil
+}
+
+// CreateTicketEvent simule l'ajout d'un événement à l'historique d'un ticket
+func (m *MockGameRepository) CreateTicketEvent(obj *transfert.TicketEvent, options ...database.Option) (*entities.TicketEvent, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.TicketEvent), nil
+}
+
+// ReadTicketEvents simule la lecture de l'historique d'un ticket
+func (m *MockGameRepository) ReadTicketEvents(obj *transfert.TicketEvent, options ...database.Option) ([]*entities.TicketEvent, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketEvent), nil
+}
+
+// CreateClaimAttempt simule l'enregistrement d'une tentative de réclamation.
+func (m *MockGameRepository) CreateClaimAttempt(obj *transfert.ClaimAttempt, options ...database.Option) (*entities.ClaimAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.ClaimAttempt), nil
+}
+
+// ReadClaimAttempts simule la lecture des tentatives de réclamation.
+func (m *MockGameRepository) ReadClaimAttempts(obj *transfert.ClaimAttempt, options ...database.Option) ([]*entities.ClaimAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.ClaimAttempt), nil
+}
+
+// CreateDraw simule la création d'un tirage.
+func (m *MockGameRepository) CreateDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// ReadDraw simule la lecture d'un tirage.
+func (m *MockGameRepository) ReadDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// ReadDraws simule la lecture de plusieurs tirages.
+func (m *MockGameRepository) ReadDraws(obj *transfert.Draw, options ...database.Option) ([]*entities.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Draw), nil
+}
+
+// UpdateDraw simule la mise à jour d'un tirage.
+func (m *MockGameRepository) UpdateDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CompleteDraw simule l'enregistrement du résultat d'un tirage.
+func (m *MockGameRepository) CompleteDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketHolders simule la lecture des détenteurs de tickets.
+func (m *MockGameRepository) ReadTicketHolders(options ...database.Option) ([]string, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]string), nil
+}
+
+// CreateShift simule l'ouverture d'un service de caisse.
+func (m *MockGameRepository) CreateShift(obj *transfert.Shift, options ...database.Option) (*entities.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Shift), nil
+}
+
+// ReadShift simule la lecture d'un service de caisse.
+func (m *MockGameRepository) ReadShift(obj *transfert.Shift, options ...database.Option) (*entities.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Shift), nil
+}
+
+// ReadShifts simule la lecture de plusieurs services de caisse.
+func (m *MockGameRepository) ReadShifts(obj *transfert.Shift, options ...database.Option) ([]*entities.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Shift), nil
+}
+
+// UpdateShift simule la mise à jour d'un service de caisse.
+func (m *MockGameRepository) UpdateShift(entity *entities.Shift, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CloseShift simule la clôture conditionnelle d'un service de caisse.
+func (m *MockGameRepository) CloseShift(entity *entities.Shift, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CreateSyncOperation simule l'enregistrement d'une opération synchronisée par une caisse.
+func (m *MockGameRepository) CreateSyncOperation(obj *transfert.SyncOperation, options ...database.Option) (*entities.SyncOperation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.SyncOperation), nil
+}
+
+// UpdateSyncOperation simule l'enregistrement du résultat d'une opération synchronisée.
+func (m *MockGameRepository) UpdateSyncOperation(entity *entities.SyncOperation, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadSyncOperation simule la lecture d'une opération synchronisée par une caisse.
+func (m *MockGameRepository) ReadSyncOperation(obj *transfert.SyncOperation, options ...database.Option) (*entities.SyncOperation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.SyncOperation), nil
+}
+
+// ReadPrizeStock simule la lecture du stock d'un lot dans une boutique.
+func (m *MockGameRepository) ReadPrizeStock(obj *transfert.PrizeStock, options ...database.Option) (*entities.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.PrizeStock), nil
+}
+
+// ReadPrizeStocks simule la lecture des stocks des lots.
+func (m *MockGameRepository) ReadPrizeStocks(obj *transfert.PrizeStock, options ...database.Option) ([]*entities.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.PrizeStock), nil
+}
+
+// RestockPrize simule le réapprovisionnement d'un lot dans une boutique.
+func (m *MockGameRepository) RestockPrize(obj *transfert.PrizeStock, options ...database.Option) (*entities.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.PrizeStock), nil
+}
+
+// ReservePrizeStock simule la réservation d'une unité du stock.
+func (m *MockGameRepository) ReservePrizeStock(entity *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReleasePrizeStock simule la libération d'une unité réservée.
+func (m *MockGameRepository) ReleasePrizeStock(entity *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ConsumePrizeStock simule la sortie du stock d'un lot remis.
+func (m *MockGameRepository) ConsumePrizeStock(entity, reserved *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, reserved, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// TransferPrizeStock simule le transfert de stock entre deux boutiques.
+func (m *MockGameRepository) TransferPrizeStock(obj *transfert.StockTransfer, options ...database.Option) (*entities.PrizeStock, *entities.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.PrizeStock), args.Get(1).(*entities.PrizeStock), nil
+}
+
+// MarkPrizeStockAlerted simule l'enregistrement de l'alerte de stock bas.
+func (m *MockGameRepository) MarkPrizeStockAlerted(entity *entities.PrizeStock, options ...database.Option) (bool, errors.ErrorInterface) {
+	args := m.Called(entity, options)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+
+	return args.Bool(0), args.Error(1).(errors.ErrorInterface)
+}
+
+// CreateCampaign simule la création d'une campagne.
+func (m *MockGameRepository) CreateCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// ReadCampaign simule la lecture d'une campagne.
+func (m *MockGameRepository) ReadCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// ReadCampaigns simule la lecture de plusieurs campagnes.
+func (m *MockGameRepository) ReadCampaigns(obj *transfert.Campaign, options ...database.Option) ([]*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Campaign), nil
+}
+
+// UpdateCampaign simule la mise à jour d'une campagne.
+func (m *MockGameRepository) UpdateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeleteCampaign simule la suppression d'une campagne.
+func (m *MockGameRepository) DeleteCampaign(obj *transfert.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// AttachTicketsToCampaign simule le rattachement des tickets à une campagne.
+func (m *MockGameRepository) AttachTicketsToCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CreatePrize simule la création d'un lot.
+func (m *MockGameRepository) CreatePrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// ReadPrize simule la lecture d'un lot.
+func (m *MockGameRepository) ReadPrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// ReadPrizes simule la lecture de plusieurs lots.
+func (m *MockGameRepository) ReadPrizes(obj *transfert.Prize, options ...database.Option) ([]*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Prize), nil
+}
+
+// UpdatePrize simule la mise à jour d'un lot.
+func (m *MockGameRepository) UpdatePrize(entity *entities.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeletePrize simule la suppression d'un lot.
+func (m *MockGameRepository) DeletePrize(obj *transfert.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
 // Tests pour la méthode HydrateDBWithTickets
 func TestHydrateDBWithTickets(t *testing.T) {
 	// Initialisation du MockGameRepository
 	mockRepo := new(MockGameRepository)
 
-	token1 := token.Generate(12)
-	token2 := token.Generate(12)
+	code, _ := token.Generate(12)
+	legacy := &entities.Ticket{ID: "legacy", Token: code}
 
-	// Configuration du mock pour ReadTickets
-	mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{
-		{Token: token1},
-		{Token: token2},
-	}, errors.ErrorInterface(nil))
+	// Configuration du mock pour ReadTickets : un ticket sans séquence, puis plus aucun
+	mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{legacy}, errors.ErrorInterface(nil)).Once()
+	mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{}, errors.ErrorInterface(nil))
+
+	// Configuration du mock pour UpdateTicket
+	mockRepo.On("UpdateTicket", legacy, mock.Anything).Return(errors.ErrorInterface(nil))
 
 	// Configuration du mock pour CountTicket
 	mockRepo.On("CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
-		return ticket != nil && ticket.Prize != nil && *ticket.Prize == "PrizeA"
+		return ticket != nil && ticket.PrizeID != nil && *ticket.PrizeID == "PrizeA"
 	}), mock.Anything).Return(100, errors.ErrorInterface(nil))
 
 	mockRepo.On("CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
-		return ticket != nil && ticket.Prize != nil && *ticket.Prize == "PrizeB"
+		return ticket != nil && ticket.PrizeID != nil && *ticket.PrizeID == "PrizeB"
 	}), mock.Anything).Return(200, errors.ErrorInterface(nil))
 
-	// Configuration du mock pour CreateTickets
-	mockRepo.On("CreateTickets", mock.Anything, mock.Anything).Return(errors.ErrorInterface(nil))
+	// Configuration du mock pour CreateTickets, chaque lot est conservé
+	chunks := [][]*transfert.Ticket{}
+	mockRepo.On("CreateTickets", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		chunks = append(chunks, args.Get(0).([]*transfert.Ticket))
+	}).Return(errors.ErrorInterface(nil))
 
 	// Appel de la méthode HydrateDBWithTickets
 	dispatch := map[string]int{
 		"PrizeA": 50,
 		"PrizeB": 50,
 	}
-	events.HydrateDBWithTickets(mockRepo, 1000, dispatch)
+	assert.NoError(t, events.HydrateDBWithTickets(mockRepo, 1000, dispatch, 100))
 
 	// Vérifications
-	mockRepo.AssertCalled(t, "ReadTickets", mock.Anything, mock.Anything)
-	mockRepo.AssertCalled(t, "CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
-		return ticket != nil && ticket.Prize != nil && *ticket.Prize == "PrizeA"
-	}), mock.Anything)
-	mockRepo.AssertCalled(t, "CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
-		return ticket != nil && ticket.Prize != nil && *ticket.Prize == "PrizeB"
-	}), mock.Anything)
-	mockRepo.AssertCalled(t, "CreateTickets", mock.Anything, mock.Anything)
+	assert.NotNil(t, legacy.Sequence)
+	mockRepo.AssertNumberOfCalls(t, "UpdateTicket", 1)
+
+	counts := map[string]int{}
+	tokens := map[string]bool{}
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, len(chunk), 100)
+
+		chunkCounts := map[string]int{}
+		for _, ticket := range chunk {
+			chunkCounts[*ticket.PrizeID]++
+			assert.Nil(t, token.NewLuhnP(ticket.Token).Validate())
+			assert.False(t, tokens[*ticket.Token])
+			tokens[*ticket.Token] = true
+		}
+
+		// 400 PrizeA et 300 PrizeB manquent : chaque lot de 100 en garde la proportion à un ticket près
+		assert.InDelta(t, 400.0/7, chunkCounts["PrizeA"], 1)
+		assert.InDelta(t, 300.0/7, chunkCounts["PrizeB"], 1)
+
+		for prize, count := range chunkCounts {
+			counts[prize] += count
+		}
+	}
+
+	assert.Len(t, chunks, 7)
+	assert.Equal(t, map[string]int{"PrizeA": 400, "PrizeB": 300}, counts)
+}
+
+func TestHydrateDBWithTicketsAlreadyReady(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+
+	mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{}, errors.ErrorInterface(nil))
+	mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(500, errors.ErrorInterface(nil))
+
+	assert.NoError(t, events.HydrateDBWithTickets(mockRepo, 1000, map[string]int{"PrizeA": 50, "PrizeB": 50}, 0))
+	mockRepo.AssertNotCalled(t, "CreateTickets", mock.Anything, mock.Anything)
+}
+
+func TestHydrateDBWithTicketsErrors(t *testing.T) {
+	dispatch := map[string]int{"PrizeA": 100}
+
+	t.Run("count failure", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{}, errors.ErrorInterface(nil))
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(0, errors.ErrInternalServer)
+
+		assert.ErrorIs(t, events.HydrateDBWithTickets(mockRepo, 10, dispatch, 0), errors.ErrInternalServer)
+	})
+
+	t.Run("insert failure", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{}, errors.ErrorInterface(nil))
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(0, errors.ErrorInterface(nil))
+		mockRepo.On("CreateTickets", mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		assert.ErrorIs(t, events.HydrateDBWithTickets(mockRepo, 10, dispatch, 0), errors.ErrInternalServer)
+		mockRepo.AssertNumberOfCalls(t, "CreateTickets", 1)
+	})
+}
+
+// interruptedRepository simule une génération interrompue après un nombre de lots
+type interruptedRepository struct {
+	*repositories.GameRepository
+	chunks int
+}
+
+func (r *interruptedRepository) CreateTickets(objs []*transfert.Ticket, options ...database.Option) errors.ErrorInterface {
+	if r.chunks == 0 {
+		return errors.ErrInternalServer
+	}
+
+	r.chunks--
+
+	return r.GameRepository.CreateTickets(objs, options...)
+}
+
+func TestHydrateDBWithTicketsResume(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	assert.NoError(t, err)
+
+	dbInstance, err := database.FromDB(gormDB)
+	assert.NoError(t, err)
+	assert.NoError(t, repositories.Migrate(dbInstance))
+
+	repo := repositories.NewGameRepository(dbInstance)
+
+	prizeA, _ := repo.CreatePrize(&transfert.Prize{Code: aws.String("A"), Percentage: aws.Int(80)})
+	prizeB, _ := repo.CreatePrize(&transfert.Prize{Code: aws.String("B"), Percentage: aws.Int(20)})
+	dispatch := map[string]int{prizeA.ID: 80, prizeB.ID: 20}
+
+	count := func(prize string) int {
+		total, err := repo.CountTicket(&transfert.Ticket{PrizeID: &prize})
+		assert.Nil(t, err)
+		return total
+	}
+
+	// La génération s'arrête après deux lots
+	interrupted := &interruptedRepository{GameRepository: repo, chunks: 2}
+	assert.Error(t, events.HydrateDBWithTickets(interrupted, 1000, dispatch, 100))
+	assert.Equal(t, 160, count(prizeA.ID))
+	assert.Equal(t, 40, count(prizeB.ID))
+
+	// La reprise ne génère que les tickets manquants
+	assert.NoError(t, events.HydrateDBWithTickets(repo, 1000, dispatch, 100))
+	assert.Equal(t, 800, count(prizeA.ID))
+	assert.Equal(t, 200, count(prizeB.ID))
+
+	sequenced, _ := repo.CountTicket(&transfert.Ticket{}, database.Where("sequence IS NOT NULL"))
+	assert.Equal(t, 1000, sequenced)
+}
+
+func TestHydrateDBWithTicketsAfterVoid(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	assert.NoError(t, err)
+
+	dbInstance, err := database.FromDB(gormDB)
+	assert.NoError(t, err)
+	assert.NoError(t, repositories.Migrate(dbInstance))
+
+	repo := repositories.NewGameRepository(dbInstance)
+
+	prizeA, _ := repo.CreatePrize(&transfert.Prize{Code: aws.String("A"), Percentage: aws.Int(80)})
+	prizeB, _ := repo.CreatePrize(&transfert.Prize{Code: aws.String("B"), Percentage: aws.Int(20)})
+	dispatch := map[string]int{prizeA.ID: 80, prizeB.ID: 20}
+
+	live := func(prize string) int {
+		total, err := repo.CountTicket(&transfert.Ticket{PrizeID: &prize}, database.Where("voided_at IS NULL"))
+		assert.Nil(t, err)
+		return total
+	}
+
+	assert.NoError(t, events.HydrateDBWithTickets(repo, 100, dispatch, 0))
+
+	tickets, _ := repo.ReadTickets(&transfert.Ticket{PrizeID: &prizeA.ID}, database.Limit(2))
+	assert.Len(t, tickets, 2)
+
+	// Un ticket annulé sort du jeu, un ticket réémis est remplacé par un autre du même lot
+	assert.True(t, tickets[0].Void(aws.String("damaged"), aws.String("admin")))
+	assert.Nil(t, repo.VoidTicket(tickets[0]))
+
+	assert.True(t, tickets[1].Void(aws.String("misprinted"), aws.String("admin")))
+	code, _ := token.Generate(12)
+	assert.Nil(t, repo.ReissueTicket(tickets[1], tickets[1].Reissue(code)))
+
+	assert.Equal(t, 79, live(prizeA.ID))
+
+	// La génération suivante ne remplace que le ticket annulé
+	assert.NoError(t, events.HydrateDBWithTickets(repo, 100, dispatch, 0))
+	assert.Equal(t, 80, live(prizeA.ID))
+	assert.Equal(t, 20, live(prizeB.ID))
+
+	total, _ := repo.CountTicket(&transfert.Ticket{})
+	assert.Equal(t, 102, total)
 }
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"fmt"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+)
+
+// SyncPrizes ensures the prizes described in the configuration exist in the database
+// The percentages must sum to 100 before any ticket is generated,
+// each prize is matched by code, created if missing and updated otherwise.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface the game repository
+// - desired: []*transfert.Prize the prizes read from the configuration
+//
+// Returns:
+// - map[string]int: the percentage of tickets for each prize ID
+// - error: an error if the prizes are inconsistent or cannot be saved
+func SyncPrizes(repo repositories.GameRepositoryInterface, desired []*transfert.Prize) (map[string]int, error) {
+	prizes := make([]*entities.Prize, 0, len(desired))
+	codes := make(map[string]bool)
+
+	for _, prize := range desired {
+		if prize.Code == nil || *prize.Code == "" || codes[*prize.Code] {
+			return nil, fmt.Errorf("each prize requires a unique code: %w", errors_domain_game.ErrPrizeAlreadyExists)
+		}
+
+		codes[*prize.Code] = true
+		prizes = append(prizes, entities.CreatePrize(prize))
+	}
+
+	if !entities.IsDispatchValid(prizes) {
+		return nil, fmt.Errorf("the percentages of the prizes must sum to 100: %w", errors_domain_game.ErrPrizeInvalidDispatch)
+	}
+
+	dispatch := make(map[string]int)
+	for _, obj := range desired {
+		prize, err := repo.ReadPrize(&transfert.Prize{Code: obj.Code})
+		switch err {
+		case nil:
+			prize.Update(obj)
+			if err := repo.UpdatePrize(prize); err != nil {
+				return nil, fmt.Errorf("failed to update prize %s: %w", *obj.Code, err)
+			}
+		case errors_domain_game.ErrPrizeNotFound:
+			if prize, err = repo.CreatePrize(obj); err != nil {
+				return nil, fmt.Errorf("failed to create prize %s: %w", *obj.Code, err)
+			}
+		default:
+			return nil, fmt.Errorf("failed to read prize %s: %w", *obj.Code, err)
+		}
+
+		dispatch[prize.ID] = prize.Percentage
+	}
+
+	fmt.Printf("%d prizes are ready\n", len(dispatch))
+
+	return dispatch, nil
+}
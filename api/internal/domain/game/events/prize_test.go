@@ -0,0 +1,74 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func desiredPrizes() []*transfert.Prize {
+	return []*transfert.Prize{
+		{Code: aws.String("infuser"), Label: aws.String("Infuseur à thé"), Value: aws.Float64(8), Percentage: aws.Int(60)},
+		{Code: aws.String("box-39"), Label: aws.String("Coffret découverte 39€"), Value: aws.Float64(39), Percentage: aws.Int(40)},
+	}
+}
+
+func TestSyncPrizes(t *testing.T) {
+	t.Run("creates the missing prizes and updates the others", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		desired := desiredPrizes()
+		existing := &entities.Prize{ID: "infuser-id", Code: desired[0].Code, Percentage: 50}
+		created := &entities.Prize{ID: "box-39-id", Code: desired[1].Code, Percentage: 40}
+
+		mockRepo.On("ReadPrize", &transfert.Prize{Code: desired[0].Code}, mock.Anything).Return(existing, nil)
+		mockRepo.On("UpdatePrize", existing, mock.Anything).Return(nil)
+		mockRepo.On("ReadPrize", &transfert.Prize{Code: desired[1].Code}, mock.Anything).Return(nil, errors_domain_game.ErrPrizeNotFound)
+		mockRepo.On("CreatePrize", desired[1], mock.Anything).Return(created, nil)
+
+		dispatch, err := events.SyncPrizes(mockRepo, desired)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"infuser-id": 60, "box-39-id": 40}, dispatch)
+		assert.Equal(t, "Infuseur à thé", *existing.Label)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects percentages not summing to 100", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		desired := desiredPrizes()
+		desired[1].Percentage = aws.Int(30)
+
+		dispatch, err := events.SyncPrizes(mockRepo, desired)
+		assert.Nil(t, dispatch)
+		assert.ErrorIs(t, err, errors_domain_game.ErrPrizeInvalidDispatch)
+		mockRepo.AssertNotCalled(t, "ReadPrize")
+	})
+
+	t.Run("rejects duplicated codes", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		desired := desiredPrizes()
+		desired[1].Code = desired[0].Code
+
+		dispatch, err := events.SyncPrizes(mockRepo, desired)
+		assert.Nil(t, dispatch)
+		assert.ErrorIs(t, err, errors_domain_game.ErrPrizeAlreadyExists)
+	})
+
+	t.Run("fails on database error", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		desired := desiredPrizes()
+
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		dispatch, err := events.SyncPrizes(mockRepo, desired)
+		assert.Nil(t, dispatch)
+		assert.ErrorIs(t, err, errors.ErrInternalServer)
+	})
+}
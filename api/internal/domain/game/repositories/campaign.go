@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateCampaign creates a new campaign
+// Inserts a new campaign into the database based on the transfert.Campaign input object
+//
+// Parameters:
+// - obj: *transfert.Campaign - The campaign transfer object to create
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Campaign: The created campaign entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	campaign := entities.CreateCampaign(obj)
+
+	query := r.store.Engine.Create(campaign)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return nil, errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return campaign, nil
+}
+
+// ReadCampaign reads a campaign from the database
+// Finds and returns a campaign based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Campaign - The campaign transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Campaign: The found campaign entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	campaign := &entities.Campaign{}
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(campaign)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrCampaignNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return campaign, nil
+}
+
+// ReadCampaigns reads multiple campaigns from the database
+// Finds and returns a list of campaigns based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Campaign - The campaign transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.Campaign: A slice of found campaign entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadCampaigns(obj *transfert.Campaign, options ...database.Option) ([]*entities.Campaign, errors.ErrorInterface) {
+	var campaigns []*entities.Campaign
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&campaigns)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return campaigns, nil
+}
+
+// UpdateCampaign updates an existing campaign in the database
+// Saves all the fields of the campaign entity
+//
+// Parameters:
+// - entity: *entities.Campaign - The campaign entity to update
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) UpdateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Save(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// DeleteCampaign deletes a campaign from the database
+// Removes a campaign based on the provided transfer object
+//
+// Parameters:
+// - obj: *transfert.Campaign - The campaign transfer object to delete
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) DeleteCampaign(obj *transfert.Campaign, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Where(obj).Delete(&entities.Campaign{})
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// AttachTicketsToCampaign links the tickets without campaign to the given campaign
+//
+// Parameters:
+// - entity: *entities.Campaign - The campaign receiving the tickets
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) AttachTicketsToCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(&entities.Ticket{}).Where("campaign_id IS NULL").Update("campaign_id", entity.ID)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateCampaign(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Campaign{
+		Label:      aws.String("Ouverture Nice"),
+		Start:      aws.String("2024-11-01 00:00:00"),
+		End:        aws.String("2024-11-30 23:59:59"),
+		ClaimUntil: aws.String("2024-12-30 23:59:59"),
+		Timezone:   aws.String("Europe/Paris"),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "campaigns" \("id","created_at","updated_at","deleted_at","label","start_at","end_at","claim_until","timezone"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // DeletedAt
+				dto.Label,        // Label
+				sqlmock.AnyArg(), // StartAt
+				sqlmock.AnyArg(), // EndAt
+				sqlmock.AnyArg(), // ClaimUntil
+				"Europe/Paris",   // Timezone
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		entity, err := repo.CreateCampaign(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, entity)
+		assert.True(t, entity.IsValid())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation with database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "campaigns"`).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint"))
+		mock.ExpectRollback()
+
+		entity, err := repo.CreateCampaign(dto)
+		assert.NotNil(t, err)
+		assert.Nil(t, entity)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadCampaign(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	campaignID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns" WHERE "campaigns"."id" = \$1 AND "campaigns"."deleted_at" IS NULL ORDER BY "campaigns"."id" LIMIT \$2`).
+			WithArgs(campaignID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label", "timezone"}).AddRow(campaignID, "Ouverture Nice", "Europe/Paris"))
+
+		campaign, err := repo.ReadCampaign(&transfert.Campaign{ID: aws.String(campaignID)})
+		assert.Nil(t, err)
+		assert.Equal(t, "Ouverture Nice", *campaign.Label)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("campaign not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns"`).
+			WithArgs(campaignID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		campaign, err := repo.ReadCampaign(&transfert.Campaign{ID: aws.String(campaignID)})
+		assert.Nil(t, campaign)
+		assert.Equal(t, "campaign.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns"`).
+			WithArgs(campaignID, 1).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		campaign, err := repo.ReadCampaign(&transfert.Campaign{ID: aws.String(campaignID)})
+		assert.Nil(t, campaign)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadCampaigns(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns" WHERE "campaigns"."deleted_at" IS NULL`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("campaign-1").AddRow("campaign-2"))
+
+		campaigns, err := repo.ReadCampaigns(&transfert.Campaign{})
+		assert.Nil(t, err)
+		assert.Len(t, campaigns, 2)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns"`).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		campaigns, err := repo.ReadCampaigns(&transfert.Campaign{})
+		assert.Nil(t, campaigns)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateCampaign(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	campaign := &entities.Campaign{ID: "campaign-1", Label: aws.String("Ouverture Nice")}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "campaigns" SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.UpdateCampaign(campaign))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "campaigns" SET`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateCampaign(campaign)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteCampaign(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	campaignID := "campaign-1"
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "campaigns" SET "deleted_at"=\$1 WHERE "campaigns"."id" = \$2 AND "campaigns"."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), campaignID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.DeleteCampaign(&transfert.Campaign{ID: aws.String(campaignID)}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "campaigns" SET "deleted_at"`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		err := repo.DeleteCampaign(&transfert.Campaign{ID: aws.String(campaignID)})
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAttachTicketsToCampaign(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	campaign := &entities.Campaign{ID: "campaign-1"}
+
+	t.Run("successful attachment", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET "campaign_id"=\$1,"updated_at"=\$2 WHERE campaign_id IS NULL AND "tickets"."deleted_at" IS NULL`).
+			WithArgs(campaign.ID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.AttachTicketsToCampaign(campaign))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET "campaign_id"`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		err := repo.AttachTicketsToCampaign(campaign)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
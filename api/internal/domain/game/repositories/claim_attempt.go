@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateClaimAttempt records a failed attempt to claim a ticket
+//
+// Parameters:
+// - obj: *transfert.ClaimAttempt - The attempt transfer object to create
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.ClaimAttempt: The created attempt entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateClaimAttempt(obj *transfert.ClaimAttempt, options ...database.Option) (*entities.ClaimAttempt, errors.ErrorInterface) {
+	attempt := entities.CreateClaimAttempt(obj)
+
+	query := r.store.Engine.Create(attempt)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return nil, errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return attempt, nil
+}
+
+// ReadClaimAttempts reads the failed attempts to claim a ticket
+// Finds and returns a list of attempts based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.ClaimAttempt - The attempt transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.ClaimAttempt: A slice of found attempt entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadClaimAttempts(obj *transfert.ClaimAttempt, options ...database.Option) ([]*entities.ClaimAttempt, errors.ErrorInterface) {
+	var attempts []*entities.ClaimAttempt
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&attempts)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return attempts, nil
+}
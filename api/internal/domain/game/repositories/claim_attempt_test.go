@@ -0,0 +1,90 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateClaimAttempt(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.ClaimAttempt{
+		CredentialID: aws.String("client-123"),
+		IP:           aws.String("203.0.113.7"),
+		Token:        aws.String("123456789012"),
+		Reason:       aws.String("not_found"),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "claim_attempts" \("id","created_at","updated_at","deleted_at","credential_id","ip","token","reason"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // DeletedAt
+				dto.CredentialID, // CredentialID
+				dto.IP,           // IP
+				dto.Token,        // Token
+				dto.Reason,       // Reason
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		attempt, err := repo.CreateClaimAttempt(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, attempt)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation with database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "claim_attempts"`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		attempt, err := repo.CreateClaimAttempt(dto)
+		assert.Nil(t, attempt)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadClaimAttempts(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.ClaimAttempt{IP: aws.String("203.0.113.7")}
+	since := time.Now().Add(-time.Hour)
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "claim_attempts" WHERE "claim_attempts"."ip" = \$1 AND created_at > \$2 AND "claim_attempts"."deleted_at" IS NULL`).
+			WithArgs(dto.IP, since).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ip"}).AddRow("attempt-1", "203.0.113.7").AddRow("attempt-2", "203.0.113.7"))
+
+		attempts, err := repo.ReadClaimAttempts(dto, database.Where("created_at > ?", since))
+		assert.Nil(t, err)
+		assert.Len(t, attempts, 2)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "claim_attempts"`).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		attempts, err := repo.ReadClaimAttempts(dto)
+		assert.Nil(t, attempts)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
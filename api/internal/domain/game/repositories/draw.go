@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+// CreateDraw creates a new draw
+// Inserts a new draw into the database based on the transfert.Draw input object
+//
+// Parameters:
+// - obj: *transfert.Draw - The draw transfer object to create
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Draw: The created draw entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	draw := entities.CreateDraw(obj)
+
+	query := r.store.Engine.Create(draw)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return nil, errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return draw, nil
+}
+
+// ReadDraw reads a draw from the database
+// Finds and returns a draw, with its winners, based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Draw - The draw transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Draw: The found draw entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	draw := &entities.Draw{}
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(draw)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrDrawNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return draw, nil
+}
+
+// ReadDraws reads multiple draws from the database
+// Finds and returns a list of draws based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Draw - The draw transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.Draw: A slice of found draw entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadDraws(obj *transfert.Draw, options ...database.Option) ([]*entities.Draw, errors.ErrorInterface) {
+	var draws []*entities.Draw
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&draws)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return draws, nil
+}
+
+// UpdateDraw updates an existing draw in the database
+// Saves the draw entity along with its winners
+//
+// Parameters:
+// - entity: *entities.Draw - The draw entity to update
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) UpdateDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Save(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// CompleteDraw stores the result of a draw and its winners in a single transaction
+// The update only applies while the draw is not done, so that two concurrent runs never both select winners.
+//
+// Parameters:
+// - entity: *entities.Draw - The draw with its seed, its entries and its winners
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrDrawAlreadyDone if the draw was run in the meantime
+func (r *GameRepository) CompleteDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	err := r.store.Engine.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&entities.Draw{}).Where("id = ? AND drawn_at IS NULL", entity.ID)
+		for _, option := range options {
+			option(query)
+		}
+
+		result := query.Updates(map[string]any{
+			"seed":         entity.Seed,
+			"entries":      entity.Entries,
+			"entries_hash": entity.EntriesHash,
+			"drawn_at":     entity.DrawnAt,
+		})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors_domain_game.ErrDrawAlreadyDone
+		}
+
+		if len(entity.Winners) == 0 {
+			return nil
+		}
+
+		return tx.Create(&entity.Winners).Error
+	})
+
+	if err == nil {
+		return nil
+	}
+
+	if err == errors_domain_game.ErrDrawAlreadyDone {
+		return errors_domain_game.ErrDrawAlreadyDone
+	}
+
+	return errors.ErrInternalServer.Log(err)
+}
@@ -0,0 +1,257 @@
+package repositories_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateDraw(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Draw{
+		Commitment: aws.String("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"),
+		Count:      aws.Int(2),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "draws"`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // DeletedAt
+				dto.Commitment,   // Commitment
+				nil,              // Seed
+				2,                // Count
+				0,                // Entries
+				nil,              // EntriesHash
+				nil,              // CredentialID
+				nil,              // DrawnAt
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		entity, err := repo.CreateDraw(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, entity)
+		assert.Equal(t, 2, entity.Count)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation with database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "draws"`).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint"))
+		mock.ExpectRollback()
+
+		entity, err := repo.CreateDraw(dto)
+		assert.NotNil(t, err)
+		assert.Nil(t, entity)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadDraw(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	drawID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "draws" WHERE "draws"."id" = \$1 AND "draws"."deleted_at" IS NULL ORDER BY "draws"."id" LIMIT \$2`).
+			WithArgs(drawID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "count"}).AddRow(drawID, 1))
+		mock.ExpectQuery(`SELECT \* FROM "winners" WHERE "winners"."draw_id" = \$1 AND "winners"."deleted_at" IS NULL`).
+			WithArgs(drawID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "draw_id", "position", "credential_id"}).
+				AddRow("w2", drawID, 2, "client-2").
+				AddRow("w1", drawID, 1, "client-1"))
+
+		draw, err := repo.ReadDraw(&transfert.Draw{ID: aws.String(drawID)})
+		assert.Nil(t, err)
+		assert.NotNil(t, draw)
+		assert.Len(t, draw.Winners, 2)
+		assert.Equal(t, "client-1", *draw.Winners[0].CredentialID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("draw not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "draws"`).
+			WithArgs(drawID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		draw, err := repo.ReadDraw(&transfert.Draw{ID: aws.String(drawID)})
+		assert.NotNil(t, err)
+		assert.Nil(t, draw)
+		assert.Equal(t, "draw.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "draws"`).
+			WithArgs(drawID, 1).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		draw, err := repo.ReadDraw(&transfert.Draw{ID: aws.String(drawID)})
+		assert.NotNil(t, err)
+		assert.Nil(t, draw)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadDraws(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "draws" WHERE "draws"."deleted_at" IS NULL`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("draw-1"))
+		mock.ExpectQuery(`SELECT \* FROM "winners"`).
+			WithArgs("draw-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		draws, err := repo.ReadDraws(&transfert.Draw{})
+		assert.Nil(t, err)
+		assert.Len(t, draws, 1)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "draws"`).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		draws, err := repo.ReadDraws(&transfert.Draw{})
+		assert.NotNil(t, err)
+		assert.Nil(t, draws)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateDraw(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	draw := &entities.Draw{
+		ID:         "draw-1",
+		Commitment: aws.String("commitment"),
+		Count:      1,
+	}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "draws" SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateDraw(draw)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "draws" SET`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateDraw(draw)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCompleteDrawConcurrency(t *testing.T) {
+	const runs = 20
+
+	repo := setupStock(t)
+
+	draw, cerr := repo.CreateDraw(&transfert.Draw{Commitment: aws.String("commitment"), Count: aws.Int(1)})
+	if !assert.Nil(t, cerr) {
+		return
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make(chan errors.ErrorInterface, runs)
+
+	for i := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Every run read the draw while it was not done yet
+			now := time.Now()
+			entity := &entities.Draw{ID: draw.ID, Seed: aws.String("seed"), Entries: 1, EntriesHash: aws.String("hash"), DrawnAt: &now}
+			entity.Winners = entities.Winners{{DrawID: &draw.ID, Position: 1, CredentialID: aws.String(fmt.Sprintf("client-%d", i))}}
+
+			<-start
+			results <- repo.CompleteDraw(entity)
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(results)
+
+	done := 0
+	for err := range results {
+		if err == nil {
+			done++
+			continue
+		}
+
+		assert.Equal(t, errors_domain_game.ErrDrawAlreadyDone, err)
+	}
+
+	assert.Equal(t, 1, done)
+
+	stored, rerr := repo.ReadDraw(&transfert.Draw{ID: &draw.ID})
+	if assert.Nil(t, rerr) {
+		assert.True(t, stored.IsDrawn())
+		assert.Len(t, stored.Winners, 1)
+	}
+}
+
+func TestReadTicketHolders(t *testing.T) {
+	repo := setupStock(t)
+
+	for _, holder := range []*string{aws.String("client-a"), aws.String("client-b"), aws.String("client-a"), nil} {
+		code, _ := token.Generate(12)
+		_, err := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString(), CredentialID: holder})
+		assert.Nil(t, err)
+	}
+
+	code, _ := token.Generate(12)
+	voided, err := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString(), CredentialID: aws.String("client-c")})
+	assert.Nil(t, err)
+	now := time.Now()
+	voided.VoidedAt = &now
+	assert.Nil(t, repo.UpdateTicket(voided))
+
+	holders, err := repo.ReadTicketHolders()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"client-a", "client-b"}, holders)
+}
@@ -1,11 +1,14 @@
 package repositories
 
 import (
+	"strings"
+
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
 )
 
 type GameRepository struct {
@@ -13,21 +16,127 @@ type GameRepository struct {
 }
 
 type GameRepositoryInterface interface {
+	Transaction(fn func(repo GameRepositoryInterface) errors.ErrorInterface) errors.ErrorInterface
+
 	// Ticket
 	CreateTicket(obj *transfert.Ticket, options ...database.Option) (*entities.Ticket, errors.ErrorInterface)
 	CreateTickets(objs []*transfert.Ticket, options ...database.Option) errors.ErrorInterface
 	ReadTicket(obj *transfert.Ticket, options ...database.Option) (*entities.Ticket, errors.ErrorInterface)
 	ReadTickets(obj *transfert.Ticket, options ...database.Option) ([]*entities.Ticket, errors.ErrorInterface)
+	PaginateTickets(obj *transfert.Ticket, list *database.List, options ...database.Option) (*database.Page[*entities.Ticket], errors.ErrorInterface)
 	UpdateTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface
+	ClaimTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface
+	RedeemTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface
+	ReserveTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface
+	ExpireTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface
 	DeleteTicket(obj *transfert.Ticket, options ...database.Option) errors.ErrorInterface
 	CountTicket(obj *transfert.Ticket, options ...database.Option) (int, errors.ErrorInterface)
+	AssignTicketsToStore(ids []string, storeID string, options ...database.Option) (int, errors.ErrorInterface)
+	IssueTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface
+	VoidTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface
+	ReissueTicket(voided, replacement *entities.Ticket, options ...database.Option) errors.ErrorInterface
+	AggregateTickets(obj *transfert.Ticket, options ...database.Option) ([]*entities.Aggregate, errors.ErrorInterface)
+	ReadTicketHolders(options ...database.Option) ([]string, errors.ErrorInterface)
+
+	// Ticket event
+	CreateTicketEvent(obj *transfert.TicketEvent, options ...database.Option) (*entities.TicketEvent, errors.ErrorInterface)
+	ReadTicketEvents(obj *transfert.TicketEvent, options ...database.Option) ([]*entities.TicketEvent, errors.ErrorInterface)
+
+	// Claim attempt
+	CreateClaimAttempt(obj *transfert.ClaimAttempt, options ...database.Option) (*entities.ClaimAttempt, errors.ErrorInterface)
+	ReadClaimAttempts(obj *transfert.ClaimAttempt, options ...database.Option) ([]*entities.ClaimAttempt, errors.ErrorInterface)
+
+	// Campaign
+	CreateCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface)
+	ReadCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface)
+	ReadCampaigns(obj *transfert.Campaign, options ...database.Option) ([]*entities.Campaign, errors.ErrorInterface)
+	UpdateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface
+	DeleteCampaign(obj *transfert.Campaign, options ...database.Option) errors.ErrorInterface
+	AttachTicketsToCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface
+
+	// Prize
+	CreatePrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface)
+	ReadPrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface)
+	ReadPrizes(obj *transfert.Prize, options ...database.Option) ([]*entities.Prize, errors.ErrorInterface)
+	UpdatePrize(entity *entities.Prize, options ...database.Option) errors.ErrorInterface
+	DeletePrize(obj *transfert.Prize, options ...database.Option) errors.ErrorInterface
+
+	// Prize stock
+	ReadPrizeStock(obj *transfert.PrizeStock, options ...database.Option) (*entities.PrizeStock, errors.ErrorInterface)
+	ReadPrizeStocks(obj *transfert.PrizeStock, options ...database.Option) ([]*entities.PrizeStock, errors.ErrorInterface)
+	RestockPrize(obj *transfert.PrizeStock, options ...database.Option) (*entities.PrizeStock, errors.ErrorInterface)
+	ReservePrizeStock(entity *entities.PrizeStock, options ...database.Option) errors.ErrorInterface
+	ReleasePrizeStock(entity *entities.PrizeStock, options ...database.Option) errors.ErrorInterface
+	ConsumePrizeStock(entity, reserved *entities.PrizeStock, options ...database.Option) errors.ErrorInterface
+	TransferPrizeStock(obj *transfert.StockTransfer, options ...database.Option) (*entities.PrizeStock, *entities.PrizeStock, errors.ErrorInterface)
+	MarkPrizeStockAlerted(entity *entities.PrizeStock, options ...database.Option) (bool, errors.ErrorInterface)
+
+	// Draw
+	CreateDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface)
+	ReadDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface)
+	ReadDraws(obj *transfert.Draw, options ...database.Option) ([]*entities.Draw, errors.ErrorInterface)
+	UpdateDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface
+	CompleteDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface
+
+	// Shift
+	CreateShift(obj *transfert.Shift, options ...database.Option) (*entities.Shift, errors.ErrorInterface)
+	ReadShift(obj *transfert.Shift, options ...database.Option) (*entities.Shift, errors.ErrorInterface)
+	ReadShifts(obj *transfert.Shift, options ...database.Option) ([]*entities.Shift, errors.ErrorInterface)
+	UpdateShift(entity *entities.Shift, options ...database.Option) errors.ErrorInterface
+	CloseShift(entity *entities.Shift, options ...database.Option) errors.ErrorInterface
+
+	// Sync operation
+	CreateSyncOperation(obj *transfert.SyncOperation, options ...database.Option) (*entities.SyncOperation, errors.ErrorInterface)
+	UpdateSyncOperation(entity *entities.SyncOperation, options ...database.Option) errors.ErrorInterface
+	ReadSyncOperation(obj *transfert.SyncOperation, options ...database.Option) (*entities.SyncOperation, errors.ErrorInterface)
 }
 
 func NewGameRepository(store *database.Database) *GameRepository {
-	store.Engine.AutoMigrate(entities.Ticket{})
 	return &GameRepository{store}
 }
 
+// Migrate creates or updates the tables of the game
+// Runs once at startup, the repositories built for each request leave the schema alone
+//
+// Parameters:
+// - store: *database.Database the game database
+//
+// Returns:
+// - error: an error if the schema cannot be migrated
+func Migrate(store *database.Database) error {
+	return store.Engine.AutoMigrate(entities.Prize{}, entities.Campaign{}, entities.Ticket{}, entities.Draw{}, entities.Winner{}, entities.ClaimAttempt{}, entities.TicketEvent{}, entities.PrizeStock{}, entities.Shift{}, entities.ShiftLine{}, entities.ShiftAnomaly{}, entities.SyncOperation{})
+}
+
+// Transaction runs several operations of the repository at once
+// The repository given to fn works in a single transaction, committed when fn succeeds and rolled back otherwise.
+//
+// Parameters:
+// - fn: func(repo GameRepositoryInterface) errors.ErrorInterface - The operations, on the repository of the transaction
+//
+// Returns:
+// - errors.ErrorInterface: The error of fn, or an internal error if the transaction cannot be committed
+func (r *GameRepository) Transaction(fn func(repo GameRepositoryInterface) errors.ErrorInterface) errors.ErrorInterface {
+	var failure errors.ErrorInterface
+
+	err := r.store.Engine.Transaction(func(tx *gorm.DB) error {
+		if failure = fn(&GameRepository{&database.Database{Config: r.store.Config, Engine: tx}}); failure != nil {
+			return failure
+		}
+
+		return nil
+	})
+
+	if failure != nil {
+		return failure
+	}
+
+	if err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	return nil
+}
+
 // CreateTicket creates a new ticket
 // Inserts a new ticket into the database based on the transfert.Ticket input object
 //
@@ -109,6 +218,21 @@ func (r *GameRepository) ReadTickets(obj *transfert.Ticket, options ...database.
 	return tickets, nil
 }
 
+// PaginateTickets reads a page of the tickets matching the transfer object
+// The list sorts, filters and pages the tickets, the total counts the tickets of every page.
+//
+// Parameters:
+// - obj: *transfert.Ticket the conditions of the list
+// - list: *database.List the requested page, checked against the columns a client may use
+// - options: ...database.Option options applied to the page only, such as Preload
+//
+// Returns:
+// - *database.Page[*entities.Ticket]: the page of tickets
+// - errors.ErrorInterface: an error if the page cannot be read
+func (r *GameRepository) PaginateTickets(obj *transfert.Ticket, list *database.List, options ...database.Option) (*database.Page[*entities.Ticket], errors.ErrorInterface) {
+	return database.Paginate[*entities.Ticket](r.store.Engine.Model(&entities.Ticket{}).Where(obj), list, options...)
+}
+
 // ReadTicket reads a ticket from the database
 // Finds and returns a ticket based on the provided transfer object and options
 //
@@ -161,6 +285,282 @@ func (r *GameRepository) UpdateTicket(entity *entities.Ticket, options ...databa
 	return nil
 }
 
+// ClaimTicket links a ticket to its player only if nobody claimed it before
+// The claim is a single conditional update, when concurrent requests claim the same ticket
+// the database lets only one of them through.
+//
+// Parameters:
+// - entity: *entities.Ticket - The ticket with the credential and the status of the claim
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketAlreadyClaimed if the ticket was claimed in the meantime
+func (r *GameRepository) ClaimTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(entity).Where(
+		"credential_id IS NULL AND (status = ? OR status = '' OR status IS NULL)", entities.TicketUnclaimed,
+	)
+
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Updates(map[string]any{
+		"credential_id": entity.CredentialID,
+		"status":        entity.Status,
+		"claimed_at":    entity.ClaimedAt,
+	})
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_game.ErrTicketAlreadyClaimed
+	}
+
+	return nil
+}
+
+// RedeemTicket records the delivery of the prize of a claimed ticket
+// The update only applies while the ticket is claimed and not voided, so that a ticket is never redeemed twice.
+//
+// Parameters:
+// - entity: *entities.Ticket - The ticket holding the caisse, the employee and the shift of the redemption
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketAlreadyRedeemed if the ticket left the claimed state in the meantime
+func (r *GameRepository) RedeemTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	result := redeemTicket(r.store.Engine, entity, options...)
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_game.ErrTicketAlreadyRedeemed
+	}
+
+	return nil
+}
+
+// redeemTicket runs the conditional update of a redemption on the given connection
+//
+// Parameters:
+// - db: *gorm.DB - The connection or the transaction
+// - entity: *entities.Ticket - The redeemed ticket
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *gorm.DB: the result of the update
+func redeemTicket(db *gorm.DB, entity *entities.Ticket, options ...database.Option) *gorm.DB {
+	query := db.Model(entity).Where("status = ? AND voided_at IS NULL", entities.TicketClaimed)
+
+	for _, option := range options {
+		option(query)
+	}
+
+	return query.Updates(map[string]any{
+		"status":             entity.Status,
+		"redeemed_at":        entity.RedeemedAt,
+		"redeemed_caisse_id": entity.RedeemedCaisseID,
+		"redeemed_by":        entity.RedeemedBy,
+		"redeemed_shift_id":  entity.RedeemedShiftID,
+	})
+}
+
+// ReserveTicket records the store holding a unit of the prize of a claimed ticket
+// Only the reservation is written, and only while the ticket is claimed and not voided, the other columns are left to the concurrent actions.
+//
+// Parameters:
+// - entity: *entities.Ticket - The ticket holding the store of the reservation
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketNotClaimed if the ticket left the claimed state in the meantime
+func (r *GameRepository) ReserveTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(entity).Where("status = ? AND voided_at IS NULL", entities.TicketClaimed)
+
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Update("reserved_store_id", entity.ReservedStoreID)
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_game.ErrTicketNotClaimed
+	}
+
+	return nil
+}
+
+// ExpireTicket records that the prize of a claimed ticket was not collected in time
+// Only the status is written, and only while the ticket is claimed and not voided, so that a concurrent redemption is never overwritten.
+//
+// Parameters:
+// - entity: *entities.Ticket - The expired ticket
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketNotClaimed if the ticket left the claimed state in the meantime
+func (r *GameRepository) ExpireTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(entity).Where("status = ? AND voided_at IS NULL", entities.TicketClaimed)
+
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Update("status", entity.Status)
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_game.ErrTicketNotClaimed
+	}
+
+	return nil
+}
+
+// IssueTicket records the issuance of a ticket at a caisse
+// The update only applies while the ticket is still in the pool, so that two caisses never issue the same ticket.
+// The unique index on the store and the receipt refuses a second ticket for a purchase, even issued concurrently.
+//
+// Parameters:
+// - entity: *entities.Ticket - The ticket holding the caisse, the store and the purchase
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketAlreadyIssued if the ticket left the pool in the meantime, ErrTicketReceiptUsed if the receipt already got a ticket
+func (r *GameRepository) IssueTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(entity).Where("credential_id IS NULL AND store_id IS NULL AND issued_at IS NULL AND voided_at IS NULL")
+
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Updates(map[string]any{
+		"caisse_id": entity.CaisseID,
+		"store_id":  entity.StoreID,
+		"receipt":   entity.Receipt,
+		"amount":    entity.Amount,
+		"issued_at": entity.IssuedAt,
+		"issued_by": entity.IssuedBy,
+		"shift_id":  entity.ShiftID,
+	})
+
+	if result.Error != nil {
+		if duplicated(result.Error) {
+			return errors_domain_game.ErrTicketReceiptUsed
+		}
+
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_game.ErrTicketAlreadyIssued
+	}
+
+	return nil
+}
+
+// duplicated reports whether a query was refused by a unique index, the messages differ between the SQL dialects
+func duplicated(err error) bool {
+	message := err.Error()
+
+	return strings.Contains(message, "UNIQUE constraint failed") || // SQLite
+		strings.Contains(message, "duplicate key value") || // PostgreSQL
+		strings.Contains(message, "Duplicate entry") // MySQL
+}
+
+// voidable matches the tickets which can still be voided, final tickets are left untouched
+const voidable = "voided_at IS NULL AND (status IS NULL OR status IN ?)"
+
+// voidTicket records the void of a ticket only if it was not voided, redeemed or expired in the meantime
+func voidTicket(db *gorm.DB, entity *entities.Ticket, options ...database.Option) *gorm.DB {
+	query := db.Model(entity).Where(voidable, []entities.TicketStatus{"", entities.TicketUnclaimed, entities.TicketClaimed})
+
+	for _, option := range options {
+		option(query)
+	}
+
+	return query.Updates(map[string]any{
+		"status":      entity.Status,
+		"voided_at":   entity.VoidedAt,
+		"voided_by":   entity.VoidedBy,
+		"void_reason": entity.VoidReason,
+	})
+}
+
+// VoidTicket takes a ticket out of the game, its record is kept
+// The void is a single conditional update, a ticket redeemed in the meantime is not voided.
+//
+// Parameters:
+// - entity: *entities.Ticket - The ticket with the status, the author and the reason of the void
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketVoided if the ticket left a voidable state in the meantime
+func (r *GameRepository) VoidTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	result := voidTicket(r.store.Engine, entity, options...)
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_game.ErrTicketVoided
+	}
+
+	return nil
+}
+
+// ReissueTicket voids a ticket and stores its replacement in a single transaction
+// Either both are stored or none, so the prize of the voided ticket never loses nor gains a ticket.
+//
+// Parameters:
+// - voided: *entities.Ticket - The ticket with the status, the author and the reason of the void
+// - replacement: *entities.Ticket - The ticket replacing it, see entities.Ticket.Reissue
+// - options: ...database.Option - Additional options to customize the void
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketVoided if the ticket left a voidable state in the meantime
+func (r *GameRepository) ReissueTicket(voided, replacement *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	err := r.store.Engine.Transaction(func(tx *gorm.DB) error {
+		result := voidTicket(tx, voided, options...)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors_domain_game.ErrTicketVoided
+		}
+
+		// The purchase moves to the replacement, the receipt is traced back through ReissuedFromID
+		if err := tx.Model(voided).Update("receipt", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(replacement).Error
+	})
+
+	if err == nil {
+		return nil
+	}
+
+	if err == errors_domain_game.ErrTicketVoided {
+		return errors_domain_game.ErrTicketVoided
+	}
+
+	return errors.ErrInternalServer.Log(err)
+}
+
 // DeleteTicket deletes a ticket from the database
 // Removes a ticket based on the provided transfer object
 //
@@ -211,3 +611,80 @@ func (r *GameRepository) CountTicket(obj *transfert.Ticket, options ...database.
 
 	return int(count), nil
 }
+
+// AssignTicketsToStore sends tickets to a store to be printed
+// Only the tickets not yet assigned are updated, so that concurrent exports never share a ticket.
+//
+// Parameters:
+// - ids: []string - The IDs of the tickets to assign
+// - storeID: string - The store receiving the tickets
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - int: The number of tickets assigned
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) AssignTicketsToStore(ids []string, storeID string, options ...database.Option) (int, errors.ErrorInterface) {
+	query := r.store.Engine.Model(&entities.Ticket{}).Where("id IN ? AND store_id IS NULL", ids)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Update("store_id", storeID)
+
+	if result.Error != nil {
+		return 0, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// AggregateTickets counts the tickets by group
+// The groups and the aggregates are given by the options, see database.GroupBy and database.Count.
+//
+// Parameters:
+// - obj: *transfert.Ticket - The ticket transfer object with search parameters
+// - options: ...database.Option - The grouping, the aggregates and the additional conditions
+//
+// Returns:
+// - []*entities.Aggregate: The groups of tickets
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) AggregateTickets(obj *transfert.Ticket, options ...database.Option) ([]*entities.Aggregate, errors.ErrorInterface) {
+	var aggregates []*entities.Aggregate
+	ticket := entities.CreateTicket(obj)
+
+	query := r.store.Engine.Model(&entities.Ticket{}).Where(ticket)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Scan(&aggregates)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return aggregates, nil
+}
+
+// ReadTicketHolders reads the distinct credentials holding a claimed ticket that is not voided
+//
+// Parameters:
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []string: the credential IDs of the holders
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadTicketHolders(options ...database.Option) ([]string, errors.ErrorInterface) {
+	var ids []string
+
+	query := r.store.Engine.Model(&entities.Ticket{}).Where("credential_id IS NOT NULL AND voided_at IS NULL")
+	for _, option := range options {
+		option(query)
+	}
+
+	if err := query.Distinct("credential_id").Pluck("credential_id", &ids).Error; err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	return ids, nil
+}
@@ -2,6 +2,9 @@ package repositories_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -9,12 +12,16 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func setup() (*repositories.GameRepository, sqlmock.Sqlmock, func()) {
@@ -31,13 +38,13 @@ func TestCreateTicket(t *testing.T) {
 	defer cleanup()
 
 	dto := &transfert.Ticket{
-		Prize: aws.String("PrizeA"),
-		Token: aws.String("unique-token"),
+		PrizeID: aws.String("PrizeA"),
+		Token:   aws.String("unique-token"),
 	}
 
 	t.Run("successful creation", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID
 				sqlmock.AnyArg(), // CreatedAt
@@ -45,7 +52,27 @@ func TestCreateTicket(t *testing.T) {
 				nil,              // DeletedAt
 				nil,              // CredentialID
 				dto.Token,        // Token
-				dto.Prize,        // Prize
+				dto.PrizeID,      // Prize
+				"unclaimed",      // Status
+				nil,              // ClaimedAt
+				nil,              // RedeemedAt
+				nil,              // RedeemedCaisseID
+				nil,              // RedeemedBy
+				nil,              // RedeemedShiftID
+				nil,              // CampaignID
+				sqlmock.AnyArg(), // Sequence
+				nil,              // StoreIDNone
+				nil,              // CaisseIDNone
+				nil,              // ReceiptNone
+				nil,              // AmountNone
+				nil,              // IssuedAtNone
+				nil,              // IssuedByNone
+				nil,              // ShiftIDNone
+				nil,              // VoidedAtNone
+				nil,              // VoidedByNone
+				nil,              // VoidReasonNone
+				nil,              // ReissuedFromIDNone
+				nil,              // ReservedStoreIDNone
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -62,7 +89,7 @@ func TestCreateTicket(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID
 				sqlmock.AnyArg(), // CreatedAt
@@ -70,7 +97,27 @@ func TestCreateTicket(t *testing.T) {
 				nil,              // DeletedAt
 				nil,              // CredentialID
 				dtoWithoutPrize.Token,
-				nil, // Prize is missing
+				nil,              // Prize is missing
+				"unclaimed",      // Status
+				nil,              // ClaimedAt
+				nil,              // RedeemedAt
+				nil,              // RedeemedCaisseID
+				nil,              // RedeemedBy
+				nil,              // RedeemedShiftID
+				nil,              // CampaignID
+				sqlmock.AnyArg(), // Sequence
+				nil,              // StoreIDNone
+				nil,              // CaisseIDNone
+				nil,              // ReceiptNone
+				nil,              // AmountNone
+				nil,              // IssuedAtNone
+				nil,              // IssuedByNone
+				nil,              // ShiftIDNone
+				nil,              // VoidedAtNone
+				nil,              // VoidedByNone
+				nil,              // VoidReasonNone
+				nil,              // ReissuedFromIDNone
+				nil,              // ReservedStoreIDNone
 			).WillReturnError(fmt.Errorf("constraint violation"))
 
 		mock.ExpectRollback()
@@ -85,7 +132,7 @@ func TestCreateTicket(t *testing.T) {
 
 	t.Run("creation with duplicate token", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID
 				sqlmock.AnyArg(), // CreatedAt
@@ -93,7 +140,27 @@ func TestCreateTicket(t *testing.T) {
 				nil,              // DeletedAt
 				nil,              // CredentialID
 				dto.Token,        // Token
-				dto.Prize,        // Prize
+				dto.PrizeID,      // Prize
+				"unclaimed",      // Status
+				nil,              // ClaimedAt
+				nil,              // RedeemedAt
+				nil,              // RedeemedCaisseID
+				nil,              // RedeemedBy
+				nil,              // RedeemedShiftID
+				nil,              // CampaignID
+				sqlmock.AnyArg(), // Sequence
+				nil,              // StoreIDNone
+				nil,              // CaisseIDNone
+				nil,              // ReceiptNone
+				nil,              // AmountNone
+				nil,              // IssuedAtNone
+				nil,              // IssuedByNone
+				nil,              // ShiftIDNone
+				nil,              // VoidedAtNone
+				nil,              // VoidedByNone
+				nil,              // VoidReasonNone
+				nil,              // ReissuedFromIDNone
+				nil,              // ReservedStoreIDNone
 			).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint"))
 
 		mock.ExpectRollback()
@@ -108,7 +175,7 @@ func TestCreateTicket(t *testing.T) {
 
 	t.Run("creation with database connection error", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID
 				sqlmock.AnyArg(), // CreatedAt
@@ -116,7 +183,27 @@ func TestCreateTicket(t *testing.T) {
 				nil,              // DeletedAt
 				nil,              // CredentialID
 				dto.Token,        // Token
-				dto.Prize,        // Prize
+				dto.PrizeID,      // Prize
+				"unclaimed",      // Status
+				nil,              // ClaimedAt
+				nil,              // RedeemedAt
+				nil,              // RedeemedCaisseID
+				nil,              // RedeemedBy
+				nil,              // RedeemedShiftID
+				nil,              // CampaignID
+				sqlmock.AnyArg(), // Sequence
+				nil,              // StoreIDNone
+				nil,              // CaisseIDNone
+				nil,              // ReceiptNone
+				nil,              // AmountNone
+				nil,              // IssuedAtNone
+				nil,              // IssuedByNone
+				nil,              // ShiftIDNone
+				nil,              // VoidedAtNone
+				nil,              // VoidedByNone
+				nil,              // VoidReasonNone
+				nil,              // ReissuedFromIDNone
+				nil,              // ReservedStoreIDNone
 			).WillReturnError(fmt.Errorf("database is unavailable"))
 
 		mock.ExpectRollback()
@@ -131,7 +218,7 @@ func TestCreateTicket(t *testing.T) {
 
 	t.Run("successful creation with custom options", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID
 				sqlmock.AnyArg(), // CreatedAt
@@ -139,7 +226,27 @@ func TestCreateTicket(t *testing.T) {
 				nil,              // DeletedAt
 				nil,              // CredentialID
 				dto.Token,        // Token
-				dto.Prize,        // Prize
+				dto.PrizeID,      // Prize
+				"unclaimed",      // Status
+				nil,              // ClaimedAt
+				nil,              // RedeemedAt
+				nil,              // RedeemedCaisseID
+				nil,              // RedeemedBy
+				nil,              // RedeemedShiftID
+				nil,              // CampaignID
+				sqlmock.AnyArg(), // Sequence
+				nil,              // StoreIDNone
+				nil,              // CaisseIDNone
+				nil,              // ReceiptNone
+				nil,              // AmountNone
+				nil,              // IssuedAtNone
+				nil,              // IssuedByNone
+				nil,              // ShiftIDNone
+				nil,              // VoidedAtNone
+				nil,              // VoidedByNone
+				nil,              // VoidReasonNone
+				nil,              // ReissuedFromIDNone
+				nil,              // ReservedStoreIDNone
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -157,12 +264,12 @@ func TestCreateTickets(t *testing.T) {
 
 	t.Run("successful creation of multiple tickets", func(t *testing.T) {
 		tickets := []*transfert.Ticket{
-			{Prize: aws.String("PrizeA"), Token: aws.String("TokenA")},
-			{Prize: aws.String("PrizeB"), Token: aws.String("TokenB")},
+			{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+			{PrizeID: aws.String("PrizeB"), Token: aws.String("TokenB")},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID (Ticket 1)
 				sqlmock.AnyArg(), // CreatedAt (Ticket 1)
@@ -171,6 +278,26 @@ func TestCreateTickets(t *testing.T) {
 				nil,              // CredentialID (Ticket 1)
 				"TokenA",         // Token (Ticket 1)
 				"PrizeA",         // Prize (Ticket 1)
+				"unclaimed",      // Status (Ticket 1)
+				nil,              // ClaimedAt (Ticket 1)
+				nil,              // RedeemedAt (Ticket 1)
+				nil,              // RedeemedCaisseID (Ticket 1)
+				nil,              // RedeemedBy (Ticket 1)
+				nil,              // RedeemedShiftID (Ticket 1)
+				nil,              // CampaignID (Ticket 1)
+				sqlmock.AnyArg(), // Sequence (Ticket 1)
+				nil,              // StoreID (Ticket 1)
+				nil,              // CaisseID (Ticket 1)
+				nil,              // Receipt (Ticket 1)
+				nil,              // Amount (Ticket 1)
+				nil,              // IssuedAt (Ticket 1)
+				nil,              // IssuedBy (Ticket 1)
+				nil,              // ShiftID (Ticket 1)
+				nil,              // VoidedAt (Ticket 1)
+				nil,              // VoidedBy (Ticket 1)
+				nil,              // VoidReason (Ticket 1)
+				nil,              // ReissuedFromID (Ticket 1)
+				nil,              // ReservedStoreID (Ticket 1)
 
 				sqlmock.AnyArg(), // ID (Ticket 2)
 				sqlmock.AnyArg(), // CreatedAt (Ticket 2)
@@ -179,6 +306,26 @@ func TestCreateTickets(t *testing.T) {
 				nil,              // CredentialID (Ticket 2)
 				"TokenB",         // Token (Ticket 2)
 				"PrizeB",         // Prize (Ticket 2)
+				"unclaimed",      // Status (Ticket 2)
+				nil,              // ClaimedAt (Ticket 2)
+				nil,              // RedeemedAt (Ticket 2)
+				nil,              // RedeemedCaisseID (Ticket 2)
+				nil,              // RedeemedBy (Ticket 2)
+				nil,              // RedeemedShiftID (Ticket 2)
+				nil,              // CampaignID (Ticket 2)
+				sqlmock.AnyArg(), // Sequence (Ticket 2)
+				nil,              // StoreID (Ticket 2)
+				nil,              // CaisseID (Ticket 2)
+				nil,              // Receipt (Ticket 2)
+				nil,              // Amount (Ticket 2)
+				nil,              // IssuedAt (Ticket 2)
+				nil,              // IssuedBy (Ticket 2)
+				nil,              // ShiftID (Ticket 2)
+				nil,              // VoidedAt (Ticket 2)
+				nil,              // VoidedBy (Ticket 2)
+				nil,              // VoidReason (Ticket 2)
+				nil,              // ReissuedFromID (Ticket 2)
+				nil,              // ReservedStoreID (Ticket 2)
 			).WillReturnResult(sqlmock.NewResult(2, 2))
 		mock.ExpectCommit()
 
@@ -190,12 +337,12 @@ func TestCreateTickets(t *testing.T) {
 
 	t.Run("creation with duplicate token", func(t *testing.T) {
 		tickets := []*transfert.Ticket{
-			{Prize: aws.String("PrizeA"), Token: aws.String("TokenA")},
-			{Prize: aws.String("PrizeB"), Token: aws.String("TokenB")},
+			{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+			{PrizeID: aws.String("PrizeB"), Token: aws.String("TokenB")},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID (Ticket 1)
 				sqlmock.AnyArg(), // CreatedAt (Ticket 1)
@@ -204,6 +351,26 @@ func TestCreateTickets(t *testing.T) {
 				nil,              // CredentialID (Ticket 1)
 				"TokenA",         // Token (Ticket 1)
 				"PrizeA",         // Prize (Ticket 1)
+				"unclaimed",      // Status (Ticket 1)
+				nil,              // ClaimedAt (Ticket 1)
+				nil,              // RedeemedAt (Ticket 1)
+				nil,              // RedeemedCaisseID (Ticket 1)
+				nil,              // RedeemedBy (Ticket 1)
+				nil,              // RedeemedShiftID (Ticket 1)
+				nil,              // CampaignID (Ticket 1)
+				sqlmock.AnyArg(), // Sequence (Ticket 1)
+				nil,              // StoreID (Ticket 1)
+				nil,              // CaisseID (Ticket 1)
+				nil,              // Receipt (Ticket 1)
+				nil,              // Amount (Ticket 1)
+				nil,              // IssuedAt (Ticket 1)
+				nil,              // IssuedBy (Ticket 1)
+				nil,              // ShiftID (Ticket 1)
+				nil,              // VoidedAt (Ticket 1)
+				nil,              // VoidedBy (Ticket 1)
+				nil,              // VoidReason (Ticket 1)
+				nil,              // ReissuedFromID (Ticket 1)
+				nil,              // ReservedStoreID (Ticket 1)
 
 				sqlmock.AnyArg(), // ID (Ticket 2)
 				sqlmock.AnyArg(), // CreatedAt (Ticket 2)
@@ -212,6 +379,26 @@ func TestCreateTickets(t *testing.T) {
 				nil,              // CredentialID (Ticket 2)
 				"TokenB",         // Token (Ticket 2)
 				"PrizeB",         // Prize (Ticket 2)
+				"unclaimed",      // Status (Ticket 2)
+				nil,              // ClaimedAt (Ticket 2)
+				nil,              // RedeemedAt (Ticket 2)
+				nil,              // RedeemedCaisseID (Ticket 2)
+				nil,              // RedeemedBy (Ticket 2)
+				nil,              // RedeemedShiftID (Ticket 2)
+				nil,              // CampaignID (Ticket 2)
+				sqlmock.AnyArg(), // Sequence (Ticket 2)
+				nil,              // StoreID (Ticket 2)
+				nil,              // CaisseID (Ticket 2)
+				nil,              // Receipt (Ticket 2)
+				nil,              // Amount (Ticket 2)
+				nil,              // IssuedAt (Ticket 2)
+				nil,              // IssuedBy (Ticket 2)
+				nil,              // ShiftID (Ticket 2)
+				nil,              // VoidedAt (Ticket 2)
+				nil,              // VoidedBy (Ticket 2)
+				nil,              // VoidReason (Ticket 2)
+				nil,              // ReissuedFromID (Ticket 2)
+				nil,              // ReservedStoreID (Ticket 2)
 			).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint"))
 
 		mock.ExpectRollback()
@@ -225,12 +412,12 @@ func TestCreateTickets(t *testing.T) {
 
 	t.Run("database unavailable", func(t *testing.T) {
 		tickets := []*transfert.Ticket{
-			{Prize: aws.String("PrizeA"), Token: aws.String("TokenA")},
-			{Prize: aws.String("PrizeB"), Token: aws.String("TokenB")},
+			{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+			{PrizeID: aws.String("PrizeB"), Token: aws.String("TokenB")},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID (Ticket 1)
 				sqlmock.AnyArg(), // CreatedAt (Ticket 1)
@@ -239,6 +426,26 @@ func TestCreateTickets(t *testing.T) {
 				nil,              // CredentialID (Ticket 1)
 				"TokenA",         // Token (Ticket 1)
 				"PrizeA",         // Prize (Ticket 1)
+				"unclaimed",      // Status (Ticket 1)
+				nil,              // ClaimedAt (Ticket 1)
+				nil,              // RedeemedAt (Ticket 1)
+				nil,              // RedeemedCaisseID (Ticket 1)
+				nil,              // RedeemedBy (Ticket 1)
+				nil,              // RedeemedShiftID (Ticket 1)
+				nil,              // CampaignID (Ticket 1)
+				sqlmock.AnyArg(), // Sequence (Ticket 1)
+				nil,              // StoreID (Ticket 1)
+				nil,              // CaisseID (Ticket 1)
+				nil,              // Receipt (Ticket 1)
+				nil,              // Amount (Ticket 1)
+				nil,              // IssuedAt (Ticket 1)
+				nil,              // IssuedBy (Ticket 1)
+				nil,              // ShiftID (Ticket 1)
+				nil,              // VoidedAt (Ticket 1)
+				nil,              // VoidedBy (Ticket 1)
+				nil,              // VoidReason (Ticket 1)
+				nil,              // ReissuedFromID (Ticket 1)
+				nil,              // ReservedStoreID (Ticket 1)
 
 				sqlmock.AnyArg(), // ID (Ticket 2)
 				sqlmock.AnyArg(), // CreatedAt (Ticket 2)
@@ -247,6 +454,26 @@ func TestCreateTickets(t *testing.T) {
 				nil,              // CredentialID (Ticket 2)
 				"TokenB",         // Token (Ticket 2)
 				"PrizeB",         // Prize (Ticket 2)
+				"unclaimed",      // Status (Ticket 2)
+				nil,              // ClaimedAt (Ticket 2)
+				nil,              // RedeemedAt (Ticket 2)
+				nil,              // RedeemedCaisseID (Ticket 2)
+				nil,              // RedeemedBy (Ticket 2)
+				nil,              // RedeemedShiftID (Ticket 2)
+				nil,              // CampaignID (Ticket 2)
+				sqlmock.AnyArg(), // Sequence (Ticket 2)
+				nil,              // StoreID (Ticket 2)
+				nil,              // CaisseID (Ticket 2)
+				nil,              // Receipt (Ticket 2)
+				nil,              // Amount (Ticket 2)
+				nil,              // IssuedAt (Ticket 2)
+				nil,              // IssuedBy (Ticket 2)
+				nil,              // ShiftID (Ticket 2)
+				nil,              // VoidedAt (Ticket 2)
+				nil,              // VoidedBy (Ticket 2)
+				nil,              // VoidReason (Ticket 2)
+				nil,              // ReissuedFromID (Ticket 2)
+				nil,              // ReservedStoreID (Ticket 2)
 			).WillReturnError(fmt.Errorf("database is unavailable"))
 
 		mock.ExpectRollback()
@@ -260,12 +487,12 @@ func TestCreateTickets(t *testing.T) {
 
 	t.Run("successful creation with custom options", func(t *testing.T) {
 		tickets := []*transfert.Ticket{
-			{Prize: aws.String("PrizeA"), Token: aws.String("TokenA")},
-			{Prize: aws.String("PrizeB"), Token: aws.String("TokenB")},
+			{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+			{PrizeID: aws.String("PrizeB"), Token: aws.String("TokenB")},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","status","claimed_at","redeemed_at","redeemed_caisse_id","redeemed_by","redeemed_shift_id","campaign_id","sequence","store_id","caisse_id","receipt","amount","issued_at","issued_by","shift_id","voided_at","voided_by","void_reason","reissued_from_id","reserved_store_id"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID (Ticket 1)
 				sqlmock.AnyArg(), // CreatedAt (Ticket 1)
@@ -274,6 +501,26 @@ func TestCreateTickets(t *testing.T) {
 				nil,              // CredentialID (Ticket 1)
 				"TokenA",         // Token (Ticket 1)
 				"PrizeA",         // Prize (Ticket 1)
+				"unclaimed",      // Status (Ticket 1)
+				nil,              // ClaimedAt (Ticket 1)
+				nil,              // RedeemedAt (Ticket 1)
+				nil,              // RedeemedCaisseID (Ticket 1)
+				nil,              // RedeemedBy (Ticket 1)
+				nil,              // RedeemedShiftID (Ticket 1)
+				nil,              // CampaignID (Ticket 1)
+				sqlmock.AnyArg(), // Sequence (Ticket 1)
+				nil,              // StoreID (Ticket 1)
+				nil,              // CaisseID (Ticket 1)
+				nil,              // Receipt (Ticket 1)
+				nil,              // Amount (Ticket 1)
+				nil,              // IssuedAt (Ticket 1)
+				nil,              // IssuedBy (Ticket 1)
+				nil,              // ShiftID (Ticket 1)
+				nil,              // VoidedAt (Ticket 1)
+				nil,              // VoidedBy (Ticket 1)
+				nil,              // VoidReason (Ticket 1)
+				nil,              // ReissuedFromID (Ticket 1)
+				nil,              // ReservedStoreID (Ticket 1)
 
 				sqlmock.AnyArg(), // ID (Ticket 2)
 				sqlmock.AnyArg(), // CreatedAt (Ticket 2)
@@ -282,6 +529,26 @@ func TestCreateTickets(t *testing.T) {
 				nil,              // CredentialID (Ticket 2)
 				"TokenB",         // Token (Ticket 2)
 				"PrizeB",         // Prize (Ticket 2),
+				"unclaimed",      // Status (Ticket 2)
+				nil,              // ClaimedAt (Ticket 2)
+				nil,              // RedeemedAt (Ticket 2)
+				nil,              // RedeemedCaisseID (Ticket 2)
+				nil,              // RedeemedBy (Ticket 2)
+				nil,              // RedeemedShiftID (Ticket 2)
+				nil,              // CampaignID (Ticket 2)
+				sqlmock.AnyArg(), // Sequence (Ticket 2)
+				nil,              // StoreID (Ticket 2)
+				nil,              // CaisseID (Ticket 2)
+				nil,              // Receipt (Ticket 2)
+				nil,              // Amount (Ticket 2)
+				nil,              // IssuedAt (Ticket 2)
+				nil,              // IssuedBy (Ticket 2)
+				nil,              // ShiftID (Ticket 2)
+				nil,              // VoidedAt (Ticket 2)
+				nil,              // VoidedBy (Ticket 2)
+				nil,              // VoidReason (Ticket 2)
+				nil,              // ReissuedFromID (Ticket 2)
+				nil,              // ReservedStoreID (Ticket 2)
 			).WillReturnResult(sqlmock.NewResult(2, 2))
 		mock.ExpectCommit()
 
@@ -297,14 +564,14 @@ func TestReadTicket(t *testing.T) {
 	defer cleanup()
 
 	dto := &transfert.Ticket{
-		Prize: aws.String("PrizeA"),
-		Token: aws.String("unique-token"),
+		PrizeID: aws.String("PrizeA"),
+		Token:   aws.String("unique-token"),
 	}
 
 	t.Run("successful read", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
-			WithArgs(dto.Prize, dto.Token, 1). // Inclure la limite
-			WillReturnRows(sqlmock.NewRows([]string{"id", "prize", "token"}).AddRow("some-id", "PrizeA", "unique-token"))
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize_id" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
+			WithArgs(dto.PrizeID, dto.Token, 1). // Inclure la limite
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "token"}).AddRow("some-id", "PrizeA", "unique-token"))
 
 		entity, err := repo.ReadTicket(dto)
 
@@ -313,8 +580,8 @@ func TestReadTicket(t *testing.T) {
 		assert.NotNil(t, entity)
 
 		// Vérification des champs retournés
-		if assert.NotNil(t, entity.Prize) { // Vérifie avant d'accéder à Prize
-			assert.Equal(t, *dto.Prize, *entity.Prize)
+		if assert.NotNil(t, entity.PrizeID) { // Vérifie avant d'accéder à Prize
+			assert.Equal(t, *dto.PrizeID, *entity.PrizeID)
 		}
 		if assert.NotNil(t, entity.Token) { // Vérifie avant d'accéder à Token
 			assert.Equal(t, "unique-token", entity.Token.String())
@@ -324,8 +591,8 @@ func TestReadTicket(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
-			WithArgs(dto.Prize, dto.Token, 1).          // Inclure la limite
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize_id" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
+			WithArgs(dto.PrizeID, dto.Token, 1).        // Inclure la limite
 			WillReturnRows(sqlmock.NewRows([]string{})) // Aucune ligne retournée
 
 		entity, err := repo.ReadTicket(dto)
@@ -339,8 +606,8 @@ func TestReadTicket(t *testing.T) {
 	})
 
 	t.Run("database error", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
-			WithArgs(dto.Prize, dto.Token, 1).
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize_id" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
+			WithArgs(dto.PrizeID, dto.Token, 1).
 			WillReturnError(fmt.Errorf("database error"))
 
 		entity, err := repo.ReadTicket(dto, database.Limit(1))
@@ -359,14 +626,14 @@ func TestReadTickets(t *testing.T) {
 	defer cleanup()
 
 	dto := &transfert.Ticket{
-		Prize: aws.String("PrizeA"),
-		Token: aws.String("unique-token"),
+		PrizeID: aws.String("PrizeA"),
+		Token:   aws.String("unique-token"),
 	}
 
 	t.Run("successful read", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE`).
-			WithArgs(dto.Prize, dto.Token).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "prize", "token"}).
+			WithArgs(dto.PrizeID, dto.Token).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "token"}).
 				AddRow("ticket1", "PrizeA", "unique-token").
 				AddRow("ticket2", "PrizeA", "unique-token"))
 
@@ -374,7 +641,7 @@ func TestReadTickets(t *testing.T) {
 		assert.Nil(t, err)
 		assert.NotNil(t, tickets)
 		assert.Len(t, tickets, 2)
-		assert.Equal(t, "PrizeA", *tickets[0].Prize)
+		assert.Equal(t, "PrizeA", *tickets[0].PrizeID)
 		assert.Equal(t, "unique-token", tickets[0].Token.String())
 
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -382,7 +649,7 @@ func TestReadTickets(t *testing.T) {
 
 	t.Run("no tickets found", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE`).
-			WithArgs(dto.Prize, dto.Token).
+			WithArgs(dto.PrizeID, dto.Token).
 			WillReturnRows(sqlmock.NewRows([]string{}))
 
 		tickets, err := repo.ReadTickets(dto)
@@ -395,7 +662,7 @@ func TestReadTickets(t *testing.T) {
 
 	t.Run("database error", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE`).
-			WithArgs(dto.Prize, dto.Token).
+			WithArgs(dto.PrizeID, dto.Token).
 			WillReturnError(fmt.Errorf("database error"))
 
 		tickets, err := repo.ReadTickets(dto)
@@ -408,8 +675,8 @@ func TestReadTickets(t *testing.T) {
 
 	t.Run("successful read with custom options", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE`).
-			WithArgs(dto.Prize, dto.Token).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "prize", "token"}).
+			WithArgs(dto.PrizeID, dto.Token).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "token"}).
 				AddRow("ticket1", "PrizeA", "unique-token").
 				AddRow("ticket2", "PrizeA", "unique-token"))
 
@@ -426,11 +693,11 @@ func TestUpdateTicket(t *testing.T) {
 	repo, mock, cleanup := setup()
 	defer cleanup()
 
-	token1 := token.Generate(12)
+	token1, _ := token.Generate(12)
 
 	entity := &entities.Ticket{
 		ID:           "some-id",
-		Prize:        aws.String("PrizeA"),
+		PrizeID:      aws.String("PrizeA"),
 		Token:        token1,
 		CredentialID: nil,
 		CreatedAt:    time.Now(),
@@ -446,7 +713,27 @@ func TestUpdateTicket(t *testing.T) {
 				nil,                 // DeletedAt
 				entity.CredentialID, // CredentialID
 				entity.Token,        // Token
-				entity.Prize,        // Prize
+				entity.PrizeID,      // Prize
+				entity.Status,       // Status
+				nil,                 // ClaimedAt
+				nil,                 // RedeemedAt
+				nil,                 // RedeemedCaisseID
+				nil,                 // RedeemedBy
+				nil,                 // RedeemedShiftID
+				nil,                 // CampaignID
+				sqlmock.AnyArg(),    // Sequence
+				nil,                 // StoreIDNone
+				nil,                 // CaisseIDNone
+				nil,                 // ReceiptNone
+				nil,                 // AmountNone
+				nil,                 // IssuedAtNone
+				nil,                 // IssuedByNone
+				nil,                 // ShiftIDNone
+				nil,                 // VoidedAtNone
+				nil,                 // VoidedByNone
+				nil,                 // VoidReasonNone
+				nil,                 // ReissuedFromIDNone
+				nil,                 // ReservedStoreIDNone
 				entity.ID,           // ID
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
@@ -466,7 +753,27 @@ func TestUpdateTicket(t *testing.T) {
 				nil,                 // DeletedAt
 				entity.CredentialID, // CredentialID
 				entity.Token,        // Token
-				entity.Prize,        // Prize
+				entity.PrizeID,      // Prize
+				entity.Status,       // Status
+				nil,                 // ClaimedAt
+				nil,                 // RedeemedAt
+				nil,                 // RedeemedCaisseID
+				nil,                 // RedeemedBy
+				nil,                 // RedeemedShiftID
+				nil,                 // CampaignID
+				sqlmock.AnyArg(),    // Sequence
+				nil,                 // StoreIDNone
+				nil,                 // CaisseIDNone
+				nil,                 // ReceiptNone
+				nil,                 // AmountNone
+				nil,                 // IssuedAtNone
+				nil,                 // IssuedByNone
+				nil,                 // ShiftIDNone
+				nil,                 // VoidedAtNone
+				nil,                 // VoidedByNone
+				nil,                 // VoidReasonNone
+				nil,                 // ReissuedFromIDNone
+				nil,                 // ReservedStoreIDNone
 				entity.ID,           // ID
 			).WillReturnError(fmt.Errorf("update error"))
 		mock.ExpectRollback()
@@ -479,6 +786,626 @@ func TestUpdateTicket(t *testing.T) {
 	})
 }
 
+func TestAssignTicketsToStore(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	assign := `UPDATE "tickets" SET "store_id"=\$1,"updated_at"=\$2 ` +
+		`WHERE \(id IN \(\$3,\$4\) AND store_id IS NULL\) AND "tickets"."deleted_at" IS NULL`
+
+	t.Run("successful assignment", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(assign).
+			WithArgs("store-123", sqlmock.AnyArg(), "ticket-1", "ticket-2").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		count, err := repo.AssignTicketsToStore([]string{"ticket-1", "ticket-2"}, "store-123")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("tickets assigned in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(assign).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		count, err := repo.AssignTicketsToStore([]string{"ticket-1", "ticket-2"}, "store-123")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("assignment failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(assign).WillReturnError(fmt.Errorf("update error"))
+		mock.ExpectRollback()
+
+		count, err := repo.AssignTicketsToStore([]string{"ticket-1", "ticket-2"}, "store-123")
+		assert.Equal(t, 0, count)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestClaimTicket(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	entity := &entities.Ticket{
+		ID:           "some-id",
+		CredentialID: aws.String("client-123"),
+		Status:       entities.TicketClaimed,
+	}
+
+	claim := `UPDATE "tickets" SET "claimed_at"=\$1,"credential_id"=\$2,"status"=\$3,"updated_at"=\$4 ` +
+		`WHERE \(credential_id IS NULL AND \(status = \$5 OR status = '' OR status IS NULL\)\) ` +
+		`AND "tickets"."deleted_at" IS NULL AND "id" = \$6`
+
+	t.Run("successful claim", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(claim).
+			WithArgs(entity.ClaimedAt, entity.CredentialID, entity.Status, sqlmock.AnyArg(), entities.TicketUnclaimed, entity.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.ClaimTicket(entity)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket claimed in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(claim).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.ClaimTicket(entity)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyClaimed, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("claim failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(claim).WillReturnError(fmt.Errorf("update error"))
+		mock.ExpectRollback()
+
+		err := repo.ClaimTicket(entity)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestIssueTicket(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	now := time.Now()
+	entity := &entities.Ticket{
+		ID:       "some-id",
+		CaisseID: aws.String("caisse-123"),
+		StoreID:  aws.String("store-123"),
+		Receipt:  aws.String("R-0001"),
+		Amount:   aws.Float64(54.9),
+		IssuedAt: &now,
+		IssuedBy: aws.String("employee-123"),
+	}
+
+	issue := `UPDATE "tickets" SET "amount"=\$1,"caisse_id"=\$2,"issued_at"=\$3,"issued_by"=\$4,"receipt"=\$5,"shift_id"=\$6,"store_id"=\$7,"updated_at"=\$8 ` +
+		`WHERE \(credential_id IS NULL AND store_id IS NULL AND issued_at IS NULL AND voided_at IS NULL\) ` +
+		`AND "tickets"."deleted_at" IS NULL AND "id" = \$9`
+
+	t.Run("successful issuance", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(issue).
+			WithArgs(entity.Amount, entity.CaisseID, entity.IssuedAt, entity.IssuedBy, entity.Receipt, entity.ShiftID, entity.StoreID, sqlmock.AnyArg(), entity.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.IssueTicket(entity)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket issued in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(issue).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.IssueTicket(entity)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyIssued, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("receipt rewarded concurrently", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(issue).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint \"idx_tickets_store_receipt\""))
+		mock.ExpectRollback()
+
+		err := repo.IssueTicket(entity)
+		assert.Equal(t, errors_domain_game.ErrTicketReceiptUsed, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("issuance failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(issue).WillReturnError(fmt.Errorf("update error"))
+		mock.ExpectRollback()
+
+		err := repo.IssueTicket(entity)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestVoidTicket(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	now := time.Now()
+	entity := &entities.Ticket{
+		ID:         "some-id",
+		Status:     entities.TicketVoided,
+		VoidedAt:   &now,
+		VoidedBy:   aws.String("admin-123"),
+		VoidReason: aws.String("misprinted"),
+	}
+
+	void := `UPDATE "tickets" SET "status"=\$1,"void_reason"=\$2,"voided_at"=\$3,"voided_by"=\$4,"updated_at"=\$5 ` +
+		`WHERE \(voided_at IS NULL AND \(status IS NULL OR status IN \(\$6,\$7,\$8\)\)\) ` +
+		`AND "tickets"."deleted_at" IS NULL AND "id" = \$9`
+
+	t.Run("successful void", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(void).
+			WithArgs(entities.TicketVoided, entity.VoidReason, entity.VoidedAt, entity.VoidedBy, sqlmock.AnyArg(), "", entities.TicketUnclaimed, entities.TicketClaimed, entity.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.VoidTicket(entity)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket finalized in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(void).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.VoidTicket(entity)
+		assert.Equal(t, errors_domain_game.ErrTicketVoided, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("void failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(void).WillReturnError(fmt.Errorf("update error"))
+		mock.ExpectRollback()
+
+		err := repo.VoidTicket(entity)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("successful reissue", func(t *testing.T) {
+		replacement := entity.Reissue(token.Luhn("000000000026"))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(void).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "tickets" SET "receipt"=\$1`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "tickets"`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.ReissueTicket(entity, replacement)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, replacement.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("reissue of a ticket finalized in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(void).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.ReissueTicket(entity, entity.Reissue(token.Luhn("000000000026")))
+		assert.Equal(t, errors_domain_game.ErrTicketVoided, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("reissue failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(void).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "tickets" SET "receipt"=\$1`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "tickets"`).WillReturnError(fmt.Errorf("insert error"))
+		mock.ExpectRollback()
+
+		err := repo.ReissueTicket(entity, entity.Reissue(token.Luhn("000000000026")))
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestClaimTicketConcurrency hammers one ticket from many players at once, only one of them may win
+// The PostgreSQL run needs a database, its DSN is read from THETIPTOP_TEST_POSTGRES_DSN.
+func TestClaimTicketConcurrency(t *testing.T) {
+	const players = 50
+
+	hammer := func(t *testing.T, dialector gorm.Dialector) {
+		gormDB, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		dbInstance, err := database.FromDB(gormDB)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		if !assert.NoError(t, repositories.Migrate(dbInstance)) {
+			return
+		}
+
+		repo := repositories.NewGameRepository(dbInstance)
+
+		code, _ := token.Generate(12)
+		ticket, cerr := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+		if !assert.Nil(t, cerr) {
+			return
+		}
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		results := make(chan errors.ErrorInterface, players)
+
+		for i := range players {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// Every player read the ticket while it was still unclaimed
+				entity := *ticket
+				entity.Claim(aws.String(fmt.Sprintf("client-%d", i)))
+
+				<-start
+				results <- repo.ClaimTicket(&entity)
+			}()
+		}
+
+		close(start)
+		wg.Wait()
+		close(results)
+
+		winners := 0
+		for err := range results {
+			if err == nil {
+				winners++
+				continue
+			}
+
+			assert.Equal(t, errors_domain_game.ErrTicketAlreadyClaimed, err)
+		}
+
+		assert.Equal(t, 1, winners)
+
+		stored, rerr := repo.ReadTicket(&transfert.Ticket{ID: &ticket.ID})
+		if assert.Nil(t, rerr) {
+			assert.Equal(t, entities.TicketClaimed, stored.Status)
+			assert.NotNil(t, stored.CredentialID)
+		}
+	}
+
+	t.Run("SQLite", func(t *testing.T) {
+		hammer(t, sqlite.Open(filepath.Join(t.TempDir(), "game.db")+"?_busy_timeout=10000&_journal_mode=WAL"))
+	})
+
+	t.Run("PostgreSQL", func(t *testing.T) {
+		dsn := os.Getenv("THETIPTOP_TEST_POSTGRES_DSN")
+		if dsn == "" {
+			t.Skip("THETIPTOP_TEST_POSTGRES_DSN is not set")
+		}
+
+		hammer(t, postgres.Open(dsn))
+	})
+}
+
+func TestRedeemTicketConcurrency(t *testing.T) {
+	const caisses = 50
+
+	hammer := func(t *testing.T, dialector gorm.Dialector) {
+		gormDB, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		dbInstance, err := database.FromDB(gormDB)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		if !assert.NoError(t, repositories.Migrate(dbInstance)) {
+			return
+		}
+
+		repo := repositories.NewGameRepository(dbInstance)
+
+		code, _ := token.Generate(12)
+		ticket, cerr := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+		if !assert.Nil(t, cerr) {
+			return
+		}
+
+		ticket.Claim(aws.String("client-1"))
+		if !assert.Nil(t, repo.ClaimTicket(ticket)) {
+			return
+		}
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		results := make(chan errors.ErrorInterface, caisses)
+
+		for i := range caisses {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// Every caisse read the ticket while it was still claimed
+				entity := *ticket
+				entity.Redeem(aws.String(fmt.Sprintf("caisse-%d", i)), aws.String("employee-1"))
+
+				<-start
+				results <- repo.RedeemTicket(&entity)
+			}()
+		}
+
+		close(start)
+		wg.Wait()
+		close(results)
+
+		winners := 0
+		for err := range results {
+			if err == nil {
+				winners++
+				continue
+			}
+
+			assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+		}
+
+		assert.Equal(t, 1, winners)
+
+		stored, rerr := repo.ReadTicket(&transfert.Ticket{ID: &ticket.ID})
+		if assert.Nil(t, rerr) {
+			assert.Equal(t, entities.TicketRedeemed, stored.Status)
+			assert.NotNil(t, stored.RedeemedCaisseID)
+		}
+
+		// A voided ticket cannot be handed over anymore
+		code, _ = token.Generate(12)
+		voided, cerr := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+		if !assert.Nil(t, cerr) {
+			return
+		}
+
+		voided.Claim(aws.String("client-2"))
+		assert.Nil(t, repo.ClaimTicket(voided))
+
+		stale := *voided
+		voided.Void(aws.String("damaged"), aws.String("admin-1"))
+		assert.Nil(t, repo.VoidTicket(voided))
+
+		stale.Redeem(aws.String("caisse-1"), aws.String("employee-1"))
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, repo.RedeemTicket(&stale))
+	}
+
+	t.Run("SQLite", func(t *testing.T) {
+		hammer(t, sqlite.Open(filepath.Join(t.TempDir(), "game.db")+"?_busy_timeout=10000&_journal_mode=WAL"))
+	})
+
+	t.Run("PostgreSQL", func(t *testing.T) {
+		dsn := os.Getenv("THETIPTOP_TEST_POSTGRES_DSN")
+		if dsn == "" {
+			t.Skip("THETIPTOP_TEST_POSTGRES_DSN is not set")
+		}
+
+		hammer(t, postgres.Open(dsn))
+	})
+}
+
+func TestReserveTicket(t *testing.T) {
+	repo := setupStock(t)
+
+	code, _ := token.Generate(12)
+	ticket, err := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.True(t, ticket.Claim(aws.String("client-1")))
+	assert.Nil(t, repo.ClaimTicket(ticket))
+
+	// A stale copy only writes its reservation, the redemption stored meanwhile is kept
+	stale := *ticket
+	assert.True(t, ticket.Redeem(aws.String("caisse-1"), aws.String("employee-1")))
+	assert.Nil(t, repo.RedeemTicket(ticket))
+
+	stale.ReservedStoreID = aws.String("store-1")
+	assert.Equal(t, errors_domain_game.ErrTicketNotClaimed, repo.ReserveTicket(&stale))
+
+	stored, err := repo.ReadTicket(&transfert.Ticket{ID: &ticket.ID})
+	if assert.Nil(t, err) {
+		assert.Equal(t, entities.TicketRedeemed, stored.Status)
+		assert.Nil(t, stored.ReservedStoreID)
+	}
+
+	code, _ = token.Generate(12)
+	ticket, err = repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.True(t, ticket.Claim(aws.String("client-1")))
+	assert.Nil(t, repo.ClaimTicket(ticket))
+
+	ticket.ReservedStoreID = aws.String("store-1")
+	assert.Nil(t, repo.ReserveTicket(ticket))
+
+	stored, err = repo.ReadTicket(&transfert.Ticket{ID: &ticket.ID})
+	if assert.Nil(t, err) {
+		assert.Equal(t, entities.TicketClaimed, stored.Status)
+		assert.Equal(t, "store-1", *stored.ReservedStoreID)
+	}
+}
+
+func TestExpireTicket(t *testing.T) {
+	repo := setupStock(t)
+
+	code, _ := token.Generate(12)
+	ticket, err := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.True(t, ticket.Claim(aws.String("client-1")))
+	assert.Nil(t, repo.ClaimTicket(ticket))
+
+	// A stale copy cannot expire a ticket redeemed meanwhile
+	stale := *ticket
+	assert.True(t, ticket.Redeem(aws.String("caisse-1"), aws.String("employee-1")))
+	assert.Nil(t, repo.RedeemTicket(ticket))
+
+	assert.True(t, stale.Expire())
+	assert.Equal(t, errors_domain_game.ErrTicketNotClaimed, repo.ExpireTicket(&stale))
+
+	stored, err := repo.ReadTicket(&transfert.Ticket{ID: &ticket.ID})
+	if assert.Nil(t, err) {
+		assert.Equal(t, entities.TicketRedeemed, stored.Status)
+	}
+
+	code, _ = token.Generate(12)
+	ticket, err = repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.True(t, ticket.Claim(aws.String("client-1")))
+	assert.Nil(t, repo.ClaimTicket(ticket))
+	assert.True(t, ticket.Expire())
+	assert.Nil(t, repo.ExpireTicket(ticket))
+
+	stored, err = repo.ReadTicket(&transfert.Ticket{ID: &ticket.ID})
+	if assert.Nil(t, err) {
+		assert.Equal(t, entities.TicketExpired, stored.Status)
+		assert.Equal(t, "client-1", *stored.CredentialID)
+	}
+}
+
+func TestIssueTicketReceipt(t *testing.T) {
+	repo := setupStock(t)
+
+	issue := func(store, receipt string) errors.ErrorInterface {
+		code, _ := token.Generate(12)
+		ticket, err := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+		if !assert.Nil(t, err) {
+			return err
+		}
+
+		ticket.Issue(aws.String("caisse-1"), aws.String(store), aws.String(receipt), aws.Float64(54.9), aws.String("employee-1"))
+		return repo.IssueTicket(ticket)
+	}
+
+	assert.Nil(t, issue("store-1", "R-0001"))
+	assert.Equal(t, errors_domain_game.ErrTicketReceiptUsed, issue("store-1", "R-0001"))
+
+	// Receipts are numbered by each store
+	assert.Nil(t, issue("store-2", "R-0001"))
+
+	// The replacement of a ticket takes the receipt over
+	voided, err := repo.ReadTicket(&transfert.Ticket{}, database.Where("store_id = ? AND receipt = ?", "store-1", "R-0001"))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	voided.Void(aws.String("damaged"), aws.String("admin-1"))
+	code, _ := token.Generate(12)
+	replacement := voided.Reissue(code)
+	assert.Nil(t, repo.ReissueTicket(voided, replacement))
+
+	stored, err := repo.ReadTicket(&transfert.Ticket{ID: &voided.ID})
+	if assert.Nil(t, err) {
+		assert.Nil(t, stored.Receipt)
+	}
+
+	assert.Equal(t, "R-0001", *replacement.Receipt)
+	assert.Equal(t, errors_domain_game.ErrTicketReceiptUsed, issue("store-1", "R-0001"))
+}
+
+func TestTransaction(t *testing.T) {
+	repo := setupStock(t)
+
+	code, _ := token.Generate(12)
+	ticket, err := repo.CreateTicket(&transfert.Ticket{Token: code.PointerString()})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	issue := func(failure errors.ErrorInterface) errors.ErrorInterface {
+		return repo.Transaction(func(tx repositories.GameRepositoryInterface) errors.ErrorInterface {
+			issued := *ticket
+			issued.Issue(aws.String("caisse-1"), aws.String("store-1"), aws.String("R-0001"), aws.Float64(54.9), aws.String("employee-1"))
+			if err := tx.IssueTicket(&issued); err != nil {
+				return err
+			}
+
+			if _, err := tx.CreateTicketEvent(&transfert.TicketEvent{TicketID: &ticket.ID, Action: aws.String(entities.TicketEventIssued)}); err != nil {
+				return err
+			}
+
+			return failure
+		})
+	}
+
+	// A failure rolls the issuance and its event back, the error is returned as is
+	assert.Equal(t, errors.ErrInternalServer, issue(errors.ErrInternalServer))
+
+	stored, err := repo.ReadTicket(&transfert.Ticket{ID: &ticket.ID})
+	if assert.Nil(t, err) {
+		assert.Nil(t, stored.IssuedAt)
+	}
+
+	events, err := repo.ReadTicketEvents(&transfert.TicketEvent{TicketID: &ticket.ID})
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+
+	// Without failure both are stored
+	assert.Nil(t, issue(nil))
+
+	stored, err = repo.ReadTicket(&transfert.Ticket{ID: &ticket.ID})
+	if assert.Nil(t, err) {
+		assert.NotNil(t, stored.IssuedAt)
+	}
+
+	events, err = repo.ReadTicketEvents(&transfert.TicketEvent{TicketID: &ticket.ID})
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+}
+
 func TestDeleteTicket(t *testing.T) {
 	repo, mock, cleanup := setup()
 	defer cleanup()
@@ -524,12 +1451,12 @@ func TestCountTicket(t *testing.T) {
 	defer cleanup()
 
 	dto := &transfert.Ticket{
-		Prize: aws.String("PrizeA"),
+		PrizeID: aws.String("PrizeA"),
 	}
 
 	t.Run("successful count with options", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT count\(\*\) FROM "tickets" WHERE`).
-			WithArgs(dto.Prize).
+			WithArgs(dto.PrizeID).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
 
 		count, err := repo.CountTicket(dto, database.Order("ASC"))
@@ -541,7 +1468,7 @@ func TestCountTicket(t *testing.T) {
 
 	t.Run("count failure with options", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT count\(\*\) FROM "tickets" WHERE`).
-			WithArgs(dto.Prize).
+			WithArgs(dto.PrizeID).
 			WillReturnError(fmt.Errorf("count error"))
 
 		count, err := repo.CountTicket(dto, database.Order("ASC"))
@@ -552,3 +1479,46 @@ func TestCountTicket(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestAggregateTickets(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	aggregate := `SELECT COALESCE\(prize_id, ''\) AS bucket,COUNT\(\*\) AS count,COALESCE\(SUM\(amount\), 0\) AS amount ` +
+		`FROM "tickets" WHERE "tickets"."store_id" = \$1 AND issued_at IS NOT NULL AND "tickets"."deleted_at" IS NULL GROUP BY "bucket"`
+
+	options := []database.Option{
+		database.GroupByAs("COALESCE(prize_id, '')", "bucket"),
+		database.Count("count"),
+		database.Sum("amount", "amount"),
+		database.Where("issued_at IS NOT NULL"),
+	}
+
+	t.Run("successful aggregation", func(t *testing.T) {
+		mock.ExpectQuery(aggregate).
+			WithArgs("store-123").
+			WillReturnRows(sqlmock.NewRows([]string{"bucket", "count", "amount"}).
+				AddRow("prize-1", 3, 149.7).
+				AddRow("prize-2", 1, 54.9))
+
+		aggregates, err := repo.AggregateTickets(&transfert.Ticket{StoreID: aws.String("store-123")}, options...)
+		assert.Nil(t, err)
+		if assert.Len(t, aggregates, 2) {
+			assert.Equal(t, "prize-1", aggregates[0].Bucket)
+			assert.Equal(t, 3, aggregates[0].Count)
+			assert.Equal(t, 149.7, aggregates[0].Amount)
+		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("aggregation failure", func(t *testing.T) {
+		mock.ExpectQuery(aggregate).WillReturnError(fmt.Errorf("query error"))
+
+		aggregates, err := repo.AggregateTickets(&transfert.Ticket{StoreID: aws.String("store-123")}, options...)
+		assert.Nil(t, aggregates)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreatePrize creates a new prize
+// Inserts a new prize into the database based on the transfert.Prize input object
+//
+// Parameters:
+// - obj: *transfert.Prize - The prize transfer object to create
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Prize: The created prize entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreatePrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	prize := entities.CreatePrize(obj)
+
+	query := r.store.Engine.Create(prize)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return nil, errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return prize, nil
+}
+
+// ReadPrize reads a prize from the database
+// Finds and returns a prize based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Prize - The prize transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Prize: The found prize entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadPrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	prize := &entities.Prize{}
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(prize)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrPrizeNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return prize, nil
+}
+
+// ReadPrizes reads multiple prizes from the database
+// Finds and returns a list of prizes based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Prize - The prize transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.Prize: A slice of found prize entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadPrizes(obj *transfert.Prize, options ...database.Option) ([]*entities.Prize, errors.ErrorInterface) {
+	var prizes []*entities.Prize
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&prizes)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return prizes, nil
+}
+
+// UpdatePrize updates an existing prize in the database
+// Saves all the fields of the prize entity
+//
+// Parameters:
+// - entity: *entities.Prize - The prize entity to update
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) UpdatePrize(entity *entities.Prize, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Save(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// DeletePrize deletes a prize from the database
+// Removes a prize based on the provided transfer object
+//
+// Parameters:
+// - obj: *transfert.Prize - The prize transfer object to delete
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) DeletePrize(obj *transfert.Prize, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Where(obj).Delete(&entities.Prize{})
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,347 @@
+package repositories
+
+import (
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+// ReadPrizeStock reads the stock of a prize in a store
+//
+// Parameters:
+// - obj: *transfert.PrizeStock - The stock transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.PrizeStock: The found stock entity
+// - errors.ErrorInterface: ErrPrizeStockNotFound if the prize is not stocked in the store
+func (r *GameRepository) ReadPrizeStock(obj *transfert.PrizeStock, options ...database.Option) (*entities.PrizeStock, errors.ErrorInterface) {
+	stock := &entities.PrizeStock{}
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(stock)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors_domain_game.ErrPrizeStockNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return stock, nil
+}
+
+// ReadPrizeStocks reads the stocks of the prizes in the stores
+// Finds and returns a list of stocks based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.PrizeStock - The stock transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.PrizeStock: A slice of found stock entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadPrizeStocks(obj *transfert.PrizeStock, options ...database.Option) ([]*entities.PrizeStock, errors.ErrorInterface) {
+	var stocks []*entities.PrizeStock
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&stocks)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return stocks, nil
+}
+
+// restock adds units of a prize to a store, the stock is created on its first delivery
+// The alert is rearmed once the available units are above the threshold again.
+func restock(tx *gorm.DB, prizeID, storeID string, quantity int, threshold *int) (*entities.PrizeStock, error) {
+	stock := &entities.PrizeStock{}
+
+	result := tx.Where(&entities.PrizeStock{PrizeID: prizeID, StoreID: storeID}).Limit(1).Find(stock)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		stock = &entities.PrizeStock{PrizeID: prizeID, StoreID: storeID, Quantity: quantity}
+		if threshold != nil {
+			stock.Threshold = *threshold
+		}
+
+		return stock, tx.Create(stock).Error
+	}
+
+	updates := map[string]any{"quantity": gorm.Expr("quantity + ?", quantity)}
+	if threshold != nil {
+		updates["threshold"] = *threshold
+	}
+
+	if err := tx.Model(stock).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.First(stock, "id = ?", stock.ID).Error; err != nil {
+		return nil, err
+	}
+
+	if stock.AlertedAt != nil && !stock.IsLow() {
+		if err := tx.Model(stock).Update("alerted_at", nil).Error; err != nil {
+			return nil, err
+		}
+
+		stock.AlertedAt = nil
+	}
+
+	return stock, nil
+}
+
+// RestockPrize adds units of a prize to the stock of a store
+// The stock is created on the first delivery of the prize to the store.
+//
+// Parameters:
+// - obj: *transfert.PrizeStock - The prize, the store, the delivered quantity and optionally the alert threshold
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.PrizeStock: The stock after the delivery
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) RestockPrize(obj *transfert.PrizeStock, options ...database.Option) (*entities.PrizeStock, errors.ErrorInterface) {
+	var stock *entities.PrizeStock
+
+	db := r.store.Engine
+	for _, option := range options {
+		db = option(db)
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		stock, err = restock(tx, *obj.PrizeID, *obj.StoreID, *obj.Quantity, obj.Threshold)
+		return err
+	})
+
+	if err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	return stock, nil
+}
+
+// ReservePrizeStock promises a unit of the stock to a claimed ticket
+// The reservation is a single conditional update, concurrent claims never reserve more units than the store holds.
+//
+// Parameters:
+// - entity: *entities.PrizeStock - The stock to reserve a unit from, reloaded after the reservation
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrPrizeOutOfStock if no unit is available anymore
+func (r *GameRepository) ReservePrizeStock(entity *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(entity).Where("quantity > reserved")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Update("reserved", gorm.Expr("reserved + 1"))
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_game.ErrPrizeOutOfStock
+	}
+
+	if err := r.store.Engine.First(entity, "id = ?", entity.ID).Error; err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	return nil
+}
+
+// releasePrizeStock gives back a unit promised to a ticket, a stock without reservation is left untouched
+func releasePrizeStock(tx *gorm.DB, entity *entities.PrizeStock) error {
+	return tx.Model(entity).Where("reserved > 0").Update("reserved", gorm.Expr("reserved - 1")).Error
+}
+
+// ReleasePrizeStock gives back the unit promised to a ticket which will not be redeemed
+//
+// Parameters:
+// - entity: *entities.PrizeStock - The stock holding the reservation
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReleasePrizeStock(entity *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	db := r.store.Engine
+	for _, option := range options {
+		db = option(db)
+	}
+
+	if err := releasePrizeStock(db, entity); err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	return nil
+}
+
+// ConsumePrizeStock takes the unit of a redeemed ticket out of the stock of the store handing it over
+// When the ticket reserved its unit in the same store, the reservation is consumed.
+// Otherwise the store hands over one of its available units and the reservation held elsewhere is released,
+// both in a single transaction.
+//
+// Parameters:
+// - entity: *entities.PrizeStock - The stock of the store handing the prize over, reloaded after the update
+// - reserved: *entities.PrizeStock - The stock holding the reservation of the ticket, nil if there is none
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrPrizeOutOfStock if the store has no unit to hand over
+func (r *GameRepository) ConsumePrizeStock(entity, reserved *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	db := r.store.Engine
+	for _, option := range options {
+		db = option(db)
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(entity)
+		updates := map[string]any{"quantity": gorm.Expr("quantity - 1")}
+
+		if reserved != nil && reserved.ID == entity.ID {
+			query = query.Where("reserved > 0 AND quantity > 0")
+			updates["reserved"] = gorm.Expr("reserved - 1")
+		} else {
+			query = query.Where("quantity > reserved")
+		}
+
+		result := query.Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors_domain_game.ErrPrizeOutOfStock
+		}
+
+		if reserved != nil && reserved.ID != entity.ID {
+			if err := releasePrizeStock(tx, reserved); err != nil {
+				return err
+			}
+		}
+
+		return tx.First(entity, "id = ?", entity.ID).Error
+	})
+
+	if err == nil {
+		return nil
+	}
+
+	if err == errors_domain_game.ErrPrizeOutOfStock {
+		return errors_domain_game.ErrPrizeOutOfStock
+	}
+
+	return errors.ErrInternalServer.Log(err)
+}
+
+// TransferPrizeStock moves available units of a prize from a store to another in a single transaction
+// Units promised to claimed tickets stay in the source store.
+//
+// Parameters:
+// - obj: *transfert.StockTransfer - The prize, the source and destination stores and the quantity to move
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.PrizeStock: The stock of the source store after the transfer
+// - *entities.PrizeStock: The stock of the destination store after the transfer
+// - errors.ErrorInterface: ErrPrizeStockNotFound or ErrPrizeOutOfStock if the source cannot provide the units
+func (r *GameRepository) TransferPrizeStock(obj *transfert.StockTransfer, options ...database.Option) (*entities.PrizeStock, *entities.PrizeStock, errors.ErrorInterface) {
+	from := &entities.PrizeStock{}
+	var to *entities.PrizeStock
+
+	db := r.store.Engine
+	for _, option := range options {
+		db = option(db)
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&entities.PrizeStock{PrizeID: *obj.PrizeID, StoreID: *obj.FromStoreID}).First(from).Error; err == gorm.ErrRecordNotFound {
+			return errors_domain_game.ErrPrizeStockNotFound
+		} else if err != nil {
+			return err
+		}
+
+		result := tx.Model(from).Where("quantity - reserved >= ?", *obj.Quantity).Update("quantity", gorm.Expr("quantity - ?", *obj.Quantity))
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors_domain_game.ErrPrizeOutOfStock
+		}
+
+		if err := tx.First(from, "id = ?", from.ID).Error; err != nil {
+			return err
+		}
+
+		var err error
+		to, err = restock(tx, *obj.PrizeID, *obj.ToStoreID, *obj.Quantity, nil)
+		return err
+	})
+
+	if err == nil {
+		return from, to, nil
+	}
+
+	if err == errors_domain_game.ErrPrizeStockNotFound || err == errors_domain_game.ErrPrizeOutOfStock {
+		return nil, nil, err.(errors.ErrorInterface)
+	}
+
+	return nil, nil, errors.ErrInternalServer.Log(err)
+}
+
+// MarkPrizeStockAlerted records that the low stock alert of a store was sent
+// Only the first caller marks the stock, so a single alert is sent until the stock is refilled.
+//
+// Parameters:
+// - entity: *entities.PrizeStock - The stock to mark
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - bool: true if the stock was marked by this call
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) MarkPrizeStockAlerted(entity *entities.PrizeStock, options ...database.Option) (bool, errors.ErrorInterface) {
+	now := time.Now()
+
+	query := r.store.Engine.Model(entity).Where("alerted_at IS NULL")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Update("alerted_at", now)
+
+	if result.Error != nil {
+		return false, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	entity.AlertedAt = &now
+
+	return true, nil
+}
@@ -0,0 +1,222 @@
+package repositories_test
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupStock opens a SQLite database, the stock updates are computed by the database itself
+func setupStock(t *testing.T) *repositories.GameRepository {
+	gormDB, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "stock.db")+"?_busy_timeout=10000&_journal_mode=WAL"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	dbInstance, err := database.FromDB(gormDB)
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	if err := repositories.Migrate(dbInstance); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	return repositories.NewGameRepository(dbInstance)
+}
+
+func TestRestockPrize(t *testing.T) {
+	repo := setupStock(t)
+
+	t.Run("first delivery creates the stock", func(t *testing.T) {
+		stock, err := repo.RestockPrize(&transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String("store-1"), Quantity: aws.Int(3), Threshold: aws.Int(1)})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, stock.ID)
+		assert.Equal(t, 3, stock.Quantity)
+		assert.Equal(t, 1, stock.Threshold)
+	})
+
+	t.Run("next deliveries add units and keep the threshold", func(t *testing.T) {
+		stock, err := repo.RestockPrize(&transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String("store-1"), Quantity: aws.Int(2)})
+		assert.Nil(t, err)
+		assert.Equal(t, 5, stock.Quantity)
+		assert.Equal(t, 1, stock.Threshold)
+
+		stocks, err := repo.ReadPrizeStocks(&transfert.PrizeStock{PrizeID: aws.String("prize-1")})
+		assert.Nil(t, err)
+		assert.Len(t, stocks, 1)
+	})
+
+	t.Run("a refill rearms the alert", func(t *testing.T) {
+		stock, err := repo.ReadPrizeStock(&transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String("store-1")})
+		assert.Nil(t, err)
+
+		marked, err := repo.MarkPrizeStockAlerted(stock)
+		assert.Nil(t, err)
+		assert.True(t, marked)
+
+		marked, err = repo.MarkPrizeStockAlerted(stock)
+		assert.Nil(t, err)
+		assert.False(t, marked)
+
+		stock, err = repo.RestockPrize(&transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String("store-1"), Quantity: aws.Int(1)})
+		assert.Nil(t, err)
+		assert.Nil(t, stock.AlertedAt)
+	})
+
+	t.Run("unknown stock", func(t *testing.T) {
+		stock, err := repo.ReadPrizeStock(&transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String("store-404")})
+		assert.Nil(t, stock)
+		assert.Equal(t, errors_domain_game.ErrPrizeStockNotFound, err)
+	})
+}
+
+func TestReservePrizeStock(t *testing.T) {
+	const players = 20
+
+	repo := setupStock(t)
+	stock, err := repo.RestockPrize(&transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String("store-1"), Quantity: aws.Int(5)})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan error, players)
+
+	for range players {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			entity := *stock
+			if err := repo.ReservePrizeStock(&entity); err != nil {
+				results <- err
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	refused := 0
+	for err := range results {
+		assert.Equal(t, errors_domain_game.ErrPrizeOutOfStock, err)
+		refused++
+	}
+
+	// Concurrent claims never promise more units than the store holds
+	assert.Equal(t, players-5, refused)
+
+	stored, rerr := repo.ReadPrizeStock(&transfert.PrizeStock{ID: &stock.ID})
+	if assert.Nil(t, rerr) {
+		assert.Equal(t, 5, stored.Reserved)
+		assert.Equal(t, 0, stored.Available())
+	}
+
+	assert.Nil(t, repo.ReleasePrizeStock(stored))
+	stored, _ = repo.ReadPrizeStock(&transfert.PrizeStock{ID: &stock.ID})
+	assert.Equal(t, 4, stored.Reserved)
+}
+
+func TestConsumePrizeStock(t *testing.T) {
+	repo := setupStock(t)
+
+	stock := func(store string, quantity int) *entities.PrizeStock {
+		entity, err := repo.RestockPrize(&transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String(store), Quantity: aws.Int(quantity)})
+		assert.Nil(t, err)
+		return entity
+	}
+
+	t.Run("redemption in the store of the reservation", func(t *testing.T) {
+		entity := stock("store-1", 2)
+		assert.Nil(t, repo.ReservePrizeStock(entity))
+
+		assert.Nil(t, repo.ConsumePrizeStock(entity, entity))
+		assert.Equal(t, 1, entity.Quantity)
+		assert.Equal(t, 0, entity.Reserved)
+	})
+
+	t.Run("redemption in another store releases the reservation", func(t *testing.T) {
+		reserved := stock("store-2", 1)
+		assert.Nil(t, repo.ReservePrizeStock(reserved))
+
+		entity := stock("store-3", 1)
+		assert.Nil(t, repo.ConsumePrizeStock(entity, reserved))
+		assert.Equal(t, 0, entity.Quantity)
+
+		reserved, _ = repo.ReadPrizeStock(&transfert.PrizeStock{ID: &reserved.ID})
+		assert.Equal(t, 1, reserved.Quantity)
+		assert.Equal(t, 0, reserved.Reserved)
+	})
+
+	t.Run("units promised to other tickets are not handed over", func(t *testing.T) {
+		entity := stock("store-4", 1)
+		assert.Nil(t, repo.ReservePrizeStock(entity))
+
+		err := repo.ConsumePrizeStock(entity, nil)
+		assert.Equal(t, errors_domain_game.ErrPrizeOutOfStock, err)
+	})
+
+	t.Run("out of stock leaves the reservation untouched", func(t *testing.T) {
+		reserved := stock("store-5", 1)
+		assert.Nil(t, repo.ReservePrizeStock(reserved))
+
+		entity := stock("store-6", 0)
+		assert.Equal(t, errors_domain_game.ErrPrizeOutOfStock, repo.ConsumePrizeStock(entity, reserved))
+
+		reserved, _ = repo.ReadPrizeStock(&transfert.PrizeStock{ID: &reserved.ID})
+		assert.Equal(t, 1, reserved.Reserved)
+	})
+}
+
+func TestTransferPrizeStock(t *testing.T) {
+	repo := setupStock(t)
+
+	source, err := repo.RestockPrize(&transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String("store-1"), Quantity: aws.Int(4)})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Nil(t, repo.ReservePrizeStock(source))
+
+	transfer := func(from string, quantity int) *transfert.StockTransfer {
+		return &transfert.StockTransfer{
+			PrizeID:     aws.String("prize-1"),
+			FromStoreID: aws.String(from),
+			ToStoreID:   aws.String("store-2"),
+			Quantity:    aws.Int(quantity),
+		}
+	}
+
+	t.Run("successful transfer", func(t *testing.T) {
+		from, to, err := repo.TransferPrizeStock(transfer("store-1", 2))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, from.Quantity)
+		assert.Equal(t, 1, from.Reserved)
+		assert.Equal(t, 2, to.Quantity)
+	})
+
+	t.Run("reserved units stay in the source store", func(t *testing.T) {
+		_, _, err := repo.TransferPrizeStock(transfer("store-1", 2))
+		assert.Equal(t, errors_domain_game.ErrPrizeOutOfStock, err)
+
+		stored, _ := repo.ReadPrizeStock(&transfert.PrizeStock{ID: &source.ID})
+		assert.Equal(t, 2, stored.Quantity)
+	})
+
+	t.Run("unknown source store", func(t *testing.T) {
+		_, _, err := repo.TransferPrizeStock(transfer("store-404", 1))
+		assert.Equal(t, errors_domain_game.ErrPrizeStockNotFound, err)
+	})
+}
@@ -0,0 +1,189 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreatePrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Prize{
+		Code:        aws.String("infuser"),
+		Label:       aws.String("Infuseur à thé"),
+		Description: aws.String("Un infuseur en inox"),
+		Value:       aws.Float64(9.9),
+		Image:       aws.String("https://cdn.thetiptop.local/infuser.png"),
+		Percentage:  aws.Int(60),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "prizes" \("id","created_at","updated_at","deleted_at","code","label","description","value","image","percentage"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // DeletedAt
+				dto.Code,         // Code
+				dto.Label,        // Label
+				dto.Description,  // Description
+				9.9,              // Value
+				dto.Image,        // Image
+				60,               // Percentage
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		entity, err := repo.CreatePrize(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, entity)
+		assert.Equal(t, 60, entity.Percentage)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation with database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "prizes"`).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint"))
+		mock.ExpectRollback()
+
+		entity, err := repo.CreatePrize(dto)
+		assert.NotNil(t, err)
+		assert.Nil(t, entity)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadPrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	prizeID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes" WHERE "prizes"."id" = \$1 AND "prizes"."deleted_at" IS NULL ORDER BY "prizes"."id" LIMIT \$2`).
+			WithArgs(prizeID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "label"}).AddRow(prizeID, "infuser", "Infuseur à thé"))
+
+		prize, err := repo.ReadPrize(&transfert.Prize{ID: aws.String(prizeID)})
+		assert.Nil(t, err)
+		assert.Equal(t, "infuser", *prize.Code)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("prize not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes"`).
+			WithArgs(prizeID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		prize, err := repo.ReadPrize(&transfert.Prize{ID: aws.String(prizeID)})
+		assert.Nil(t, prize)
+		assert.Equal(t, "prize.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes"`).
+			WithArgs(prizeID, 1).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		prize, err := repo.ReadPrize(&transfert.Prize{ID: aws.String(prizeID)})
+		assert.Nil(t, prize)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadPrizes(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes" WHERE "prizes"."deleted_at" IS NULL`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("prize-1").AddRow("prize-2"))
+
+		prizes, err := repo.ReadPrizes(&transfert.Prize{})
+		assert.Nil(t, err)
+		assert.Len(t, prizes, 2)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes"`).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		prizes, err := repo.ReadPrizes(&transfert.Prize{})
+		assert.Nil(t, prizes)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdatePrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	prize := &entities.Prize{ID: "prize-1", Code: aws.String("infuser"), Percentage: 60}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "prizes" SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.UpdatePrize(prize))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "prizes" SET`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		err := repo.UpdatePrize(prize)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeletePrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	prizeID := "prize-1"
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "prizes" SET "deleted_at"=\$1 WHERE "prizes"."id" = \$2 AND "prizes"."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), prizeID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.DeletePrize(&transfert.Prize{ID: aws.String(prizeID)}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "prizes" SET "deleted_at"`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		err := repo.DeletePrize(&transfert.Prize{ID: aws.String(prizeID)})
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
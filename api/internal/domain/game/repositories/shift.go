@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateShift opens a new shift
+// Inserts a new shift into the database based on the transfert.Shift input object
+//
+// Parameters:
+// - obj: *transfert.Shift - The shift transfer object to create
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Shift: The created shift entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateShift(obj *transfert.Shift, options ...database.Option) (*entities.Shift, errors.ErrorInterface) {
+	shift := entities.CreateShift(obj)
+
+	query := r.store.Engine.Create(shift)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return nil, errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return shift, nil
+}
+
+// ReadShift reads a shift from the database
+// Finds and returns a shift based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Shift - The shift transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Shift: The found shift entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadShift(obj *transfert.Shift, options ...database.Option) (*entities.Shift, errors.ErrorInterface) {
+	shift := &entities.Shift{}
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(shift)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrShiftNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return shift, nil
+}
+
+// ReadShifts reads multiple shifts from the database
+// Finds and returns a list of shifts based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Shift - The shift transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.Shift: A slice of found shift entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadShifts(obj *transfert.Shift, options ...database.Option) ([]*entities.Shift, errors.ErrorInterface) {
+	var shifts []*entities.Shift
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&shifts)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return shifts, nil
+}
+
+// UpdateShift updates an existing shift in the database
+// Saves the shift entity along with the lines and the anomalies of its Z-report
+//
+// Parameters:
+// - entity: *entities.Shift - The shift entity to update
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) UpdateShift(entity *entities.Shift, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Save(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// CloseShift freezes the Z-report of an open shift
+// The shift is only closed while it is still open, so that two concurrent closes never overwrite each other's report.
+// The totals, the lines and the anomalies of the report are stored in a single transaction.
+//
+// Parameters:
+// - entity: *entities.Shift - The shift holding its Z-report, see entities.Shift.Close
+// - options: ...database.Option - Additional options to customize the update
+//
+// Returns:
+// - errors.ErrorInterface: ErrShiftClosed if the shift was closed in the meantime
+func (r *GameRepository) CloseShift(entity *entities.Shift, options ...database.Option) errors.ErrorInterface {
+	err := r.store.Engine.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(entity).Omit(clause.Associations).Where("closed_at IS NULL")
+		for _, option := range options {
+			option(query)
+		}
+
+		result := query.Updates(map[string]any{
+			"closed_by": entity.ClosedBy,
+			"closed_at": entity.ClosedAt,
+			"issued":    entity.Issued,
+			"redeemed":  entity.Redeemed,
+			"voided":    entity.Voided,
+			"amount":    entity.Amount,
+		})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors_domain_game.ErrShiftClosed
+		}
+
+		if len(entity.Lines) > 0 {
+			if err := tx.Create(entity.Lines).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(entity.Anomalies) > 0 {
+			return tx.Create(entity.Anomalies).Error
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		return nil
+	}
+
+	if err == errors_domain_game.ErrShiftClosed {
+		return errors_domain_game.ErrShiftClosed
+	}
+
+	return errors.ErrInternalServer.Log(err)
+}
@@ -0,0 +1,202 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateShift(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Shift{
+		CaisseID: aws.String("caisse-1"),
+		StoreID:  aws.String("store-1"),
+		OpenedBy: aws.String("employee-1"),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "shifts"`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				dto.CaisseID,     // CaisseID
+				dto.StoreID,      // StoreID
+				dto.OpenedBy,     // OpenedBy
+				sqlmock.AnyArg(), // OpenedAt
+				nil,              // ClosedBy
+				nil,              // ClosedAt
+				0,                // Issued
+				0,                // Redeemed
+				0,                // Voided
+				0.0,              // Amount
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		entity, err := repo.CreateShift(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, entity)
+		assert.True(t, entity.IsOpen())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation with database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "shifts"`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		entity, err := repo.CreateShift(dto)
+		assert.Nil(t, entity)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadShift(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	caisseID := "caisse-1"
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "shifts" WHERE "shifts"."caisse_id" = \$1 AND closed_at IS NULL ORDER BY "shifts"."id" LIMIT \$2`).
+			WithArgs(caisseID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "caisse_id"}).AddRow("shift-1", caisseID))
+
+		shift, err := repo.ReadShift(&transfert.Shift{CaisseID: &caisseID}, database.Where("closed_at IS NULL"))
+		assert.Nil(t, err)
+		assert.Equal(t, "shift-1", shift.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("shift not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "shifts"`).
+			WithArgs(caisseID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		shift, err := repo.ReadShift(&transfert.Shift{CaisseID: &caisseID})
+		assert.Nil(t, shift)
+		assert.Equal(t, "shift.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "shifts"`).
+			WithArgs(caisseID, 1).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		shift, err := repo.ReadShift(&transfert.Shift{CaisseID: &caisseID})
+		assert.Nil(t, shift)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadShifts(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	storeID := "store-1"
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "shifts" WHERE "shifts"."store_id" = \$1 ORDER BY opened_at DESC`).
+			WithArgs(storeID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("shift-2").AddRow("shift-1"))
+
+		shifts, err := repo.ReadShifts(&transfert.Shift{StoreID: &storeID}, database.Order("opened_at DESC"))
+		assert.Nil(t, err)
+		assert.Len(t, shifts, 2)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "shifts"`).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		shifts, err := repo.ReadShifts(&transfert.Shift{})
+		assert.Nil(t, shifts)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateShift(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	shift := &entities.Shift{ID: "shift-1", OpenedBy: aws.String("employee-1")}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "shifts" SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateShift(shift)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "shifts" SET`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateShift(shift)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCloseShift(t *testing.T) {
+	repo := setupStock(t)
+
+	shift, err := repo.CreateShift(&transfert.Shift{
+		CaisseID: aws.String("caisse-1"),
+		StoreID:  aws.String("store-1"),
+		OpenedBy: aws.String("employee-1"),
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// A stale copy read before the first close cannot overwrite its report
+	stale := *shift
+
+	shift.Close(aws.String("employee-1"), []*entities.Ticket{
+		{ID: "ticket-1", PrizeID: aws.String("prize-1"), IssuedBy: aws.String("employee-1")},
+		{ID: "ticket-2", PrizeID: aws.String("prize-1"), IssuedBy: aws.String("employee-2")},
+	}, nil)
+	assert.Nil(t, repo.CloseShift(shift))
+
+	stale.Close(aws.String("employee-2"), nil, nil)
+	assert.Equal(t, errors_domain_game.ErrShiftClosed, repo.CloseShift(&stale))
+
+	stored, err := repo.ReadShift(&transfert.Shift{ID: &shift.ID}, database.Preload("Lines"), database.Preload("Anomalies"))
+	if assert.Nil(t, err) {
+		assert.False(t, stored.IsOpen())
+		assert.Equal(t, "employee-1", *stored.ClosedBy)
+		assert.Equal(t, 2, stored.Issued)
+		assert.Len(t, stored.Lines, 1)
+		assert.Len(t, stored.Anomalies, 1)
+	}
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateSyncOperation records the outcome of an operation uploaded by an offline caisse
+// The idempotency ID is the primary key, an operation is only recorded once.
+//
+// Parameters:
+// - obj: *transfert.SyncOperation - The operation with its outcome
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.SyncOperation: The recorded operation
+// - errors.ErrorInterface: ErrSyncOperationRecorded if the operation was already recorded
+func (r *GameRepository) CreateSyncOperation(obj *transfert.SyncOperation, options ...database.Option) (*entities.SyncOperation, errors.ErrorInterface) {
+	operation := entities.CreateSyncOperation(obj)
+
+	query := r.store.Engine.Create(operation)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		if duplicated(query.Error) {
+			return nil, errors_domain_game.ErrSyncOperationRecorded
+		}
+
+		return nil, errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return operation, nil
+}
+
+// UpdateSyncOperation records the final outcome of an operation, the operation itself is left untouched
+//
+// Parameters:
+// - entity: *entities.SyncOperation - The operation with its outcome
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) UpdateSyncOperation(entity *entities.SyncOperation, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Updates(map[string]any{
+		"status":    entity.Status,
+		"error":     entity.Error,
+		"ticket_id": entity.TicketID,
+	})
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// ReadSyncOperation reads the recorded outcome of an uploaded operation
+//
+// Parameters:
+// - obj: *transfert.SyncOperation - The operation with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.SyncOperation: The recorded operation
+// - errors.ErrorInterface: ErrSyncOperationNotFound if the operation was never uploaded
+func (r *GameRepository) ReadSyncOperation(obj *transfert.SyncOperation, options ...database.Option) (*entities.SyncOperation, errors.ErrorInterface) {
+	operation := &entities.SyncOperation{}
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(operation)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrSyncOperationNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return operation, nil
+}
@@ -0,0 +1,246 @@
+package repositories_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestCreateSyncOperation(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.SyncOperation{
+		ID:           aws.String("operation-1"),
+		Type:         aws.String(entities.SyncOperationRedeem),
+		OccurredAt:   aws.String("2024-03-01T10:30:00Z"),
+		CaisseID:     aws.String("caisse-1"),
+		TicketID:     aws.String("ticket-1"),
+		CredentialID: aws.String("employee-1"),
+		Status:       aws.String(entities.SyncOperationApplied),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "sync_operations"`).
+			WithArgs(
+				"operation-1",                 // ID
+				sqlmock.AnyArg(),              // CreatedAt
+				entities.SyncOperationRedeem,  // Type
+				entities.SyncOperationApplied, // Status
+				nil,                           // Error
+				sqlmock.AnyArg(),              // OccurredAt
+				dto.CaisseID,                  // CaisseID
+				dto.TicketID,                  // TicketID
+				dto.CredentialID,              // CredentialID
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		entity, err := repo.CreateSyncOperation(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "operation-1", entity.ID)
+		assert.True(t, entity.IsApplied())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("operation already recorded", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "sync_operations"`).WillReturnError(fmt.Errorf(`ERROR: duplicate key value violates unique constraint "sync_operations_pkey" (SQLSTATE 23505)`))
+		mock.ExpectRollback()
+
+		entity, err := repo.CreateSyncOperation(dto)
+		assert.Nil(t, entity)
+		assert.Equal(t, errors_domain_game.ErrSyncOperationRecorded, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation with database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "sync_operations"`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		entity, err := repo.CreateSyncOperation(dto)
+		assert.Nil(t, entity)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateSyncOperation(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	operation := &entities.SyncOperation{ID: "operation-1", Status: entities.SyncOperationConflict, Error: aws.String("ticket.already_redeemed")}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "sync_operations" SET "error"=\$1,"status"=\$2,"ticket_id"=\$3 WHERE "id" = \$4`).
+			WithArgs(operation.Error, entities.SyncOperationConflict, nil, "operation-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.UpdateSyncOperation(operation))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update with database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "sync_operations"`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		assert.Equal(t, "common.internal_error", repo.UpdateSyncOperation(operation).Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSyncOperationConcurrency(t *testing.T) {
+	const uploads = 20
+
+	hammer := func(t *testing.T, dialector gorm.Dialector) {
+		gormDB, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		dbInstance, err := database.FromDB(gormDB)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		if !assert.NoError(t, repositories.Migrate(dbInstance)) {
+			return
+		}
+
+		repo := repositories.NewGameRepository(dbInstance)
+		id := uuid.NewString()
+
+		var wg sync.WaitGroup
+		var applied atomic.Int32
+		start := make(chan struct{})
+		results := make(chan errors.ErrorInterface, uploads)
+
+		for range uploads {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				<-start
+				// Every upload of the operation claims it before applying it
+				results <- repo.Transaction(func(tx repositories.GameRepositoryInterface) errors.ErrorInterface {
+					operation, err := tx.CreateSyncOperation(&transfert.SyncOperation{
+						ID:       &id,
+						Type:     aws.String(entities.SyncOperationRedeem),
+						CaisseID: aws.String("caisse-1"),
+						Status:   aws.String(entities.SyncOperationApplied),
+					})
+
+					if err != nil {
+						return err
+					}
+
+					applied.Add(1)
+					operation.TicketID = aws.String("ticket-1")
+
+					return tx.UpdateSyncOperation(operation)
+				})
+			}()
+		}
+
+		close(start)
+		wg.Wait()
+		close(results)
+
+		for err := range results {
+			if err != nil {
+				assert.Equal(t, errors_domain_game.ErrSyncOperationRecorded, err)
+			}
+		}
+
+		assert.Equal(t, int32(1), applied.Load())
+
+		stored, rerr := repo.ReadSyncOperation(&transfert.SyncOperation{ID: &id})
+		if assert.Nil(t, rerr) {
+			assert.Equal(t, "ticket-1", *stored.TicketID)
+		}
+	}
+
+	t.Run("SQLite", func(t *testing.T) {
+		hammer(t, sqlite.Open(filepath.Join(t.TempDir(), "game.db")+"?_busy_timeout=10000&_journal_mode=WAL"))
+	})
+
+	t.Run("PostgreSQL", func(t *testing.T) {
+		dsn := os.Getenv("THETIPTOP_TEST_POSTGRES_DSN")
+		if dsn == "" {
+			t.Skip("THETIPTOP_TEST_POSTGRES_DSN is not set")
+		}
+
+		hammer(t, postgres.Open(dsn))
+	})
+}
+
+func TestReadSyncOperation(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	operationID := "operation-1"
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "sync_operations" WHERE "sync_operations"."id" = \$1 ORDER BY "sync_operations"."id" LIMIT \$2`).
+			WithArgs(operationID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(operationID, entities.SyncOperationConflict))
+
+		operation, err := repo.ReadSyncOperation(&transfert.SyncOperation{ID: &operationID})
+		assert.Nil(t, err)
+		assert.Equal(t, operationID, operation.ID)
+		assert.False(t, operation.IsApplied())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("operation not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "sync_operations"`).
+			WithArgs(operationID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		operation, err := repo.ReadSyncOperation(&transfert.SyncOperation{ID: &operationID})
+		assert.Nil(t, operation)
+		assert.Equal(t, "sync.operation_not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "sync_operations"`).
+			WithArgs(operationID, 1).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		operation, err := repo.ReadSyncOperation(&transfert.SyncOperation{ID: &operationID})
+		assert.Nil(t, operation)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
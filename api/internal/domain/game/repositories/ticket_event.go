@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateTicketEvent appends an event to the history of a ticket
+//
+// Parameters:
+// - obj: *transfert.TicketEvent - The event transfer object to create
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.TicketEvent: The created event entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateTicketEvent(obj *transfert.TicketEvent, options ...database.Option) (*entities.TicketEvent, errors.ErrorInterface) {
+	event := entities.CreateTicketEvent(obj)
+
+	query := r.store.Engine.Create(event)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return nil, errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return event, nil
+}
+
+// ReadTicketEvents reads the history of a ticket
+// Finds and returns a list of events based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.TicketEvent - The event transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.TicketEvent: A slice of found event entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadTicketEvents(obj *transfert.TicketEvent, options ...database.Option) ([]*entities.TicketEvent, errors.ErrorInterface) {
+	var events []*entities.TicketEvent
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&events)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return events, nil
+}
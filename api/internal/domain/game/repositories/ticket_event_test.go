@@ -0,0 +1,94 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTicketEvent(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.TicketEvent{
+		TicketID:     aws.String("ticket-1"),
+		Action:       aws.String("claimed"),
+		CredentialID: aws.String("client-123"),
+		Role:         aws.String("client"),
+		IP:           aws.String("203.0.113.7"),
+		UserAgent:    aws.String("curl/8.0"),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "ticket_events" \("id","created_at","ticket_id","action","credential_id","role","ip","user_agent"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				*dto.TicketID,    // TicketID
+				*dto.Action,      // Action
+				dto.CredentialID, // CredentialID
+				dto.Role,         // Role
+				dto.IP,           // IP
+				dto.UserAgent,    // UserAgent
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		event, err := repo.CreateTicketEvent(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, event)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation with database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "ticket_events"`).WillReturnError(fmt.Errorf("database is unavailable"))
+		mock.ExpectRollback()
+
+		event, err := repo.CreateTicketEvent(dto)
+		assert.Nil(t, event)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadTicketEvents(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.TicketEvent{TicketID: aws.String("ticket-1")}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_events" WHERE "ticket_events"."ticket_id" = \$1 ORDER BY created_at`).
+			WithArgs(dto.TicketID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ticket_id", "action"}).
+				AddRow("event-1", "ticket-1", "issued").
+				AddRow("event-2", "ticket-1", "claimed"))
+
+		events, err := repo.ReadTicketEvents(dto, database.Order("created_at"))
+		assert.Nil(t, err)
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, "claimed", events[1].Action)
+		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_events"`).
+			WillReturnError(fmt.Errorf("database is unavailable"))
+
+		events, err := repo.ReadTicketEvents(dto)
+		assert.Nil(t, events)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// CreateCampaign registers a new campaign with its participation and redemption windows
+//
+// Parameters:
+// - dto: *transfert.Campaign the campaign to create
+//
+// Returns:
+// - *entities.Campaign: the created campaign
+// - errors.ErrorInterface: an error if the campaign cannot be created
+func (s *CampaignService) CreateCampaign(dto *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if !entities.CreateCampaign(dto).IsValid() {
+		return nil, errors_domain_game.ErrCampaignInvalidWindow
+	}
+
+	if _, err := s.repo.ReadCampaign(&transfert.Campaign{Label: dto.Label}); err == nil {
+		return nil, errors_domain_game.ErrCampaignAlreadyExists
+	}
+
+	return s.repo.CreateCampaign(dto)
+}
+
+func (s *CampaignService) GetCampaign(dto *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return s.repo.ReadCampaign(dto)
+}
+
+func (s *CampaignService) GetCampaigns() ([]*entities.Campaign, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return s.repo.ReadCampaigns(&transfert.Campaign{})
+}
+
+// UpdateCampaign changes the label, the timezone or the windows of a campaign
+//
+// Parameters:
+// - dto: *transfert.Campaign the campaign ID and the fields to change
+//
+// Returns:
+// - *entities.Campaign: the updated campaign
+// - errors.ErrorInterface: an error if the campaign cannot be updated
+func (s *CampaignService) UpdateCampaign(dto *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Label != nil {
+		if other, err := s.repo.ReadCampaign(&transfert.Campaign{Label: dto.Label}); err == nil && other.ID != campaign.ID {
+			return nil, errors_domain_game.ErrCampaignAlreadyExists
+		}
+	}
+
+	campaign.Update(dto)
+
+	if !campaign.IsValid() {
+		return nil, errors_domain_game.ErrCampaignInvalidWindow
+	}
+
+	if err := s.repo.UpdateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+func (s *CampaignService) DeleteCampaign(dto *transfert.Campaign) errors.ErrorInterface {
+	if dto == nil {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadCampaign(&transfert.Campaign{ID: dto.ID}); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteCampaign(&transfert.Campaign{ID: dto.ID})
+}
@@ -0,0 +1,238 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var campaignRoles = []security.Role{security.ROLE_ADMIN}
+
+func campaignDTO() *transfert.Campaign {
+	return &transfert.Campaign{
+		Label:      aws.String("Ouverture Nice"),
+		Start:      aws.String("2024-11-01 00:00:00"),
+		End:        aws.String("2024-11-30 23:59:59"),
+		ClaimUntil: aws.String("2024-12-30 23:59:59"),
+		Timezone:   aws.String("Europe/Paris"),
+	}
+}
+
+func Test_CreateCampaign(t *testing.T) {
+	t.Run("Should create the campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+		dto := campaignDTO()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Label}, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+		mockRepo.On("CreateCampaign", dto, mock.Anything).Return(&entities.Campaign{ID: "campaign-id"}, nil)
+
+		campaign, err := service.CreateCampaign(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "campaign-id", campaign.ID)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse inconsistent windows", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+		dto := campaignDTO()
+		dto.ClaimUntil = aws.String("2024-11-15 00:00:00")
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+
+		campaign, err := service.CreateCampaign(dto)
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidWindow, err)
+		assert.Nil(t, campaign)
+
+		mockRepo.AssertNotCalled(t, "CreateCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an existing label", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+		dto := campaignDTO()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Label}, mock.Anything).Return(&entities.Campaign{}, nil)
+
+		campaign, err := service.CreateCampaign(dto)
+		assert.Equal(t, errors_domain_game.ErrCampaignAlreadyExists, err)
+		assert.Nil(t, campaign)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		campaign, err := service.CreateCampaign(campaignDTO())
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, campaign)
+	})
+
+	t.Run("Should return error without dto", func(t *testing.T) {
+		service, _, _ := setupCampaign()
+
+		campaign, err := service.CreateCampaign(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+		assert.Nil(t, campaign)
+	})
+}
+
+func Test_GetCampaign(t *testing.T) {
+	campaignID := "campaign-id"
+
+	t.Run("Should return the campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(&entities.Campaign{ID: campaignID}, nil)
+
+		campaign, err := service.GetCampaign(&transfert.Campaign{ID: &campaignID})
+		assert.Nil(t, err)
+		assert.Equal(t, campaignID, campaign.ID)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		campaign, err := service.GetCampaign(&transfert.Campaign{ID: &campaignID})
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, campaign)
+	})
+}
+
+func Test_GetCampaigns(t *testing.T) {
+	t.Run("Should return the campaigns", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaigns", &transfert.Campaign{}, mock.Anything).Return([]*entities.Campaign{{ID: "campaign-id"}}, nil)
+
+		campaigns, err := service.GetCampaigns()
+		assert.Nil(t, err)
+		assert.Len(t, campaigns, 1)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		campaigns, err := service.GetCampaigns()
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, campaigns)
+	})
+}
+
+func Test_UpdateCampaign(t *testing.T) {
+	campaignID := "campaign-id"
+
+	t.Run("Should update the windows", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+		existing := entities.CreateCampaign(campaignDTO())
+		existing.ID = campaignID
+
+		dto := &transfert.Campaign{ID: &campaignID, ClaimUntil: aws.String("2025-01-15 23:59:59")}
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(existing, nil)
+		mockRepo.On("UpdateCampaign", existing, mock.Anything).Return(nil)
+
+		campaign, err := service.UpdateCampaign(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, 2025, campaign.ClaimUntil.Year())
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse inconsistent windows", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+		existing := entities.CreateCampaign(campaignDTO())
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(existing, nil)
+
+		campaign, err := service.UpdateCampaign(&transfert.Campaign{ID: &campaignID, End: aws.String("2024-10-01 00:00:00")})
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidWindow, err)
+		assert.Nil(t, campaign)
+
+		mockRepo.AssertNotCalled(t, "UpdateCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a label used by another campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+		label := aws.String("Ouverture Lyon")
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(&entities.Campaign{ID: campaignID}, nil)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: label}, mock.Anything).Return(&entities.Campaign{ID: "other-id"}, nil)
+
+		campaign, err := service.UpdateCampaign(&transfert.Campaign{ID: &campaignID, Label: label})
+		assert.Equal(t, errors_domain_game.ErrCampaignAlreadyExists, err)
+		assert.Nil(t, campaign)
+	})
+
+	t.Run("Should return error when the campaign is unknown", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		campaign, err := service.UpdateCampaign(&transfert.Campaign{ID: &campaignID})
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+		assert.Nil(t, campaign)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		campaign, err := service.UpdateCampaign(&transfert.Campaign{ID: &campaignID})
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, campaign)
+	})
+}
+
+func Test_DeleteCampaign(t *testing.T) {
+	campaignID := "campaign-id"
+
+	t.Run("Should delete the campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(&entities.Campaign{ID: campaignID}, nil)
+		mockRepo.On("DeleteCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(nil)
+
+		assert.Nil(t, service.DeleteCampaign(&transfert.Campaign{ID: &campaignID}))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the campaign is unknown", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, service.DeleteCampaign(&transfert.Campaign{ID: &campaignID}))
+		mockRepo.AssertNotCalled(t, "DeleteCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setupCampaign()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.DeleteCampaign(&transfert.Campaign{ID: &campaignID}))
+	})
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+)
+
+// ClaimTicket links the ticket matching a printed code to the authenticated player
+// The check digit is verified before any query, failed attempts are recorded per credential and per IP
+// and lock the claims for an escalating duration.
+//
+// Parameters:
+// - dto: *transfert.Claim the printed code and the IP of the player
+//
+// Returns:
+// - *entities.Ticket: the claimed ticket
+// - errors.ErrorInterface: an error if the ticket cannot be claimed
+func (s *GameService) ClaimTicket(dto *transfert.Claim) (*entities.Ticket, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsAuthenticated() {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if err := token.Tickets().Validate(token.NewLuhnP(dto.Token)); err != nil {
+		return nil, err
+	}
+
+	credentialID := s.security.GetCredentialID()
+
+	if err := s.checkClaimLockout(credentialID, dto.IP); err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{Token: dto.Token})
+	if err == errors_domain_game.ErrTicketNotFound {
+		return nil, s.failClaim(dto, credentialID, entities.ClaimFailureNotFound)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Submitting the code of a ticket already claimed by the player is not a guess
+	if ticket.GetStatus() != entities.TicketUnclaimed && ticket.GetOwnerID() == *credentialID {
+		return ticket, nil
+	}
+
+	if err := s.checkParticipation(ticket); err != nil {
+		return nil, err
+	}
+
+	// Unavailable tickets are reported as not found to avoid revealing valid codes
+	if !ticket.Claim(credentialID) {
+		return nil, s.failClaim(dto, credentialID, entities.ClaimFailureUnavailable)
+	}
+
+	err = s.repo.Transaction(func(repo repositories.GameRepositoryInterface) errors.ErrorInterface {
+		if err := repo.ClaimTicket(ticket); err != nil {
+			return err
+		}
+
+		return s.record(repo, ticket, entities.TicketEventClaimed)
+	})
+
+	// Losing the race against another player is handled like any unavailable ticket
+	if err == errors_domain_game.ErrTicketAlreadyClaimed {
+		return nil, s.failClaim(dto, credentialID, entities.ClaimFailureUnavailable)
+	} else if err != nil {
+		return nil, err
+	}
+
+	s.reserveStock(ticket)
+
+	if err := s.sendPrizeMail(ticket); err != nil {
+		logger.Error(err)
+	}
+
+	return ticket, nil
+}
+
+// GetClaimAttempts lists the failed attempts to claim a ticket for fraud review
+//
+// Parameters:
+// - dto: *transfert.ClaimAttempt the credential or the IP to review, all attempts if empty
+//
+// Returns:
+// - []*entities.ClaimAttempt: the failed attempts, most recent first
+// - errors.ErrorInterface: an error if the attempts cannot be read
+func (s *GameService) GetClaimAttempts(dto *transfert.ClaimAttempt) ([]*entities.ClaimAttempt, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return s.repo.ReadClaimAttempts(dto, database.Order("created_at DESC"))
+}
+
+// checkClaimLockout refuses the claim while the credential or the IP is locked
+//
+// Parameters:
+// - credentialID: *string the credential of the player
+// - ip: *string the IP of the player
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketClaimLocked if the claims are locked
+func (s *GameService) checkClaimLockout(credentialID, ip *string) errors.ErrorInterface {
+	now := time.Now()
+	since := database.Where("created_at > ?", now.Add(-entities.ClaimAttemptWindow))
+
+	filters := []*transfert.ClaimAttempt{{CredentialID: credentialID}}
+	if ip != nil && *ip != "" {
+		filters = append(filters, &transfert.ClaimAttempt{IP: ip})
+	}
+
+	for _, filter := range filters {
+		attempts, err := s.repo.ReadClaimAttempts(filter, since)
+		if err != nil {
+			return err
+		}
+
+		if entities.ClaimLockedUntil(attempts, now).After(now) {
+			return errors_domain_game.ErrTicketClaimLocked
+		}
+	}
+
+	return nil
+}
+
+// failClaim records a failed attempt and returns the error to send to the player
+//
+// Parameters:
+// - dto: *transfert.Claim the failed claim
+// - credentialID: *string the credential of the player
+// - reason: string the cause of the failure
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketNotFound, or the error of the record
+func (s *GameService) failClaim(dto *transfert.Claim, credentialID *string, reason string) errors.ErrorInterface {
+	if _, err := s.repo.CreateClaimAttempt(&transfert.ClaimAttempt{
+		CredentialID: credentialID,
+		IP:           dto.IP,
+		Token:        dto.Token,
+		Reason:       aws.String(reason),
+	}); err != nil {
+		return err
+	}
+
+	return errors_domain_game.ErrTicketNotFound
+}
@@ -0,0 +1,211 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func failures(count int, at time.Time) []*entities.ClaimAttempt {
+	attempts := make([]*entities.ClaimAttempt, count)
+	for i := range attempts {
+		attempts[i] = &entities.ClaimAttempt{CreatedAt: at}
+	}
+
+	return attempts
+}
+
+func Test_ClaimTicket(t *testing.T) {
+	cid := aws.String("client-123")
+	ip := aws.String("203.0.113.7")
+	generated, _ := token.Generate(12)
+	code := generated.PointerString()
+
+	claimable := func() (*transfert.Claim, *GameRepositoryMock, *PermissionMock, func() (*entities.Ticket, errors.ErrorInterface)) {
+		service, mockRepo, mockPerms := setup()
+		dto := &transfert.Claim{Token: code, IP: ip}
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+
+		return dto, mockRepo, mockPerms, func() (*entities.Ticket, errors.ErrorInterface) {
+			return service.ClaimTicket(dto)
+		}
+	}
+
+	t.Run("Should claim the ticket matching the code", func(t *testing.T) {
+		_, mockRepo, _, claim := claimable()
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: code}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := claim()
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+		assert.Equal(t, cid, ticket.CredentialID)
+		mockRepo.AssertNotCalled(t, "CreateClaimAttempt", mock.Anything, mock.Anything)
+		mockRepo.AssertCalled(t, "CreateTicketEvent", mock.MatchedBy(func(obj *transfert.TicketEvent) bool {
+			return *obj.TicketID == "ticket-123" && *obj.Action == entities.TicketEventClaimed && *obj.IP == "203.0.113.7"
+		}), mock.Anything)
+	})
+
+	t.Run("Should refuse an invalid check digit before any query", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsAuthenticated").Return(true)
+
+		ticket, err := service.ClaimTicket(&transfert.Claim{Token: aws.String("000000000001"), IP: ip})
+
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrValueIsNotLuhn, err)
+		mockRepo.AssertNotCalled(t, "ReadClaimAttempts", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should record an unknown code", func(t *testing.T) {
+		dto, mockRepo, _, claim := claimable()
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+		mockRepo.On("CreateClaimAttempt", &transfert.ClaimAttempt{
+			CredentialID: cid,
+			IP:           dto.IP,
+			Token:        dto.Token,
+			Reason:       aws.String(entities.ClaimFailureNotFound),
+		}, mock.Anything).Return(&entities.ClaimAttempt{}, nil)
+
+		ticket, err := claim()
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should hide and record a ticket claimed by someone else", func(t *testing.T) {
+		_, mockRepo, _, claim := claimable()
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", CredentialID: aws.String("other"), Status: entities.TicketClaimed}, nil)
+		mockRepo.On("CreateClaimAttempt", mock.MatchedBy(func(attempt *transfert.ClaimAttempt) bool {
+			return *attempt.Reason == entities.ClaimFailureUnavailable
+		}), mock.Anything).Return(&entities.ClaimAttempt{}, nil)
+
+		ticket, err := claim()
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+		mockRepo.AssertNotCalled(t, "ClaimTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should hide and record a claim lost to a concurrent player", func(t *testing.T) {
+		_, mockRepo, _, claim := claimable()
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketAlreadyClaimed)
+		mockRepo.On("CreateClaimAttempt", mock.MatchedBy(func(attempt *transfert.ClaimAttempt) bool {
+			return *attempt.Reason == entities.ClaimFailureUnavailable
+		}), mock.Anything).Return(&entities.ClaimAttempt{}, nil)
+
+		ticket, err := claim()
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should return a ticket already claimed by the player", func(t *testing.T) {
+		_, mockRepo, _, claim := claimable()
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", CredentialID: cid, Status: entities.TicketClaimed}, nil)
+
+		ticket, err := claim()
+		assert.Nil(t, err)
+		assert.Equal(t, "ticket-123", ticket.ID)
+		mockRepo.AssertNotCalled(t, "CreateClaimAttempt", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ClaimTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should lock a credential with too many failures", func(t *testing.T) {
+		_, mockRepo, _, claim := claimable()
+
+		mockRepo.On("ReadClaimAttempts", &transfert.ClaimAttempt{CredentialID: cid}, mock.Anything).Return(failures(5, time.Now()), nil)
+
+		ticket, err := claim()
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketClaimLocked, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should lock an IP with too many failures", func(t *testing.T) {
+		_, mockRepo, _, claim := claimable()
+
+		mockRepo.On("ReadClaimAttempts", &transfert.ClaimAttempt{CredentialID: cid}, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadClaimAttempts", &transfert.ClaimAttempt{IP: ip}, mock.Anything).Return(failures(10, time.Now().Add(-10*time.Minute)), nil)
+
+		ticket, err := claim()
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketClaimLocked, err)
+	})
+
+	t.Run("Should accept a claim once the lockout is over", func(t *testing.T) {
+		_, mockRepo, _, claim := claimable()
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return(failures(5, time.Now().Add(-2*time.Minute)), nil)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := claim()
+		assert.Nil(t, err)
+		assert.NotNil(t, ticket)
+	})
+
+	t.Run("Should refuse anonymous users", func(t *testing.T) {
+		service, _, mockPerms := setup()
+		mockPerms.On("IsAuthenticated").Return(false)
+
+		ticket, err := service.ClaimTicket(&transfert.Claim{Token: code})
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setup()
+
+		ticket, err := service.ClaimTicket(nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func Test_GetClaimAttempts(t *testing.T) {
+	t.Run("Should list the attempts for employees", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := &transfert.ClaimAttempt{IP: aws.String("203.0.113.7")}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadClaimAttempts", dto, mock.Anything).Return([]*entities.ClaimAttempt{{ID: "attempt-1"}}, nil)
+
+		attempts, err := service.GetClaimAttempts(dto)
+		assert.Nil(t, err)
+		assert.Len(t, attempts, 1)
+	})
+
+	t.Run("Should refuse other users", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
+
+		attempts, err := service.GetClaimAttempts(&transfert.ClaimAttempt{})
+		assert.Nil(t, attempts)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail/template"
+)
+
+// CreateDraw registers a draw with its published seed commitment
+// The seed itself is only revealed later, when the draw is run.
+//
+// Parameters:
+// - dto: *transfert.Draw the draw with its commitment and number of winners
+//
+// Returns:
+// - *entities.Draw: the registered draw
+// - errors.ErrorInterface: an error if the draw cannot be registered
+func (s *DrawService) CreateDraw(dto *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadDraw(&transfert.Draw{Commitment: dto.Commitment}); err == nil {
+		return nil, errors_domain_game.ErrDrawAlreadyExists
+	}
+
+	return s.repo.CreateDraw(&transfert.Draw{
+		Commitment:   dto.Commitment,
+		Count:        dto.Count,
+		CredentialID: s.security.GetCredentialID(),
+	})
+}
+
+// RunDraw reveals the seed of a registered draw and selects the winners
+// Entries are the clients holding at least one claimed ticket.
+//
+// Parameters:
+// - dto: *transfert.Draw the draw ID and the revealed seed
+//
+// Returns:
+// - *entities.Draw: the draw with its winners
+// - errors.ErrorInterface: an error if the draw cannot be run
+func (s *DrawService) RunDraw(dto *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	draw, err := s.repo.ReadDraw(&transfert.Draw{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if draw.IsDrawn() {
+		return nil, errors_domain_game.ErrDrawAlreadyDone
+	}
+
+	if !draw.Verify(*dto.Seed) {
+		return nil, errors_domain_game.ErrDrawCommitmentMismatch
+	}
+
+	holders, err := s.repo.ReadTicketHolders()
+	if err != nil {
+		return nil, err
+	}
+
+	// employees and admins may hold tickets, only the clients take part in the draw
+	ids, err := s.repoUser.ReadClientCredentialIDs(holders)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := entities.DrawEntries(ids)
+	if len(entries) == 0 {
+		return nil, errors_domain_game.ErrDrawNoEntries
+	}
+
+	hash := entities.DrawEntriesHash(entries)
+	now := time.Now()
+
+	draw.Seed = dto.Seed
+	draw.Entries = len(entries)
+	draw.EntriesHash = &hash
+	draw.DrawnAt = &now
+	draw.Winners = make(entities.Winners, 0, draw.Count)
+
+	for i, winner := range entities.DrawWinners(*dto.Seed, entries, draw.Count) {
+		draw.Winners = append(draw.Winners, &entities.Winner{
+			DrawID:       &draw.ID,
+			Position:     i + 1,
+			CredentialID: &winner,
+		})
+	}
+
+	if err := s.repo.CompleteDraw(draw); err != nil {
+		return nil, err
+	}
+
+	// the draw is persisted and can be replayed, a delivery failure must not cancel it
+	if err := s.sendDrawMail(draw); err != nil {
+		logger.Error(err)
+	}
+
+	return draw, nil
+}
+
+// GetDraw returns a draw by its ID
+//
+// Parameters:
+// - dto: *transfert.Draw the draw to read
+//
+// Returns:
+// - *entities.Draw: the draw
+// - errors.ErrorInterface: an error if the draw cannot be read
+func (s *DrawService) GetDraw(dto *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return s.repo.ReadDraw(dto)
+}
+
+// GetDraws returns every registered draw
+//
+// Returns:
+// - []*entities.Draw: the draws
+// - errors.ErrorInterface: an error if the draws cannot be read
+func (s *DrawService) GetDraws() ([]*entities.Draw, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return s.repo.ReadDraws(&transfert.Draw{}, database.Order("created_at DESC"))
+}
+
+// sendDrawMail sends the result of a draw to the configured recipients
+// Recipients are read from project.draw.recipients, nothing is sent when the list is empty.
+//
+// Parameters:
+// - draw: *entities.Draw the draw to report
+//
+// Returns:
+// - errors.ErrorInterface: an error if the mail cannot be sent
+func (s *DrawService) sendDrawMail(draw *entities.Draw) errors.ErrorInterface {
+	recipients := config.Get("project.draw.recipients", []string{}).([]string)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	tpl := template.NewTemplate("draw")
+	if tpl == nil {
+		return errors.ErrMailTemplateNotFound
+	}
+
+	winners := make([]string, 0, len(draw.Winners))
+	for _, winner := range draw.Winners {
+		winners = append(winners, strconv.Itoa(winner.Position)+". "+*winner.CredentialID)
+	}
+
+	text, html, err := tpl.Inject(template.Data{
+		"AppName":     env.APP_NAME,
+		"ID":          draw.ID,
+		"Commitment":  *draw.Commitment,
+		"Seed":        *draw.Seed,
+		"Entries":     strconv.Itoa(draw.Entries),
+		"EntriesHash": *draw.EntriesHash,
+		"DrawnAt":     draw.DrawnAt.Format(time.RFC3339),
+		"Winners":     strings.Join(winners, "\n"),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	m := &mail.Mail{
+		To:      recipients,
+		Subject: "The Tip Top - Tirage au sort",
+		Text:    text,
+		Html:    html,
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.mail.Send(m); err == nil {
+			return nil
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	return errors.ErrMailSendFailed
+}
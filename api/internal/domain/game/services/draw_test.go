@@ -0,0 +1,229 @@
+package services_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var drawRoles = []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}
+
+func commitment(seed string) *string {
+	sum := sha256.Sum256([]byte(seed))
+	return aws.String(hex.EncodeToString(sum[:]))
+}
+
+func Test_CreateDraw(t *testing.T) {
+	dto := &transfert.Draw{Commitment: commitment("seed"), Count: aws.Int(1)}
+
+	t.Run("Should register the draw", func(t *testing.T) {
+		service, mockRepo, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-id"))
+		mockRepo.On("ReadDraw", &transfert.Draw{Commitment: dto.Commitment}, mock.Anything).Return(nil, errors_domain_game.ErrDrawNotFound)
+		mockRepo.On("CreateDraw", mock.MatchedBy(func(obj *transfert.Draw) bool {
+			return *obj.Commitment == *dto.Commitment && *obj.CredentialID == "employee-id"
+		}), mock.Anything).Return(&entities.Draw{ID: "draw-id"}, nil)
+
+		draw, err := service.CreateDraw(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "draw-id", draw.ID)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse an already registered commitment", func(t *testing.T) {
+		service, mockRepo, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraw", &transfert.Draw{Commitment: dto.Commitment}, mock.Anything).Return(&entities.Draw{}, nil)
+
+		draw, err := service.CreateDraw(dto)
+		assert.Equal(t, errors_domain_game.ErrDrawAlreadyExists, err)
+		assert.Nil(t, draw)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		draw, err := service.CreateDraw(dto)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, draw)
+	})
+}
+
+func Test_RunDraw(t *testing.T) {
+	config.Load(aws.String("../../../../config.test.yml"))
+
+	drawID := "draw-id"
+	holders := []string{"client-b", "employee-a", "client-a", "client-c"}
+	clients := []string{"client-b", "client-a", "client-c"}
+
+	t.Run("Should select the winners and send the result", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers, mockMail := setupDrawUsers()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraw", &transfert.Draw{ID: &drawID}, mock.Anything).Return(&entities.Draw{ID: drawID, Commitment: commitment("seed"), Count: 2}, nil)
+		mockRepo.On("ReadTicketHolders", mock.Anything).Return(holders, nil)
+		mockUsers.On("ReadClientCredentialIDs", holders, mock.Anything).Return(clients, nil)
+		mockRepo.On("CompleteDraw", mock.Anything, mock.Anything).Return(nil)
+		mockMail.On("Send", mock.MatchedBy(func(m *mail.Mail) bool {
+			return len(m.To) == 1 && m.To[0] == "auditor@localhost"
+		})).Return(nil)
+
+		draw, err := service.RunDraw(&transfert.Draw{ID: &drawID, Seed: aws.String("seed")})
+		assert.Nil(t, err)
+		assert.True(t, draw.IsDrawn())
+		assert.Equal(t, 3, draw.Entries)
+		assert.Equal(t, entities.DrawEntriesHash([]string{"client-a", "client-b", "client-c"}), *draw.EntriesHash)
+		assert.Len(t, draw.Winners, 2)
+
+		expected := entities.DrawWinners("seed", []string{"client-a", "client-b", "client-c"}, 2)
+		for i, winner := range draw.Winners {
+			assert.Equal(t, i+1, winner.Position)
+			assert.Equal(t, expected[i], *winner.CredentialID)
+		}
+
+		mockRepo.AssertExpectations(t)
+		mockUsers.AssertExpectations(t)
+		mockMail.AssertExpectations(t)
+	})
+
+	t.Run("Should reject a draw run concurrently", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers, mockMail := setupDrawUsers()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraw", &transfert.Draw{ID: &drawID}, mock.Anything).Return(&entities.Draw{ID: drawID, Commitment: commitment("seed"), Count: 1}, nil)
+		mockRepo.On("ReadTicketHolders", mock.Anything).Return(holders, nil)
+		mockUsers.On("ReadClientCredentialIDs", holders, mock.Anything).Return(clients, nil)
+		mockRepo.On("CompleteDraw", mock.Anything, mock.Anything).Return(errors_domain_game.ErrDrawAlreadyDone)
+
+		draw, err := service.RunDraw(&transfert.Draw{ID: &drawID, Seed: aws.String("seed")})
+		assert.Equal(t, errors_domain_game.ErrDrawAlreadyDone, err)
+		assert.Nil(t, draw)
+		mockMail.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("Should reject a seed that does not match the commitment", func(t *testing.T) {
+		service, mockRepo, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraw", &transfert.Draw{ID: &drawID}, mock.Anything).Return(&entities.Draw{ID: drawID, Commitment: commitment("seed"), Count: 1}, nil)
+
+		draw, err := service.RunDraw(&transfert.Draw{ID: &drawID, Seed: aws.String("other")})
+		assert.Equal(t, errors_domain_game.ErrDrawCommitmentMismatch, err)
+		assert.Nil(t, draw)
+	})
+
+	t.Run("Should reject a draw already done", func(t *testing.T) {
+		service, mockRepo, mockPerms, _ := setupDraw()
+
+		done := &entities.Draw{ID: drawID, Commitment: commitment("seed"), Count: 1}
+		done.DrawnAt = &done.CreatedAt
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraw", &transfert.Draw{ID: &drawID}, mock.Anything).Return(done, nil)
+
+		draw, err := service.RunDraw(&transfert.Draw{ID: &drawID, Seed: aws.String("seed")})
+		assert.Equal(t, errors_domain_game.ErrDrawAlreadyDone, err)
+		assert.Nil(t, draw)
+	})
+
+	t.Run("Should fail without entries", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers, _ := setupDrawUsers()
+
+		// only employees hold tickets
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraw", &transfert.Draw{ID: &drawID}, mock.Anything).Return(&entities.Draw{ID: drawID, Commitment: commitment("seed"), Count: 1}, nil)
+		mockRepo.On("ReadTicketHolders", mock.Anything).Return([]string{"employee-a"}, nil)
+		mockUsers.On("ReadClientCredentialIDs", []string{"employee-a"}, mock.Anything).Return([]string{}, nil)
+
+		draw, err := service.RunDraw(&transfert.Draw{ID: &drawID, Seed: aws.String("seed")})
+		assert.Equal(t, errors_domain_game.ErrDrawNoEntries, err)
+		assert.Nil(t, draw)
+	})
+
+	t.Run("Should return error when the draw is unknown", func(t *testing.T) {
+		service, mockRepo, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraw", &transfert.Draw{ID: &drawID}, mock.Anything).Return(nil, errors_domain_game.ErrDrawNotFound)
+
+		draw, err := service.RunDraw(&transfert.Draw{ID: &drawID, Seed: aws.String("seed")})
+		assert.Equal(t, errors_domain_game.ErrDrawNotFound, err)
+		assert.Nil(t, draw)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		draw, err := service.RunDraw(&transfert.Draw{ID: &drawID, Seed: aws.String("seed")})
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, draw)
+	})
+}
+
+func Test_GetDraw(t *testing.T) {
+	drawID := "draw-id"
+
+	t.Run("Should return the draw", func(t *testing.T) {
+		service, mockRepo, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraw", &transfert.Draw{ID: &drawID}, mock.Anything).Return(&entities.Draw{ID: drawID}, nil)
+
+		draw, err := service.GetDraw(&transfert.Draw{ID: &drawID})
+		assert.Nil(t, err)
+		assert.Equal(t, drawID, draw.ID)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		draw, err := service.GetDraw(&transfert.Draw{ID: &drawID})
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, draw)
+	})
+}
+
+func Test_GetDraws(t *testing.T) {
+	t.Run("Should return the draws", func(t *testing.T) {
+		service, mockRepo, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadDraws", &transfert.Draw{}, mock.Anything).Return([]*entities.Draw{{ID: "draw-id"}}, nil)
+
+		draws, err := service.GetDraws()
+		assert.Nil(t, err)
+		assert.Len(t, draws, 1)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms, _ := setupDraw()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		draws, err := service.GetDraws()
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, draws)
+	})
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"iter"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+const (
+	// ExportBatchSize is the number of tickets read at once during an export
+	ExportBatchSize = 500
+)
+
+// ExportTickets prepares the export of the tickets to print
+// When a count is given, as many unassigned tickets are first sent to the store. The tickets are then
+// read batch by batch in their shuffled order while the export is iterated, never all at once.
+//
+// Parameters:
+// - dto: *transfert.TicketExport the store and the number of tickets to assign
+//
+// Returns:
+// - iter.Seq2[*entities.Ticket, errors.ErrorInterface]: the tickets of the store, all the tickets without store
+// - errors.ErrorInterface: an error if the tickets cannot be assigned
+func (s *GameService) ExportTickets(dto *transfert.TicketExport) (iter.Seq2[*entities.Ticket, errors.ErrorInterface], errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if dto.Count != nil && *dto.Count > 0 {
+		if dto.StoreID == nil {
+			return nil, errors.ErrBadRequest
+		}
+
+		if err := s.assignTickets(*dto.StoreID, *dto.Count); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.streamTickets(&transfert.Ticket{StoreID: dto.StoreID}), nil
+}
+
+// assignTickets sends unassigned and unclaimed tickets to a store, following the shuffled sequence
+// so that the prizes are mixed in the batch as in the whole game.
+//
+// Parameters:
+// - storeID: string the store receiving the tickets
+// - count: int the number of tickets to assign
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketNotEnough if fewer tickets are available
+func (s *GameService) assignTickets(storeID string, count int) errors.ErrorInterface {
+	available, err := s.repo.CountTicket(&transfert.Ticket{}, database.Where("store_id IS NULL AND credential_id IS NULL AND voided_at IS NULL"))
+	if err != nil {
+		return err
+	}
+
+	if available < count {
+		return errors_domain_game.ErrTicketNotEnough
+	}
+
+	for assigned := 0; assigned < count; {
+		tickets, err := s.repo.ReadTickets(
+			&transfert.Ticket{},
+			database.Where("store_id IS NULL AND credential_id IS NULL AND voided_at IS NULL"),
+			database.Order("sequence"),
+			database.Limit(min(ExportBatchSize, count-assigned)),
+		)
+		if err != nil {
+			return err
+		}
+
+		// The remaining tickets were assigned by a concurrent export
+		if len(tickets) == 0 {
+			return errors_domain_game.ErrTicketNotEnough
+		}
+
+		ids := make([]string, len(tickets))
+		for i, ticket := range tickets {
+			ids[i] = ticket.ID
+		}
+
+		n, err := s.repo.AssignTicketsToStore(ids, storeID)
+		if err != nil {
+			return err
+		}
+
+		assigned += n
+	}
+
+	return nil
+}
+
+// streamTickets iterates over the tickets matching the filter, reading them by batch
+// The batches are delimited by the sequence of the last ticket read, not by an offset,
+// so each batch only walks the sequence index. The tickets issued at a caisse or voided are never printed.
+//
+// Parameters:
+// - filter: *transfert.Ticket the tickets to read
+//
+// Returns:
+// - iter.Seq2[*entities.Ticket, errors.ErrorInterface]: the tickets, or the error stopping the iteration
+func (s *GameService) streamTickets(filter *transfert.Ticket) iter.Seq2[*entities.Ticket, errors.ErrorInterface] {
+	return func(yield func(*entities.Ticket, errors.ErrorInterface) bool) {
+		last := int64(-1)
+
+		for {
+			tickets, err := s.repo.ReadTickets(filter, database.Where("issued_at IS NULL AND voided_at IS NULL AND sequence > ?", last), database.Order("sequence"), database.Limit(ExportBatchSize))
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, ticket := range tickets {
+				if !yield(ticket, nil) {
+					return
+				}
+			}
+
+			if len(tickets) < ExportBatchSize || tickets[len(tickets)-1].Sequence == nil {
+				return
+			}
+
+			last = *tickets[len(tickets)-1].Sequence
+		}
+	}
+}
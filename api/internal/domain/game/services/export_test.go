@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func sequenced(from, count int) []*entities.Ticket {
+	tickets := make([]*entities.Ticket, count)
+	for i := range tickets {
+		sequence := int64(from + i)
+		tickets[i] = &entities.Ticket{ID: "ticket-" + string(rune('a'+i%26)), Sequence: &sequence}
+	}
+
+	return tickets
+}
+
+func Test_ExportTickets(t *testing.T) {
+	storeID := aws.String("store-123")
+	admin := []security.Role{security.ROLE_ADMIN}
+
+	t.Run("Should assign the tickets to the store before exporting them", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", admin).Return(true)
+
+		assigned := sequenced(0, 3)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(10, nil)
+		mockRepo.On("ReadTickets", &transfert.Ticket{}, mock.Anything).Return(assigned, nil).Once()
+		mockRepo.On("AssignTicketsToStore", []string{"ticket-a", "ticket-b", "ticket-c"}, *storeID, mock.Anything).Return(3, nil).Once()
+		mockRepo.On("ReadTickets", &transfert.Ticket{StoreID: storeID}, mock.Anything).Return(assigned, nil).Once()
+
+		tickets, err := service.ExportTickets(&transfert.TicketExport{StoreID: storeID, Count: aws.Int(3)})
+		assert.Nil(t, err)
+
+		count := 0
+		for ticket, err := range tickets {
+			assert.Nil(t, err)
+			assert.Equal(t, assigned[count], ticket)
+			count++
+		}
+
+		assert.Equal(t, 3, count)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should read the tickets by batch", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", admin).Return(true)
+
+		mockRepo.On("ReadTickets", &transfert.Ticket{StoreID: storeID}, mock.Anything).Return(sequenced(0, services.ExportBatchSize), nil).Once()
+		mockRepo.On("ReadTickets", &transfert.Ticket{StoreID: storeID}, mock.Anything).Return(sequenced(services.ExportBatchSize, 1), nil).Once()
+
+		tickets, err := service.ExportTickets(&transfert.TicketExport{StoreID: storeID})
+		assert.Nil(t, err)
+
+		count := 0
+		for range tickets {
+			count++
+		}
+
+		assert.Equal(t, services.ExportBatchSize+1, count)
+		mockRepo.AssertNotCalled(t, "AssignTicketsToStore", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should stop reading when the export is interrupted", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", admin).Return(true)
+
+		mockRepo.On("ReadTickets", &transfert.Ticket{}, mock.Anything).Return(sequenced(0, services.ExportBatchSize), nil).Once()
+
+		tickets, _ := service.ExportTickets(&transfert.TicketExport{})
+		for range tickets {
+			break
+		}
+
+		mockRepo.AssertNumberOfCalls(t, "ReadTickets", 1)
+	})
+
+	t.Run("Should yield the reading error", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", admin).Return(true)
+
+		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		tickets, _ := service.ExportTickets(&transfert.TicketExport{})
+		for ticket, err := range tickets {
+			assert.Nil(t, ticket)
+			assert.Equal(t, errors.ErrInternalServer, err)
+		}
+	})
+
+	t.Run("Should refuse to assign more tickets than available", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", admin).Return(true)
+
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(2, nil)
+
+		tickets, err := service.ExportTickets(&transfert.TicketExport{StoreID: storeID, Count: aws.Int(5)})
+		assert.Nil(t, tickets)
+		assert.Equal(t, errors_domain_game.ErrTicketNotEnough, err)
+		mockRepo.AssertNotCalled(t, "AssignTicketsToStore", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should stop when a concurrent export took the tickets", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", admin).Return(true)
+
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(5, nil)
+		mockRepo.On("ReadTickets", &transfert.Ticket{}, mock.Anything).Return(sequenced(0, 5), nil).Once()
+		mockRepo.On("AssignTicketsToStore", mock.Anything, *storeID, mock.Anything).Return(2, nil).Once()
+		mockRepo.On("ReadTickets", &transfert.Ticket{}, mock.Anything).Return([]*entities.Ticket{}, nil).Once()
+
+		tickets, err := service.ExportTickets(&transfert.TicketExport{StoreID: storeID, Count: aws.Int(5)})
+		assert.Nil(t, tickets)
+		assert.Equal(t, errors_domain_game.ErrTicketNotEnough, err)
+	})
+
+	t.Run("Should refuse other users", func(t *testing.T) {
+		service, _, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", admin).Return(false)
+
+		tickets, err := service.ExportTickets(&transfert.TicketExport{})
+		assert.Nil(t, tickets)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setup()
+
+		tickets, err := service.ExportTickets(nil)
+		assert.Nil(t, tickets)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
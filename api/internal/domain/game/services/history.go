@@ -0,0 +1,62 @@
+package services
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// GetTicketHistory lists the actions made on a ticket, oldest first
+// Only employees and admins can read the history of a ticket.
+//
+// Parameters:
+// - dto: *transfert.Ticket the ticket
+//
+// Returns:
+// - []*entities.TicketEvent: the events of the ticket
+// - errors.ErrorInterface: an error if the ticket does not exist or the user is not allowed
+func (s *GameService) GetTicketHistory(dto *transfert.Ticket) ([]*entities.TicketEvent, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadTicketEvents(&transfert.TicketEvent{TicketID: &ticket.ID}, database.Order("created_at"))
+}
+
+// record appends an action of the current user to the history of a ticket
+// The event goes through the repository storing the action, a transaction undoes both when the history cannot be written.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface the repository storing the action
+// - ticket: *entities.Ticket the ticket acted on
+// - action: string the action, see entities.TicketEventIssued and the following
+//
+// Returns:
+// - errors.ErrorInterface: an error if the event cannot be stored
+func (s *GameService) record(repo repositories.GameRepositoryInterface, ticket *entities.Ticket, action string) errors.ErrorInterface {
+	role := string(s.security.GetRole())
+
+	_, err := repo.CreateTicketEvent(&transfert.TicketEvent{
+		TicketID:     &ticket.ID,
+		Action:       &action,
+		CredentialID: s.security.GetCredentialID(),
+		Role:         &role,
+		IP:           s.security.GetIP(),
+		UserAgent:    s.security.GetUserAgent(),
+	})
+
+	return err
+}
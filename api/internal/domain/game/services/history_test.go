@@ -0,0 +1,72 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetTicketHistory(t *testing.T) {
+	staff := []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}
+	dto := &transfert.Ticket{ID: aws.String("ticket-123")}
+
+	t.Run("Should list the events of the ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		events := []*entities.TicketEvent{
+			{TicketID: "ticket-123", Action: entities.TicketEventIssued},
+			{TicketID: "ticket-123", Action: entities.TicketEventClaimed},
+		}
+
+		mockPerms.On("IsGrantedByRoles", staff).Return(true)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("ReadTicketEvents", &transfert.TicketEvent{TicketID: aws.String("ticket-123")}, mock.Anything).Return(events, nil)
+
+		history, err := service.GetTicketHistory(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, events, history)
+
+		// Lire l'historique n'ajoute pas d'événement
+		mockRepo.AssertNotCalled(t, "CreateTicketEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when ticket not found", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", staff).Return(true)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		history, err := service.GetTicketHistory(dto)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+		assert.Nil(t, history)
+
+		mockRepo.AssertNotCalled(t, "ReadTicketEvents", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", staff).Return(false)
+
+		history, err := service.GetTicketHistory(dto)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, history)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when dto is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		history, err := service.GetTicketHistory(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+		assert.Nil(t, history)
+	})
+}
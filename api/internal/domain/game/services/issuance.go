@@ -0,0 +1,154 @@
+package services
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// IssueAttempts bounds the draws of an issuance when other caisses take the drawn tickets first
+const IssueAttempts = 5
+
+// IssueTicket hands the next ticket of the pool over at a caisse for a purchase
+// The purchase must reach the minimum amount of the configuration and a receipt gets a single ticket per store.
+// The caisse, its store, the purchase, the employee or device and the shift running on the caisse are recorded on the ticket.
+// Employees only issue tickets at the caisses of the stores they are assigned to, devices at the caisse they are paired to.
+//
+// Parameters:
+// - dto: *transfert.Issuance the caisse, the receipt and the amount of the purchase
+//
+// Returns:
+// - *entities.Ticket: the issued ticket
+// - errors.ErrorInterface: an error if the ticket cannot be issued
+func (s *GameService) IssueTicket(dto *transfert.Issuance) (*entities.Ticket, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if err := checkAmount(dto.Amount); err != nil {
+		return nil, err
+	}
+
+	caisse, err := s.caisseOf(dto.CaisseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(caisse, dto)
+}
+
+// issue hands the next ticket of the pool over at a caisse the user may operate
+//
+// Parameters:
+// - caisse: *storeEntity.Caisse the caisse issuing the ticket
+// - dto: *transfert.Issuance the receipt and the amount of the purchase
+//
+// Returns:
+// - *entities.Ticket: the issued ticket
+// - errors.ErrorInterface: an error if the ticket cannot be issued
+func (s *GameService) issue(caisse *storeEntity.Caisse, dto *transfert.Issuance) (*entities.Ticket, errors.ErrorInterface) {
+	issued, err := s.repo.CountTicket(&transfert.Ticket{}, database.Where("store_id = ? AND receipt = ?", *caisse.StoreID, aws.ToString(dto.Receipt)))
+	if err != nil {
+		return nil, err
+	}
+
+	if issued > 0 {
+		return nil, errors_domain_game.ErrTicketReceiptUsed
+	}
+
+	shiftID, err := s.shiftOf(&caisse.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for range IssueAttempts {
+		ticket, err := s.drawTicket()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.checkParticipation(ticket); err != nil {
+			return nil, err
+		}
+
+		if !ticket.Issue(&caisse.ID, caisse.StoreID, dto.Receipt, dto.Amount, s.security.GetCredentialID()) {
+			continue
+		}
+
+		ticket.ShiftID = shiftID
+
+		err = s.repo.Transaction(func(repo repositories.GameRepositoryInterface) errors.ErrorInterface {
+			if err := repo.IssueTicket(ticket); err != nil {
+				return err
+			}
+
+			return s.record(repo, ticket, entities.TicketEventIssued)
+		})
+
+		// Another caisse took the ticket since it was drawn, the next one is tried
+		if err == errors_domain_game.ErrTicketAlreadyIssued {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		return ticket, nil
+	}
+
+	return nil, errors_domain_game.ErrTicketAlreadyIssued
+}
+
+// checkAmount checks the purchase reaches the minimum amount of the configuration
+//
+// Parameters:
+// - amount: *float64 the amount of the purchase
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketAmountTooLow if the purchase is under the minimum
+func checkAmount(amount *float64) errors.ErrorInterface {
+	if aws.ToFloat64(amount) < config.Get("project.tickets.minimum", 0.0).(float64) {
+		return errors_domain_game.ErrTicketAmountTooLow
+	}
+
+	return nil
+}
+
+// drawTicket draws a ticket of the pool from the shuffled issuance sequence
+// A random position is drawn and the first ticket of the pool from there is returned, wrapping around
+// to the start of the sequence. The lookup only walks the sequence index, it does not depend on the
+// number of tickets nor on the SQL dialect. Tickets sent to a store are printed and never drawn, voided tickets neither.
+//
+// The chances of the tickets are not uniform: a ticket is drawn as often as the gap before its position is wide,
+// and tickets following already issued ones inherit their gap. Positions are random and independent of the prize,
+// so the gaps are too and each draw still yields a prize in the proportions of the remaining pool.
+//
+// Returns:
+// - *entities.Ticket: the drawn ticket
+// - errors.ErrorInterface: ErrTicketNotEnough if the pool is empty
+func (s *GameService) drawTicket() (*entities.Ticket, errors.ErrorInterface) {
+	pool := "credential_id IS NULL AND store_id IS NULL AND issued_at IS NULL AND voided_at IS NULL"
+	from := entities.RandomTicketSequence()
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{}, database.Where(pool+" AND sequence >= ?", from), database.Order("sequence"))
+	if err == errors_domain_game.ErrTicketNotFound {
+		ticket, err = s.repo.ReadTicket(&transfert.Ticket{}, database.Where(pool+" AND sequence < ?", from), database.Order("sequence"))
+	}
+
+	if err == errors_domain_game.ErrTicketNotFound {
+		return nil, errors_domain_game.ErrTicketNotEnough
+	}
+
+	return ticket, err
+}
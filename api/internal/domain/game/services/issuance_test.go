@@ -0,0 +1,221 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_IssueTicket(t *testing.T) {
+	employee := []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}
+	eid := aws.String("employee-123")
+	caisse := &storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-123")}
+	dto := &transfert.Issuance{CaisseID: aws.String("caisse-123"), Receipt: aws.String("R-0001"), Amount: aws.Float64(54.9)}
+
+	shifted := func() (*services.GameService, *GameRepositoryMock, *StoreRepositoryMock) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: dto.CaisseID}, mock.Anything).Return(caisse, nil)
+
+		return service, mockRepo, mockStores
+	}
+
+	issuable := func() (*services.GameService, *GameRepositoryMock, *StoreRepositoryMock) {
+		service, mockRepo, mockStores := shifted()
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound)
+
+		return service, mockRepo, mockStores
+	}
+
+	t.Run("Should issue a ticket of the pool to the caisse", func(t *testing.T) {
+		service, mockRepo, _ := issuable()
+
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("IssueTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "ticket-123", ticket.ID)
+		assert.Equal(t, "caisse-123", *ticket.CaisseID)
+		assert.Equal(t, "store-123", *ticket.StoreID)
+		assert.Equal(t, "R-0001", *ticket.Receipt)
+		assert.Equal(t, 54.9, *ticket.Amount)
+		assert.Equal(t, eid, ticket.IssuedBy)
+		assert.NotNil(t, ticket.IssuedAt)
+		assert.Nil(t, ticket.ShiftID)
+	})
+
+	t.Run("Should attribute the ticket to the shift of the caisse", func(t *testing.T) {
+		service, mockRepo, _ := shifted()
+
+		mockRepo.On("ReadShift", &transfert.Shift{CaisseID: &caisse.ID}, mock.Anything).Return(&entities.Shift{ID: "shift-123"}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("IssueTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "shift-123", *ticket.ShiftID)
+	})
+
+	t.Run("Should wrap around the end of the sequence", func(t *testing.T) {
+		service, mockRepo, _ := issuable()
+
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound).Once()
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil).Once()
+		mockRepo.On("IssueTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "ticket-123", ticket.ID)
+		mockRepo.AssertNumberOfCalls(t, "ReadTicket", 2)
+	})
+
+	t.Run("Should draw another ticket when a caisse took it first", func(t *testing.T) {
+		service, mockRepo, _ := issuable()
+
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil).Once()
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-456"}, nil).Once()
+		mockRepo.On("IssueTicket", mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketAlreadyIssued).Once()
+		mockRepo.On("IssueTicket", mock.Anything, mock.Anything).Return(nil).Once()
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "ticket-456", ticket.ID)
+	})
+
+	t.Run("Should give up after too many conflicts", func(t *testing.T) {
+		service, mockRepo, _ := issuable()
+
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		for range services.IssueAttempts {
+			mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil).Once()
+		}
+		mockRepo.On("IssueTicket", mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketAlreadyIssued)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyIssued, err)
+		mockRepo.AssertNumberOfCalls(t, "IssueTicket", services.IssueAttempts)
+	})
+
+	t.Run("Should refuse a receipt rewarded concurrently at another caisse", func(t *testing.T) {
+		service, mockRepo, _ := issuable()
+
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("IssueTicket", mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketReceiptUsed)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketReceiptUsed, err)
+		mockRepo.AssertNumberOfCalls(t, "IssueTicket", 1)
+	})
+
+	t.Run("Should refuse a receipt already rewarded in the store", func(t *testing.T) {
+		service, mockRepo, _ := issuable()
+
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(1, nil)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketReceiptUsed, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should report an empty pool", func(t *testing.T) {
+		service, mockRepo, _ := issuable()
+
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketNotEnough, err)
+		mockRepo.AssertNumberOfCalls(t, "ReadTicket", 2)
+	})
+
+	t.Run("Should refuse an unknown caisse", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrCaisseNotFound)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_store.ErrCaisseNotFound, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a caisse without store", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(&storeEntity.Caisse{ID: "caisse-123"}, nil)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a caisse out of the stores of the employee", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "CountTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a purchase under the minimum amount", func(t *testing.T) {
+		config.Load(aws.String("../../../../config.test.yml"))
+
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+
+		ticket, err := service.IssueTicket(&transfert.Issuance{CaisseID: dto.CaisseID, Receipt: dto.Receipt, Amount: aws.Float64(9.99)})
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketAmountTooLow, err)
+		mockStores.AssertNotCalled(t, "ReadCaisse", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a user who is not an employee", func(t *testing.T) {
+		service, _, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(false)
+
+		ticket, err := service.IssueTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockStores.AssertNotCalled(t, "ReadCaisse", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setup()
+
+		ticket, err := service.IssueTicket(nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+)
+
+// SignTicketLink builds the signed claim link of a ticket, the content of its QR code
+//
+// Parameters:
+// - dto: *transfert.Ticket the printed code of the ticket
+//
+// Returns:
+// - string: the signed link
+// - errors.ErrorInterface: an error if the ticket does not exist or the user is not an employee
+func (s *GameService) SignTicketLink(dto *transfert.Ticket) (string, errors.ErrorInterface) {
+	if dto == nil {
+		return "", errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return "", errors.ErrUnauthorized
+	}
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{Token: dto.Token})
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.record(s.repo, ticket, entities.TicketEventViewed); err != nil {
+		return "", err
+	}
+
+	return token.Links().Sign(ticket.Token.String(), time.Now()), nil
+}
+
+// ClaimLinkedTicket claims a ticket from its signed claim link
+// The signature is verified before the claim, which then goes through ClaimTicket and its lockouts.
+//
+// Parameters:
+// - dto: *transfert.Claim the printed code, the expiry and the signature of the link
+//
+// Returns:
+// - *entities.Ticket: the claimed ticket
+// - errors.ErrorInterface: an error if the link is forged or expired, or if the ticket cannot be claimed
+func (s *GameService) ClaimLinkedTicket(dto *transfert.Claim) (*entities.Ticket, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsAuthenticated() {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if err := token.Links().Verify(
+		aws.ToString(dto.Token),
+		aws.ToString(dto.Expires),
+		aws.ToString(dto.Signature),
+		time.Now(),
+	); err != nil {
+		return nil, err
+	}
+
+	return s.ClaimTicket(&transfert.Claim{Token: dto.Token, IP: dto.IP})
+}
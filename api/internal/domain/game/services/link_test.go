@@ -0,0 +1,145 @@
+package services_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_SignTicketLink(t *testing.T) {
+	employee := []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}
+	code, _ := token.Generate(12)
+
+	t.Run("Should sign the link of an existing ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-id"))
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: code.PointerString()}, mock.Anything).Return(&entities.Ticket{ID: "ticket-id", Token: code}, nil)
+
+		link, err := service.SignTicketLink(&transfert.Ticket{Token: code.PointerString()})
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(link, "/game/ticket/"+code.String()+"/claim?"))
+
+		mockRepo.AssertCalled(t, "CreateTicketEvent", mock.MatchedBy(func(obj *transfert.TicketEvent) bool {
+			return *obj.TicketID == "ticket-id" && *obj.Action == entities.TicketEventViewed
+		}), mock.Anything)
+	})
+
+	t.Run("Should refuse a user who is not an employee", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", employee).Return(false)
+
+		link, err := service.SignTicketLink(&transfert.Ticket{Token: code.PointerString()})
+		assert.Empty(t, link)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should not sign the link of an unknown ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		link, err := service.SignTicketLink(&transfert.Ticket{Token: code.PointerString()})
+		assert.Empty(t, link)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+	})
+
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setup()
+
+		_, err := service.SignTicketLink(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func Test_ClaimLinkedTicket(t *testing.T) {
+	cid := aws.String("client-123")
+	code, _ := token.Generate(12)
+
+	signed := func(now time.Time) *transfert.Claim {
+		link, _ := url.Parse(token.Links().Sign(code.String(), now))
+
+		return &transfert.Claim{
+			Token:     code.PointerString(),
+			Expires:   aws.String(link.Query().Get("expires")),
+			Signature: aws.String(link.Query().Get("signature")),
+		}
+	}
+
+	t.Run("Should claim the ticket of a signed link", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: code.PointerString()}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := service.ClaimLinkedTicket(signed(time.Now()))
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+		assert.Equal(t, cid, ticket.CredentialID)
+	})
+
+	t.Run("Should refuse a link signed for another ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsAuthenticated").Return(true)
+
+		other, _ := token.Generate(12)
+		dto := signed(time.Now())
+		dto.Token = other.PointerString()
+
+		ticket, err := service.ClaimLinkedTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrLinkIsForged, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an expired link", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsAuthenticated").Return(true)
+
+		ticket, err := service.ClaimLinkedTicket(signed(time.Now().AddDate(-1, 0, 0)))
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrLinkIsExpired, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a link without signature", func(t *testing.T) {
+		service, _, mockPerms := setup()
+		mockPerms.On("IsAuthenticated").Return(true)
+
+		ticket, err := service.ClaimLinkedTicket(&transfert.Claim{Token: code.PointerString()})
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrLinkIsForged, err)
+	})
+
+	t.Run("Should refuse an anonymous user", func(t *testing.T) {
+		service, _, mockPerms := setup()
+		mockPerms.On("IsAuthenticated").Return(false)
+
+		ticket, err := service.ClaimLinkedTicket(signed(time.Now()))
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setup()
+
+		_, err := service.ClaimLinkedTicket(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// CreatePrize adds a prize to the catalog
+//
+// Parameters:
+// - dto: *transfert.Prize the prize to create
+//
+// Returns:
+// - *entities.Prize: the created prize
+// - errors.ErrorInterface: an error if the prize cannot be created
+func (s *PrizeService) CreatePrize(dto *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadPrize(&transfert.Prize{Code: dto.Code}); err == nil {
+		return nil, errors_domain_game.ErrPrizeAlreadyExists
+	}
+
+	return s.repo.CreatePrize(dto)
+}
+
+func (s *PrizeService) GetPrize(dto *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	return s.repo.ReadPrize(dto)
+}
+
+func (s *PrizeService) GetPrizes() ([]*entities.Prize, errors.ErrorInterface) {
+	return s.repo.ReadPrizes(&transfert.Prize{})
+}
+
+// UpdatePrize changes the details of a prize
+// The percentages only drive the generation of the tickets, they are checked at startup.
+//
+// Parameters:
+// - dto: *transfert.Prize the prize ID and the fields to change
+//
+// Returns:
+// - *entities.Prize: the updated prize
+// - errors.ErrorInterface: an error if the prize cannot be updated
+func (s *PrizeService) UpdatePrize(dto *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	prize, err := s.repo.ReadPrize(&transfert.Prize{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Code != nil {
+		if other, err := s.repo.ReadPrize(&transfert.Prize{Code: dto.Code}); err == nil && other.ID != prize.ID {
+			return nil, errors_domain_game.ErrPrizeAlreadyExists
+		}
+	}
+
+	prize.Update(dto)
+
+	if err := s.repo.UpdatePrize(prize); err != nil {
+		return nil, err
+	}
+
+	return prize, nil
+}
+
+// DeletePrize removes a prize from the catalog
+// A prize can only be removed while no ticket references it.
+//
+// Parameters:
+// - dto: *transfert.Prize the prize to delete
+//
+// Returns:
+// - errors.ErrorInterface: an error if the prize cannot be deleted
+func (s *PrizeService) DeletePrize(dto *transfert.Prize) errors.ErrorInterface {
+	if dto == nil {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return errors.ErrUnauthorized
+	}
+
+	prize, err := s.repo.ReadPrize(&transfert.Prize{ID: dto.ID})
+	if err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountTicket(&transfert.Ticket{PrizeID: &prize.ID})
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors_domain_game.ErrPrizeInUse
+	}
+
+	return s.repo.DeletePrize(&transfert.Prize{ID: dto.ID})
+}
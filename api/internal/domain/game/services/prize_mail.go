@@ -0,0 +1,101 @@
+package services
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/env"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	transfertUser "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail/template"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
+)
+
+// sendPrizeMail congratulates the player of a claimed ticket and attaches the voucher of the prize
+// Nothing is sent when the ticket has no prize or the player has no email.
+//
+// Parameters:
+// - ticket: *entities.Ticket the claimed ticket
+//
+// Returns:
+// - errors.ErrorInterface: an error if the mail cannot be sent
+func (s *GameService) sendPrizeMail(ticket *entities.Ticket) errors.ErrorInterface {
+	if ticket.PrizeID == nil || ticket.CredentialID == nil {
+		return nil
+	}
+
+	credential, err := s.repoUser.ReadCredential(&transfertUser.Credential{ID: ticket.CredentialID})
+	if err != nil {
+		return err
+	}
+
+	if credential.Email == nil {
+		return nil
+	}
+
+	prize, err := s.repo.ReadPrize(&transfert.Prize{ID: ticket.PrizeID})
+	if err != nil {
+		return err
+	}
+
+	campaign, err := s.campaignOf(ticket)
+	if err != nil {
+		return err
+	}
+
+	voucher := &sheet.Voucher{
+		Title: "Bon de retrait " + ticket.Token.String(),
+		Code:  ticket.Token.String(),
+	}
+
+	if prize.Label != nil {
+		voucher.Prize = *prize.Label
+	}
+
+	if campaign != nil {
+		voucher.Deadline = campaign.ClaimUntil.In(campaign.Location()).Format("02/01/2006 15:04")
+	}
+
+	tpl := template.NewTemplate("prize_won")
+	if tpl == nil {
+		return errors.ErrMailTemplateNotFound
+	}
+
+	text, html, err := tpl.Inject(template.Data{
+		"AppName":  env.APP_NAME,
+		"Code":     voucher.Code,
+		"Prize":    voucher.Prize,
+		"Deadline": voucher.Deadline,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	var pdf bytes.Buffer
+	if err := sheet.WriteVoucher(&pdf, voucher); err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	m := &mail.Mail{
+		To:      []string{*credential.Email},
+		Subject: "The Tip Top - Votre lot vous attend",
+		Text:    text,
+		Html:    html,
+		Attachments: map[string][]byte{
+			"voucher-" + voucher.Code + ".pdf": pdf.Bytes(),
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.mail.Send(m); err == nil {
+			return nil
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	return errors.ErrMailSendFailed
+}
@@ -0,0 +1,117 @@
+package services_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_PrizeMail(t *testing.T) {
+	cid := aws.String("client-123")
+	code, _ := token.Tickets().Generate()
+	prizeID := aws.String("prize-123")
+	campaignID := aws.String("campaign-123")
+	email := aws.String("winner@thetiptop.fr")
+
+	winning := func() *entities.Ticket {
+		return &entities.Ticket{ID: "ticket-123", Token: code, PrizeID: prizeID, CampaignID: campaignID}
+	}
+
+	campaign := &entities.Campaign{
+		ID:         *campaignID,
+		Timezone:   "Europe/Paris",
+		StartAt:    time.Now().Add(-time.Hour),
+		EndAt:      time.Now().Add(time.Hour),
+		ClaimUntil: time.Date(2031, 3, 1, 23, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("Should mail the voucher of a winning ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms, _, mockUsers, mockMail := setupMail()
+		dto := &transfert.Ticket{Token: code.PointerString()}
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(winning(), nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: campaignID}, mock.Anything).Return(campaign, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ReadPrize", &transfert.Prize{ID: prizeID}, mock.Anything).Return(&entities.Prize{ID: *prizeID, Label: aws.String("Coffret découverte")}, nil)
+		mockUsers.ExpectedCalls = nil
+		mockUsers.On("ReadCredential", &userTransfert.Credential{ID: cid}, mock.Anything).Return(&user.Credential{ID: *cid, Email: email}, nil)
+
+		ticket, err := service.ClaimTicket(&transfert.Claim{Token: dto.Token})
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+
+		mockMail.AssertCalled(t, "Send", mock.MatchedBy(func(m *mail.Mail) bool {
+			voucher, ok := m.Attachments["voucher-"+code.String()+".pdf"]
+
+			return ok && bytes.HasPrefix(voucher, []byte("%PDF-")) &&
+				len(m.To) == 1 && m.To[0] == *email &&
+				strings.Contains(string(m.Text), "Coffret découverte") &&
+				strings.Contains(string(m.Text), "02/03/2031 00:00")
+		}))
+	})
+
+	t.Run("Should mail the voucher when the code is typed", func(t *testing.T) {
+		service, mockRepo, mockPerms, _, mockUsers, mockMail := setupMail()
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(winning(), nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(campaign, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(&entities.Prize{ID: *prizeID}, nil)
+		mockUsers.ExpectedCalls = nil
+		mockUsers.On("ReadCredential", mock.Anything, mock.Anything).Return(&user.Credential{ID: *cid, Email: email}, nil)
+
+		_, err := service.ClaimTicket(&transfert.Claim{Token: code.PointerString(), IP: aws.String("203.0.113.7")})
+		assert.Nil(t, err)
+		mockMail.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("Should not mail a player without email", func(t *testing.T) {
+		service, mockRepo, mockPerms, _, _, mockMail := setupMail()
+		dto := &transfert.Ticket{Token: code.PointerString()}
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(winning(), nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(campaign, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.ClaimTicket(&transfert.Claim{Token: dto.Token})
+		assert.Nil(t, err)
+		mockRepo.AssertNotCalled(t, "ReadPrize", mock.Anything, mock.Anything)
+		mockMail.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("Should not mail a ticket without prize", func(t *testing.T) {
+		service, mockRepo, mockPerms, _, mockUsers, mockMail := setupMail()
+		dto := &transfert.Ticket{Token: code.PointerString()}
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Token: code}, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.ClaimTicket(&transfert.Claim{Token: dto.Token})
+		assert.Nil(t, err)
+		mockUsers.AssertNotCalled(t, "ReadCredential", mock.Anything, mock.Anything)
+		mockMail.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
@@ -0,0 +1,198 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var prizeRoles = []security.Role{security.ROLE_ADMIN}
+
+func prizeDTO() *transfert.Prize {
+	return &transfert.Prize{
+		Code:       aws.String("infuser"),
+		Label:      aws.String("Infuseur à thé"),
+		Value:      aws.Float64(8),
+		Percentage: aws.Int(60),
+	}
+}
+
+func Test_CreatePrize(t *testing.T) {
+	t.Run("Should create the prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupPrize()
+		dto := prizeDTO()
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(true)
+		mockRepo.On("ReadPrize", &transfert.Prize{Code: dto.Code}, mock.Anything).Return(nil, errors_domain_game.ErrPrizeNotFound)
+		mockRepo.On("CreatePrize", dto, mock.Anything).Return(&entities.Prize{ID: "prize-id"}, nil)
+
+		prize, err := service.CreatePrize(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "prize-id", prize.ID)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse a duplicated code", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupPrize()
+		dto := prizeDTO()
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(true)
+		mockRepo.On("ReadPrize", &transfert.Prize{Code: dto.Code}, mock.Anything).Return(&entities.Prize{ID: "other"}, nil)
+
+		prize, err := service.CreatePrize(dto)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors_domain_game.ErrPrizeAlreadyExists, err)
+		mockRepo.AssertNotCalled(t, "CreatePrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse non admin users", func(t *testing.T) {
+		service, _, mockPerms := setupPrize()
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(false)
+
+		prize, err := service.CreatePrize(prizeDTO())
+		assert.Nil(t, prize)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setupPrize()
+
+		prize, err := service.CreatePrize(nil)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func Test_GetPrizes(t *testing.T) {
+	t.Run("Should return the catalog to anyone", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupPrize()
+
+		mockRepo.On("ReadPrizes", &transfert.Prize{}, mock.Anything).Return([]*entities.Prize{{ID: "prize-id"}}, nil)
+
+		prizes, err := service.GetPrizes()
+		assert.Nil(t, err)
+		assert.Len(t, prizes, 1)
+		mockPerms.AssertNotCalled(t, "IsGrantedByRoles", mock.Anything)
+	})
+
+	t.Run("Should return a prize by id", func(t *testing.T) {
+		service, mockRepo, _ := setupPrize()
+		dto := &transfert.Prize{ID: aws.String("prize-id")}
+
+		mockRepo.On("ReadPrize", dto, mock.Anything).Return(&entities.Prize{ID: "prize-id"}, nil)
+
+		prize, err := service.GetPrize(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "prize-id", prize.ID)
+	})
+
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setupPrize()
+
+		prize, err := service.GetPrize(nil)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func Test_UpdatePrize(t *testing.T) {
+	t.Run("Should update the prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupPrize()
+		existing := &entities.Prize{ID: "prize-id", Code: aws.String("infuser"), Percentage: 60}
+		dto := &transfert.Prize{ID: aws.String("prize-id"), Label: aws.String("Infuseur inox")}
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(true)
+		mockRepo.On("ReadPrize", &transfert.Prize{ID: dto.ID}, mock.Anything).Return(existing, nil)
+		mockRepo.On("UpdatePrize", existing, mock.Anything).Return(nil)
+
+		prize, err := service.UpdatePrize(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "Infuseur inox", *prize.Label)
+		assert.Equal(t, 60, prize.Percentage)
+	})
+
+	t.Run("Should refuse a code used by another prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupPrize()
+		dto := &transfert.Prize{ID: aws.String("prize-id"), Code: aws.String("box-39")}
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(true)
+		mockRepo.On("ReadPrize", &transfert.Prize{ID: dto.ID}, mock.Anything).Return(&entities.Prize{ID: "prize-id"}, nil)
+		mockRepo.On("ReadPrize", &transfert.Prize{Code: dto.Code}, mock.Anything).Return(&entities.Prize{ID: "other"}, nil)
+
+		prize, err := service.UpdatePrize(dto)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors_domain_game.ErrPrizeAlreadyExists, err)
+	})
+
+	t.Run("Should return not found", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupPrize()
+		dto := &transfert.Prize{ID: aws.String("prize-id")}
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(true)
+		mockRepo.On("ReadPrize", dto, mock.Anything).Return(nil, errors_domain_game.ErrPrizeNotFound)
+
+		prize, err := service.UpdatePrize(dto)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors_domain_game.ErrPrizeNotFound, err)
+	})
+
+	t.Run("Should refuse non admin users", func(t *testing.T) {
+		service, _, mockPerms := setupPrize()
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(false)
+
+		prize, err := service.UpdatePrize(&transfert.Prize{ID: aws.String("prize-id")})
+		assert.Nil(t, prize)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_DeletePrize(t *testing.T) {
+	t.Run("Should delete an unused prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupPrize()
+		dto := &transfert.Prize{ID: aws.String("prize-id")}
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(true)
+		mockRepo.On("ReadPrize", dto, mock.Anything).Return(&entities.Prize{ID: "prize-id"}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{PrizeID: aws.String("prize-id")}, mock.Anything).Return(0, nil)
+		mockRepo.On("DeletePrize", dto, mock.Anything).Return(nil)
+
+		assert.Nil(t, service.DeletePrize(dto))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse a prize referenced by tickets", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupPrize()
+		dto := &transfert.Prize{ID: aws.String("prize-id")}
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(true)
+		mockRepo.On("ReadPrize", dto, mock.Anything).Return(&entities.Prize{ID: "prize-id"}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{PrizeID: aws.String("prize-id")}, mock.Anything).Return(12, nil)
+
+		assert.Equal(t, errors_domain_game.ErrPrizeInUse, service.DeletePrize(dto))
+		mockRepo.AssertNotCalled(t, "DeletePrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse non admin users", func(t *testing.T) {
+		service, _, mockPerms := setupPrize()
+
+		mockPerms.On("IsGrantedByRoles", prizeRoles).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.DeletePrize(&transfert.Prize{ID: aws.String("prize-id")}))
+	})
+
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setupPrize()
+
+		assert.Equal(t, errors.ErrNoDto, service.DeletePrize(nil))
+	})
+}
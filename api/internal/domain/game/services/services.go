@@ -1,25 +1,117 @@
 package services
 
 import (
+	"iter"
+
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
 )
 
 type GameService struct {
-	security security.PermissionInterface
-	repo     repositories.GameRepositoryInterface
+	security  security.PermissionInterface
+	repo      repositories.GameRepositoryInterface
+	repoStore storeRepository.StoreRepositoryInterface
+	repoUser  userRepository.UserRepositoryInterface
+	mail      mail.ServiceInterface
 }
 
-func Game(security security.PermissionInterface, repo repositories.GameRepositoryInterface) *GameService {
-	return &GameService{security, repo}
+func Game(security security.PermissionInterface, repo repositories.GameRepositoryInterface, store storeRepository.StoreRepositoryInterface, user userRepository.UserRepositoryInterface, mail mail.ServiceInterface) *GameService {
+	return &GameService{security, repo, store, user, mail}
 }
 
 type GameServiceInterface interface {
-	GetTickets() ([]*entities.Ticket, errors.ErrorInterface)
-	GetRandomTicket() (*entities.Ticket, errors.ErrorInterface)
+	GetTickets(*database.List) (*database.Page[*entities.Ticket], errors.ErrorInterface)
+	IssueTicket(*transfert.Issuance) (*entities.Ticket, errors.ErrorInterface)
 	UpdateTicket(*transfert.Ticket) (*entities.Ticket, errors.ErrorInterface)
+	ClaimTicket(*transfert.Claim) (*entities.Ticket, errors.ErrorInterface)
+	ClaimLinkedTicket(*transfert.Claim) (*entities.Ticket, errors.ErrorInterface)
+	SignTicketLink(*transfert.Ticket) (string, errors.ErrorInterface)
+	GetClaimAttempts(*transfert.ClaimAttempt) ([]*entities.ClaimAttempt, errors.ErrorInterface)
 	GetTicketById(*transfert.Ticket) (*entities.Ticket, errors.ErrorInterface)
+	GetTicketHistory(*transfert.Ticket) ([]*entities.TicketEvent, errors.ErrorInterface)
+	RedeemTicket(*transfert.Redemption) (*entities.Ticket, errors.ErrorInterface)
+	VoidTicket(*transfert.Void) (*entities.Ticket, errors.ErrorInterface)
+	ReissueTicket(*transfert.Void) (*entities.Ticket, errors.ErrorInterface)
+	ExportTickets(*transfert.TicketExport) (iter.Seq2[*entities.Ticket, errors.ErrorInterface], errors.ErrorInterface)
+	GetPrizeStocks(*transfert.PrizeStock) ([]*entities.PrizeStock, errors.ErrorInterface)
+	RestockPrize(*transfert.PrizeStock) (*entities.PrizeStock, errors.ErrorInterface)
+	TransferPrizeStock(*transfert.StockTransfer) ([]*entities.PrizeStock, errors.ErrorInterface)
+	OpenShift(*transfert.Shift) (*entities.Shift, errors.ErrorInterface)
+	CloseShift(*transfert.Shift) (*entities.Shift, errors.ErrorInterface)
+	GetShifts(*transfert.Shift) ([]*entities.Shift, errors.ErrorInterface)
+	GetShiftReport(*transfert.Shift) (*entities.Shift, errors.ErrorInterface)
+	SyncCaisse(*transfert.Sync) ([]*entities.SyncOperation, errors.ErrorInterface)
+}
+
+type CampaignService struct {
+	security security.PermissionInterface
+	repo     repositories.GameRepositoryInterface
+}
+
+func Campaign(security security.PermissionInterface, repo repositories.GameRepositoryInterface) *CampaignService {
+	return &CampaignService{security, repo}
+}
+
+type CampaignServiceInterface interface {
+	CreateCampaign(*transfert.Campaign) (*entities.Campaign, errors.ErrorInterface)
+	GetCampaign(*transfert.Campaign) (*entities.Campaign, errors.ErrorInterface)
+	GetCampaigns() ([]*entities.Campaign, errors.ErrorInterface)
+	UpdateCampaign(*transfert.Campaign) (*entities.Campaign, errors.ErrorInterface)
+	DeleteCampaign(*transfert.Campaign) errors.ErrorInterface
+}
+
+type PrizeService struct {
+	security security.PermissionInterface
+	repo     repositories.GameRepositoryInterface
+}
+
+func Prize(security security.PermissionInterface, repo repositories.GameRepositoryInterface) *PrizeService {
+	return &PrizeService{security, repo}
+}
+
+type PrizeServiceInterface interface {
+	CreatePrize(*transfert.Prize) (*entities.Prize, errors.ErrorInterface)
+	GetPrize(*transfert.Prize) (*entities.Prize, errors.ErrorInterface)
+	GetPrizes() ([]*entities.Prize, errors.ErrorInterface)
+	UpdatePrize(*transfert.Prize) (*entities.Prize, errors.ErrorInterface)
+	DeletePrize(*transfert.Prize) errors.ErrorInterface
+}
+
+type DrawService struct {
+	security security.PermissionInterface
+	repo     repositories.GameRepositoryInterface
+	repoUser userRepository.UserRepositoryInterface
+	mail     mail.ServiceInterface
+}
+
+func Draw(security security.PermissionInterface, repo repositories.GameRepositoryInterface, user userRepository.UserRepositoryInterface, mail mail.ServiceInterface) *DrawService {
+	return &DrawService{security, repo, user, mail}
+}
+
+type DrawServiceInterface interface {
+	CreateDraw(*transfert.Draw) (*entities.Draw, errors.ErrorInterface)
+	RunDraw(*transfert.Draw) (*entities.Draw, errors.ErrorInterface)
+	GetDraw(*transfert.Draw) (*entities.Draw, errors.ErrorInterface)
+	GetDraws() ([]*entities.Draw, errors.ErrorInterface)
+}
+
+type StatisticsService struct {
+	security security.PermissionInterface
+	repo     repositories.GameRepositoryInterface
+}
+
+func Statistics(security security.PermissionInterface, repo repositories.GameRepositoryInterface) *StatisticsService {
+	return &StatisticsService{security, repo}
+}
+
+type StatisticsServiceInterface interface {
+	GetSeries(*transfert.Statistics) ([]*entities.Statistic, errors.ErrorInterface)
+	GetBreakdown(*transfert.Statistics) ([]*entities.Statistic, errors.ErrorInterface)
 }
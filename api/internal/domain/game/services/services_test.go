@@ -1,12 +1,21 @@
 package services_test
 
 import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kodmain/thetiptop/api/internal/application/security"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -55,6 +64,16 @@ func (m *GameRepositoryMock) ReadTickets(obj *transfert.Ticket, options ...datab
 	return args.Get(0).([]*entities.Ticket), nil
 }
 
+// PaginateTickets simule la lecture d'une page de tickets.
+func (m *GameRepositoryMock) PaginateTickets(obj *transfert.Ticket, list *database.List, options ...database.Option) (*database.Page[*entities.Ticket], errors.ErrorInterface) {
+	args := m.Called(obj, list, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*database.Page[*entities.Ticket]), nil
+}
+
 // UpdateTicket simule la mise à jour d'un ticket.
 func (m *GameRepositoryMock) UpdateTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
 	args := m.Called(entity, options)
@@ -65,6 +84,46 @@ func (m *GameRepositoryMock) UpdateTicket(entity *entities.Ticket, options ...da
 	return args.Error(0).(errors.ErrorInterface)
 }
 
+// ClaimTicket simule la réclamation conditionnelle d'un ticket.
+func (m *GameRepositoryMock) ClaimTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// RedeemTicket simule la remise conditionnelle d'un ticket.
+func (m *GameRepositoryMock) RedeemTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReserveTicket simule l'enregistrement de la boutique qui réserve le lot d'un ticket.
+func (m *GameRepositoryMock) ReserveTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ExpireTicket simule l'expiration conditionnelle d'un ticket.
+func (m *GameRepositoryMock) ExpireTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
 // DeleteTicket simule la suppression d'un ticket.
 func (m *GameRepositoryMock) DeleteTicket(obj *transfert.Ticket, options ...database.Option) errors.ErrorInterface {
 	args := m.Called(obj, options)
@@ -85,60 +144,1028 @@ func (m *GameRepositoryMock) CountTicket(obj *transfert.Ticket, options ...datab
 	return args.Int(0), nil
 }
 
-// PermissionMock est le mock pour PermissionInterface
-type PermissionMock struct {
-	mock.Mock
+// IssueTicket simule la remise d'un ticket en caisse.
+func (m *GameRepositoryMock) IssueTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
 }
 
-func (m *PermissionMock) IsAuthenticated() bool {
-	args := m.Called()
-	return args.Bool(0)
+// VoidTicket simule l'annulation d'un ticket.
+func (m *GameRepositoryMock) VoidTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
 }
 
-func (m *PermissionMock) IsGrantedByRoles(roles ...security.Role) bool {
-	args := m.Called(roles)
-	return args.Bool(0)
+// ReissueTicket simule l'annulation d'un ticket et la création de son remplaçant.
+func (m *GameRepositoryMock) ReissueTicket(voided, replacement *entities.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(voided, replacement, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
 }
 
-func (m *PermissionMock) IsGrantedByRules(roles ...security.Rule) bool {
-	args := m.Called(roles)
-	return args.Bool(0)
+// Transaction simule une transaction en exécutant les opérations sur le mock lui-même.
+func (m *GameRepositoryMock) Transaction(fn func(repo repositories.GameRepositoryInterface) errors.ErrorInterface) errors.ErrorInterface {
+	return fn(m)
 }
 
-func (m *PermissionMock) CanRead(ressource database.Entity, rules ...security.Rule) bool {
-	args := m.Called(ressource)
-	return args.Bool(0)
+// AssignTicketsToStore simule l'attribution de tickets à une boutique.
+func (m *GameRepositoryMock) AssignTicketsToStore(ids []string, storeID string, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(ids, storeID, options)
+	if args.Get(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
 }
 
-func (m *PermissionMock) CanCreate(ressource database.Entity, rules ...security.Rule) bool {
-	args := m.Called(ressource)
-	return args.Bool(0)
+// AggregateTickets simule le comptage des tickets par groupe.
+func (m *GameRepositoryMock) AggregateTickets(obj *transfert.Ticket, options ...database.Option) ([]*entities.Aggregate, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Aggregate), nil
 }
 
-func (m *PermissionMock) CanUpdate(ressource database.Entity, rules ...security.Rule) bool {
-	args := m.Called(ressource)
-	return args.Bool(0)
+// CreateTicketEvent simule l'ajout d'un événement à l'historique d'un ticket.
+func (m *GameRepositoryMock) CreateTicketEvent(obj *transfert.TicketEvent, options ...database.Option) (*entities.TicketEvent, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.TicketEvent), nil
 }
 
-func (m *PermissionMock) CanDelete(ressource database.Entity, rules ...security.Rule) bool {
-	args := m.Called(ressource)
-	return args.Bool(0)
+// ReadTicketEvents simule la lecture de l'historique d'un ticket.
+func (m *GameRepositoryMock) ReadTicketEvents(obj *transfert.TicketEvent, options ...database.Option) ([]*entities.TicketEvent, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketEvent), nil
 }
 
-func (m *PermissionMock) GetCredentialID() *string {
-	args := m.Called()
+// CreateClaimAttempt simule l'enregistrement d'une tentative de réclamation.
+func (m *GameRepositoryMock) CreateClaimAttempt(obj *transfert.ClaimAttempt, options ...database.Option) (*entities.ClaimAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.ClaimAttempt), nil
+}
+
+// ReadClaimAttempts simule la lecture des tentatives de réclamation.
+func (m *GameRepositoryMock) ReadClaimAttempts(obj *transfert.ClaimAttempt, options ...database.Option) ([]*entities.ClaimAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.ClaimAttempt), nil
+}
+
+// CreateDraw simule la création d'un tirage.
+func (m *GameRepositoryMock) CreateDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// ReadDraw simule la lecture d'un tirage.
+func (m *GameRepositoryMock) ReadDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// ReadDraws simule la lecture de plusieurs tirages.
+func (m *GameRepositoryMock) ReadDraws(obj *transfert.Draw, options ...database.Option) ([]*entities.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Draw), nil
+}
+
+// UpdateDraw simule la mise à jour d'un tirage.
+func (m *GameRepositoryMock) UpdateDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
 	if args.Get(0) == nil {
 		return nil
 	}
 
-	return args.Get(0).(*string)
+	return args.Error(0).(errors.ErrorInterface)
 }
 
-func setup() (*services.GameService, *GameRepositoryMock, *PermissionMock) {
+// CompleteDraw simule l'enregistrement du résultat d'un tirage.
+func (m *GameRepositoryMock) CompleteDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketHolders simule la lecture des détenteurs de tickets.
+func (m *GameRepositoryMock) ReadTicketHolders(options ...database.Option) ([]string, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]string), nil
+}
+
+// CreateShift simule l'ouverture d'un service de caisse.
+func (m *GameRepositoryMock) CreateShift(obj *transfert.Shift, options ...database.Option) (*entities.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Shift), nil
+}
+
+// ReadShift simule la lecture d'un service de caisse.
+func (m *GameRepositoryMock) ReadShift(obj *transfert.Shift, options ...database.Option) (*entities.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Shift), nil
+}
+
+// ReadShifts simule la lecture de plusieurs services de caisse.
+func (m *GameRepositoryMock) ReadShifts(obj *transfert.Shift, options ...database.Option) ([]*entities.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Shift), nil
+}
+
+// UpdateShift simule la mise à jour d'un service de caisse.
+func (m *GameRepositoryMock) UpdateShift(entity *entities.Shift, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CloseShift simule la clôture conditionnelle d'un service de caisse.
+func (m *GameRepositoryMock) CloseShift(entity *entities.Shift, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CreateSyncOperation simule l'enregistrement d'une opération synchronisée par une caisse.
+func (m *GameRepositoryMock) CreateSyncOperation(obj *transfert.SyncOperation, options ...database.Option) (*entities.SyncOperation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.SyncOperation), nil
+}
+
+// UpdateSyncOperation simule l'enregistrement du résultat d'une opération synchronisée.
+func (m *GameRepositoryMock) UpdateSyncOperation(entity *entities.SyncOperation, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadSyncOperation simule la lecture d'une opération synchronisée par une caisse.
+func (m *GameRepositoryMock) ReadSyncOperation(obj *transfert.SyncOperation, options ...database.Option) (*entities.SyncOperation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.SyncOperation), nil
+}
+
+// ReadPrizeStock simule la lecture du stock d'un lot dans une boutique.
+func (m *GameRepositoryMock) ReadPrizeStock(obj *transfert.PrizeStock, options ...database.Option) (*entities.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.PrizeStock), nil
+}
+
+// ReadPrizeStocks simule la lecture des stocks des lots.
+func (m *GameRepositoryMock) ReadPrizeStocks(obj *transfert.PrizeStock, options ...database.Option) ([]*entities.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.PrizeStock), nil
+}
+
+// RestockPrize simule le réapprovisionnement d'un lot dans une boutique.
+func (m *GameRepositoryMock) RestockPrize(obj *transfert.PrizeStock, options ...database.Option) (*entities.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.PrizeStock), nil
+}
+
+// ReservePrizeStock simule la réservation d'une unité du stock.
+func (m *GameRepositoryMock) ReservePrizeStock(entity *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReleasePrizeStock simule la libération d'une unité réservée.
+func (m *GameRepositoryMock) ReleasePrizeStock(entity *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ConsumePrizeStock simule la sortie du stock d'un lot remis.
+func (m *GameRepositoryMock) ConsumePrizeStock(entity, reserved *entities.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, reserved, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// TransferPrizeStock simule le transfert de stock entre deux boutiques.
+func (m *GameRepositoryMock) TransferPrizeStock(obj *transfert.StockTransfer, options ...database.Option) (*entities.PrizeStock, *entities.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.PrizeStock), args.Get(1).(*entities.PrizeStock), nil
+}
+
+// MarkPrizeStockAlerted simule l'enregistrement de l'alerte de stock bas.
+func (m *GameRepositoryMock) MarkPrizeStockAlerted(entity *entities.PrizeStock, options ...database.Option) (bool, errors.ErrorInterface) {
+	args := m.Called(entity, options)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+
+	return args.Bool(0), args.Error(1).(errors.ErrorInterface)
+}
+
+// CreateCampaign simule la création d'une campagne.
+func (m *GameRepositoryMock) CreateCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// ReadCampaign simule la lecture d'une campagne.
+func (m *GameRepositoryMock) ReadCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// ReadCampaigns simule la lecture de plusieurs campagnes.
+func (m *GameRepositoryMock) ReadCampaigns(obj *transfert.Campaign, options ...database.Option) ([]*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Campaign), nil
+}
+
+// UpdateCampaign simule la mise à jour d'une campagne.
+func (m *GameRepositoryMock) UpdateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeleteCampaign simule la suppression d'une campagne.
+func (m *GameRepositoryMock) DeleteCampaign(obj *transfert.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// AttachTicketsToCampaign simule le rattachement des tickets à une campagne.
+func (m *GameRepositoryMock) AttachTicketsToCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CreatePrize simule la création d'un lot.
+func (m *GameRepositoryMock) CreatePrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// ReadPrize simule la lecture d'un lot.
+func (m *GameRepositoryMock) ReadPrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// ReadPrizes simule la lecture de plusieurs lots.
+func (m *GameRepositoryMock) ReadPrizes(obj *transfert.Prize, options ...database.Option) ([]*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Prize), nil
+}
+
+// UpdatePrize simule la mise à jour d'un lot.
+func (m *GameRepositoryMock) UpdatePrize(entity *entities.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeletePrize simule la suppression d'un lot.
+func (m *GameRepositoryMock) DeletePrize(obj *transfert.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// StoreRepositoryMock est le mock pour StoreRepositoryInterface
+type StoreRepositoryMock struct {
+	mock.Mock
+}
+
+// CreateStores simule la création de boutiques.
+func (m *StoreRepositoryMock) CreateStores(objs []*storeTransfert.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(objs, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// ReadStores simule la lecture de boutiques.
+func (m *StoreRepositoryMock) ReadStores(obj *storeTransfert.Store, options ...database.Option) ([]*storeEntity.Store, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*storeEntity.Store), nil
+}
+
+// PaginateStores simule la lecture d'une page de boutiques.
+func (m *StoreRepositoryMock) PaginateStores(obj *storeTransfert.Store, list *database.List, options ...database.Option) (*database.Page[*storeEntity.Store], errors.ErrorInterface) {
+	args := m.Called(obj, list, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*database.Page[*storeEntity.Store]), nil
+}
+
+// ReadStore simule la lecture d'une boutique.
+func (m *StoreRepositoryMock) ReadStore(obj *storeTransfert.Store, options ...database.Option) (*storeEntity.Store, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.Store), nil
+}
+
+// DeleteStores simule la suppression de boutiques.
+func (m *StoreRepositoryMock) DeleteStores(objs []*storeTransfert.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(objs, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// UpdateStores simule la mise à jour de boutiques.
+func (m *StoreRepositoryMock) UpdateStores(objs []*storeEntity.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(objs, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreateCaisse simule la création d'une caisse.
+func (m *StoreRepositoryMock) CreateCaisse(obj *storeTransfert.Caisse, options ...database.Option) (*storeEntity.Caisse, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.Caisse), nil
+}
+
+// ReadCaisse simule la lecture d'une caisse.
+func (m *StoreRepositoryMock) ReadCaisse(obj *storeTransfert.Caisse, options ...database.Option) (*storeEntity.Caisse, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.Caisse), nil
+}
+
+// ReadCaisses simule la lecture de caisses.
+func (m *StoreRepositoryMock) ReadCaisses(obj *storeTransfert.Caisse, options ...database.Option) ([]*storeEntity.Caisse, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*storeEntity.Caisse), nil
+}
+
+// DeleteCaisse simule la suppression d'une caisse.
+func (m *StoreRepositoryMock) DeleteCaisse(obj *storeTransfert.Caisse, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// UpdateCaisse simule la mise à jour d'une caisse.
+func (m *StoreRepositoryMock) UpdateCaisse(obj *storeEntity.Caisse, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreateStore simule la création d'une boutique.
+func (m *StoreRepositoryMock) CreateStore(obj *storeTransfert.Store, options ...database.Option) (*storeEntity.Store, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.Store), nil
+}
+
+// ReplaceStoreHours simule le remplacement des horaires d'une boutique.
+func (m *StoreRepositoryMock) ReplaceStoreHours(obj *storeEntity.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreateMembership simule le rattachement d'un employé à une boutique.
+func (m *StoreRepositoryMock) CreateMembership(obj *storeTransfert.Membership, options ...database.Option) (*storeEntity.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.Membership), nil
+}
+
+// ReadMembership simule la lecture du rattachement d'un employé à une boutique.
+func (m *StoreRepositoryMock) ReadMembership(obj *storeTransfert.Membership, options ...database.Option) (*storeEntity.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.Membership), nil
+}
+
+// ReadMemberships simule la lecture des rattachements.
+func (m *StoreRepositoryMock) ReadMemberships(obj *storeTransfert.Membership, options ...database.Option) ([]*storeEntity.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*storeEntity.Membership), nil
+}
+
+// DeleteMembership simule la suppression du rattachement d'un employé à une boutique.
+func (m *StoreRepositoryMock) DeleteMembership(obj *storeTransfert.Membership, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreateDevice simule l'enregistrement d'un appareil de caisse.
+func (m *StoreRepositoryMock) CreateDevice(obj *storeTransfert.Device, options ...database.Option) (*storeEntity.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.Device), nil
+}
+
+// ReadDevice simule la lecture d'un appareil de caisse.
+func (m *StoreRepositoryMock) ReadDevice(obj *storeTransfert.Device, options ...database.Option) (*storeEntity.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.Device), nil
+}
+
+// ReadDevices simule la lecture des appareils de caisse.
+func (m *StoreRepositoryMock) ReadDevices(obj *storeTransfert.Device, options ...database.Option) ([]*storeEntity.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*storeEntity.Device), nil
+}
+
+// UpdateDevice simule la mise à jour d'un appareil de caisse.
+func (m *StoreRepositoryMock) UpdateDevice(obj *storeEntity.Device, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// PairDevice simule l'appairage conditionnel d'un appareil de caisse.
+func (m *StoreRepositoryMock) PairDevice(obj *storeEntity.Device, now time.Time, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, now, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreatePairingAttempt simule l'enregistrement d'un appairage échoué.
+func (m *StoreRepositoryMock) CreatePairingAttempt(obj *storeTransfert.PairingAttempt, options ...database.Option) (*storeEntity.PairingAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*storeEntity.PairingAttempt), nil
+}
+
+// ReadPairingAttempts simule la lecture des appairages échoués.
+func (m *StoreRepositoryMock) ReadPairingAttempts(obj *storeTransfert.PairingAttempt, options ...database.Option) ([]*storeEntity.PairingAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*storeEntity.PairingAttempt), nil
+}
+
+// UpdateStore simule la mise à jour d'une boutique.
+func (m *StoreRepositoryMock) UpdateStore(obj *storeEntity.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// DeleteStore simule la suppression d'une boutique.
+func (m *StoreRepositoryMock) DeleteStore(obj *storeTransfert.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// PermissionMock est le mock pour PermissionInterface
+type PermissionMock struct {
+	mock.Mock
+}
+
+func (m *PermissionMock) IsAuthenticated() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *PermissionMock) IsGrantedByRoles(roles ...security.Role) bool {
+	args := m.Called(roles)
+	return args.Bool(0)
+}
+
+func (m *PermissionMock) IsGrantedByRules(roles ...security.Rule) bool {
+	args := m.Called(roles)
+	return args.Bool(0)
+}
+
+func (m *PermissionMock) CanRead(ressource database.Entity, rules ...security.Rule) bool {
+	args := m.Called(ressource)
+	return args.Bool(0)
+}
+
+func (m *PermissionMock) CanCreate(ressource database.Entity, rules ...security.Rule) bool {
+	args := m.Called(ressource)
+	return args.Bool(0)
+}
+
+func (m *PermissionMock) CanUpdate(ressource database.Entity, rules ...security.Rule) bool {
+	args := m.Called(ressource)
+	return args.Bool(0)
+}
+
+func (m *PermissionMock) CanDelete(ressource database.Entity, rules ...security.Rule) bool {
+	args := m.Called(ressource)
+	return args.Bool(0)
+}
+
+func (m *PermissionMock) GetCredentialID() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
+func (m *PermissionMock) GetRole() security.Role {
+	args := m.Called()
+	return args.Get(0).(security.Role)
+}
+
+func (m *PermissionMock) GetIP() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
+func (m *PermissionMock) GetUserAgent() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
+// UserRepositoryMock est le mock pour UserRepositoryInterface
+type UserRepositoryMock struct {
+	mock.Mock
+}
+
+// ReadUser simule la lecture d'un utilisateur.
+func (m *UserRepositoryMock) ReadUser(obj *userTransfert.User, options ...database.Option) (*user.Client, *user.Employee, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(2) != nil {
+		return nil, nil, args.Error(2).(errors.ErrorInterface)
+	}
+	client, _ := args.Get(0).(*user.Client)
+	employee, _ := args.Get(1).(*user.Employee)
+	return client, employee, nil
+}
+
+// CreateClient simule la création d'un client.
+func (m *UserRepositoryMock) CreateClient(obj *userTransfert.Client, options ...database.Option) (*user.Client, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*user.Client), nil
+}
+
+// ReadClient simule la lecture d'un client.
+func (m *UserRepositoryMock) ReadClient(obj *userTransfert.Client, options ...database.Option) (*user.Client, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*user.Client), nil
+}
+
+// ReadClientCredentialIDs simule le filtrage des credentials des clients.
+func (m *UserRepositoryMock) ReadClientCredentialIDs(ids []string, options ...database.Option) ([]string, errors.ErrorInterface) {
+	args := m.Called(ids, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]string), nil
+}
+
+// UpdateClient simule la mise à jour d'un client.
+func (m *UserRepositoryMock) UpdateClient(entity *user.Client, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// DeleteClient simule la suppression d'un client.
+func (m *UserRepositoryMock) DeleteClient(obj *userTransfert.Client, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreateEmployee simule la création d'un employé.
+func (m *UserRepositoryMock) CreateEmployee(obj *userTransfert.Employee, options ...database.Option) (*user.Employee, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*user.Employee), nil
+}
+
+// ReadEmployee simule la lecture d'un employé.
+func (m *UserRepositoryMock) ReadEmployee(obj *userTransfert.Employee, options ...database.Option) (*user.Employee, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*user.Employee), nil
+}
+
+// UpdateEmployee simule la mise à jour d'un employé.
+func (m *UserRepositoryMock) UpdateEmployee(entity *user.Employee, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// DeleteEmployee simule la suppression d'un employé.
+func (m *UserRepositoryMock) DeleteEmployee(obj *userTransfert.Employee, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreateValidation simule la création d'une validation.
+func (m *UserRepositoryMock) CreateValidation(obj *userTransfert.Validation, options ...database.Option) (*user.Validation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*user.Validation), nil
+}
+
+// ReadValidation simule la lecture d'une validation.
+func (m *UserRepositoryMock) ReadValidation(obj *userTransfert.Validation, options ...database.Option) (*user.Validation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*user.Validation), nil
+}
+
+// ReadValidations simule la lecture de validations.
+func (m *UserRepositoryMock) ReadValidations(obj *userTransfert.Validation, options ...database.Option) ([]*user.Validation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*user.Validation), nil
+}
+
+// UpdateValidation simule la mise à jour d'une validation.
+func (m *UserRepositoryMock) UpdateValidation(entity *user.Validation, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// DeleteValidation simule la suppression d'une validation.
+func (m *UserRepositoryMock) DeleteValidation(obj *userTransfert.Validation, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreateCredential simule la création d'un identifiant.
+func (m *UserRepositoryMock) CreateCredential(obj *userTransfert.Credential, options ...database.Option) (*user.Credential, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*user.Credential), nil
+}
+
+// ReadCredential simule la lecture d'un identifiant.
+func (m *UserRepositoryMock) ReadCredential(obj *userTransfert.Credential, options ...database.Option) (*user.Credential, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*user.Credential), nil
+}
+
+// PaginateCredentials simule la lecture d'une page d'identifiants.
+func (m *UserRepositoryMock) PaginateCredentials(obj *userTransfert.Credential, list *database.List, options ...database.Option) (*database.Page[*user.Credential], errors.ErrorInterface) {
+	args := m.Called(obj, list, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*database.Page[*user.Credential]), nil
+}
+
+// UpdateCredential simule la mise à jour d'un identifiant.
+func (m *UserRepositoryMock) UpdateCredential(entity *user.Credential, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// DeleteCredential simule la suppression d'un identifiant.
+func (m *UserRepositoryMock) DeleteCredential(obj *userTransfert.Credential, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// MailServiceMock est le mock pour mail.ServiceInterface
+type MailServiceMock struct {
+	mock.Mock
+}
+
+func (m *MailServiceMock) Send(mail *mail.Mail) error {
+	args := m.Called(mail)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0)
+}
+
+func (m *MailServiceMock) From() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MailServiceMock) Expeditor() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func setup() (*services.GameService, *GameRepositoryMock, *PermissionMock) {
+	service, mockRepository, mockSecurity, _ := setupStores()
+
+	return service, mockRepository, mockSecurity
+}
+
+func setupStores() (*services.GameService, *GameRepositoryMock, *PermissionMock, *StoreRepositoryMock) {
+	service, mockRepository, mockSecurity, mockStores, _, _ := setupMail()
+
+	return service, mockRepository, mockSecurity, mockStores
+}
+
+func setupMail() (*services.GameService, *GameRepositoryMock, *PermissionMock, *StoreRepositoryMock, *UserRepositoryMock, *MailServiceMock) {
+	mockRepository := new(GameRepositoryMock)
+	mockSecurity := new(PermissionMock)
+	mockStores := new(StoreRepositoryMock)
+	mockUsers := new(UserRepositoryMock)
+	mockMailer := new(MailServiceMock)
+
+	service := services.Game(mockSecurity, mockRepository, mockStores, mockUsers, mockMailer)
+
+	// L'historique des tickets est écrit à chaque action, les tests qui le vérifient utilisent AssertCalled
+	mockSecurity.On("GetRole").Return(user.ROLE_EMPLOYEE).Maybe()
+	mockSecurity.On("GetIP").Return(aws.String("203.0.113.7")).Maybe()
+	mockSecurity.On("GetUserAgent").Return(aws.String("curl/8.0")).Maybe()
+	mockRepository.On("CreateTicketEvent", mock.Anything, mock.Anything).Return(&entities.TicketEvent{}, nil).Maybe()
+
+	// Les joueurs sans email ne reçoivent pas de bon, les tests du mail déclarent leurs propres attentes
+	mockUsers.On("ReadCredential", mock.Anything, mock.Anything).Return(&user.Credential{}, nil).Maybe()
+	mockMailer.On("Send", mock.Anything).Return(nil).Maybe()
+
+	return service, mockRepository, mockSecurity, mockStores, mockUsers, mockMailer
+}
+
+func setupDraw() (*services.DrawService, *GameRepositoryMock, *PermissionMock, *MailServiceMock) {
+	service, mockRepository, mockSecurity, _, mockMailer := setupDrawUsers()
+
+	return service, mockRepository, mockSecurity, mockMailer
+}
+
+func setupDrawUsers() (*services.DrawService, *GameRepositoryMock, *PermissionMock, *UserRepositoryMock, *MailServiceMock) {
+	mockRepository := new(GameRepositoryMock)
+	mockSecurity := new(PermissionMock)
+	mockUsers := new(UserRepositoryMock)
+	mockMailer := new(MailServiceMock)
+
+	service := services.Draw(mockSecurity, mockRepository, mockUsers, mockMailer)
+
+	return service, mockRepository, mockSecurity, mockUsers, mockMailer
+}
+
+func setupCampaign() (*services.CampaignService, *GameRepositoryMock, *PermissionMock) {
+	mockRepository := new(GameRepositoryMock)
+	mockSecurity := new(PermissionMock)
+
+	service := services.Campaign(mockSecurity, mockRepository)
+
+	return service, mockRepository, mockSecurity
+}
+
+func setupPrize() (*services.PrizeService, *GameRepositoryMock, *PermissionMock) {
+	mockRepository := new(GameRepositoryMock)
+	mockSecurity := new(PermissionMock)
+
+	service := services.Prize(mockSecurity, mockRepository)
+
+	return service, mockRepository, mockSecurity
+}
+
+func setupStatistics() (*services.StatisticsService, *GameRepositoryMock, *PermissionMock) {
 	mockRepository := new(GameRepositoryMock)
 	mockSecurity := new(PermissionMock)
 
-	service := services.Game(mockSecurity, mockRepository)
+	service := services.Statistics(mockSecurity, mockRepository)
+	services.StatisticsCache.Flush()
 
 	return service, mockRepository, mockSecurity
 }
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	storeServices "github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// openShift matches the shifts which are not closed yet
+const openShift = "closed_at IS NULL"
+
+// OpenShift starts a shift of the current employee on a caisse
+// A caisse runs a single shift at a time, the previous one must be closed first.
+//
+// Parameters:
+// - dto: *transfert.Shift the caisse
+//
+// Returns:
+// - *entities.Shift: the opened shift
+// - errors.ErrorInterface: an error if the caisse is unknown or already runs a shift
+func (s *GameService) OpenShift(dto *transfert.Shift) (*entities.Shift, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	caisse, err := s.caisseOf(dto.CaisseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.ReadShift(&transfert.Shift{CaisseID: &caisse.ID}, database.Where(openShift)); err == nil {
+		return nil, errors_domain_game.ErrShiftAlreadyOpen
+	} else if err != errors_domain_game.ErrShiftNotFound {
+		return nil, err
+	}
+
+	return s.repo.CreateShift(&transfert.Shift{
+		CaisseID: &caisse.ID,
+		StoreID:  caisse.StoreID,
+		OpenedBy: s.security.GetCredentialID(),
+	})
+}
+
+// CloseShift ends a shift and freezes its Z-report
+// The report counts the tickets issued and redeemed during the shift, per prize, with the voids and the anomalies.
+// Employees only close the shifts of the caisses of the stores they are assigned to, a shift is closed once.
+//
+// Parameters:
+// - dto: *transfert.Shift the shift
+//
+// Returns:
+// - *entities.Shift: the closed shift with its Z-report
+// - errors.ErrorInterface: an error if the shift is unknown, out of reach of the user or already closed
+func (s *GameService) CloseShift(dto *transfert.Shift) (*entities.Shift, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	shift, err := s.repo.ReadShift(&transfert.Shift{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.caisseOf(shift.CaisseID); err != nil {
+		return nil, err
+	}
+
+	if !shift.IsOpen() {
+		return nil, errors_domain_game.ErrShiftClosed
+	}
+
+	issued, err := s.repo.ReadTickets(&transfert.Ticket{}, database.Where("shift_id = ?", shift.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	redeemed, err := s.repo.ReadTickets(&transfert.Ticket{}, database.Where("redeemed_shift_id = ?", shift.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	shift.Close(s.security.GetCredentialID(), issued, redeemed)
+
+	if err := s.repo.CloseShift(shift); err != nil {
+		return nil, err
+	}
+
+	return shift, nil
+}
+
+// GetShifts lists the shifts of a store or a caisse, the latest first
+// Admins read every shift, employees only the shifts of the stores they are assigned to.
+//
+// Parameters:
+// - dto: *transfert.Shift the store and the caisse to filter on, both optional
+//
+// Returns:
+// - []*entities.Shift: the shifts without their Z-report details
+// - errors.ErrorInterface: an error if the shifts cannot be read
+func (s *GameService) GetShifts(dto *transfert.Shift) ([]*entities.Shift, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	options := []database.Option{database.Order("opened_at DESC")}
+
+	// Employees only see the shifts of the stores they are assigned to
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		memberships, err := s.repoStore.ReadMemberships(&storeTransfert.Membership{CredentialID: s.security.GetCredentialID()})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, len(memberships))
+		for i, membership := range memberships {
+			ids[i] = *membership.StoreID
+		}
+
+		options = append(options, database.Where("store_id IN ?", ids))
+	}
+
+	return s.repo.ReadShifts(dto, options...)
+}
+
+// GetShiftReport returns the Z-report of a closed shift
+// Admins read every report, employees only the reports of the stores they are assigned to.
+//
+// Parameters:
+// - dto: *transfert.Shift the shift
+//
+// Returns:
+// - *entities.Shift: the shift with the lines and the anomalies of its Z-report
+// - errors.ErrorInterface: an error if the shift is unknown, out of reach of the user or still open
+func (s *GameService) GetShiftReport(dto *transfert.Shift) (*entities.Shift, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	shift, err := s.repo.ReadShift(&transfert.Shift{ID: dto.ID}, database.Preload("Lines"), database.Preload("Anomalies"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.isMember(shift.StoreID); err != nil {
+		return nil, err
+	}
+
+	if shift.IsOpen() {
+		return nil, errors_domain_game.ErrShiftNotClosed
+	}
+
+	return shift, nil
+}
+
+// shiftOf returns the shift running on a caisse, tickets handled at a caisse without shift are not attributed
+//
+// Parameters:
+// - caisseID: *string the caisse
+//
+// Returns:
+// - *string: the ID of the open shift, nil if there is none
+// - errors.ErrorInterface: an error if the shifts cannot be read
+func (s *GameService) shiftOf(caisseID *string) (*string, errors.ErrorInterface) {
+	if caisseID == nil {
+		return nil, nil
+	}
+
+	shift, err := s.repo.ReadShift(&transfert.Shift{CaisseID: caisseID}, database.Where(openShift))
+	if err == errors_domain_game.ErrShiftNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &shift.ID, nil
+}
+
+// caisseOf returns a caisse the current employee or device may operate
+// Employees only operate the caisses of the stores they are assigned to, devices only the caisse they are paired to,
+// admins operate every caisse.
+//
+// Parameters:
+// - caisseID: *string the caisse
+//
+// Returns:
+// - *storeEntity.Caisse: the caisse
+// - errors.ErrorInterface: an error if the caisse is unknown, without store or out of reach of the user
+func (s *GameService) caisseOf(caisseID *string) (*storeEntity.Caisse, errors.ErrorInterface) {
+	caisse, err := s.repoStore.ReadCaisse(&storeTransfert.Caisse{ID: caisseID})
+	if err != nil {
+		return nil, err
+	}
+
+	if caisse.StoreID == nil {
+		return nil, errors_domain_store.ErrStoreNotFound
+	}
+
+	if !s.security.IsGrantedByRules(storeServices.MemberOf(s.repoStore, caisse.StoreID), storeServices.PairedTo(s.repoStore, &caisse.ID)) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return caisse, nil
+}
+
+// isMember checks the current user may operate the store, admins operate every store
+//
+// Parameters:
+// - storeID: *string the store
+//
+// Returns:
+// - errors.ErrorInterface: ErrUnauthorized if the employee is not assigned to the store
+func (s *GameService) isMember(storeID *string) errors.ErrorInterface {
+	if !s.security.IsGrantedByRules(storeServices.MemberOf(s.repoStore, storeID)) {
+		return errors.ErrUnauthorized
+	}
+
+	return nil
+}
@@ -0,0 +1,356 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var shiftRoles = []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}
+
+func Test_OpenShift(t *testing.T) {
+	eid := aws.String("employee-123")
+	dto := &transfert.Shift{CaisseID: aws.String("caisse-123")}
+	caisse := &storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-123")}
+
+	t.Run("Should open a shift on the caisse", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: dto.CaisseID}, mock.Anything).Return(caisse, nil)
+		mockRepo.On("ReadShift", &transfert.Shift{CaisseID: &caisse.ID}, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound)
+		mockRepo.On("CreateShift", &transfert.Shift{CaisseID: &caisse.ID, StoreID: caisse.StoreID, OpenedBy: eid}, mock.Anything).Return(&entities.Shift{ID: "shift-123"}, nil)
+
+		shift, err := service.OpenShift(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "shift-123", shift.ID)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse a caisse already running a shift", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(&entities.Shift{ID: "shift-123"}, nil)
+
+		shift, err := service.OpenShift(dto)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors_domain_game.ErrShiftAlreadyOpen, err)
+
+		mockRepo.AssertNotCalled(t, "CreateShift", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a caisse without store", func(t *testing.T) {
+		service, _, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(&storeEntity.Caisse{ID: "caisse-123"}, nil)
+
+		shift, err := service.OpenShift(dto)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, err)
+	})
+
+	t.Run("Should refuse a caisse out of the stores of the employee", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+
+		shift, err := service.OpenShift(dto)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "CreateShift", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when the caisse is unknown", func(t *testing.T) {
+		service, _, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrCaisseNotFound)
+
+		shift, err := service.OpenShift(dto)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors_domain_store.ErrCaisseNotFound, err)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(false)
+
+		shift, err := service.OpenShift(dto)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should return error when dto is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		shift, err := service.OpenShift(nil)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func Test_CloseShift(t *testing.T) {
+	eid := aws.String("employee-123")
+	dto := &transfert.Shift{ID: aws.String("shift-123")}
+	caisse := &storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-123")}
+
+	t.Run("Should close the shift with its Z-report", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		shift := &entities.Shift{ID: "shift-123", CaisseID: &caisse.ID, OpenedBy: eid}
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadShift", &transfert.Shift{ID: dto.ID}, mock.Anything).Return(shift, nil)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: &caisse.ID}, mock.Anything).Return(caisse, nil)
+		mockRepo.On("ReadTickets", &transfert.Ticket{}, mock.Anything).Return([]*entities.Ticket{
+			{ID: "ticket-1", PrizeID: aws.String("prize-1"), IssuedBy: eid, ShiftID: &shift.ID},
+		}, nil).Once()
+		mockRepo.On("ReadTickets", &transfert.Ticket{}, mock.Anything).Return([]*entities.Ticket{}, nil).Once()
+		mockRepo.On("CloseShift", shift, mock.Anything).Return(nil)
+
+		result, err := service.CloseShift(dto)
+		assert.Nil(t, err)
+		assert.False(t, result.IsOpen())
+		assert.Equal(t, eid, result.ClosedBy)
+		assert.Equal(t, 1, result.Issued)
+		assert.Len(t, result.Lines, 1)
+		assert.Empty(t, result.Anomalies)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "UpdateShift", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a shift of a store the employee is not assigned to", func(t *testing.T) {
+		mockRepo := new(GameRepositoryMock)
+		mockStores := new(StoreRepositoryMock)
+		service := services.Game(&security.UserAccess{CredentialID: *eid, Role: user.ROLE_EMPLOYEE}, mockRepo, mockStores, nil, nil)
+
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(&entities.Shift{ID: "shift-123", CaisseID: &caisse.ID}, nil)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+		mockStores.On("ReadMembership", &storeTransfert.Membership{StoreID: caisse.StoreID, CredentialID: eid}, mock.Anything).Return(nil, errors_domain_store.ErrMembershipNotFound)
+		mockStores.On("ReadDevice", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrDeviceNotFound).Maybe()
+
+		result, err := service.CloseShift(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadTickets", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CloseShift", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a shift closed concurrently", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(&entities.Shift{ID: "shift-123", CaisseID: &caisse.ID}, nil)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{}, nil)
+		mockRepo.On("CloseShift", mock.Anything, mock.Anything).Return(errors_domain_game.ErrShiftClosed)
+
+		result, err := service.CloseShift(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrShiftClosed, err)
+	})
+
+	t.Run("Should refuse a shift already closed", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		now := time.Now()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(&entities.Shift{ID: "shift-123", CaisseID: &caisse.ID, ClosedAt: &now}, nil)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+
+		result, err := service.CloseShift(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrShiftClosed, err)
+
+		mockRepo.AssertNotCalled(t, "CloseShift", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when the shift is unknown", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound)
+
+		result, err := service.CloseShift(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrShiftNotFound, err)
+	})
+
+	t.Run("Should return error when the tickets cannot be read", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(&entities.Shift{ID: "shift-123", CaisseID: &caisse.ID}, nil)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		result, err := service.CloseShift(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(false)
+
+		result, err := service.CloseShift(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_GetShifts(t *testing.T) {
+	admin := []security.Role{security.ROLE_ADMIN}
+	dto := &transfert.Shift{StoreID: aws.String("store-123")}
+
+	t.Run("Should list the shifts of the store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRoles", admin).Return(true)
+		mockRepo.On("ReadShifts", dto, mock.Anything).Return([]*entities.Shift{{ID: "shift-2"}, {ID: "shift-1"}}, nil)
+
+		shifts, err := service.GetShifts(dto)
+		assert.Nil(t, err)
+		assert.Len(t, shifts, 2)
+	})
+
+	t.Run("Should only list the shifts of the stores of the employee", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		eid := aws.String("employee-123")
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRoles", admin).Return(false)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockStores.On("ReadMemberships", &storeTransfert.Membership{CredentialID: eid}, mock.Anything).Return([]*storeEntity.Membership{{StoreID: aws.String("store-123")}}, nil)
+		mockRepo.On("ReadShifts", dto, mock.MatchedBy(func(options []database.Option) bool {
+			return len(options) == 2
+		})).Return([]*entities.Shift{{ID: "shift-1"}}, nil)
+
+		shifts, err := service.GetShifts(dto)
+		assert.Nil(t, err)
+		assert.Len(t, shifts, 1)
+		mockStores.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the memberships cannot be read", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRoles", admin).Return(false)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-123"))
+		mockStores.On("ReadMemberships", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		shifts, err := service.GetShifts(dto)
+		assert.Nil(t, shifts)
+		assert.Equal(t, errors.ErrInternalServer, err)
+		mockRepo.AssertNotCalled(t, "ReadShifts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(false)
+
+		shifts, err := service.GetShifts(dto)
+		assert.Nil(t, shifts)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_GetShiftReport(t *testing.T) {
+	dto := &transfert.Shift{ID: aws.String("shift-123")}
+	now := time.Now()
+
+	t.Run("Should return the Z-report of a closed shift", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadShift", &transfert.Shift{ID: dto.ID}, mock.Anything).Return(&entities.Shift{ID: "shift-123", StoreID: aws.String("store-123"), ClosedAt: &now}, nil)
+
+		shift, err := service.GetShiftReport(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "shift-123", shift.ID)
+	})
+
+	t.Run("Should return the Z-report to an employee of the store", func(t *testing.T) {
+		mockRepo := new(GameRepositoryMock)
+		mockStores := new(StoreRepositoryMock)
+		service := services.Game(&security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}, mockRepo, mockStores, nil, nil)
+
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(&entities.Shift{ID: "shift-123", StoreID: aws.String("store-123"), ClosedAt: &now}, nil)
+		mockStores.On("ReadMembership", &storeTransfert.Membership{StoreID: aws.String("store-123"), CredentialID: aws.String("employee-123")}, mock.Anything).Return(&storeEntity.Membership{}, nil)
+
+		shift, err := service.GetShiftReport(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "shift-123", shift.ID)
+	})
+
+	t.Run("Should refuse an employee of another store", func(t *testing.T) {
+		mockRepo := new(GameRepositoryMock)
+		mockStores := new(StoreRepositoryMock)
+		service := services.Game(&security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}, mockRepo, mockStores, nil, nil)
+
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(&entities.Shift{ID: "shift-123", StoreID: aws.String("store-123"), ClosedAt: &now}, nil)
+		mockStores.On("ReadMembership", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrMembershipNotFound)
+
+		shift, err := service.GetShiftReport(dto)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should refuse a shift still open", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(&entities.Shift{ID: "shift-123"}, nil)
+
+		shift, err := service.GetShiftReport(dto)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors_domain_game.ErrShiftNotClosed, err)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", shiftRoles).Return(false)
+
+		shift, err := service.GetShiftReport(dto)
+		assert.Nil(t, shift)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
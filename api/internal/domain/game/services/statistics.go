@@ -0,0 +1,257 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data/cache"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+const (
+	// StatisticsTTL is how long computed statistics are served before being counted again
+	StatisticsTTL = time.Minute
+	// StatisticsMaxDays is the longest period of a time series
+	StatisticsMaxDays = 366
+)
+
+// StatisticsCache keeps the statistics computed recently, it is shared by all the requests
+var StatisticsCache = cache.New[[]*entities.Statistic](StatisticsTTL)
+
+// statisticsMetrics are the dates of the lifecycle counted by the statistics, in the order of entities.Statistic
+var statisticsMetrics = []string{"issued_at", "claimed_at", "redeemed_at"}
+
+// statisticsBuckets group the tickets for each dimension, from the date of the metric
+// Days are UTC days, like the bounds of the period.
+var statisticsBuckets = map[string]func(column string) database.Option{
+	entities.StatisticsByDay:   func(column string) database.Option { return database.GroupByDay(column, "bucket") },
+	entities.StatisticsByPrize: func(string) database.Option { return database.GroupByAs("COALESCE(prize_id, '')", "bucket") },
+	entities.StatisticsByStore: func(string) database.Option { return database.GroupByAs("COALESCE(store_id, '')", "bucket") },
+}
+
+// GetSeries counts the tickets issued, claimed and redeemed per UTC day
+// The days without any ticket are part of the series, with zero counts.
+//
+// Parameters:
+// - dto: *transfert.Statistics the period and the filters
+//
+// Returns:
+// - []*entities.Statistic: one statistic per day, in chronological order
+// - errors.ErrorInterface: an error if the statistics cannot be computed
+func (s *StatisticsService) GetSeries(dto *transfert.Statistics) ([]*entities.Statistic, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	series, err := s.statistics(entities.StatisticsByDay, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	return fillSeries(series, dto), nil
+}
+
+// GetBreakdown counts the tickets issued, claimed and redeemed per prize, per store or per day
+//
+// Parameters:
+// - dto: *transfert.Statistics the dimension, the period and the filters
+//
+// Returns:
+// - []*entities.Statistic: one statistic per value of the dimension
+// - errors.ErrorInterface: an error if the statistics cannot be computed
+func (s *StatisticsService) GetBreakdown(dto *transfert.Statistics) ([]*entities.Statistic, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if dto.By == nil || statisticsBuckets[*dto.By] == nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	return s.statistics(*dto.By, dto)
+}
+
+// statistics counts each metric by the dimension, the result is cached for StatisticsTTL
+//
+// Parameters:
+// - by: string the dimension
+// - dto: *transfert.Statistics the period and the filters
+//
+// Returns:
+// - []*entities.Statistic: the statistics sorted by key
+// - errors.ErrorInterface: an error if the statistics cannot be computed
+func (s *StatisticsService) statistics(by string, dto *transfert.Statistics) ([]*entities.Statistic, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	from, to, err := period(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.Join([]string{by, orEmpty(dto.From), orEmpty(dto.To), orEmpty(dto.StoreID), orEmpty(dto.PrizeID), orEmpty(dto.CampaignID)}, "|")
+	if statistics, ok := StatisticsCache.Get(key); ok {
+		return statistics, nil
+	}
+
+	filter := &transfert.Ticket{StoreID: dto.StoreID, PrizeID: dto.PrizeID, CampaignID: dto.CampaignID}
+	byKey := map[string]*entities.Statistic{}
+
+	for metric, column := range statisticsMetrics {
+		options := []database.Option{
+			statisticsBuckets[by](column),
+			database.Count("count"),
+			database.Where(column + " IS NOT NULL"),
+		}
+
+		if metric == 0 {
+			options = append(options, database.Sum("amount", "amount"))
+		}
+
+		if from != nil {
+			options = append(options, database.WhereDay(column, ">=", from.Format(time.DateOnly)))
+		}
+
+		if to != nil {
+			options = append(options, database.WhereDay(column, "<=", to.Format(time.DateOnly)))
+		}
+
+		aggregates, err := s.repo.AggregateTickets(filter, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, aggregate := range aggregates {
+			bucket := aggregate.Bucket
+			// Some drivers read a date as a full timestamp
+			if by == entities.StatisticsByDay && len(bucket) > len(time.DateOnly) {
+				bucket = bucket[:len(time.DateOnly)]
+			}
+
+			statistic, ok := byKey[bucket]
+			if !ok {
+				statistic = &entities.Statistic{Key: bucket}
+				byKey[bucket] = statistic
+			}
+
+			switch metric {
+			case 0:
+				statistic.Issued += aggregate.Count
+				statistic.Amount += aggregate.Amount
+			case 1:
+				statistic.Claimed += aggregate.Count
+			case 2:
+				statistic.Redeemed += aggregate.Count
+			}
+		}
+	}
+
+	statistics := make([]*entities.Statistic, 0, len(byKey))
+	for _, statistic := range byKey {
+		statistics = append(statistics, statistic)
+	}
+
+	slices.SortFunc(statistics, func(a, b *entities.Statistic) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	StatisticsCache.Set(key, statistics)
+
+	return statistics, nil
+}
+
+// period parses the bounds of the period as UTC days
+//
+// Parameters:
+// - dto: *transfert.Statistics the period
+//
+// Returns:
+// - *time.Time: the first day, nil without a start
+// - *time.Time: the last day, nil without an end
+// - errors.ErrorInterface: ErrBadRequest if a bound is not a date
+func period(dto *transfert.Statistics) (*time.Time, *time.Time, errors.ErrorInterface) {
+	var from, to *time.Time
+
+	if dto.From != nil {
+		day, err := time.ParseInLocation(time.DateOnly, *dto.From, time.UTC)
+		if err != nil {
+			return nil, nil, errors.ErrBadRequest
+		}
+
+		from = &day
+	}
+
+	if dto.To != nil {
+		day, err := time.ParseInLocation(time.DateOnly, *dto.To, time.UTC)
+		if err != nil {
+			return nil, nil, errors.ErrBadRequest
+		}
+
+		to = &day
+	}
+
+	return from, to, nil
+}
+
+// fillSeries adds the missing days of a series, from the start to the end of the period
+// Without a bound, the series starts or ends with the first or the last day counted.
+//
+// Parameters:
+// - series: []*entities.Statistic the days counted, in chronological order
+// - dto: *transfert.Statistics the period
+//
+// Returns:
+// - []*entities.Statistic: every day of the period
+func fillSeries(series []*entities.Statistic, dto *transfert.Statistics) []*entities.Statistic {
+	start, end := dto.From, dto.To
+	if len(series) > 0 {
+		if start == nil {
+			start = &series[0].Key
+		}
+
+		if end == nil {
+			end = &series[len(series)-1].Key
+		}
+	}
+
+	if start == nil || end == nil {
+		return series
+	}
+
+	from, errFrom := time.Parse(time.DateOnly, *start)
+	to, errTo := time.Parse(time.DateOnly, *end)
+	if errFrom != nil || errTo != nil {
+		return series
+	}
+
+	filled := []*entities.Statistic{}
+	i := 0
+	for day := from; !day.After(to) && len(filled) < StatisticsMaxDays; day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+		if i < len(series) && series[i].Key == key {
+			filled = append(filled, series[i])
+			i++
+			continue
+		}
+
+		filled = append(filled, &entities.Statistic{Key: key})
+	}
+
+	return filled
+}
+
+// orEmpty dereferences an optional filter
+func orEmpty(str *string) string {
+	if str == nil {
+		return ""
+	}
+
+	return *str
+}
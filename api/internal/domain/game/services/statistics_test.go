@@ -0,0 +1,172 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var statisticsRoles = []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}
+
+func Test_GetBreakdown(t *testing.T) {
+	dto := &transfert.Statistics{By: aws.String(entities.StatisticsByPrize), StoreID: aws.String("store-1")}
+	filter := &transfert.Ticket{StoreID: dto.StoreID}
+
+	t.Run("Should merge the metrics of each prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupStatistics()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+		mockRepo.On("AggregateTickets", filter, mock.Anything).Return([]*entities.Aggregate{
+			{Bucket: "prize-2", Count: 3, Amount: 150},
+			{Bucket: "prize-1", Count: 1, Amount: 50},
+		}, nil).Once()
+		mockRepo.On("AggregateTickets", filter, mock.Anything).Return([]*entities.Aggregate{
+			{Bucket: "prize-2", Count: 2},
+		}, nil).Once()
+		mockRepo.On("AggregateTickets", filter, mock.Anything).Return([]*entities.Aggregate{
+			{Bucket: "prize-2", Count: 1},
+		}, nil).Once()
+
+		statistics, err := service.GetBreakdown(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, []*entities.Statistic{
+			{Key: "prize-1", Issued: 1, Amount: 50},
+			{Key: "prize-2", Issued: 3, Claimed: 2, Redeemed: 1, Amount: 150},
+		}, statistics)
+
+		mockRepo.AssertNumberOfCalls(t, "AggregateTickets", 3)
+	})
+
+	t.Run("Should serve the statistics from the cache", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupStatistics()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+		mockRepo.On("AggregateTickets", filter, mock.Anything).Return([]*entities.Aggregate{}, nil)
+
+		_, err := service.GetBreakdown(dto)
+		assert.Nil(t, err)
+		_, err = service.GetBreakdown(dto)
+		assert.Nil(t, err)
+
+		mockRepo.AssertNumberOfCalls(t, "AggregateTickets", 3)
+	})
+
+	t.Run("Should refuse an unknown dimension", func(t *testing.T) {
+		service, mockRepo, _ := setupStatistics()
+
+		statistics, err := service.GetBreakdown(&transfert.Statistics{By: aws.String("color")})
+		assert.Equal(t, errors.ErrBadRequest, err)
+		assert.Nil(t, statistics)
+
+		mockRepo.AssertNotCalled(t, "AggregateTickets", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setupStatistics()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(false)
+
+		statistics, err := service.GetBreakdown(dto)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		assert.Nil(t, statistics)
+	})
+
+	t.Run("Should return error of the repository", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupStatistics()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+		mockRepo.On("AggregateTickets", filter, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		statistics, err := service.GetBreakdown(dto)
+		assert.Equal(t, errors.ErrInternalServer, err)
+		assert.Nil(t, statistics)
+	})
+
+	t.Run("Should return error when dto is nil", func(t *testing.T) {
+		service, _, _ := setupStatistics()
+
+		statistics, err := service.GetBreakdown(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+		assert.Nil(t, statistics)
+	})
+}
+
+func Test_GetSeries(t *testing.T) {
+	t.Run("Should fill the days of the period", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupStatistics()
+		dto := &transfert.Statistics{From: aws.String("2024-11-01"), To: aws.String("2024-11-04")}
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+		mockRepo.On("AggregateTickets", &transfert.Ticket{}, mock.Anything).Return([]*entities.Aggregate{
+			{Bucket: "2024-11-02T00:00:00Z", Count: 4, Amount: 200},
+		}, nil).Once()
+		mockRepo.On("AggregateTickets", &transfert.Ticket{}, mock.Anything).Return([]*entities.Aggregate{
+			{Bucket: "2024-11-03", Count: 2},
+		}, nil).Once()
+		mockRepo.On("AggregateTickets", &transfert.Ticket{}, mock.Anything).Return([]*entities.Aggregate{}, nil).Once()
+
+		series, err := service.GetSeries(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, []*entities.Statistic{
+			{Key: "2024-11-01"},
+			{Key: "2024-11-02", Issued: 4, Amount: 200},
+			{Key: "2024-11-03", Claimed: 2},
+			{Key: "2024-11-04"},
+		}, series)
+	})
+
+	t.Run("Should span the days counted without a period", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupStatistics()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+		mockRepo.On("AggregateTickets", &transfert.Ticket{}, mock.Anything).Return([]*entities.Aggregate{
+			{Bucket: "2024-11-01", Count: 1},
+			{Bucket: "2024-11-03", Count: 1},
+		}, nil)
+
+		series, err := service.GetSeries(&transfert.Statistics{})
+		assert.Nil(t, err)
+		if assert.Len(t, series, 3) {
+			assert.Equal(t, 0, series[1].Issued)
+			assert.Equal(t, 1, series[2].Redeemed)
+		}
+	})
+
+	t.Run("Should return an empty series without tickets", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupStatistics()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+		mockRepo.On("AggregateTickets", &transfert.Ticket{}, mock.Anything).Return([]*entities.Aggregate{}, nil)
+
+		series, err := service.GetSeries(&transfert.Statistics{})
+		assert.Nil(t, err)
+		assert.Empty(t, series)
+	})
+
+	t.Run("Should refuse an invalid period", func(t *testing.T) {
+		service, mockRepo, mockPerms := setupStatistics()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+
+		series, err := service.GetSeries(&transfert.Statistics{From: aws.String("2024-11-31")})
+		assert.Equal(t, errors.ErrBadRequest, err)
+		assert.Nil(t, series)
+
+		mockRepo.AssertNotCalled(t, "AggregateTickets", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when dto is nil", func(t *testing.T) {
+		service, _, _ := setupStatistics()
+
+		series, err := service.GetSeries(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+		assert.Nil(t, series)
+	})
+}
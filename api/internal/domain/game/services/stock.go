@@ -0,0 +1,360 @@
+package services
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail/template"
+)
+
+// GetPrizeStocks lists the stocks of the prizes in the stores
+// Only employees and admins can read the stocks.
+//
+// Parameters:
+// - dto: *transfert.PrizeStock the prize and the store to filter on, both optional
+//
+// Returns:
+// - []*entities.PrizeStock: the stocks
+// - errors.ErrorInterface: an error if the stocks cannot be read
+func (s *GameService) GetPrizeStocks(dto *transfert.PrizeStock) ([]*entities.PrizeStock, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return s.repo.ReadPrizeStocks(dto, database.Order("prize_id, store_id"))
+}
+
+// RestockPrize records a delivery of a prize to a store
+// Only admins and the employees assigned to the store can restock. A store receiving a prize for the first time gets
+// the alert threshold of the configuration unless another one is given.
+//
+// Parameters:
+// - dto: *transfert.PrizeStock the prize, the store, the delivered quantity and optionally the threshold
+//
+// Returns:
+// - *entities.PrizeStock: the stock after the delivery
+// - errors.ErrorInterface: an error if the prize or the store does not exist
+func (s *GameService) RestockPrize(dto *transfert.PrizeStock) (*entities.PrizeStock, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadPrize(&transfert.Prize{ID: dto.PrizeID}); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repoStore.ReadStore(&storeTransfert.Store{ID: dto.StoreID}); err != nil {
+		return nil, err
+	}
+
+	if err := s.isMember(dto.StoreID); err != nil {
+		return nil, err
+	}
+
+	if dto.Threshold == nil {
+		_, err := s.repo.ReadPrizeStock(&transfert.PrizeStock{PrizeID: dto.PrizeID, StoreID: dto.StoreID})
+		if err == errors_domain_game.ErrPrizeStockNotFound {
+			dto.Threshold = aws.Int(config.Get("project.stock.threshold", 0).(int))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	stock, err := s.repo.RestockPrize(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	s.alertStock(stock)
+
+	return stock, nil
+}
+
+// TransferPrizeStock moves available units of a prize from a store to another
+// Only admins and the employees assigned to both stores can transfer, units reserved for claimed tickets stay in the source store.
+//
+// Parameters:
+// - dto: *transfert.StockTransfer the prize, the source and destination stores and the quantity
+//
+// Returns:
+// - []*entities.PrizeStock: the stocks of the source and of the destination after the transfer
+// - errors.ErrorInterface: an error if the source does not have enough available units
+func (s *GameService) TransferPrizeStock(dto *transfert.StockTransfer) ([]*entities.PrizeStock, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repoStore.ReadStore(&storeTransfert.Store{ID: dto.ToStoreID}); err != nil {
+		return nil, err
+	}
+
+	for _, storeID := range []*string{dto.FromStoreID, dto.ToStoreID} {
+		if err := s.isMember(storeID); err != nil {
+			return nil, err
+		}
+	}
+
+	from, to, err := s.repo.TransferPrizeStock(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	s.alertStock(from)
+
+	return []*entities.PrizeStock{from, to}, nil
+}
+
+// reserveStock promises a unit of the prize of a claimed ticket in the store which issued it
+// Prizes without stock in the store are not tracked. A store out of stock leaves the ticket without
+// reservation, the player is directed to another store at redemption. The unit and the reservation of the ticket
+// are stored at once, a failure is logged and does not undo the claim.
+//
+// Parameters:
+// - ticket: *entities.Ticket the claimed ticket
+func (s *GameService) reserveStock(ticket *entities.Ticket) {
+	if ticket.PrizeID == nil || ticket.StoreID == nil {
+		return
+	}
+
+	stock, err := s.repo.ReadPrizeStock(&transfert.PrizeStock{PrizeID: ticket.PrizeID, StoreID: ticket.StoreID})
+	if err == errors_domain_game.ErrPrizeStockNotFound {
+		return
+	} else if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	ticket.ReservedStoreID = ticket.StoreID
+	err = s.repo.Transaction(func(repo repositories.GameRepositoryInterface) errors.ErrorInterface {
+		if err := repo.ReservePrizeStock(stock); err != nil {
+			return err
+		}
+
+		return repo.ReserveTicket(ticket)
+	})
+
+	if err != nil {
+		ticket.ReservedStoreID = nil
+
+		if err != errors_domain_game.ErrPrizeOutOfStock {
+			logger.Error(err)
+		}
+
+		return
+	}
+
+	s.alertStock(stock)
+}
+
+// releaseStock gives back the unit reserved for a ticket which will not be redeemed
+// A failure is logged and does not undo the action on the ticket.
+//
+// Parameters:
+// - ticket: *entities.Ticket the voided ticket
+func (s *GameService) releaseStock(ticket *entities.Ticket) {
+	if ticket.PrizeID == nil || ticket.ReservedStoreID == nil {
+		return
+	}
+
+	stock, err := s.repo.ReadPrizeStock(&transfert.PrizeStock{PrizeID: ticket.PrizeID, StoreID: ticket.ReservedStoreID})
+	if err == errors_domain_game.ErrPrizeStockNotFound {
+		return
+	} else if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if err := s.repo.ReleasePrizeStock(stock); err != nil {
+		logger.Error(err)
+	}
+}
+
+// consumeStock takes the prize of a redeemed ticket out of the stock of the store of the caisse
+// Prizes without any stock are not tracked. When the store has no unit left, the error lists
+// the stores where the player can collect the prize instead.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface the repository storing the redemption
+// - ticket: *entities.Ticket the ticket being redeemed
+// - caisse: *storeEntity.Caisse the caisse handing the prize over
+//
+// Returns:
+// - *entities.PrizeStock: the stock the prize was taken from, nil if the prize is not tracked
+// - errors.ErrorInterface: an error if the store cannot hand the prize over
+func (s *GameService) consumeStock(repo repositories.GameRepositoryInterface, ticket *entities.Ticket, caisse *storeEntity.Caisse) (*entities.PrizeStock, errors.ErrorInterface) {
+	if ticket.PrizeID == nil {
+		return nil, nil
+	}
+
+	stocks, err := repo.ReadPrizeStocks(&transfert.PrizeStock{PrizeID: ticket.PrizeID})
+	if err != nil || len(stocks) == 0 {
+		return nil, err
+	}
+
+	var stock, reserved *entities.PrizeStock
+	for _, candidate := range stocks {
+		if candidate.StoreID == aws.ToString(caisse.StoreID) {
+			stock = candidate
+		}
+
+		if candidate.StoreID == aws.ToString(ticket.ReservedStoreID) {
+			reserved = candidate
+		}
+	}
+
+	if stock == nil {
+		return nil, s.outOfStock(stocks, reserved)
+	}
+
+	if err := repo.ConsumePrizeStock(stock, reserved); err == errors_domain_game.ErrPrizeOutOfStock {
+		return nil, s.outOfStock(stocks, reserved)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// outOfStock lists the stores where a prize can still be collected
+// The unit reserved for the ticket counts as available in the store holding the reservation.
+//
+// Parameters:
+// - stocks: []*entities.PrizeStock the stocks of the prize
+// - reserved: *entities.PrizeStock the stock which holds the reservation of the ticket, nil if there is none
+//
+// Returns:
+// - *errors_domain_game.OutOfStock: the error listing the stores
+func (s *GameService) outOfStock(stocks []*entities.PrizeStock, reserved *entities.PrizeStock) *errors_domain_game.OutOfStock {
+	stores := []*errors_domain_game.AvailableStore{}
+
+	for _, stock := range stocks {
+		available := stock.Available()
+		if reserved != nil && reserved.ID == stock.ID {
+			available++
+		}
+
+		if available <= 0 {
+			continue
+		}
+
+		store := &errors_domain_game.AvailableStore{StoreID: stock.StoreID, Available: available}
+		if entity, err := s.repoStore.ReadStore(&storeTransfert.Store{ID: &stock.StoreID}); err == nil {
+			store.Label = entity.Label
+		}
+
+		stores = append(stores, store)
+	}
+
+	return errors_domain_game.NewOutOfStock(stores)
+}
+
+// alertStock mails the configured recipients once the available units of a store reach its threshold
+// A single alert is sent until the stock is refilled, a failure is logged and does not undo the action.
+//
+// Parameters:
+// - stock: *entities.PrizeStock the stock after the action
+func (s *GameService) alertStock(stock *entities.PrizeStock) {
+	if stock == nil || !stock.IsLow() || stock.AlertedAt != nil {
+		return
+	}
+
+	recipients := config.Get("project.stock.recipients", []string{}).([]string)
+	if len(recipients) == 0 {
+		return
+	}
+
+	marked, err := s.repo.MarkPrizeStockAlerted(stock)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if !marked {
+		return
+	}
+
+	if err := s.sendStockMail(stock, recipients); err != nil {
+		logger.Error(err)
+	}
+}
+
+// sendStockMail sends the low stock alert of a store to the recipients
+//
+// Parameters:
+// - stock: *entities.PrizeStock the low stock
+// - recipients: []string the addresses to alert, read from project.stock.recipients
+//
+// Returns:
+// - errors.ErrorInterface: an error if the mail cannot be sent
+func (s *GameService) sendStockMail(stock *entities.PrizeStock, recipients []string) errors.ErrorInterface {
+	prize, store := stock.PrizeID, stock.StoreID
+
+	if entity, err := s.repo.ReadPrize(&transfert.Prize{ID: &stock.PrizeID}); err == nil && entity.Label != nil {
+		prize = *entity.Label
+	}
+
+	if entity, err := s.repoStore.ReadStore(&storeTransfert.Store{ID: &stock.StoreID}); err == nil && entity.Label != nil {
+		store = *entity.Label
+	}
+
+	tpl := template.NewTemplate("stock_low")
+	if tpl == nil {
+		return errors.ErrMailTemplateNotFound
+	}
+
+	text, html, err := tpl.Inject(template.Data{
+		"AppName":   env.APP_NAME,
+		"Prize":     prize,
+		"Store":     store,
+		"Available": strconv.Itoa(stock.Available()),
+		"Reserved":  strconv.Itoa(stock.Reserved),
+		"Threshold": strconv.Itoa(stock.Threshold),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	m := &mail.Mail{
+		To:      recipients,
+		Subject: "The Tip Top - Stock bas",
+		Text:    text,
+		Html:    html,
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.mail.Send(m); err == nil {
+			return nil
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	return errors.ErrMailSendFailed
+}
@@ -0,0 +1,416 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var stockRoles = []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}
+
+func Test_GetPrizeStocks(t *testing.T) {
+	dto := &transfert.PrizeStock{StoreID: aws.String("store-1")}
+
+	t.Run("Should list the stocks of a store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(true)
+		mockRepo.On("ReadPrizeStocks", dto, mock.Anything).Return([]*entities.PrizeStock{{ID: "stock-1", StoreID: "store-1"}}, nil)
+
+		stocks, err := service.GetPrizeStocks(dto)
+		assert.Nil(t, err)
+		assert.Len(t, stocks, 1)
+	})
+
+	t.Run("Should refuse a player", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(false)
+
+		stocks, err := service.GetPrizeStocks(dto)
+		assert.Nil(t, stocks)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should refuse a missing dto", func(t *testing.T) {
+		service, _, _ := setup()
+
+		stocks, err := service.GetPrizeStocks(nil)
+		assert.Nil(t, stocks)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func Test_RestockPrize(t *testing.T) {
+	config.Load(aws.String("../../../../config.test.yml"))
+
+	dto := func() *transfert.PrizeStock {
+		return &transfert.PrizeStock{PrizeID: aws.String("prize-1"), StoreID: aws.String("store-1"), Quantity: aws.Int(10)}
+	}
+
+	t.Run("Should give the configured threshold to a first delivery", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(&entities.Prize{ID: "prize-1"}, nil)
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(&storeEntity.Store{ID: "store-1"}, nil)
+		mockRepo.On("ReadPrizeStock", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrPrizeStockNotFound)
+		mockRepo.On("RestockPrize", mock.MatchedBy(func(obj *transfert.PrizeStock) bool {
+			return obj.Threshold != nil && *obj.Threshold == 5
+		}), mock.Anything).Return(&entities.PrizeStock{ID: "stock-1", Quantity: 10, Threshold: 5}, nil)
+
+		stock, err := service.RestockPrize(dto())
+		assert.Nil(t, err)
+		assert.Equal(t, 10, stock.Quantity)
+		mockRepo.AssertNotCalled(t, "MarkPrizeStockAlerted", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should keep the threshold of a stocked store", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(&entities.Prize{ID: "prize-1"}, nil)
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(&storeEntity.Store{ID: "store-1"}, nil)
+		mockRepo.On("ReadPrizeStock", mock.Anything, mock.Anything).Return(&entities.PrizeStock{ID: "stock-1", Threshold: 2}, nil)
+		mockRepo.On("RestockPrize", mock.MatchedBy(func(obj *transfert.PrizeStock) bool {
+			return obj.Threshold == nil
+		}), mock.Anything).Return(&entities.PrizeStock{ID: "stock-1", Quantity: 12, Threshold: 2}, nil)
+
+		_, err := service.RestockPrize(dto())
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should alert when the stock is low", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores, _, mockMail := setupMail()
+		mockMail.ExpectedCalls = nil
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(&entities.Prize{ID: "prize-1", Label: aws.String("Coffret découverte")}, nil)
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(&storeEntity.Store{ID: "store-1", Label: aws.String("Paris 11")}, nil)
+		mockRepo.On("RestockPrize", mock.Anything, mock.Anything).Return(&entities.PrizeStock{ID: "stock-1", PrizeID: "prize-1", StoreID: "store-1", Quantity: 3, Threshold: 5}, nil)
+		mockRepo.On("MarkPrizeStockAlerted", mock.Anything, mock.Anything).Return(true, nil)
+		mockMail.On("Send", mock.MatchedBy(func(m *mail.Mail) bool {
+			return len(m.To) == 1 && m.To[0] == "stock@localhost" &&
+				strings.Contains(string(m.Text), "Coffret découverte") && strings.Contains(string(m.Text), "Paris 11")
+		})).Return(nil)
+
+		request := dto()
+		request.Threshold = aws.Int(5)
+
+		_, err := service.RestockPrize(request)
+		assert.Nil(t, err)
+		mockMail.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("Should not alert twice", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores, _, mockMail := setupMail()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(&entities.Prize{ID: "prize-1"}, nil)
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(&storeEntity.Store{ID: "store-1"}, nil)
+		mockRepo.On("RestockPrize", mock.Anything, mock.Anything).Return(&entities.PrizeStock{ID: "stock-1", Quantity: 1, Threshold: 5}, nil)
+		mockRepo.On("MarkPrizeStockAlerted", mock.Anything, mock.Anything).Return(false, nil)
+
+		request := dto()
+		request.Threshold = aws.Int(5)
+
+		_, err := service.RestockPrize(request)
+		assert.Nil(t, err)
+		mockMail.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("Should refuse an unknown store", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(&entities.Prize{ID: "prize-1"}, nil)
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		stock, err := service.RestockPrize(dto())
+		assert.Nil(t, stock)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, err)
+		mockRepo.AssertNotCalled(t, "RestockPrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an employee of another store", func(t *testing.T) {
+		mockRepo := new(GameRepositoryMock)
+		mockStores := new(StoreRepositoryMock)
+		service := services.Game(&security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}, mockRepo, mockStores, nil, nil)
+
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(&entities.Prize{ID: "prize-1"}, nil)
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(&storeEntity.Store{ID: "store-1"}, nil)
+		mockStores.On("ReadMembership", &storeTransfert.Membership{StoreID: aws.String("store-1"), CredentialID: aws.String("employee-123")}, mock.Anything).Return(nil, errors_domain_store.ErrMembershipNotFound)
+
+		stock, err := service.RestockPrize(dto())
+		assert.Nil(t, stock)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockStores.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "RestockPrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a player", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(false)
+
+		stock, err := service.RestockPrize(dto())
+		assert.Nil(t, stock)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_TransferPrizeStock(t *testing.T) {
+	dto := &transfert.StockTransfer{
+		PrizeID:     aws.String("prize-1"),
+		FromStoreID: aws.String("store-1"),
+		ToStoreID:   aws.String("store-2"),
+		Quantity:    aws.Int(2),
+	}
+
+	t.Run("Should return both stocks", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadStore", &storeTransfert.Store{ID: dto.ToStoreID}, mock.Anything).Return(&storeEntity.Store{ID: "store-2"}, nil)
+		mockRepo.On("TransferPrizeStock", dto, mock.Anything).Return(
+			&entities.PrizeStock{StoreID: "store-1", Quantity: 8},
+			&entities.PrizeStock{StoreID: "store-2", Quantity: 2}, nil,
+		)
+
+		stocks, err := service.TransferPrizeStock(dto)
+		assert.Nil(t, err)
+		assert.Len(t, stocks, 2)
+		assert.Equal(t, "store-1", stocks[0].StoreID)
+		assert.Equal(t, "store-2", stocks[1].StoreID)
+	})
+
+	t.Run("Should refuse to move reserved units", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", stockRoles).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(&storeEntity.Store{ID: "store-2"}, nil)
+		mockRepo.On("TransferPrizeStock", dto, mock.Anything).Return(nil, nil, errors_domain_game.ErrPrizeOutOfStock)
+
+		stocks, err := service.TransferPrizeStock(dto)
+		assert.Nil(t, stocks)
+		assert.Equal(t, errors_domain_game.ErrPrizeOutOfStock, err)
+	})
+
+	t.Run("Should refuse an employee who does not work in the source store", func(t *testing.T) {
+		mockRepo := new(GameRepositoryMock)
+		mockStores := new(StoreRepositoryMock)
+		service := services.Game(&security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}, mockRepo, mockStores, nil, nil)
+
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(&storeEntity.Store{ID: "store-2"}, nil)
+		mockStores.On("ReadMembership", &storeTransfert.Membership{StoreID: dto.FromStoreID, CredentialID: aws.String("employee-123")}, mock.Anything).Return(nil, errors_domain_store.ErrMembershipNotFound)
+		mockStores.On("ReadMembership", &storeTransfert.Membership{StoreID: dto.ToStoreID, CredentialID: aws.String("employee-123")}, mock.Anything).Return(&storeEntity.Membership{}, nil)
+
+		stocks, err := service.TransferPrizeStock(dto)
+		assert.Nil(t, stocks)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "TransferPrizeStock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an employee who does not work in the destination store", func(t *testing.T) {
+		mockRepo := new(GameRepositoryMock)
+		mockStores := new(StoreRepositoryMock)
+		service := services.Game(&security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}, mockRepo, mockStores, nil, nil)
+
+		mockStores.On("ReadStore", mock.Anything, mock.Anything).Return(&storeEntity.Store{ID: "store-2"}, nil)
+		mockStores.On("ReadMembership", &storeTransfert.Membership{StoreID: dto.FromStoreID, CredentialID: aws.String("employee-123")}, mock.Anything).Return(&storeEntity.Membership{}, nil)
+		mockStores.On("ReadMembership", &storeTransfert.Membership{StoreID: dto.ToStoreID, CredentialID: aws.String("employee-123")}, mock.Anything).Return(nil, errors_domain_store.ErrMembershipNotFound)
+
+		stocks, err := service.TransferPrizeStock(dto)
+		assert.Nil(t, stocks)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "TransferPrizeStock", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_StockLifecycle(t *testing.T) {
+	employee := aws.String("employee-123")
+	prizeID := aws.String("prize-1")
+	code, _ := token.Tickets().Generate()
+
+	claimed := func() *entities.Ticket {
+		return &entities.Ticket{
+			ID:              "ticket-123",
+			CredentialID:    aws.String("client-123"),
+			Status:          entities.TicketClaimed,
+			PrizeID:         prizeID,
+			ReservedStoreID: aws.String("store-1"),
+		}
+	}
+
+	redemption := &transfert.Redemption{TicketID: aws.String("ticket-123"), CaisseID: aws.String("caisse-123")}
+
+	t.Run("Should reserve a unit in the store which issued the ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := &transfert.Ticket{Token: code.PointerString()}
+		stock := &entities.PrizeStock{ID: "stock-1", PrizeID: *prizeID, StoreID: "store-1", Quantity: 10, Threshold: 1}
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", PrizeID: prizeID, StoreID: aws.String("store-1")}, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("client-123"))
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ReadPrizeStock", &transfert.PrizeStock{PrizeID: prizeID, StoreID: aws.String("store-1")}, mock.Anything).Return(stock, nil)
+		mockRepo.On("ReservePrizeStock", stock, mock.Anything).Return(nil)
+		mockRepo.On("ReserveTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := service.ClaimTicket(&transfert.Claim{Token: dto.Token})
+		assert.Nil(t, err)
+		assert.Equal(t, aws.String("store-1"), ticket.ReservedStoreID)
+		mockRepo.AssertNotCalled(t, "UpdateTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should claim even if the store is out of stock", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := &transfert.Ticket{Token: code.PointerString()}
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", PrizeID: prizeID, StoreID: aws.String("store-1")}, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("client-123"))
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ReadPrizeStock", mock.Anything, mock.Anything).Return(&entities.PrizeStock{ID: "stock-1"}, nil)
+		mockRepo.On("ReservePrizeStock", mock.Anything, mock.Anything).Return(errors_domain_game.ErrPrizeOutOfStock)
+
+		ticket, err := service.ClaimTicket(&transfert.Claim{Token: dto.Token})
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+		assert.Nil(t, ticket.ReservedStoreID)
+		mockRepo.AssertNotCalled(t, "ReserveTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should claim without reservation when the ticket cannot hold it", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := &transfert.Ticket{Token: code.PointerString()}
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", PrizeID: prizeID, StoreID: aws.String("store-1")}, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("client-123"))
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ReadPrizeStock", mock.Anything, mock.Anything).Return(&entities.PrizeStock{ID: "stock-1"}, nil)
+		mockRepo.On("ReservePrizeStock", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ReserveTicket", mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketNotClaimed)
+
+		ticket, err := service.ClaimTicket(&transfert.Claim{Token: dto.Token})
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+		assert.Nil(t, ticket.ReservedStoreID)
+	})
+
+	t.Run("Should consume the reservation at the caisse of the store", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		stock := &entities.PrizeStock{ID: "stock-1", PrizeID: *prizeID, StoreID: "store-1", Quantity: 10, Reserved: 1}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockPerms.On("GetCredentialID").Return(employee)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(claimed(), nil)
+		mockRepo.On("ReadPrizeStocks", &transfert.PrizeStock{PrizeID: prizeID}, mock.Anything).Return([]*entities.PrizeStock{stock}, nil)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: redemption.CaisseID}, mock.Anything).Return(&storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-1")}, nil)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound)
+		mockRepo.On("ConsumePrizeStock", stock, stock, mock.Anything).Return(nil)
+		mockRepo.On("RedeemTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := service.RedeemTicket(redemption)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketRedeemed, ticket.Status)
+	})
+
+	t.Run("Should list the stores which still have the prize", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		stocks := []*entities.PrizeStock{
+			{ID: "stock-1", PrizeID: *prizeID, StoreID: "store-1", Quantity: 1, Reserved: 1},
+			{ID: "stock-2", PrizeID: *prizeID, StoreID: "store-2", Quantity: 0},
+			{ID: "stock-3", PrizeID: *prizeID, StoreID: "store-3", Quantity: 4, Reserved: 1},
+		}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockPerms.On("GetCredentialID").Return(employee)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(claimed(), nil)
+		mockRepo.On("ReadPrizeStocks", mock.Anything, mock.Anything).Return(stocks, nil)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(&storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-2")}, nil)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound)
+		mockRepo.On("RedeemTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ConsumePrizeStock", stocks[1], stocks[0], mock.Anything).Return(errors_domain_game.ErrPrizeOutOfStock)
+		mockStores.On("ReadStore", &storeTransfert.Store{ID: aws.String("store-1")}, mock.Anything).Return(&storeEntity.Store{ID: "store-1", Label: aws.String("Paris 11")}, nil)
+		mockStores.On("ReadStore", &storeTransfert.Store{ID: aws.String("store-3")}, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		ticket, err := service.RedeemTicket(redemption)
+		assert.Nil(t, ticket)
+
+		outOfStock, ok := err.(*errors_domain_game.OutOfStock)
+		if assert.True(t, ok) {
+			assert.Equal(t, errors_domain_game.ErrPrizeOutOfStock.Code(), outOfStock.Code())
+			assert.Equal(t, []*errors_domain_game.AvailableStore{
+				{StoreID: "store-1", Label: aws.String("Paris 11"), Available: 1},
+				{StoreID: "store-3", Available: 3},
+			}, outOfStock.Stores)
+		}
+
+		mockRepo.AssertNotCalled(t, "CreateTicketEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should leave the stock alone when the ticket is redeemed in the meantime", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		stock := &entities.PrizeStock{ID: "stock-1", PrizeID: *prizeID, StoreID: "store-1", Quantity: 10, Reserved: 1}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockPerms.On("GetCredentialID").Return(employee)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(claimed(), nil)
+		mockRepo.On("ReadPrizeStocks", mock.Anything, mock.Anything).Return([]*entities.PrizeStock{stock}, nil)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(&storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-1")}, nil)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound)
+		mockRepo.On("RedeemTicket", mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketAlreadyRedeemed)
+
+		ticket, err := service.RedeemTicket(redemption)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+		mockRepo.AssertNotCalled(t, "ConsumePrizeStock", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should release the reservation of a voided ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		stock := &entities.PrizeStock{ID: "stock-1", PrizeID: *prizeID, StoreID: "store-1", Quantity: 10, Reserved: 1}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-123"))
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(claimed(), nil)
+		mockRepo.On("VoidTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ReadPrizeStock", &transfert.PrizeStock{PrizeID: prizeID, StoreID: aws.String("store-1")}, mock.Anything).Return(stock, nil)
+		mockRepo.On("ReleasePrizeStock", stock, mock.Anything).Return(nil)
+
+		_, err := service.VoidTicket(&transfert.Void{TicketID: aws.String("ticket-123"), Reason: aws.String("damaged")})
+		assert.Nil(t, err)
+		mockRepo.AssertCalled(t, "ReleasePrizeStock", stock, mock.Anything)
+	})
+}
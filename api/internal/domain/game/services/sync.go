@@ -0,0 +1,232 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// SyncLimit bounds the operations uploaded in a single batch
+const SyncLimit = 500
+
+// SyncClockSkew is the drift tolerated between the clock of a caisse and the clock of the server
+const SyncClockSkew = 5 * time.Minute
+
+// SyncCaisse applies the operations queued by a caisse while it was offline
+// The operations are applied in the order of their client-side timestamps, the upload order breaking the ties.
+// An operation refused by the game, a ticket already redeemed at another caisse for instance, is reported
+// as a conflict and does not stop the batch. Each outcome is recorded under the idempotency ID of the operation,
+// along with the operation itself, so that a batch uploaded again, even concurrently after a lost response,
+// returns the same outcomes without applying anything twice.
+// An internal error stops the batch: the operations applied before it are recorded and replayed on the next upload.
+//
+// Parameters:
+// - dto: *transfert.Sync the caisse and its queued operations
+//
+// Returns:
+// - []*entities.SyncOperation: the outcome of each operation, in the order they were applied
+// - errors.ErrorInterface: an error if the caisse cannot be operated or the batch cannot be processed
+func (s *GameService) SyncCaisse(dto *transfert.Sync) ([]*entities.SyncOperation, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if len(dto.Operations) > SyncLimit {
+		return nil, errors_domain_game.ErrSyncTooManyOperations
+	}
+
+	caisse, err := s.caisseOf(dto.CaisseID)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]*transfert.SyncOperation, len(dto.Operations))
+	copy(operations, dto.Operations)
+	sort.SliceStable(operations, func(i, j int) bool {
+		return occurredAt(operations[i]).Before(occurredAt(operations[j]))
+	})
+
+	results := make([]*entities.SyncOperation, 0, len(operations))
+	for _, operation := range operations {
+		result, err := s.syncOperation(caisse, operation)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// syncOperation applies an uploaded operation once and records its outcome
+// The record is inserted first, in the transaction applying the operation: its idempotency ID claims the operation,
+// so a concurrent upload of the same operation waits for it and replays its outcome, and an outcome which cannot be
+// recorded undoes the operation.
+//
+// Parameters:
+// - caisse: *storeEntity.Caisse the caisse which queued the operation
+// - operation: *transfert.SyncOperation the operation
+//
+// Returns:
+// - *entities.SyncOperation: the outcome of the operation, the recorded one if it was already uploaded
+// - errors.ErrorInterface: an internal error if the operation cannot be processed
+func (s *GameService) syncOperation(caisse *storeEntity.Caisse, operation *transfert.SyncOperation) (*entities.SyncOperation, errors.ErrorInterface) {
+	if recorded, err := s.replayOperation(caisse, operation); err != errors_domain_game.ErrSyncOperationNotFound {
+		return recorded, err
+	}
+
+	var outcome *entities.SyncOperation
+	err := s.repo.Transaction(func(repo repositories.GameRepositoryInterface) errors.ErrorInterface {
+		claimed, err := repo.CreateSyncOperation(&transfert.SyncOperation{
+			ID:           operation.ID,
+			Type:         operation.Type,
+			OccurredAt:   operation.OccurredAt,
+			CaisseID:     &caisse.ID,
+			TicketID:     operation.TicketID,
+			CredentialID: s.security.GetCredentialID(),
+			Status:       aws.String(entities.SyncOperationApplied),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		ticket, err := s.within(repo).applyOperation(caisse, operation)
+		if err != nil {
+			if err.Code() >= 500 {
+				return err
+			}
+
+			claimed.Status = entities.SyncOperationConflict
+			claimed.Error = aws.String(err.Error())
+		} else {
+			claimed.TicketID = &ticket.ID
+		}
+
+		outcome = claimed
+
+		return repo.UpdateSyncOperation(claimed)
+	})
+
+	// Another upload of the operation was recorded in the meantime
+	if err == errors_domain_game.ErrSyncOperationRecorded {
+		return s.replayOperation(caisse, operation)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return outcome, nil
+}
+
+// replayOperation returns the recorded outcome of an operation already uploaded
+// The idempotency ID of an operation used for another one is reported as a conflict, nothing is applied nor recorded.
+//
+// Parameters:
+// - caisse: *storeEntity.Caisse the caisse which queued the operation
+// - operation: *transfert.SyncOperation the operation
+//
+// Returns:
+// - *entities.SyncOperation: the recorded outcome
+// - errors.ErrorInterface: ErrSyncOperationNotFound if the operation was never uploaded
+func (s *GameService) replayOperation(caisse *storeEntity.Caisse, operation *transfert.SyncOperation) (*entities.SyncOperation, errors.ErrorInterface) {
+	recorded, err := s.repo.ReadSyncOperation(&transfert.SyncOperation{ID: operation.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.ToString(recorded.CaisseID) != caisse.ID || recorded.Type != aws.ToString(operation.Type) {
+		return entities.CreateSyncOperation(&transfert.SyncOperation{
+			ID:         operation.ID,
+			Type:       operation.Type,
+			OccurredAt: operation.OccurredAt,
+			CaisseID:   &caisse.ID,
+			Status:     aws.String(entities.SyncOperationConflict),
+			Error:      aws.String(errors_domain_game.ErrSyncOperationMismatch.Error()),
+		}), nil
+	}
+
+	recorded.Replayed = true
+
+	return recorded, nil
+}
+
+// within returns a copy of the service working with the repository of a transaction
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface the repository of the transaction
+//
+// Returns:
+// - *GameService: the service bound to the transaction
+func (s *GameService) within(repo repositories.GameRepositoryInterface) *GameService {
+	bound := *s
+	bound.repo = repo
+
+	return &bound
+}
+
+// applyOperation issues or redeems a ticket for an uploaded operation
+//
+// Parameters:
+// - caisse: *storeEntity.Caisse the caisse which queued the operation
+// - operation: *transfert.SyncOperation the operation
+//
+// Returns:
+// - *entities.Ticket: the issued or redeemed ticket
+// - errors.ErrorInterface: the reason why the game refused the operation
+func (s *GameService) applyOperation(caisse *storeEntity.Caisse, operation *transfert.SyncOperation) (*entities.Ticket, errors.ErrorInterface) {
+	if occurredAt(operation).After(time.Now().Add(SyncClockSkew)) {
+		return nil, errors_domain_game.ErrSyncOperationInFuture
+	}
+
+	switch aws.ToString(operation.Type) {
+	case entities.SyncOperationIssue:
+		if err := checkAmount(operation.Amount); err != nil {
+			return nil, err
+		}
+
+		return s.issue(caisse, &transfert.Issuance{
+			CaisseID: &caisse.ID,
+			Receipt:  operation.Receipt,
+			Amount:   operation.Amount,
+		})
+	case entities.SyncOperationRedeem:
+		return s.redeem(caisse, &transfert.Redemption{
+			TicketID: operation.TicketID,
+			CaisseID: &caisse.ID,
+		})
+	}
+
+	return nil, errors_domain_game.ErrSyncUnknownOperation
+}
+
+// occurredAt reads the client-side timestamp of an operation, the zero time if it is missing or malformed
+//
+// Parameters:
+// - operation: *transfert.SyncOperation the operation
+//
+// Returns:
+// - time.Time: the time the operation occurred at the caisse
+func occurredAt(operation *transfert.SyncOperation) time.Time {
+	t, err := time.Parse(time.RFC3339, aws.ToString(operation.OccurredAt))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
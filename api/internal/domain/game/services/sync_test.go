@@ -0,0 +1,273 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_SyncCaisse(t *testing.T) {
+	config.Load(aws.String("../../../../config.test.yml"))
+
+	employee := []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}
+	eid := aws.String("employee-123")
+	caisse := &storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-123")}
+
+	issue := &transfert.SyncOperation{
+		ID:         aws.String("operation-1"),
+		Type:       aws.String(entities.SyncOperationIssue),
+		OccurredAt: aws.String("2024-03-01T10:00:00Z"),
+		Receipt:    aws.String("R-0001"),
+		Amount:     aws.Float64(54.9),
+	}
+
+	redeem := &transfert.SyncOperation{
+		ID:         aws.String("operation-2"),
+		Type:       aws.String(entities.SyncOperationRedeem),
+		OccurredAt: aws.String("2024-03-01T10:05:00+01:00"),
+		TicketID:   aws.String("ticket-456"),
+	}
+
+	syncable := func() (*services.GameService, *GameRepositoryMock) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: &caisse.ID}, mock.Anything).Return(caisse, nil)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound).Maybe()
+
+		return service, mockRepo
+	}
+
+	// L'opération est réservée par son enregistrement, son résultat est écrit ensuite
+	claimed := func(mockRepo *GameRepositoryMock, id string) {
+		mockRepo.On("CreateSyncOperation", mock.MatchedBy(func(obj *transfert.SyncOperation) bool {
+			return *obj.ID == id && *obj.CaisseID == caisse.ID && obj.CredentialID == eid
+		}), mock.Anything).Return(&entities.SyncOperation{ID: id, Status: entities.SyncOperationApplied}, nil).Once()
+	}
+
+	recorded := func(mockRepo *GameRepositoryMock, id, status string) {
+		claimed(mockRepo, id)
+		mockRepo.On("UpdateSyncOperation", mock.MatchedBy(func(operation *entities.SyncOperation) bool {
+			return operation.ID == id && operation.Status == status
+		}), mock.Anything).Return(nil).Once()
+	}
+
+	t.Run("Should apply the operations in the order they occurred", func(t *testing.T) {
+		service, mockRepo := syncable()
+
+		mockRepo.On("ReadSyncOperation", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrSyncOperationNotFound)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("IssueTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: redeem.TicketID}, mock.Anything).Return(&entities.Ticket{ID: "ticket-456", Status: entities.TicketClaimed}, nil)
+		mockRepo.On("RedeemTicket", mock.Anything, mock.Anything).Return(nil)
+		recorded(mockRepo, "operation-1", entities.SyncOperationApplied)
+		recorded(mockRepo, "operation-2", entities.SyncOperationApplied)
+
+		// The redemption occurred at 09:05 UTC, before the issuance, even though it was uploaded last
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: []*transfert.SyncOperation{issue, redeem}})
+		assert.Nil(t, err)
+		if assert.Len(t, results, 2) {
+			assert.Equal(t, "operation-2", results[0].ID)
+			assert.Equal(t, "operation-1", results[1].ID)
+		}
+
+		mockRepo.AssertCalled(t, "UpdateSyncOperation", mock.MatchedBy(func(operation *entities.SyncOperation) bool {
+			return operation.ID == "operation-1" && *operation.TicketID == "ticket-123"
+		}), mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should report a ticket already redeemed elsewhere as a conflict", func(t *testing.T) {
+		service, mockRepo := syncable()
+
+		mockRepo.On("ReadSyncOperation", &transfert.SyncOperation{ID: redeem.ID}, mock.Anything).Return(nil, errors_domain_game.ErrSyncOperationNotFound)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: redeem.TicketID}, mock.Anything).Return(&entities.Ticket{ID: "ticket-456", Status: entities.TicketRedeemed}, nil)
+		claimed(mockRepo, "operation-2")
+		mockRepo.On("UpdateSyncOperation", mock.MatchedBy(func(operation *entities.SyncOperation) bool {
+			return operation.Status == entities.SyncOperationConflict && *operation.Error == errors_domain_game.ErrTicketAlreadyRedeemed.Error()
+		}), mock.Anything).Return(nil)
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: []*transfert.SyncOperation{redeem}})
+		assert.Nil(t, err)
+		if assert.Len(t, results, 1) {
+			assert.False(t, results[0].IsApplied())
+		}
+
+		mockRepo.AssertNotCalled(t, "RedeemTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should replay an operation already uploaded", func(t *testing.T) {
+		service, mockRepo := syncable()
+
+		mockRepo.On("ReadSyncOperation", &transfert.SyncOperation{ID: redeem.ID}, mock.Anything).Return(&entities.SyncOperation{
+			ID:       "operation-2",
+			Type:     entities.SyncOperationRedeem,
+			Status:   entities.SyncOperationApplied,
+			CaisseID: &caisse.ID,
+		}, nil)
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: []*transfert.SyncOperation{redeem}})
+		assert.Nil(t, err)
+		if assert.Len(t, results, 1) {
+			assert.True(t, results[0].Replayed)
+			assert.True(t, results[0].IsApplied())
+		}
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CreateSyncOperation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an idempotency ID used for another operation", func(t *testing.T) {
+		service, mockRepo := syncable()
+
+		mockRepo.On("ReadSyncOperation", &transfert.SyncOperation{ID: redeem.ID}, mock.Anything).Return(&entities.SyncOperation{
+			ID:       "operation-2",
+			Type:     entities.SyncOperationRedeem,
+			Status:   entities.SyncOperationApplied,
+			CaisseID: aws.String("caisse-456"),
+		}, nil)
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: []*transfert.SyncOperation{redeem}})
+		assert.Nil(t, err)
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, entities.SyncOperationConflict, results[0].Status)
+			assert.Equal(t, errors_domain_game.ErrSyncOperationMismatch.Error(), *results[0].Error)
+			assert.False(t, results[0].Replayed)
+		}
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CreateSyncOperation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should report operations in the future or of an unknown type as conflicts", func(t *testing.T) {
+		service, mockRepo := syncable()
+
+		future := &transfert.SyncOperation{
+			ID:         aws.String("operation-3"),
+			Type:       aws.String(entities.SyncOperationRedeem),
+			OccurredAt: aws.String(time.Now().Add(time.Hour).Format(time.RFC3339)),
+			TicketID:   aws.String("ticket-456"),
+		}
+
+		unknown := &transfert.SyncOperation{
+			ID:         aws.String("operation-4"),
+			Type:       aws.String("void"),
+			OccurredAt: aws.String("2024-03-01T10:00:00Z"),
+		}
+
+		mockRepo.On("ReadSyncOperation", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrSyncOperationNotFound)
+		claimed(mockRepo, "operation-3")
+		claimed(mockRepo, "operation-4")
+		mockRepo.On("UpdateSyncOperation", mock.MatchedBy(func(operation *entities.SyncOperation) bool {
+			return operation.ID == "operation-3" && *operation.Error == errors_domain_game.ErrSyncOperationInFuture.Error()
+		}), mock.Anything).Return(nil)
+		mockRepo.On("UpdateSyncOperation", mock.MatchedBy(func(operation *entities.SyncOperation) bool {
+			return operation.ID == "operation-4" && *operation.Error == errors_domain_game.ErrSyncUnknownOperation.Error()
+		}), mock.Anything).Return(nil)
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: []*transfert.SyncOperation{future, unknown}})
+		assert.Nil(t, err)
+		assert.Len(t, results, 2)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should stop the batch on an internal error", func(t *testing.T) {
+		service, mockRepo := syncable()
+
+		mockRepo.On("ReadSyncOperation", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrSyncOperationNotFound)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: redeem.TicketID}, mock.Anything).Return(nil, errors.ErrInternalServer)
+		claimed(mockRepo, "operation-2")
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: []*transfert.SyncOperation{issue, redeem}})
+		assert.Nil(t, results)
+		assert.Equal(t, errors.ErrInternalServer, err)
+
+		mockRepo.AssertNotCalled(t, "UpdateSyncOperation", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "IssueTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should stop the batch when the outcome cannot be recorded", func(t *testing.T) {
+		service, mockRepo := syncable()
+
+		mockRepo.On("ReadSyncOperation", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrSyncOperationNotFound)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: redeem.TicketID}, mock.Anything).Return(&entities.Ticket{ID: "ticket-456", Status: entities.TicketClaimed}, nil)
+		mockRepo.On("RedeemTicket", mock.Anything, mock.Anything).Return(nil)
+		claimed(mockRepo, "operation-2")
+		mockRepo.On("UpdateSyncOperation", mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: []*transfert.SyncOperation{redeem}})
+		assert.Nil(t, results)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+
+	t.Run("Should replay an operation recorded by a concurrent upload", func(t *testing.T) {
+		service, mockRepo := syncable()
+
+		mockRepo.On("ReadSyncOperation", &transfert.SyncOperation{ID: redeem.ID}, mock.Anything).Return(nil, errors_domain_game.ErrSyncOperationNotFound).Once()
+		mockRepo.On("CreateSyncOperation", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrSyncOperationRecorded)
+		mockRepo.On("ReadSyncOperation", &transfert.SyncOperation{ID: redeem.ID}, mock.Anything).Return(&entities.SyncOperation{
+			ID:       "operation-2",
+			Type:     entities.SyncOperationRedeem,
+			Status:   entities.SyncOperationApplied,
+			CaisseID: &caisse.ID,
+		}, nil)
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: []*transfert.SyncOperation{redeem}})
+		assert.Nil(t, err)
+		if assert.Len(t, results, 1) {
+			assert.True(t, results[0].Replayed)
+			assert.True(t, results[0].IsApplied())
+		}
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "RedeemTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a batch too large", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(true)
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID, Operations: make([]*transfert.SyncOperation, services.SyncLimit+1)})
+		assert.Nil(t, results)
+		assert.Equal(t, errors_domain_game.ErrSyncTooManyOperations, err)
+
+		mockStores.AssertNotCalled(t, "ReadCaisse", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ReadSyncOperation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a user who is not an employee", func(t *testing.T) {
+		service, _, mockPerms, mockStores := setupStores()
+		mockPerms.On("IsGrantedByRoles", employee).Return(false)
+
+		results, err := service.SyncCaisse(&transfert.Sync{CaisseID: &caisse.ID})
+		assert.Nil(t, results)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockStores.AssertNotCalled(t, "ReadCaisse", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a missing dto", func(t *testing.T) {
+		service, _, _, _ := setupStores()
+
+		results, err := service.SyncCaisse(nil)
+		assert.Nil(t, results)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
@@ -1,68 +1,229 @@
 package services
 
 import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
 	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 )
 
-func (s *GameService) GetRandomTicket() (*entities.Ticket, errors.ErrorInterface) {
-	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+func (s *GameService) GetTickets(list *database.List) (*database.Page[*entities.Ticket], errors.ErrorInterface) {
+	if list == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	tickets, err := s.repo.PaginateTickets(&transfert.Ticket{
+		CredentialID: s.security.GetCredentialID(),
+	}, list, database.Preload("Prize"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// UpdateTicket claims the ticket matching a printed code for the clients still calling PUT /game/ticket
+// The claim goes through ClaimTicket, the check digit and the lockout apply the same way.
+//
+// Parameters:
+// - dto: *transfert.Ticket the printed code of the ticket
+//
+// Returns:
+// - *entities.Ticket: the claimed ticket
+// - errors.ErrorInterface: an error if the ticket cannot be claimed
+func (s *GameService) UpdateTicket(dto *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	return s.ClaimTicket(&transfert.Claim{Token: dto.Token, IP: s.security.GetIP()})
+}
+
+func (s *GameService) GetTicketById(dto *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return nil, errors.ErrUnauthorized
 	}
 
-	ticket, err := s.repo.ReadTicket(&transfert.Ticket{}, database.Where("credential_id IS NULL"), database.Order("RANDOM()"))
+	ticket, err := s.repo.ReadTicket(dto)
+
 	if err != nil {
-		return nil, errors.ErrNoData
+		return nil, err
+	}
+
+	if err := s.record(s.repo, ticket, entities.TicketEventViewed); err != nil {
+		return nil, err
 	}
 
 	return ticket, nil
 }
 
-func (s *GameService) GetTickets() ([]*entities.Ticket, errors.ErrorInterface) {
-	tickets, err := s.repo.ReadTickets(&transfert.Ticket{
-		CredentialID: s.security.GetCredentialID(),
-	})
+// RedeemTicket hands over the prize of a claimed ticket at a caisse
+// Only employees and paired devices can redeem a ticket, each ticket can only be redeemed once.
+// When the prize is stocked, the store of the caisse must have a unit of it left.
+// The redemption is attributed to the shift running on the caisse, if any.
+// Employees only redeem tickets at the caisses of the stores they are assigned to, devices at the caisse they are paired to.
+//
+// Parameters:
+// - dto: *transfert.Redemption the ticket and the caisse delivering the prize
+//
+// Returns:
+// - *entities.Ticket: the redeemed ticket
+// - errors.ErrorInterface: an error if the ticket cannot be redeemed
+func (s *GameService) RedeemTicket(dto *transfert.Redemption) (*entities.Ticket, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE) {
+		return nil, errors.ErrUnauthorized
+	}
 
+	caisse, err := s.caisseOf(dto.CaisseID)
 	if err != nil {
-		return nil, errors.ErrNoData
+		return nil, err
 	}
 
-	return tickets, nil
+	return s.redeem(caisse, dto)
 }
 
-func (s *GameService) UpdateTicket(dto *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
-	ticket, err := s.repo.ReadTicket(dto, database.Where("credential_id IS NULL"))
+// redeem hands over the prize of a claimed ticket at a caisse the user may operate
+//
+// Parameters:
+// - caisse: *storeEntity.Caisse the caisse delivering the prize
+// - dto: *transfert.Redemption the ticket
+//
+// Returns:
+// - *entities.Ticket: the redeemed ticket
+// - errors.ErrorInterface: an error if the ticket cannot be redeemed
+func (s *GameService) redeem(caisse *storeEntity.Caisse, dto *transfert.Redemption) (*entities.Ticket, errors.ErrorInterface) {
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{ID: dto.TicketID})
+	if err != nil {
+		return nil, err
+	}
 
+	campaign, err := s.campaignOf(ticket)
 	if err != nil {
 		return nil, err
 	}
 
-	if !s.security.IsAuthenticated() {
-		return nil, errors.ErrUnauthorized
+	if campaign != nil && campaign.IsClaimExpired(time.Now()) && ticket.Expire() {
+		err := s.repo.Transaction(func(repo repositories.GameRepositoryInterface) errors.ErrorInterface {
+			if err := repo.ExpireTicket(ticket); err != nil {
+				return err
+			}
+
+			return s.record(repo, ticket, entities.TicketEventExpired)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, errors_domain_game.ErrCampaignClaimExpired
 	}
 
-	ticket.CredentialID = s.security.GetCredentialID()
+	if !ticket.Redeem(&caisse.ID, s.security.GetCredentialID()) {
+		return nil, redemptionError(ticket.GetStatus())
+	}
 
-	if err := s.repo.UpdateTicket(ticket); err != nil {
+	if ticket.RedeemedShiftID, err = s.shiftOf(&caisse.ID); err != nil {
 		return nil, err
 	}
 
-	return ticket, nil
-}
+	// The stock is only taken once the redemption is granted, both are undone together
+	var stock *entities.PrizeStock
+	err = s.repo.Transaction(func(repo repositories.GameRepositoryInterface) errors.ErrorInterface {
+		if err := repo.RedeemTicket(ticket); err != nil {
+			return err
+		}
 
-func (s *GameService) GetTicketById(dto *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
-	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
-		return nil, errors.ErrUnauthorized
-	}
+		consumed, err := s.consumeStock(repo, ticket, caisse)
+		if err != nil {
+			return err
+		}
 
-	ticket, err := s.repo.ReadTicket(dto)
+		stock = consumed
+
+		return s.record(repo, ticket, entities.TicketEventRedeemed)
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	s.alertStock(stock)
+
 	return ticket, nil
 }
+
+// campaignOf returns the campaign of the ticket
+// Tickets without campaign, or whose campaign was removed, are not bound to any window.
+//
+// Parameters:
+// - ticket: *entities.Ticket the ticket
+//
+// Returns:
+// - *entities.Campaign: the campaign of the ticket, nil if there is none
+// - errors.ErrorInterface: an error if the campaign cannot be read
+func (s *GameService) campaignOf(ticket *entities.Ticket) (*entities.Campaign, errors.ErrorInterface) {
+	if ticket.CampaignID == nil {
+		return nil, nil
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: ticket.CampaignID})
+	if err == errors_domain_game.ErrCampaignNotFound {
+		return nil, nil
+	}
+
+	return campaign, err
+}
+
+// checkParticipation checks that the ticket is played during the participation window of its campaign
+//
+// Parameters:
+// - ticket: *entities.Ticket the ticket being claimed
+//
+// Returns:
+// - errors.ErrorInterface: an error if the campaign is not started or already ended
+func (s *GameService) checkParticipation(ticket *entities.Ticket) errors.ErrorInterface {
+	campaign, err := s.campaignOf(ticket)
+	if err != nil || campaign == nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if !campaign.IsStarted(now) {
+		return errors_domain_game.ErrCampaignNotStarted
+	}
+
+	if campaign.IsEnded(now) {
+		return errors_domain_game.ErrCampaignEnded
+	}
+
+	return nil
+}
+
+// redemptionError explains why a ticket in the given state cannot be redeemed
+//
+// Parameters:
+// - status: entities.TicketStatus the current state of the ticket
+//
+// Returns:
+// - errors.ErrorInterface: the matching domain error
+func redemptionError(status entities.TicketStatus) errors.ErrorInterface {
+	switch status {
+	case entities.TicketRedeemed:
+		return errors_domain_game.ErrTicketAlreadyRedeemed
+	case entities.TicketExpired:
+		return errors_domain_game.ErrTicketExpired
+	case entities.TicketVoided:
+		return errors_domain_game.ErrTicketVoided
+	default:
+		return errors_domain_game.ErrTicketNotClaimed
+	}
+}
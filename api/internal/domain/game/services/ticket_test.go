@@ -2,57 +2,23 @@ package services_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kodmain/thetiptop/api/internal/application/security"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	storeEntity "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
 	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func Test_GetRandomTicket(t *testing.T) {
-	t.Run("Should return ticket", func(t *testing.T) {
-		service, mockRepo, mockPerms := setup()
-
-		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{}, nil)
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
-
-		ticket, err := service.GetRandomTicket()
-		assert.Nil(t, err)
-		assert.NotNil(t, ticket)
-
-		mockRepo.AssertExpectations(t)
-	})
-
-	t.Run("Should return error when unauthorized", func(t *testing.T) {
-		service, _, mockPerms := setup()
-
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
-
-		ticket, err := service.GetRandomTicket()
-		assert.NotNil(t, err)
-		assert.Nil(t, ticket)
-
-		mockPerms.AssertExpectations(t)
-	})
-
-	t.Run("Should return error when repository return error", func(t *testing.T) {
-		service, mockRepo, mockPerms := setup()
-
-		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(nil, errors.ErrNoData)
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
-
-		ticket, err := service.GetRandomTicket()
-		assert.NotNil(t, err)
-		assert.Nil(t, ticket)
-
-		mockRepo.AssertExpectations(t)
-	})
-}
-
 func Test_GetTickets(t *testing.T) {
 	t.Run("Should return tickets", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
@@ -60,16 +26,17 @@ func Test_GetTickets(t *testing.T) {
 		credentialID := "valid-credential-id"
 
 		// Configuration des mocks
-		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{{ID: "123"}}, nil)
+		mockRepo.On("PaginateTickets", &transfert.Ticket{CredentialID: &credentialID}, mock.Anything, mock.Anything).Return(&database.Page[*entities.Ticket]{Items: []*entities.Ticket{{ID: "123"}}, Total: 1}, nil)
 		mockPerms.On("GetCredentialID").Return(&credentialID)
 
 		// Appeler la méthode testée
-		tickets, err := service.GetTickets()
+		tickets, err := service.GetTickets(database.NewList(nil))
 
 		// Assertions
-		assert.Nil(t, err)        // Vérifie qu'il n'y a pas d'erreur
-		assert.NotNil(t, tickets) // Vérifie que des tickets sont retournés
-		assert.Len(t, tickets, 1) // Vérifie le nombre de tickets
+		assert.Nil(t, err)              // Vérifie qu'il n'y a pas d'erreur
+		assert.NotNil(t, tickets)       // Vérifie que des tickets sont retournés
+		assert.Len(t, tickets.Items, 1) // Vérifie le nombre de tickets
+		assert.Equal(t, int64(1), tickets.Total)
 
 		// Vérifications des attentes
 		mockRepo.AssertExpectations(t)
@@ -83,10 +50,10 @@ func Test_GetTickets(t *testing.T) {
 
 		// Configuration des mocks
 		mockPerms.On("GetCredentialID").Return(&credentialID)
-		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return(nil, errors.ErrNoData)
+		mockRepo.On("PaginateTickets", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.ErrNoData)
 
 		// Appeler la méthode testée
-		tickets, err := service.GetTickets()
+		tickets, err := service.GetTickets(database.NewList(nil))
 
 		// Assertions
 		assert.NotNil(t, err)
@@ -97,109 +64,99 @@ func Test_GetTickets(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 		mockPerms.AssertExpectations(t)
 	})
+
+	t.Run("Should refuse a nil list", func(t *testing.T) {
+		service, _, _ := setup()
+
+		_, err := service.GetTickets(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
 }
 
 func Test_UpdateTicket(t *testing.T) {
 	cid := aws.String("client-123")
-	t.Run("Should return updated ticket", func(t *testing.T) {
-		service, mockRepo, mockPerms := setup()
+	ip := aws.String("203.0.113.7")
+	generated, _ := token.Generate(12)
+	code := generated.PointerString()
 
-		dto := &transfert.Ticket{
-			CredentialID: cid,
-		}
-
-		ticket := &entities.Ticket{
-			ID:           "ticket-123",
-			CredentialID: cid,
-		}
+	t.Run("Should claim the ticket matching the code", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := &transfert.Ticket{Token: code}
 
-		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
 		mockPerms.On("IsAuthenticated").Return(true)
 		mockPerms.On("GetCredentialID").Return(cid)
-		mockRepo.On("UpdateTicket", ticket, mock.Anything).Return(nil)
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: code}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
 
-		updatedTicket, err := service.UpdateTicket(dto)
+		ticket, err := service.UpdateTicket(dto)
 		assert.Nil(t, err)
-		assert.NotNil(t, updatedTicket)
-
-		assert.Equal(t, ticket, updatedTicket)
-
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+		assert.Equal(t, cid, ticket.CredentialID)
 		mockRepo.AssertExpectations(t)
-		mockPerms.AssertExpectations(t)
 	})
 
-	t.Run("Should return error when ticket not found", func(t *testing.T) {
-		service, mockRepo, mockPerms := setup()
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setup()
 
-		dto := &transfert.Ticket{
-			CredentialID: cid,
-		}
+		ticket, err := service.UpdateTicket(nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
 
-		mockRepo.On("ReadTicket", dto, mock.Anything).Return(nil, errors.ErrNoData)
+	t.Run("Should refuse an invalid check digit before any query", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
 		mockPerms.On("IsAuthenticated").Return(true)
 
-		updatedTicket, err := service.UpdateTicket(dto)
-		assert.NotNil(t, err)
-		assert.Nil(t, updatedTicket)
-		assert.Equal(t, errors.ErrNoData, err)
-
-		mockRepo.AssertExpectations(t)
+		ticket, err := service.UpdateTicket(&transfert.Ticket{ID: aws.String("ticket-123"), Token: aws.String("000000000001")})
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrValueIsNotLuhn, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ClaimTicket", mock.Anything, mock.Anything)
 	})
 
-	t.Run("Should return error when unauthorized", func(t *testing.T) {
+	t.Run("Should count an unknown code toward the lockout", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		dto := &transfert.Ticket{
-			CredentialID: cid,
-		}
-
-		ticket := &entities.Ticket{
-			ID:           "ticket-123",
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+		mockRepo.On("CreateClaimAttempt", &transfert.ClaimAttempt{
 			CredentialID: cid,
-		}
-
-		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
-		mockPerms.On("IsAuthenticated").Return(false)
-
-		updatedTicket, err := service.UpdateTicket(dto)
-		assert.NotNil(t, err)
-		assert.Nil(t, updatedTicket)
-		assert.Equal(t, errors.ErrUnauthorized, err)
+			IP:           ip,
+			Token:        code,
+			Reason:       aws.String(entities.ClaimFailureNotFound),
+		}, mock.Anything).Return(&entities.ClaimAttempt{}, nil)
 
+		ticket, err := service.UpdateTicket(&transfert.Ticket{Token: code})
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
 		mockRepo.AssertExpectations(t)
-		mockPerms.AssertExpectations(t)
 	})
 
-	t.Run("Should return error when update fails", func(t *testing.T) {
+	t.Run("Should refuse the claims of a locked credential", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		dto := &transfert.Ticket{
-			CredentialID: cid,
-		}
-
-		ticket := &entities.Ticket{
-			ID:           "ticket-123",
-			CredentialID: cid,
-		}
-
-		// Configuration des mocks
-		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
 		mockPerms.On("IsAuthenticated").Return(true)
 		mockPerms.On("GetCredentialID").Return(cid)
-		mockRepo.On("UpdateTicket", ticket, mock.Anything).Return(errors.ErrNoData)
+		mockRepo.On("ReadClaimAttempts", &transfert.ClaimAttempt{CredentialID: cid}, mock.Anything).Return(failures(5, time.Now()), nil)
 
-		// Appel de la méthode à tester
-		updatedTicket, err := service.UpdateTicket(dto)
+		ticket, err := service.UpdateTicket(&transfert.Ticket{Token: code})
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketClaimLocked, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
 
-		// Vérification des résultats
-		assert.NotNil(t, err)
-		assert.Nil(t, updatedTicket)
-		assert.Equal(t, errors.ErrNoData, err)
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		mockPerms.On("IsAuthenticated").Return(false)
 
-		mockRepo.AssertExpectations(t)
-		mockPerms.AssertExpectations(t)
+		ticket, err := service.UpdateTicket(&transfert.Ticket{Token: code})
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
 	})
-
 }
 
 func Test_GetTicketById(t *testing.T) {
@@ -215,7 +172,8 @@ func Test_GetTicketById(t *testing.T) {
 		}
 
 		// Configuration des mocks
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-id"))
 		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
 
 		// Appel de la méthode à tester
@@ -226,6 +184,16 @@ func Test_GetTicketById(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, ticket, result)
 
+		// La consultation est inscrite dans l'historique du ticket
+		mockRepo.AssertCalled(t, "CreateTicketEvent", &transfert.TicketEvent{
+			TicketID:     aws.String("ticket-123"),
+			Action:       aws.String(entities.TicketEventViewed),
+			CredentialID: aws.String("employee-id"),
+			Role:         aws.String(string(user.ROLE_EMPLOYEE)),
+			IP:           aws.String("203.0.113.7"),
+			UserAgent:    aws.String("curl/8.0"),
+		}, mock.Anything)
+
 		mockRepo.AssertExpectations(t)
 		mockPerms.AssertExpectations(t)
 	})
@@ -238,7 +206,7 @@ func Test_GetTicketById(t *testing.T) {
 		}
 
 		// Configuration des mocks
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
 
 		// Appel de la méthode à tester
 		result, err := service.GetTicketById(dto)
@@ -259,7 +227,7 @@ func Test_GetTicketById(t *testing.T) {
 		}
 
 		// Configuration des mocks
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		mockRepo.On("ReadTicket", dto, mock.Anything).Return(nil, errors.ErrNoData)
 
 		// Appel de la méthode à tester
@@ -282,7 +250,7 @@ func Test_GetTicketById(t *testing.T) {
 		}
 
 		// Configuration des mocks
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		mockRepo.On("ReadTicket", dto, mock.Anything).Return(nil, errors.ErrBadRequest)
 
 		// Appel de la méthode à tester
@@ -298,3 +266,286 @@ func Test_GetTicketById(t *testing.T) {
 	})
 
 }
+
+func Test_RedeemTicket(t *testing.T) {
+	employee := aws.String("employee-123")
+	dto := &transfert.Redemption{
+		TicketID: aws.String("ticket-123"),
+		CaisseID: aws.String("caisse-123"),
+	}
+	caisse := &storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-123")}
+
+	t.Run("Should redeem a claimed ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		ticket := &entities.Ticket{
+			ID:           "ticket-123",
+			CredentialID: aws.String("client-123"),
+			Status:       entities.TicketClaimed,
+		}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: dto.CaisseID}, mock.Anything).Return(caisse, nil)
+		mockPerms.On("GetCredentialID").Return(employee)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadShift", &transfert.Shift{CaisseID: dto.CaisseID}, mock.Anything).Return(&entities.Shift{ID: "shift-123"}, nil)
+		mockRepo.On("RedeemTicket", ticket, mock.Anything).Return(nil)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketRedeemed, result.Status)
+		assert.Equal(t, dto.CaisseID, result.RedeemedCaisseID)
+		assert.Equal(t, employee, result.RedeemedBy)
+		assert.Equal(t, "shift-123", *result.RedeemedShiftID)
+		assert.NotNil(t, result.RedeemedAt)
+
+		mockRepo.AssertCalled(t, "CreateTicketEvent", mock.MatchedBy(func(obj *transfert.TicketEvent) bool {
+			return *obj.Action == entities.TicketEventRedeemed && *obj.CredentialID == *employee
+		}), mock.Anything)
+		mockRepo.AssertExpectations(t)
+		mockPerms.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse a ticket already redeemed", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		ticket := &entities.Ticket{
+			ID:           "ticket-123",
+			CredentialID: aws.String("client-123"),
+			Status:       entities.TicketRedeemed,
+		}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: dto.CaisseID}, mock.Anything).Return(caisse, nil)
+		mockPerms.On("GetCredentialID").Return(employee)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(ticket, nil)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+
+		mockRepo.AssertNotCalled(t, "RedeemTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a ticket redeemed concurrently at another caisse", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		ticket := &entities.Ticket{
+			ID:           "ticket-123",
+			CredentialID: aws.String("client-123"),
+			Status:       entities.TicketClaimed,
+		}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: dto.CaisseID}, mock.Anything).Return(caisse, nil)
+		mockPerms.On("GetCredentialID").Return(employee)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound)
+		mockRepo.On("RedeemTicket", ticket, mock.Anything).Return(errors_domain_game.ErrTicketAlreadyRedeemed)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+
+		mockRepo.AssertNotCalled(t, "CreateTicketEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a ticket not claimed", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: dto.CaisseID}, mock.Anything).Return(caisse, nil)
+		mockPerms.On("GetCredentialID").Return(employee)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketNotClaimed, err)
+	})
+
+	t.Run("Should return error when ticket not found", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", &storeTransfert.Caisse{ID: dto.CaisseID}, mock.Anything).Return(caisse, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+	})
+
+	t.Run("Should refuse a caisse out of the stores of the employee", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(false)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_CampaignWindows(t *testing.T) {
+	cid := aws.String("client-123")
+	campaignID := aws.String("campaign-123")
+	now := time.Now()
+
+	generated, _ := token.Generate(12)
+	code := generated.PointerString()
+
+	campaign := func(start, end, claimUntil time.Duration) *entities.Campaign {
+		return &entities.Campaign{
+			ID:         *campaignID,
+			StartAt:    now.Add(start),
+			EndAt:      now.Add(end),
+			ClaimUntil: now.Add(claimUntil),
+		}
+	}
+
+	claim := func(c *entities.Campaign) (*entities.Ticket, *GameRepositoryMock, error) {
+		service, mockRepo, mockPerms := setup()
+		dto := &transfert.Claim{Token: code}
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: code}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", CampaignID: campaignID}, nil)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: campaignID}, mock.Anything).Return(c, nil)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+
+		ticket, err := service.ClaimTicket(dto)
+		if err != nil {
+			return ticket, mockRepo, err
+		}
+
+		return ticket, mockRepo, nil
+	}
+
+	t.Run("Should accept a claim during the participation window", func(t *testing.T) {
+		ticket, _, err := claim(campaign(-time.Hour, time.Hour, 2*time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+	})
+
+	t.Run("Should refuse a claim before the campaign starts", func(t *testing.T) {
+		ticket, mockRepo, err := claim(campaign(time.Hour, 2*time.Hour, 3*time.Hour))
+		assert.Equal(t, errors_domain_game.ErrCampaignNotStarted, err)
+		assert.Nil(t, ticket)
+		mockRepo.AssertNotCalled(t, "ClaimTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a claim after the campaign ends", func(t *testing.T) {
+		ticket, mockRepo, err := claim(campaign(-2*time.Hour, -time.Hour, time.Hour))
+		assert.Equal(t, errors_domain_game.ErrCampaignEnded, err)
+		assert.Nil(t, ticket)
+		mockRepo.AssertNotCalled(t, "ClaimTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should ignore a campaign that no longer exists", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := &transfert.Claim{Token: code}
+
+		mockRepo.On("ReadClaimAttempts", mock.Anything, mock.Anything).Return([]*entities.ClaimAttempt{}, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: code}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", CampaignID: campaignID}, nil)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: campaignID}, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+		mockRepo.On("ClaimTicket", mock.Anything, mock.Anything).Return(nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+
+		ticket, err := service.ClaimTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, ticket.Status)
+	})
+
+	redeem := func(c *entities.Campaign, ticket *entities.Ticket) (*entities.Ticket, *GameRepositoryMock, error) {
+		service, mockRepo, mockPerms, mockStores := setupStores()
+		dto := &transfert.Redemption{TicketID: aws.String("ticket-123"), CaisseID: aws.String("caisse-123")}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(&storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-123")}, nil)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-123"))
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: campaignID}, mock.Anything).Return(c, nil)
+		mockRepo.On("ReadShift", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrShiftNotFound)
+		mockRepo.On("ExpireTicket", ticket, mock.Anything).Return(nil)
+		mockRepo.On("RedeemTicket", ticket, mock.Anything).Return(nil)
+
+		result, err := service.RedeemTicket(dto)
+		if err != nil {
+			return result, mockRepo, err
+		}
+
+		return result, mockRepo, nil
+	}
+
+	t.Run("Should redeem a prize after the campaign ended but before the deadline", func(t *testing.T) {
+		ticket := &entities.Ticket{ID: "ticket-123", CredentialID: cid, Status: entities.TicketClaimed, CampaignID: campaignID}
+
+		result, _, err := redeem(campaign(-2*time.Hour, -time.Hour, time.Hour), ticket)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketRedeemed, result.Status)
+	})
+
+	t.Run("Should expire the ticket after the claim deadline", func(t *testing.T) {
+		ticket := &entities.Ticket{ID: "ticket-123", CredentialID: cid, Status: entities.TicketClaimed, CampaignID: campaignID}
+
+		result, mockRepo, err := redeem(campaign(-3*time.Hour, -2*time.Hour, -time.Hour), ticket)
+		assert.Equal(t, errors_domain_game.ErrCampaignClaimExpired, err)
+		assert.Nil(t, result)
+		assert.Equal(t, entities.TicketExpired, ticket.Status)
+		assert.Nil(t, ticket.RedeemedAt)
+		mockRepo.AssertCalled(t, "ExpireTicket", ticket, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertCalled(t, "CreateTicketEvent", mock.MatchedBy(func(obj *transfert.TicketEvent) bool {
+			return *obj.TicketID == "ticket-123" && *obj.Action == entities.TicketEventExpired
+		}), mock.Anything)
+	})
+
+	t.Run("Should not expire a ticket redeemed concurrently", func(t *testing.T) {
+		ticket := &entities.Ticket{ID: "ticket-123", CredentialID: cid, Status: entities.TicketClaimed, CampaignID: campaignID}
+		service, mockRepo, mockPerms, mockStores := setupStores()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN, security.ROLE_DEVICE}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockStores.On("ReadCaisse", mock.Anything, mock.Anything).Return(&storeEntity.Caisse{ID: "caisse-123", StoreID: aws.String("store-123")}, nil)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(campaign(-3*time.Hour, -2*time.Hour, -time.Hour), nil)
+		mockRepo.On("ExpireTicket", ticket, mock.Anything).Return(errors_domain_game.ErrTicketNotClaimed)
+
+		result, err := service.RedeemTicket(&transfert.Redemption{TicketID: aws.String("ticket-123"), CaisseID: aws.String("caisse-123")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketNotClaimed, err)
+		mockRepo.AssertNotCalled(t, "CreateTicketEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should keep reporting a redeemed ticket after the claim deadline", func(t *testing.T) {
+		ticket := &entities.Ticket{ID: "ticket-123", CredentialID: cid, Status: entities.TicketRedeemed, CampaignID: campaignID}
+
+		result, mockRepo, err := redeem(campaign(-3*time.Hour, -2*time.Hour, -time.Hour), ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "RedeemTicket", mock.Anything, mock.Anything)
+	})
+}
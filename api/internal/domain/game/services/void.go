@@ -0,0 +1,146 @@
+package services
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+)
+
+// CodeAttempts bounds the codes drawn for a replacement when they are already used by other tickets
+const CodeAttempts = 5
+
+// VoidTicket takes a damaged or misprinted ticket out of the game
+// Only admins can void a ticket. The ticket keeps its record with the reason of the void,
+// the next generation of tickets replaces it in the share of its prize.
+//
+// Parameters:
+// - dto: *transfert.Void the ticket and the reason of the void
+//
+// Returns:
+// - *entities.Ticket: the voided ticket
+// - errors.ErrorInterface: an error if the ticket cannot be voided
+func (s *GameService) VoidTicket(dto *transfert.Void) (*entities.Ticket, errors.ErrorInterface) {
+	ticket, err := s.voidable(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.Transaction(func(repo repositories.GameRepositoryInterface) errors.ErrorInterface {
+		if err := repo.VoidTicket(ticket); err != nil {
+			return err
+		}
+
+		return s.record(repo, ticket, entities.TicketEventVoided)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.releaseStock(ticket)
+
+	return ticket, nil
+}
+
+// ReissueTicket voids a ticket and replaces it under a fresh code
+// Only admins can reissue a ticket. The replacement carries over the prize, the campaign and the
+// distribution of the voided ticket, both are stored at once so the share of the prize is unchanged.
+//
+// Parameters:
+// - dto: *transfert.Void the ticket and the reason of the void
+//
+// Returns:
+// - *entities.Ticket: the replacement
+// - errors.ErrorInterface: an error if the ticket cannot be reissued
+func (s *GameService) ReissueTicket(dto *transfert.Void) (*entities.Ticket, errors.ErrorInterface) {
+	ticket, err := s.voidable(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.freshCode()
+	if err != nil {
+		return nil, err
+	}
+
+	replacement := ticket.Reissue(code)
+
+	err = s.repo.Transaction(func(repo repositories.GameRepositoryInterface) errors.ErrorInterface {
+		if err := repo.ReissueTicket(ticket, replacement); err != nil {
+			return err
+		}
+
+		if err := s.record(repo, ticket, entities.TicketEventVoided); err != nil {
+			return err
+		}
+
+		return s.record(repo, replacement, entities.TicketEventReissued)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.releaseStock(ticket)
+
+	return replacement, nil
+}
+
+// voidable reads the ticket of the dto and voids it in memory, nothing is stored yet
+//
+// Parameters:
+// - dto: *transfert.Void the ticket and the reason of the void
+//
+// Returns:
+// - *entities.Ticket: the voided ticket
+// - errors.ErrorInterface: an error if the user is not an admin or the ticket is final
+func (s *GameService) voidable(dto *transfert.Void) (*entities.Ticket, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{ID: dto.TicketID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only final states refuse a void, they are explained as for a redemption
+	if !ticket.Void(dto.Reason, s.security.GetCredentialID()) {
+		return nil, redemptionError(ticket.GetStatus())
+	}
+
+	return ticket, nil
+}
+
+// freshCode draws a ticket code that no other ticket uses
+//
+// Returns:
+// - token.Luhn: the code
+// - errors.ErrorInterface: ErrTicketCodesExhausted if the CodeAttempts codes drawn are all used
+func (s *GameService) freshCode() (token.Luhn, errors.ErrorInterface) {
+	for range CodeAttempts {
+		code, err := token.Tickets().Generate()
+		if err != nil {
+			return "", errors.ErrInternalServer.Log(err)
+		}
+
+		used, cerr := s.repo.CountTicket(&transfert.Ticket{Token: code.PointerString()})
+		if cerr != nil {
+			return "", cerr
+		}
+
+		if used == 0 {
+			return code, nil
+		}
+	}
+
+	return "", errors_domain_game.ErrTicketCodesExhausted
+}
@@ -0,0 +1,244 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_VoidTicket(t *testing.T) {
+	admin := aws.String("admin-123")
+	dto := &transfert.Void{
+		TicketID: aws.String("ticket-123"),
+		Reason:   aws.String("misprinted"),
+	}
+
+	t.Run("Should void a ticket and keep its record", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Status: entities.TicketUnclaimed}, nil)
+		mockRepo.On("VoidTicket", mock.Anything, mock.Anything).Return(nil)
+
+		ticket, err := service.VoidTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketVoided, ticket.Status)
+		assert.Equal(t, dto.Reason, ticket.VoidReason)
+		assert.Equal(t, admin, ticket.VoidedBy)
+
+		mockRepo.AssertNotCalled(t, "DeleteTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertCalled(t, "CreateTicketEvent", mock.MatchedBy(func(obj *transfert.TicketEvent) bool {
+			return *obj.TicketID == "ticket-123" && *obj.Action == entities.TicketEventVoided
+		}), mock.Anything)
+	})
+
+	t.Run("Should return error when the history cannot be written", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		// L'échec de l'historique remplace l'attente par défaut de setup
+		mockRepo.ExpectedCalls = nil
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Status: entities.TicketUnclaimed}, nil)
+		mockRepo.On("VoidTicket", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("CreateTicketEvent", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		ticket, err := service.VoidTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+
+	t.Run("Should refuse to void a redeemed ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Status: entities.TicketRedeemed}, nil)
+
+		ticket, err := service.VoidTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+		mockRepo.AssertNotCalled(t, "VoidTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse to void a ticket twice", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Status: entities.TicketVoided}, nil)
+
+		ticket, err := service.VoidTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketVoided, err)
+	})
+
+	t.Run("Should return error when ticket is finalized in the meantime", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Status: entities.TicketClaimed}, nil)
+		mockRepo.On("VoidTicket", mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketVoided)
+
+		ticket, err := service.VoidTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketVoided, err)
+		mockRepo.AssertNotCalled(t, "CreateTicketEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when ticket not found", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		ticket, err := service.VoidTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+	})
+
+	t.Run("Should return error when not admin", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		ticket, err := service.VoidTicket(dto)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when dto is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		ticket, err := service.VoidTicket(nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func Test_ReissueTicket(t *testing.T) {
+	admin := aws.String("admin-123")
+	dto := &transfert.Void{
+		TicketID: aws.String("ticket-123"),
+		Reason:   aws.String("damaged"),
+	}
+
+	voidable := func() *entities.Ticket {
+		return &entities.Ticket{
+			ID:         "ticket-123",
+			Token:      "000000000018",
+			Status:     entities.TicketUnclaimed,
+			PrizeID:    aws.String("prize-123"),
+			CampaignID: aws.String("campaign-123"),
+			StoreID:    aws.String("store-123"),
+		}
+	}
+
+	t.Run("Should replace the ticket by a fresh code of the same prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.TicketID}, mock.Anything).Return(voidable(), nil)
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(0, nil)
+		mockRepo.On("ReissueTicket", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		replacement, err := service.ReissueTicket(dto)
+		assert.Nil(t, err)
+		assert.NotEqual(t, "000000000018", replacement.Token.String())
+		assert.Equal(t, "prize-123", *replacement.PrizeID)
+		assert.Equal(t, "campaign-123", *replacement.CampaignID)
+		assert.Equal(t, "store-123", *replacement.StoreID)
+		assert.Equal(t, "ticket-123", *replacement.ReissuedFromID)
+
+		mockRepo.AssertCalled(t, "ReissueTicket", mock.MatchedBy(func(voided *entities.Ticket) bool {
+			return voided.Status == entities.TicketVoided && *voided.VoidReason == "damaged"
+		}), replacement, mock.Anything)
+		mockRepo.AssertCalled(t, "CreateTicketEvent", mock.MatchedBy(func(obj *transfert.TicketEvent) bool {
+			return *obj.TicketID == "ticket-123" && *obj.Action == entities.TicketEventVoided
+		}), mock.Anything)
+		mockRepo.AssertCalled(t, "CreateTicketEvent", mock.MatchedBy(func(obj *transfert.TicketEvent) bool {
+			return *obj.Action == entities.TicketEventReissued
+		}), mock.Anything)
+	})
+
+	t.Run("Should draw another code when the first one is taken", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(voidable(), nil)
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(1, nil).Once()
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(0, nil)
+		mockRepo.On("ReissueTicket", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.ReissueTicket(dto)
+		assert.Nil(t, err)
+		mockRepo.AssertNumberOfCalls(t, "CountTicket", 2)
+	})
+
+	t.Run("Should give up when every code drawn is taken", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(voidable(), nil)
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(1, nil)
+
+		replacement, err := service.ReissueTicket(dto)
+		assert.Nil(t, replacement)
+		assert.Equal(t, errors_domain_game.ErrTicketCodesExhausted, err)
+		mockRepo.AssertNumberOfCalls(t, "CountTicket", services.CodeAttempts)
+		mockRepo.AssertNotCalled(t, "ReissueTicket", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse to reissue an expired ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Status: entities.TicketExpired}, nil)
+
+		replacement, err := service.ReissueTicket(dto)
+		assert.Nil(t, replacement)
+		assert.Equal(t, errors_domain_game.ErrTicketExpired, err)
+		mockRepo.AssertNotCalled(t, "ReissueTicket", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when the reissue fails", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("GetCredentialID").Return(admin)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(voidable(), nil)
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(0, nil)
+		mockRepo.On("ReissueTicket", mock.Anything, mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		replacement, err := service.ReissueTicket(dto)
+		assert.Nil(t, replacement)
+		assert.Equal(t, errors.ErrInternalServer, err)
+		mockRepo.AssertNotCalled(t, "CreateTicketEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when not admin", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		replacement, err := service.ReissueTicket(dto)
+		assert.Nil(t, replacement)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kodmain/thetiptop/api/config"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"gorm.io/gorm"
+)
+
+type Devices []*Device
+
+// Device is a caisse terminal, paired once with a one-time code then authenticated by its own token
+type Device struct {
+	// Gorm model
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Entity
+	Label     *string     `gorm:"type:varchar(255)" json:"label,omitempty"`
+	Code      *token.Luhn `gorm:"type:varchar(8);uniqueIndex" json:"code,omitempty"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+	PairedAt  *time.Time  `json:"paired_at,omitempty"`
+	RevokedAt *time.Time  `json:"revoked_at,omitempty"`
+
+	// Relations
+	CaisseID  *string `gorm:"type:varchar(36);index;" json:"caisse_id"`
+	StoreID   *string `gorm:"type:varchar(36);index;" json:"store_id"`
+	CreatedBy *string `gorm:"type:varchar(36);" json:"created_by"`
+}
+
+// BeforeCreate génère l'identifiant et le code d'appairage à usage unique de l'appareil
+func (device *Device) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	device.ID = id.String()
+
+	if device.Code == nil && device.PairedAt == nil {
+		code, err := token.Pairings().Generate()
+		if err != nil {
+			return err
+		}
+
+		duration, err := time.ParseDuration(config.GetString("security.pairing.expire", "10m"))
+		if err != nil {
+			return err
+		}
+
+		expiresAt := time.Now().Add(duration)
+		device.Code = code.Pointer()
+		device.ExpiresAt = &expiresAt
+	}
+
+	return nil
+}
+
+func (device *Device) IsPublic() bool {
+	return false
+}
+
+func (device *Device) GetOwnerID() string {
+	if device.CreatedBy == nil {
+		return ""
+	}
+
+	return *device.CreatedBy
+}
+
+// IsPaired reports whether the device exchanged its pairing code
+func (device *Device) IsPaired() bool {
+	return device.PairedAt != nil
+}
+
+// IsRevoked reports whether the device credential was revoked
+func (device *Device) IsRevoked() bool {
+	return device.RevokedAt != nil
+}
+
+// IsActive reports whether the device credential may still be used
+func (device *Device) IsActive() bool {
+	return device.IsPaired() && !device.IsRevoked()
+}
+
+// CanPair reports whether the pairing code is still usable at the given time
+func (device *Device) CanPair(now time.Time) bool {
+	if device.IsPaired() || device.IsRevoked() || device.Code == nil {
+		return false
+	}
+
+	return device.ExpiresAt == nil || now.Before(*device.ExpiresAt)
+}
+
+// Pair consumes the pairing code, the code cannot be used twice
+func (device *Device) Pair(now time.Time) bool {
+	if !device.CanPair(now) {
+		return false
+	}
+
+	device.PairedAt = &now
+	device.Code = nil
+	device.ExpiresAt = nil
+
+	return true
+}
+
+// Revoke revokes the device credential, a revoked device is never granted again
+func (device *Device) Revoke(now time.Time) bool {
+	if device.IsRevoked() {
+		return false
+	}
+
+	device.RevokedAt = &now
+	device.Code = nil
+	device.ExpiresAt = nil
+
+	return true
+}
+
+func CreateDevice(obj *transfert.Device) *Device {
+	return &Device{
+		Label:     obj.Label,
+		CaisseID:  obj.CaisseID,
+		StoreID:   obj.StoreID,
+		CreatedBy: obj.CreatedBy,
+	}
+}
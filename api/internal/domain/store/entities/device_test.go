@@ -0,0 +1,92 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCreateDevice(t *testing.T) {
+	caisseID := uuid.NewString()
+	storeID := uuid.NewString()
+	credentialID := uuid.NewString()
+	label := "Comptoir"
+
+	device := entities.CreateDevice(&transfert.Device{
+		Label:     &label,
+		CaisseID:  &caisseID,
+		StoreID:   &storeID,
+		CreatedBy: &credentialID,
+	})
+
+	assert.Equal(t, label, *device.Label)
+	assert.Equal(t, caisseID, *device.CaisseID)
+	assert.Equal(t, storeID, *device.StoreID)
+	assert.Equal(t, credentialID, device.GetOwnerID())
+	assert.False(t, device.IsPublic())
+	assert.Equal(t, "", entities.CreateDevice(&transfert.Device{}).GetOwnerID())
+}
+
+func TestDevice_Lifecycle(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Minute)
+
+	device := &entities.Device{Code: token.NewLuhn("ABCDEFGH").Pointer(), ExpiresAt: &expiresAt}
+	assert.False(t, device.IsPaired())
+	assert.False(t, device.IsActive())
+	assert.True(t, device.CanPair(now))
+	assert.False(t, device.CanPair(expiresAt.Add(time.Second)))
+
+	// Le code d'appairage ne sert qu'une fois
+	assert.True(t, device.Pair(now))
+	assert.Nil(t, device.Code)
+	assert.True(t, device.IsActive())
+	assert.False(t, device.Pair(now))
+
+	assert.True(t, device.Revoke(now))
+	assert.True(t, device.IsRevoked())
+	assert.False(t, device.IsActive())
+	assert.False(t, device.Revoke(now))
+
+	// Un appareil révoqué avant son appairage ne peut plus être appairé
+	pending := &entities.Device{Code: token.NewLuhn("ABCDEFGH").Pointer()}
+	assert.True(t, pending.Revoke(now))
+	assert.False(t, pending.CanPair(now))
+}
+
+func TestDevice_CRUD(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.Nil(t, err)
+
+	err = db.AutoMigrate(&entities.Device{})
+	assert.Nil(t, err)
+
+	caisseID := uuid.NewString()
+
+	device := &entities.Device{CaisseID: &caisseID}
+	assert.Nil(t, db.Create(device).Error)
+	assert.NotEmpty(t, device.ID)
+	assert.NotNil(t, device.Code)
+	assert.Nil(t, token.Pairings().Validate(*device.Code))
+	assert.True(t, device.ExpiresAt.After(time.Now()))
+
+	// Les appareils appairés n'ont plus de code, plusieurs peuvent coexister
+	assert.True(t, device.Pair(time.Now()))
+	assert.Nil(t, db.Save(device).Error)
+
+	other := &entities.Device{CaisseID: &caisseID}
+	assert.Nil(t, db.Create(other).Error)
+	assert.True(t, other.Pair(time.Now()))
+	assert.Nil(t, db.Save(other).Error)
+
+	var paired int64
+	assert.Nil(t, db.Model(&entities.Device{}).Where("paired_at IS NOT NULL").Count(&paired).Error)
+	assert.Equal(t, int64(2), paired)
+}
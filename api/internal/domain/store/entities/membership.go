@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"gorm.io/gorm"
+)
+
+type Memberships []*Membership
+
+// Membership assigns an employee to a store, the employee only operates the caisses of its stores
+type Membership struct {
+	// Gorm model
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	StoreID      *string `gorm:"type:varchar(36);uniqueIndex:idx_membership;" json:"store_id"`
+	CredentialID *string `gorm:"type:varchar(36);uniqueIndex:idx_membership;index;" json:"credential_id"`
+}
+
+func (membership *Membership) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	membership.ID = id.String()
+
+	return nil
+}
+
+func (membership *Membership) IsPublic() bool {
+	return false
+}
+
+func (membership *Membership) GetOwnerID() string {
+	if membership.CredentialID == nil {
+		return ""
+	}
+
+	return *membership.CredentialID
+}
+
+func CreateMembership(obj *transfert.Membership) *Membership {
+	return &Membership{
+		StoreID:      obj.StoreID,
+		CredentialID: obj.CredentialID,
+	}
+}
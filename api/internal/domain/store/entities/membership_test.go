@@ -0,0 +1,45 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCreateMembership(t *testing.T) {
+	storeID := uuid.NewString()
+	credentialID := uuid.NewString()
+
+	membership := entities.CreateMembership(&transfert.Membership{
+		StoreID:      &storeID,
+		CredentialID: &credentialID,
+	})
+
+	assert.Equal(t, storeID, *membership.StoreID)
+	assert.Equal(t, credentialID, membership.GetOwnerID())
+	assert.False(t, membership.IsPublic())
+	assert.Equal(t, "", entities.CreateMembership(&transfert.Membership{}).GetOwnerID())
+}
+
+func TestMembership_CRUD(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.Nil(t, err)
+
+	err = db.AutoMigrate(&entities.Membership{})
+	assert.Nil(t, err)
+
+	storeID := uuid.NewString()
+	credentialID := uuid.NewString()
+
+	membership := &entities.Membership{StoreID: &storeID, CredentialID: &credentialID}
+	assert.Nil(t, db.Create(membership).Error)
+	assert.NotEmpty(t, membership.ID)
+
+	// Un employé n'est rattaché qu'une fois à un magasin
+	assert.NotNil(t, db.Create(&entities.Membership{StoreID: &storeID, CredentialID: &credentialID}).Error)
+}
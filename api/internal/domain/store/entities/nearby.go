@@ -0,0 +1,63 @@
+package entities
+
+import "math"
+
+// EarthRadius is the mean radius of the Earth in kilometers
+const EarthRadius = 6371.0
+
+// NearbyStore is a store found around a position
+type NearbyStore struct {
+	*Store
+	Distance float64 `json:"distance"`
+	IsOpen   bool    `json:"is_open"`
+}
+
+// Distance returns the great-circle distance in kilometers between two positions, computed with the haversine formula
+//
+// Parameters:
+// - lat1, lng1: float64 the first position in degrees
+// - lat2, lng2: float64 the second position in degrees
+//
+// Returns:
+// - float64: the distance in kilometers
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the latitudes and longitudes enclosing the circle around a position
+// It narrows the search in the database before the exact distance is computed.
+//
+// Parameters:
+// - lat, lng: float64 the center in degrees
+// - radius: float64 the radius in kilometers
+//
+// Returns:
+// - float64: the minimal and maximal latitudes then longitudes
+func BoundingBox(lat, lng, radius float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := degrees(radius / EarthRadius)
+	minLat, maxLat = math.Max(-90, lat-dLat), math.Min(90, lat+dLat)
+
+	if minLat == -90 || maxLat == 90 {
+		return minLat, maxLat, -180, 180
+	}
+
+	dLng := degrees(math.Asin(math.Min(1, math.Sin(radius/EarthRadius)/math.Cos(radians(lat)))))
+	if minLng, maxLng = lng-dLng, lng+dLng; minLng < -180 || maxLng > 180 {
+		return minLat, maxLat, -180, 180
+	}
+
+	return minLat, maxLat, minLng, maxLng
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,42 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	assert.Zero(t, entities.Distance(48.8566, 2.3522, 48.8566, 2.3522))
+	assert.InDelta(t, 392, entities.Distance(48.8566, 2.3522, 45.7640, 4.8357), 2)
+	assert.InDelta(t, entities.Distance(48.8566, 2.3522, 45.7640, 4.8357), entities.Distance(45.7640, 4.8357, 48.8566, 2.3522), 1e-9)
+}
+
+func TestBoundingBox(t *testing.T) {
+	t.Run("encloses the circle", func(t *testing.T) {
+		minLat, maxLat, minLng, maxLng := entities.BoundingBox(48.8566, 2.3522, 10)
+
+		assert.Less(t, minLat, 48.8566)
+		assert.Greater(t, maxLat, 48.8566)
+		assert.Less(t, minLng, 2.3522)
+		assert.Greater(t, maxLng, 2.3522)
+		assert.InDelta(t, 10, entities.Distance(48.8566, 2.3522, maxLat, 2.3522), 0.01)
+		assert.GreaterOrEqual(t, entities.Distance(48.8566, 2.3522, 48.8566, maxLng), 10.0)
+	})
+
+	t.Run("covers every longitude near a pole", func(t *testing.T) {
+		_, maxLat, minLng, maxLng := entities.BoundingBox(89.99, 0, 10)
+
+		assert.Equal(t, 90.0, maxLat)
+		assert.Equal(t, -180.0, minLng)
+		assert.Equal(t, 180.0, maxLng)
+	})
+
+	t.Run("covers every longitude across the antimeridian", func(t *testing.T) {
+		_, _, minLng, maxLng := entities.BoundingBox(0, 179.99, 10)
+
+		assert.Equal(t, -180.0, minLng)
+		assert.Equal(t, 180.0, maxLng)
+	})
+}
@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"gorm.io/gorm"
+)
+
+const clock = "15:04"
+
+type Openings []*Opening
+
+// Opening is a weekly time slot during which a store welcomes its clients
+type Opening struct {
+	// Gorm model
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"-"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Relations
+	StoreID *string `gorm:"type:varchar(36);index;" json:"-"`
+
+	Weekday time.Weekday `gorm:"type:integer" json:"weekday"`
+	Opens   string       `gorm:"type:varchar(5)" json:"opens"`
+	Closes  string       `gorm:"type:varchar(5)" json:"closes"`
+}
+
+func (opening *Opening) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	opening.ID = id.String()
+
+	return nil
+}
+
+func CreateOpening(obj *transfert.Opening) *Opening {
+	return &Opening{
+		Weekday: time.Weekday(*obj.Weekday),
+		Opens:   *obj.Opens,
+		Closes:  *obj.Closes,
+	}
+}
+
+type OpeningExceptions []*OpeningException
+
+// OpeningException replaces the weekly hours of a store for a day, the store is closed all day without slot
+type OpeningException struct {
+	// Gorm model
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"-"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Relations
+	StoreID *string `gorm:"type:varchar(36);index;" json:"-"`
+
+	Date   string  `gorm:"type:varchar(10);index" json:"date"`
+	Opens  *string `gorm:"type:varchar(5)" json:"opens"`
+	Closes *string `gorm:"type:varchar(5)" json:"closes"`
+	Reason *string `gorm:"type:varchar(255)" json:"reason"`
+}
+
+func (exception *OpeningException) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	exception.ID = id.String()
+
+	return nil
+}
+
+func CreateOpeningException(obj *transfert.OpeningException) *OpeningException {
+	return &OpeningException{
+		Date:   *obj.Date,
+		Opens:  obj.Opens,
+		Closes: obj.Closes,
+		Reason: obj.Reason,
+	}
+}
+
+// Location returns the timezone of the store, UTC when none is set or the name is unknown
+func (store *Store) Location() *time.Location {
+	if store.Timezone != nil {
+		if location, err := time.LoadLocation(*store.Timezone); err == nil {
+			return location
+		}
+	}
+
+	return time.UTC
+}
+
+// IsOpenAt reports whether the store welcomes its clients at the given instant
+// An online store is always open, a physical one follows the exception of the day
+// in its timezone when there is one and its weekly hours otherwise.
+//
+// Parameters:
+// - at: time.Time the instant to check
+//
+// Returns:
+// - bool: true if the store is open
+func (store *Store) IsOpenAt(at time.Time) bool {
+	if store.IsOnline != nil && *store.IsOnline {
+		return true
+	}
+
+	local := at.In(store.Location())
+	day, now := local.Format(time.DateOnly), local.Format(clock)
+
+	for _, exception := range store.Exceptions {
+		if exception.Date == day {
+			return exception.Opens != nil && exception.Closes != nil && within(now, *exception.Opens, *exception.Closes)
+		}
+	}
+
+	for _, opening := range store.Openings {
+		if opening.Weekday == local.Weekday() && within(now, opening.Opens, opening.Closes) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// within reports whether the time of day falls in the slot, the closing time excluded
+func within(now, opens, closes string) bool {
+	return opens <= now && now < closes
+}
@@ -0,0 +1,83 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOpening(t *testing.T) {
+	opening := entities.CreateOpening(&transfert.Opening{
+		Weekday: aws.Int(1),
+		Opens:   aws.String("09:00"),
+		Closes:  aws.String("18:00"),
+	})
+
+	assert.Equal(t, time.Monday, opening.Weekday)
+	assert.Equal(t, "09:00", opening.Opens)
+	assert.Equal(t, "18:00", opening.Closes)
+	assert.Nil(t, opening.BeforeCreate(nil))
+	assert.NotEmpty(t, opening.ID)
+}
+
+func TestCreateOpeningException(t *testing.T) {
+	exception := entities.CreateOpeningException(&transfert.OpeningException{
+		Date:   aws.String("2026-12-25"),
+		Reason: aws.String("Christmas"),
+	})
+
+	assert.Equal(t, "2026-12-25", exception.Date)
+	assert.Nil(t, exception.Opens)
+	assert.Equal(t, "Christmas", *exception.Reason)
+	assert.Nil(t, exception.BeforeCreate(nil))
+	assert.NotEmpty(t, exception.ID)
+}
+
+func TestStore_Location(t *testing.T) {
+	assert.Equal(t, time.UTC, (&entities.Store{}).Location())
+	assert.Equal(t, time.UTC, (&entities.Store{Timezone: aws.String("Nowhere/Unknown")}).Location())
+	assert.Equal(t, "Europe/Paris", (&entities.Store{Timezone: aws.String("Europe/Paris")}).Location().String())
+}
+
+func TestStore_IsOpenAt(t *testing.T) {
+	store := &entities.Store{
+		IsOnline: aws.Bool(false),
+		Timezone: aws.String("Europe/Paris"),
+		Openings: entities.Openings{
+			{Weekday: time.Monday, Opens: "09:00", Closes: "12:00"},
+			{Weekday: time.Monday, Opens: "14:00", Closes: "18:00"},
+		},
+		Exceptions: entities.OpeningExceptions{
+			{Date: "2026-12-21", Opens: aws.String("10:00"), Closes: aws.String("20:00")},
+			{Date: "2026-12-28"},
+		},
+	}
+
+	// 2026-12-14 is a Monday, Paris is at UTC+1 in winter
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{"opening of the morning slot", time.Date(2026, 12, 14, 8, 0, 0, 0, time.UTC), true},
+		{"lunch break", time.Date(2026, 12, 14, 12, 30, 0, 0, time.UTC), false},
+		{"closing time excluded", time.Date(2026, 12, 14, 17, 0, 0, 0, time.UTC), false},
+		{"other weekday", time.Date(2026, 12, 15, 10, 0, 0, 0, time.UTC), false},
+		{"exception with a slot", time.Date(2026, 12, 21, 18, 0, 0, 0, time.UTC), true},
+		{"exception closed all day", time.Date(2026, 12, 28, 10, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, store.IsOpenAt(tt.at))
+		})
+	}
+
+	t.Run("online store", func(t *testing.T) {
+		assert.True(t, (&entities.Store{IsOnline: aws.Bool(true)}).IsOpenAt(time.Now()))
+	})
+}
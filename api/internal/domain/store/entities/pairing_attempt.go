@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"gorm.io/gorm"
+)
+
+// PairingAttemptWindow is the period during which the failed pairings are taken into account
+const PairingAttemptWindow = 24 * time.Hour
+
+// PairingLockout locks the pairings for a duration once a number of failures is reached
+type PairingLockout struct {
+	Failures int
+	Duration time.Duration
+}
+
+// PairingLockouts are the escalating lockouts, sorted by number of failures
+var PairingLockouts = []PairingLockout{
+	{Failures: 5, Duration: time.Minute},
+	{Failures: 10, Duration: 15 * time.Minute},
+	{Failures: 20, Duration: PairingAttemptWindow},
+}
+
+// PairingAttempt is a failed attempt to pair a device, the pairing endpoint being open to anyone
+type PairingAttempt struct {
+	// Gorm model
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Entity
+	IP   *string `gorm:"type:varchar(45);index" json:"ip"`
+	Code *string `gorm:"type:varchar(8)" json:"code"`
+}
+
+// BeforeCreate génère l'identifiant de la tentative
+func (attempt *PairingAttempt) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	attempt.ID = id.String()
+
+	return nil
+}
+
+func CreatePairingAttempt(obj *transfert.PairingAttempt) *PairingAttempt {
+	return &PairingAttempt{
+		IP:   obj.IP,
+		Code: obj.Code,
+	}
+}
+
+// PairingLockedUntil computes the end of the lockout caused by the failed pairings
+// The lockout starts at the last failure and lasts according to the number of failures in the window.
+//
+// Parameters:
+// - attempts: []*PairingAttempt the failed pairings of an IP
+// - now: time.Time the reference time
+//
+// Returns:
+// - time.Time: the end of the lockout, zero if the pairings are not locked
+func PairingLockedUntil(attempts []*PairingAttempt, now time.Time) time.Time {
+	var failures int
+	var last time.Time
+
+	for _, attempt := range attempts {
+		if now.Sub(attempt.CreatedAt) > PairingAttemptWindow {
+			continue
+		}
+
+		failures++
+		if attempt.CreatedAt.After(last) {
+			last = attempt.CreatedAt
+		}
+	}
+
+	var duration time.Duration
+	for _, lockout := range PairingLockouts {
+		if failures >= lockout.Failures {
+			duration = lockout.Duration
+		}
+	}
+
+	if duration == 0 {
+		return time.Time{}
+	}
+
+	return last.Add(duration)
+}
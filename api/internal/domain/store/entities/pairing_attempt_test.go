@@ -0,0 +1,53 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePairingAttempt(t *testing.T) {
+	input := &transfert.PairingAttempt{
+		IP:   aws.String("203.0.113.7"),
+		Code: aws.String("ABCDEFGH"),
+	}
+
+	attempt := entities.CreatePairingAttempt(input)
+
+	assert.Equal(t, input.IP, attempt.IP)
+	assert.Equal(t, input.Code, attempt.Code)
+	assert.Nil(t, attempt.BeforeCreate(nil))
+	assert.NotEmpty(t, attempt.ID)
+}
+
+func TestPairingLockedUntil(t *testing.T) {
+	now := time.Now()
+
+	attempts := func(count int, at time.Time) []*entities.PairingAttempt {
+		list := make([]*entities.PairingAttempt, count)
+		for i := range list {
+			list[i] = &entities.PairingAttempt{CreatedAt: at}
+		}
+		return list
+	}
+
+	t.Run("no lockout under the first threshold", func(t *testing.T) {
+		assert.True(t, entities.PairingLockedUntil(attempts(4, now), now).IsZero())
+		assert.True(t, entities.PairingLockedUntil(nil, now).IsZero())
+	})
+
+	t.Run("escalating lockouts", func(t *testing.T) {
+		assert.Equal(t, now.Add(time.Minute), entities.PairingLockedUntil(attempts(5, now), now))
+		assert.Equal(t, now.Add(15*time.Minute), entities.PairingLockedUntil(attempts(10, now), now))
+		assert.Equal(t, now.Add(24*time.Hour), entities.PairingLockedUntil(attempts(25, now), now))
+	})
+
+	t.Run("failures outside the window are ignored", func(t *testing.T) {
+		list := append(attempts(20, now.Add(-25*time.Hour)), attempts(4, now)...)
+		assert.True(t, entities.PairingLockedUntil(list, now).IsZero())
+	})
+}
@@ -15,16 +15,26 @@ type Store struct {
 	UpdatedAt time.Time       `json:"-"`
 	DeletedAt *gorm.DeletedAt `gorm:"index" json:"-"`
 
-	Label    *string `gorm:"type:varchar(255);uniqueIndex" json:"label"`
-	IsOnline *bool   `gorm:"type:boolean" json:"is_online"`
-
-	Caisses Caisses `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"caisses"`
+	Label     *string  `gorm:"type:varchar(255);uniqueIndex" json:"label"`
+	IsOnline  *bool    `gorm:"type:boolean" json:"is_online"`
+	Address   *string  `gorm:"type:varchar(255)" json:"address"`
+	Latitude  *float64 `gorm:"index" json:"latitude"`
+	Longitude *float64 `gorm:"index" json:"longitude"`
+	Timezone  *string  `gorm:"type:varchar(64)" json:"timezone"`
+
+	Caisses    Caisses           `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"caisses"`
+	Openings   Openings          `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"openings,omitempty"`
+	Exceptions OpeningExceptions `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"exceptions,omitempty"`
 }
 
 func CreateStore(obj *transfert.Store) *Store {
 	t := &Store{
-		Label:    obj.Label,
-		IsOnline: obj.IsOnline,
+		Label:     obj.Label,
+		IsOnline:  obj.IsOnline,
+		Address:   obj.Address,
+		Latitude:  obj.Latitude,
+		Longitude: obj.Longitude,
+		Timezone:  obj.Timezone,
 	}
 
 	if obj.ID != nil {
@@ -8,7 +8,16 @@ import (
 
 var (
 	// Store errors
-	ErrStoreNotFound = errors.New(http.StatusNotFound, "store.not_found")
+	ErrStoreNotFound      = errors.New(http.StatusNotFound, "store.not_found")
+	ErrStoreAlreadyExists = errors.New(http.StatusConflict, "store.already_exists")
 	// Caisse errors
 	ErrCaisseNotFound = errors.New(http.StatusNotFound, "caisse.not_found")
+	// Membership errors
+	ErrMembershipNotFound      = errors.New(http.StatusNotFound, "membership.not_found")
+	ErrMembershipAlreadyExists = errors.New(http.StatusConflict, "membership.already_exists")
+	// Device errors
+	ErrDeviceNotFound       = errors.New(http.StatusNotFound, "device.not_found")
+	ErrDevicePairingExpired = errors.New(http.StatusGone, "device.pairing_expired")
+	ErrDeviceRevoked        = errors.New(http.StatusConflict, "device.revoked")
+	ErrDevicePairingLocked  = errors.New(http.StatusTooManyRequests, "device.pairing_locked")
 )
@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
 )
 
@@ -37,88 +38,87 @@ func ConvertTransferToEntity(store *transfert.Store) *entities.Store {
 	}
 }
 
-// CreateStores Ensures stores in the database match the desired state
-func CreateStores(repo repositories.StoreRepositoryInterface) {
-	desiredStores := []*transfert.Store{
-		{Label: aws.String("DigitalStore"), IsOnline: aws.Bool(true)},
-		{Label: aws.String("PhysicalStore"), IsOnline: aws.Bool(false)},
-	}
-
-	// Retrieve existing stores from the database
-	existingEntityStores, err := repo.ReadStores(&transfert.Store{})
-	if err != nil {
-		panic(fmt.Sprintf("Failed to read stores: %v", err))
-	}
+// DefaultCaisses is the number of caisses opened with each default store
+const DefaultCaisses = 4
 
-	// Convert existing entities to transfer objects
-	var existingStores []*transfert.Store
-	for _, entityStore := range existingEntityStores {
-		existingStores = append(existingStores, ConvertEntityToTransfer(entityStore))
-	}
+// StoreSeed describes a store to reconcile and the number of caisses it must at least have
+type StoreSeed struct {
+	Store   *transfert.Store
+	Caisses int
+}
 
-	// Map existing stores by label for quick lookup
-	existingStoreMap := make(map[string]*transfert.Store)
-	for _, store := range existingStores {
-		existingStoreMap[*store.Label] = store
+// DefaultStores returns the stores seeded into an empty database when the configuration declares none
+//
+// Returns:
+// - []*StoreSeed: the digital and the physical store with their caisses
+func DefaultStores() []*StoreSeed {
+	return []*StoreSeed{
+		{Store: &transfert.Store{Label: aws.String("DigitalStore"), IsOnline: aws.Bool(true)}, Caisses: DefaultCaisses},
+		{Store: &transfert.Store{Label: aws.String("PhysicalStore"), IsOnline: aws.Bool(false)}, Caisses: DefaultCaisses},
 	}
+}
 
-	// Prepare stores to add and remove
-	var storesToAdd []*transfert.Store
-	var storesToRemove []*transfert.Store
-
-	// Determine stores to add
-	for _, desiredStore := range desiredStores {
-		if _, exists := existingStoreMap[*desiredStore.Label]; !exists {
-			storesToAdd = append(storesToAdd, desiredStore)
+// SyncStores reconciles the stores described in the configuration with the database without deleting anything
+// Each store is matched by label, created if missing and its kind updated otherwise, then its caisses
+// are topped up to the configured count. Stores missing from the configuration are kept as they are.
+// When the configuration declares no store, the default stores are seeded into an empty database only.
+//
+// Parameters:
+// - repo: repositories.StoreRepositoryInterface the store repository
+// - desired: []*StoreSeed the stores read from the configuration
+//
+// Returns:
+// - error: an error if the stores are inconsistent or cannot be saved
+func SyncStores(repo repositories.StoreRepositoryInterface, desired []*StoreSeed) error {
+	if len(desired) == 0 {
+		existing, err := repo.ReadStores(&transfert.Store{})
+		if err != nil {
+			return fmt.Errorf("failed to read stores: %w", err)
 		}
-	}
 
-	// Determine stores to remove
-	desiredStoreMap := make(map[string]bool)
-	for _, desiredStore := range desiredStores {
-		desiredStoreMap[*desiredStore.Label] = true
-	}
-	for _, existingStore := range existingStores {
-		if !desiredStoreMap[*existingStore.Label] {
-			storesToRemove = append(storesToRemove, existingStore)
+		if len(existing) > 0 {
+			fmt.Println("Store synchronization completed")
+			return nil
 		}
+
+		desired = DefaultStores()
 	}
 
-	// Create stores that are missing
-	if len(storesToAdd) > 0 {
-		if err := repo.CreateStores(storesToAdd); err != nil {
-			panic(fmt.Sprintf("Failed to insert stores: %v", err))
+	labels := make(map[string]bool)
+	for _, seed := range desired {
+		if seed.Store == nil || seed.Store.Label == nil || *seed.Store.Label == "" || labels[*seed.Store.Label] {
+			return fmt.Errorf("each store requires a unique label: %w", errors_domain_store.ErrStoreAlreadyExists)
 		}
-		fmt.Printf("%d stores were added\n", len(storesToAdd))
 
-		// Retrieve existing stores from the database
-		existingEntityStores, err = repo.ReadStores(&transfert.Store{})
-		if err != nil {
-			panic(fmt.Sprintf("Failed to read stores: %v", err))
-		}
+		labels[*seed.Store.Label] = true
+	}
 
-		// Convert existing entities to transfer objects
-		for _, entityStore := range existingEntityStores {
-			entityStore.Caisses = []*entities.Caisse{
-				{StoreID: &entityStore.ID},
-				{StoreID: &entityStore.ID},
-				{StoreID: &entityStore.ID},
-				{StoreID: &entityStore.ID},
+	for _, seed := range desired {
+		store, err := repo.ReadStore(&transfert.Store{Label: seed.Store.Label})
+		switch err {
+		case nil:
+			if seed.Store.IsOnline != nil && (store.IsOnline == nil || *store.IsOnline != *seed.Store.IsOnline) {
+				store.IsOnline = seed.Store.IsOnline
+				if err := repo.UpdateStore(store); err != nil {
+					return fmt.Errorf("failed to update store %s: %w", *seed.Store.Label, err)
+				}
 			}
+		case errors_domain_store.ErrStoreNotFound:
+			if store, err = repo.CreateStore(seed.Store); err != nil {
+				return fmt.Errorf("failed to create store %s: %w", *seed.Store.Label, err)
+			}
+		default:
+			return fmt.Errorf("failed to read store %s: %w", *seed.Store.Label, err)
 		}
 
-		repo.UpdateStores(existingEntityStores)
-
-		fmt.Println("Caisse synchronization completed")
-	}
-
-	// Remove stores that are extra
-	if len(storesToRemove) > 0 {
-		if err := repo.DeleteStores(storesToRemove); err != nil {
-			panic(fmt.Sprintf("Failed to delete stores: %v", err))
+		for i := len(store.Caisses); i < seed.Caisses; i++ {
+			if _, err := repo.CreateCaisse(&transfert.Caisse{StoreID: &store.ID}); err != nil {
+				return fmt.Errorf("failed to create a caisse for store %s: %w", *seed.Store.Label, err)
+			}
 		}
-		fmt.Printf("%d stores were removed\n", len(storesToRemove))
 	}
 
-	fmt.Println("Store synchronization completed")
+	fmt.Printf("%d stores are ready\n", len(desired))
+
+	return nil
 }
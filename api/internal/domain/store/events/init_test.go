@@ -2,6 +2,7 @@ package events_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/stretchr/testify/assert"
@@ -9,6 +10,7 @@ import (
 
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/events"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
@@ -35,6 +37,22 @@ func (m *MockStoreRepository) ReadStores(obj *transfert.Store, options ...databa
 	return nil, err
 }
 
+// PaginateStores simule la méthode PaginateStores de StoreRepositoryInterface
+func (m *MockStoreRepository) PaginateStores(obj *transfert.Store, list *database.List, options ...database.Option) (*database.Page[*entities.Store], errors.ErrorInterface) {
+	args := m.Called(obj, list, options)
+
+	var err errors.ErrorInterface
+	if e := args.Get(1); e != nil {
+		err = e.(errors.ErrorInterface)
+	}
+
+	if result := args.Get(0); result != nil {
+		return result.(*database.Page[*entities.Store]), err
+	}
+
+	return nil, err
+}
+
 // CreateCaisse simule la méthode CreateCaisse de StoreRepositoryInterface
 func (m *MockStoreRepository) CreateCaisse(obj *transfert.Caisse, options ...database.Option) (*entities.Caisse, errors.ErrorInterface) {
 	args := m.Called(obj, options)
@@ -154,6 +172,176 @@ func (m *MockStoreRepository) ReadStore(obj *transfert.Store, options ...databas
 	return nil, err
 }
 
+// CreateStore simule la méthode CreateStore de StoreRepositoryInterface
+func (m *MockStoreRepository) CreateStore(obj *transfert.Store, options ...database.Option) (*entities.Store, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	var err errors.ErrorInterface
+	if e := args.Get(1); e != nil {
+		err = e.(errors.ErrorInterface)
+	}
+
+	if result := args.Get(0); result != nil {
+		return result.(*entities.Store), err
+	}
+
+	return nil, err
+}
+
+// ReplaceStoreHours simule le remplacement des horaires d'une boutique
+func (m *MockStoreRepository) ReplaceStoreHours(obj *entities.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+
+	return nil
+}
+
+// CreateMembership simule la méthode CreateMembership de StoreRepositoryInterface
+func (m *MockStoreRepository) CreateMembership(obj *transfert.Membership, options ...database.Option) (*entities.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Membership), nil
+}
+
+// ReadMembership simule la méthode ReadMembership de StoreRepositoryInterface
+func (m *MockStoreRepository) ReadMembership(obj *transfert.Membership, options ...database.Option) (*entities.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Membership), nil
+}
+
+// ReadMemberships simule la méthode ReadMemberships de StoreRepositoryInterface
+func (m *MockStoreRepository) ReadMemberships(obj *transfert.Membership, options ...database.Option) ([]*entities.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Membership), nil
+}
+
+// DeleteMembership simule la méthode DeleteMembership de StoreRepositoryInterface
+func (m *MockStoreRepository) DeleteMembership(obj *transfert.Membership, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+
+	return nil
+}
+
+// CreateDevice simule la méthode CreateDevice de StoreRepositoryInterface
+func (m *MockStoreRepository) CreateDevice(obj *transfert.Device, options ...database.Option) (*entities.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Device), nil
+}
+
+// ReadDevice simule la méthode ReadDevice de StoreRepositoryInterface
+func (m *MockStoreRepository) ReadDevice(obj *transfert.Device, options ...database.Option) (*entities.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Device), nil
+}
+
+// ReadDevices simule la méthode ReadDevices de StoreRepositoryInterface
+func (m *MockStoreRepository) ReadDevices(obj *transfert.Device, options ...database.Option) ([]*entities.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Device), nil
+}
+
+// UpdateDevice simule la méthode UpdateDevice de StoreRepositoryInterface
+func (m *MockStoreRepository) UpdateDevice(obj *entities.Device, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+
+	return nil
+}
+
+// PairDevice simule la méthode PairDevice de StoreRepositoryInterface
+func (m *MockStoreRepository) PairDevice(obj *entities.Device, now time.Time, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, now, options)
+
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+
+	return nil
+}
+
+// CreatePairingAttempt simule la méthode CreatePairingAttempt de StoreRepositoryInterface
+func (m *MockStoreRepository) CreatePairingAttempt(obj *transfert.PairingAttempt, options ...database.Option) (*entities.PairingAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.PairingAttempt), nil
+}
+
+// ReadPairingAttempts simule la méthode ReadPairingAttempts de StoreRepositoryInterface
+func (m *MockStoreRepository) ReadPairingAttempts(obj *transfert.PairingAttempt, options ...database.Option) ([]*entities.PairingAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.PairingAttempt), nil
+}
+
+// UpdateStore simule la méthode UpdateStore de StoreRepositoryInterface
+func (m *MockStoreRepository) UpdateStore(obj *entities.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+
+	return nil
+}
+
+// DeleteStore simule la méthode DeleteStore de StoreRepositoryInterface
+func (m *MockStoreRepository) DeleteStore(obj *transfert.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+
+	if args.Error(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+
+	return nil
+}
+
 // setupStoreRepository initialise l'environnement de test en créant une instance du mock et en retournant une fonction de nettoyage
 func setupStoreRepository() (*MockStoreRepository, func()) {
 	mockRepo := new(MockStoreRepository)
@@ -199,58 +387,95 @@ func TestConvertTransferToEntity(t *testing.T) {
 	})
 }
 
-func TestCreateStores(t *testing.T) {
-
-	t.Run("nominal case", func(t *testing.T) {
+func TestSyncStores(t *testing.T) {
+	t.Run("seeds the default stores into an empty database", func(t *testing.T) {
 		mockRepo, cleanup := setupStoreRepository()
 		defer cleanup()
 
 		mockRepo.On("ReadStores", mock.Anything, mock.Anything).Return([]*entities.Store{}, nil)
-		mockRepo.On("CreateStores", mock.Anything, mock.Anything).Return(nil)
-		mockRepo.On("UpdateStores", mock.Anything, mock.Anything).Return(nil)
-		events.CreateStores(mockRepo)
+		mockRepo.On("ReadStore", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+		mockRepo.On("CreateStore", mock.Anything, mock.Anything).Return(&entities.Store{ID: "store-id"}, nil)
+		mockRepo.On("CreateCaisse", &transfert.Caisse{StoreID: aws.String("store-id")}, mock.Anything).Return(&entities.Caisse{}, nil)
+
+		assert.NoError(t, events.SyncStores(mockRepo, nil))
+		mockRepo.AssertNumberOfCalls(t, "CreateStore", 2)
+		mockRepo.AssertNumberOfCalls(t, "CreateCaisse", 2*events.DefaultCaisses)
 	})
 
-	t.Run("nominal existing store", func(t *testing.T) {
+	t.Run("keeps the stores of a database already seeded", func(t *testing.T) {
 		mockRepo, cleanup := setupStoreRepository()
 		defer cleanup()
 
 		mockRepo.On("ReadStores", mock.Anything, mock.Anything).Return([]*entities.Store{
-			{
-				Label:    aws.String("DigitalStore"),
-				IsOnline: aws.Bool(true),
-			},
-			{
-				Label:    aws.String("NotDesiredStore"),
-				IsOnline: aws.Bool(false),
-			},
+			{Label: aws.String("NotDesiredStore"), IsOnline: aws.Bool(false)},
 		}, nil)
-		mockRepo.On("CreateStores", mock.Anything, mock.Anything).Return(nil)
-		mockRepo.On("UpdateStores", mock.Anything, mock.Anything).Return(nil)
-		mockRepo.On("DeleteStores", mock.Anything, mock.Anything).Return(nil)
-		events.CreateStores(mockRepo)
+
+		assert.NoError(t, events.SyncStores(mockRepo, nil))
+		mockRepo.AssertNotCalled(t, "CreateStore", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "DeleteStores", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reconciles the configured stores without deleting", func(t *testing.T) {
+		mockRepo, cleanup := setupStoreRepository()
+		defer cleanup()
+
+		existing := &entities.Store{
+			ID:       "nice-id",
+			Label:    aws.String("Nice"),
+			IsOnline: aws.Bool(true),
+			Caisses:  entities.Caisses{{}},
+		}
+
+		mockRepo.On("ReadStore", &transfert.Store{Label: aws.String("Nice")}, mock.Anything).Return(existing, nil)
+		mockRepo.On("UpdateStore", existing, mock.Anything).Return(nil)
+		mockRepo.On("CreateCaisse", &transfert.Caisse{StoreID: aws.String("nice-id")}, mock.Anything).Return(&entities.Caisse{}, nil)
+		mockRepo.On("ReadStore", &transfert.Store{Label: aws.String("Web")}, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+		mockRepo.On("CreateStore", mock.Anything, mock.Anything).Return(&entities.Store{ID: "web-id"}, nil)
+
+		assert.NoError(t, events.SyncStores(mockRepo, []*events.StoreSeed{
+			{Store: &transfert.Store{Label: aws.String("Nice"), IsOnline: aws.Bool(false)}, Caisses: 3},
+			{Store: &transfert.Store{Label: aws.String("Web"), IsOnline: aws.Bool(true)}},
+		}))
+
+		assert.False(t, *existing.IsOnline)
+		mockRepo.AssertNumberOfCalls(t, "CreateCaisse", 2)
+		mockRepo.AssertNumberOfCalls(t, "CreateStore", 1)
+		mockRepo.AssertNotCalled(t, "ReadStores", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "DeleteStores", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects duplicated labels", func(t *testing.T) {
+		mockRepo, cleanup := setupStoreRepository()
+		defer cleanup()
+
+		err := events.SyncStores(mockRepo, []*events.StoreSeed{
+			{Store: &transfert.Store{Label: aws.String("Nice")}},
+			{Store: &transfert.Store{Label: aws.String("Nice")}},
+		})
+
+		assert.ErrorIs(t, err, errors_domain_store.ErrStoreAlreadyExists)
+		mockRepo.AssertNotCalled(t, "ReadStore", mock.Anything, mock.Anything)
 	})
 
 	t.Run("error ReadStores", func(t *testing.T) {
 		mockRepo, cleanup := setupStoreRepository()
 		defer cleanup()
 
-		// Configuration du mock pour ReadStores avec une erreur
 		mockRepo.On("ReadStores", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer).Once()
 
-		// Configuration des autres mocks (non appelés dans ce scénario)
-		mockRepo.On("CreateStores", mock.Anything, mock.Anything).Return(nil)
-		mockRepo.On("UpdateStores", mock.Anything, mock.Anything).Return(nil)
+		err := events.SyncStores(mockRepo, nil)
+		assert.ErrorIs(t, err, errors.ErrInternalServer)
+		mockRepo.AssertNotCalled(t, "CreateStore", mock.Anything, mock.Anything)
+	})
 
-		// Assertion que la fonction panique avec le message attendu
-		assert.PanicsWithValue(t, "Failed to read stores: common.internal_error", func() {
-			events.CreateStores(mockRepo)
-		})
+	t.Run("error ReadStore", func(t *testing.T) {
+		mockRepo, cleanup := setupStoreRepository()
+		defer cleanup()
 
-		// Assertions pour vérifier que les autres méthodes ne sont pas appelées
-		mockRepo.AssertCalled(t, "ReadStores", mock.Anything, mock.Anything)
-		mockRepo.AssertNotCalled(t, "CreateStores", mock.Anything, mock.Anything)
-		mockRepo.AssertNotCalled(t, "UpdateStores", mock.Anything, mock.Anything)
-		mockRepo.AssertNotCalled(t, "DeleteStores", mock.Anything, mock.Anything)
+		mockRepo.On("ReadStore", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		err := events.SyncStores(mockRepo, events.DefaultStores())
+		assert.ErrorIs(t, err, errors.ErrInternalServer)
+		mockRepo.AssertNotCalled(t, "CreateStore", mock.Anything, mock.Anything)
 	})
 }
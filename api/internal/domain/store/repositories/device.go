@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateDevice registers a caisse device waiting for its pairing
+//
+// Parameters:
+// - obj: *transfert.Device the caisse, the store and the label of the device
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - *entities.Device: the created device with its pairing code
+// - errors.ErrorInterface: an error if the device cannot be saved
+func (r *StoreRepository) CreateDevice(obj *transfert.Device, options ...database.Option) (*entities.Device, errors.ErrorInterface) {
+	device := entities.CreateDevice(obj)
+
+	result := r.store.Engine.Create(device)
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return device, nil
+}
+
+// ReadDevice reads a caisse device
+//
+// Parameters:
+// - obj: *transfert.Device the ID, the pairing code or the caisse of the device
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - *entities.Device: the device
+// - errors.ErrorInterface: ErrDeviceNotFound if no device matches
+func (r *StoreRepository) ReadDevice(obj *transfert.Device, options ...database.Option) (*entities.Device, errors.ErrorInterface) {
+	var device *entities.Device
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(&device)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_store.ErrDeviceNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return device, nil
+}
+
+// ReadDevices lists the caisse devices of a store or of a caisse
+//
+// Parameters:
+// - obj: *transfert.Device the store or the caisse of the devices
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - []*entities.Device: the devices
+// - errors.ErrorInterface: an error if the devices cannot be read
+func (r *StoreRepository) ReadDevices(obj *transfert.Device, options ...database.Option) ([]*entities.Device, errors.ErrorInterface) {
+	var devices []*entities.Device
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&devices)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return devices, nil
+}
+
+// UpdateDevice saves the pairing and the revocation of a caisse device
+//
+// Parameters:
+// - obj: *entities.Device the device to save
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - errors.ErrorInterface: an error if the device cannot be saved
+func (r *StoreRepository) UpdateDevice(obj *entities.Device, options ...database.Option) errors.ErrorInterface {
+	result := r.store.Engine.Save(obj)
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// PairDevice consumes the pairing code of a caisse device
+// The code is checked by the update itself, so that a code used or expired in the meantime pairs nothing.
+//
+// Parameters:
+// - obj: *entities.Device the device read by its pairing code, with its label
+// - now: time.Time the time of the pairing
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrDevicePairingExpired if the code is no longer usable
+func (r *StoreRepository) PairDevice(obj *entities.Device, now time.Time, options ...database.Option) errors.ErrorInterface {
+	if obj.Code == nil {
+		return errors_domain_store.ErrDevicePairingExpired
+	}
+
+	query := r.store.Engine.Model(&entities.Device{ID: obj.ID}).Where("code = ? AND paired_at IS NULL AND revoked_at IS NULL AND expires_at > ?", obj.Code.String(), now)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Updates(map[string]any{
+		"label":      obj.Label,
+		"paired_at":  now,
+		"code":       nil,
+		"expires_at": nil,
+	})
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_store.ErrDevicePairingExpired
+	}
+
+	obj.Pair(now)
+
+	return nil
+}
+
+// CreatePairingAttempt records a failed attempt to pair a device
+//
+// Parameters:
+// - obj: *transfert.PairingAttempt the IP and the code of the attempt
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - *entities.PairingAttempt: the recorded attempt
+// - errors.ErrorInterface: an error if the attempt cannot be saved
+func (r *StoreRepository) CreatePairingAttempt(obj *transfert.PairingAttempt, options ...database.Option) (*entities.PairingAttempt, errors.ErrorInterface) {
+	attempt := entities.CreatePairingAttempt(obj)
+
+	result := r.store.Engine.Create(attempt)
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return attempt, nil
+}
+
+// ReadPairingAttempts reads the failed attempts to pair a device
+//
+// Parameters:
+// - obj: *transfert.PairingAttempt the IP of the attempts
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - []*entities.PairingAttempt: the attempts
+// - errors.ErrorInterface: an error if the attempts cannot be read
+func (r *StoreRepository) ReadPairingAttempts(obj *transfert.PairingAttempt, options ...database.Option) ([]*entities.PairingAttempt, errors.ErrorInterface) {
+	var attempts []*entities.PairingAttempt
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&attempts)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return attempts, nil
+}
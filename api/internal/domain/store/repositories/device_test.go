@@ -0,0 +1,308 @@
+package repositories_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_CreateDevice tests the CreateDevice method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_CreateDevice(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Device{CaisseID: aws.String("caisse-123"), StoreID: aws.String("store-123"), CreatedBy: aws.String("employee-123")}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "devices"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		device, err := repo.CreateDevice(dto)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, device.ID)
+		assert.NotNil(t, device.Code)
+		assert.NotNil(t, device.ExpiresAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "devices"`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		device, err := repo.CreateDevice(dto)
+		assert.Nil(t, device)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_ReadDevice tests the ReadDevice method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_ReadDevice(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Device{ID: aws.String("device-123"), CaisseID: aws.String("caisse-123")}
+	query := `SELECT \* FROM "devices" WHERE "devices"\."id" = \$1 AND "devices"\."caisse_id" = \$2 ORDER BY "devices"\."id" LIMIT \$3`
+
+	t.Run("device found", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("device-123", "caisse-123", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "caisse_id", "store_id"}).
+				AddRow("device-123", "caisse-123", "store-123"))
+
+		device, err := repo.ReadDevice(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "device-123", device.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no device found", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("device-123", "caisse-123", 1).
+			WillReturnRows(sqlmock.NewRows([]string{}))
+
+		device, err := repo.ReadDevice(dto)
+		assert.Nil(t, device)
+		assert.Equal(t, "device.not_found", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("device-123", "caisse-123", 1).
+			WillReturnError(errors.New("db error"))
+
+		device, err := repo.ReadDevice(dto)
+		assert.Nil(t, device)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_ReadDevices tests the ReadDevices method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_ReadDevices(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Device{StoreID: aws.String("store-123")}
+
+	t.Run("devices found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "devices" WHERE "devices"\."store_id" = \$1 AND paired_at IS NOT NULL ORDER BY created_at`).
+			WithArgs("store-123").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "store_id"}).
+				AddRow("d1", "store-123").
+				AddRow("d2", "store-123"))
+
+		devices, err := repo.ReadDevices(dto, database.Where("paired_at IS NOT NULL"), database.Order("created_at"))
+		assert.Nil(t, err)
+		assert.Len(t, devices, 2)
+		assert.Equal(t, "d2", devices[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "devices"`).
+			WillReturnError(errors.New("db error"))
+
+		devices, err := repo.ReadDevices(dto)
+		assert.Nil(t, devices)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_UpdateDevice tests the UpdateDevice method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_UpdateDevice(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	device := &entities.Device{ID: "device-123", CaisseID: aws.String("caisse-123")}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.UpdateDevice(device))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateDevice(device)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_PairDevice tests the PairDevice method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_PairDevice(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	pending := func() *entities.Device {
+		expiresAt := time.Now().Add(time.Minute)
+		return &entities.Device{ID: "device-123", Code: token.NewLuhn("ABCDEFGH").Pointer(), ExpiresAt: &expiresAt}
+	}
+
+	t.Run("successful pairing", func(t *testing.T) {
+		device := pending()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET .* WHERE \(code = \$\d+ AND paired_at IS NULL AND revoked_at IS NULL AND expires_at > \$\d+\) AND "id" = \$\d+`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.PairDevice(device, time.Now()))
+		assert.True(t, device.IsActive())
+		assert.Nil(t, device.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("code used or expired in the meantime", func(t *testing.T) {
+		device := pending()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.PairDevice(device, time.Now())
+		assert.Equal(t, errors_domain_store.ErrDevicePairingExpired, err)
+		assert.False(t, device.IsPaired())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("device without code", func(t *testing.T) {
+		err := repo.PairDevice(&entities.Device{ID: "device-123"}, time.Now())
+		assert.Equal(t, errors_domain_store.ErrDevicePairingExpired, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.PairDevice(pending(), time.Now())
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_CreatePairingAttempt tests the CreatePairingAttempt method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_CreatePairingAttempt(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.PairingAttempt{IP: aws.String("203.0.113.7"), Code: aws.String("ABCDEFGH")}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "pairing_attempts"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		attempt, err := repo.CreatePairingAttempt(dto)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, attempt.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "pairing_attempts"`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		attempt, err := repo.CreatePairingAttempt(dto)
+		assert.Nil(t, attempt)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_ReadPairingAttempts tests the ReadPairingAttempts method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_ReadPairingAttempts(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.PairingAttempt{IP: aws.String("203.0.113.7")}
+	query := `SELECT \* FROM "pairing_attempts" WHERE "pairing_attempts"."ip" = \$1`
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("203.0.113.7").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ip"}).AddRow("attempt-1", "203.0.113.7").AddRow("attempt-2", "203.0.113.7"))
+
+		attempts, err := repo.ReadPairingAttempts(dto)
+		assert.Nil(t, err)
+		assert.Len(t, attempts, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("203.0.113.7").
+			WillReturnError(errors.New("db error"))
+
+		attempts, err := repo.ReadPairingAttempts(dto)
+		assert.Nil(t, attempts)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
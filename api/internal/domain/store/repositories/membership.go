@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateMembership assigns an employee to a store
+//
+// Parameters:
+// - obj: *transfert.Membership the store and the credential of the employee
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - *entities.Membership: the created membership
+// - errors.ErrorInterface: an error if the membership cannot be saved
+func (r *StoreRepository) CreateMembership(obj *transfert.Membership, options ...database.Option) (*entities.Membership, errors.ErrorInterface) {
+	membership := entities.CreateMembership(obj)
+
+	result := r.store.Engine.Create(membership)
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return membership, nil
+}
+
+// ReadMembership reads the membership of an employee to a store
+//
+// Parameters:
+// - obj: *transfert.Membership the store and the credential of the employee
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - *entities.Membership: the membership
+// - errors.ErrorInterface: ErrMembershipNotFound if the employee is not assigned to the store
+func (r *StoreRepository) ReadMembership(obj *transfert.Membership, options ...database.Option) (*entities.Membership, errors.ErrorInterface) {
+	var membership *entities.Membership
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(&membership)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_store.ErrMembershipNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return membership, nil
+}
+
+// ReadMemberships lists the memberships of a store or of an employee
+//
+// Parameters:
+// - obj: *transfert.Membership the store or the credential of the employee
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - []*entities.Membership: the memberships
+// - errors.ErrorInterface: an error if the memberships cannot be read
+func (r *StoreRepository) ReadMemberships(obj *transfert.Membership, options ...database.Option) ([]*entities.Membership, errors.ErrorInterface) {
+	var memberships []*entities.Membership
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&memberships)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return memberships, nil
+}
+
+// DeleteMembership removes an employee from a store
+//
+// Parameters:
+// - obj: *transfert.Membership the store and the credential of the employee
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - errors.ErrorInterface: an error if the membership cannot be deleted
+func (r *StoreRepository) DeleteMembership(obj *transfert.Membership, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	if result := query.Delete(&entities.Membership{}); result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package repositories_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_CreateMembership tests the CreateMembership method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_CreateMembership(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Membership{StoreID: aws.String("store-123"), CredentialID: aws.String("employee-123")}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "memberships"`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "store-123", "employee-123").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		membership, err := repo.CreateMembership(dto)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, membership.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "memberships"`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		membership, err := repo.CreateMembership(dto)
+		assert.Nil(t, membership)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_ReadMembership tests the ReadMembership method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_ReadMembership(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Membership{StoreID: aws.String("store-123"), CredentialID: aws.String("employee-123")}
+	query := `SELECT \* FROM "memberships" WHERE "memberships"\."store_id" = \$1 AND "memberships"\."credential_id" = \$2 ORDER BY "memberships"\."id" LIMIT \$3`
+
+	t.Run("membership found", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("store-123", "employee-123", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "store_id", "credential_id"}).
+				AddRow("membership-123", "store-123", "employee-123"))
+
+		membership, err := repo.ReadMembership(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "membership-123", membership.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no membership found", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("store-123", "employee-123", 1).
+			WillReturnRows(sqlmock.NewRows([]string{}))
+
+		membership, err := repo.ReadMembership(dto)
+		assert.Nil(t, membership)
+		assert.Equal(t, "membership.not_found", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("store-123", "employee-123", 1).
+			WillReturnError(errors.New("db error"))
+
+		membership, err := repo.ReadMembership(dto)
+		assert.Nil(t, membership)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_ReadMemberships tests the ReadMemberships method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_ReadMemberships(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Membership{StoreID: aws.String("store-123")}
+
+	t.Run("memberships found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "memberships" WHERE "memberships"\."store_id" = \$1 ORDER BY created_at`).
+			WithArgs("store-123").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "store_id", "credential_id"}).
+				AddRow("m1", "store-123", "employee-1").
+				AddRow("m2", "store-123", "employee-2"))
+
+		memberships, err := repo.ReadMemberships(dto, database.Order("created_at"))
+		assert.Nil(t, err)
+		assert.Len(t, memberships, 2)
+		assert.Equal(t, "employee-2", *memberships[1].CredentialID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "memberships"`).
+			WillReturnError(errors.New("db error"))
+
+		memberships, err := repo.ReadMemberships(dto)
+		assert.Nil(t, memberships)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_DeleteMembership tests the DeleteMembership method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_DeleteMembership(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Membership{StoreID: aws.String("store-123"), CredentialID: aws.String("employee-123")}
+	query := `DELETE FROM "memberships" WHERE "memberships"\."store_id" = \$1 AND "memberships"\."credential_id" = \$2`
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs("store-123", "employee-123").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.DeleteMembership(dto))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.DeleteMembership(dto)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
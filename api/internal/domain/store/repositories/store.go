@@ -1,11 +1,14 @@
 package repositories
 
 import (
+	"time"
+
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
 	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
 )
 
 type StoreRepository struct {
@@ -15,19 +18,38 @@ type StoreRepository struct {
 type StoreRepositoryInterface interface {
 	CreateStores(objs []*transfert.Store, options ...database.Option) errors.ErrorInterface
 	ReadStores(obj *transfert.Store, options ...database.Option) ([]*entities.Store, errors.ErrorInterface)
+	PaginateStores(obj *transfert.Store, list *database.List, options ...database.Option) (*database.Page[*entities.Store], errors.ErrorInterface)
 	ReadStore(obj *transfert.Store, options ...database.Option) (*entities.Store, errors.ErrorInterface)
 	DeleteStores(obj []*transfert.Store, options ...database.Option) errors.ErrorInterface
 	UpdateStores(obj []*entities.Store, options ...database.Option) errors.ErrorInterface
+	CreateStore(obj *transfert.Store, options ...database.Option) (*entities.Store, errors.ErrorInterface)
+	UpdateStore(obj *entities.Store, options ...database.Option) errors.ErrorInterface
+	DeleteStore(obj *transfert.Store, options ...database.Option) errors.ErrorInterface
+	ReplaceStoreHours(obj *entities.Store, options ...database.Option) errors.ErrorInterface
 
 	CreateCaisse(obj *transfert.Caisse, options ...database.Option) (*entities.Caisse, errors.ErrorInterface)
 	ReadCaisse(obj *transfert.Caisse, options ...database.Option) (*entities.Caisse, errors.ErrorInterface)
 	ReadCaisses(obj *transfert.Caisse, options ...database.Option) ([]*entities.Caisse, errors.ErrorInterface)
 	DeleteCaisse(obj *transfert.Caisse, options ...database.Option) errors.ErrorInterface
 	UpdateCaisse(obj *entities.Caisse, options ...database.Option) errors.ErrorInterface
+
+	CreateMembership(obj *transfert.Membership, options ...database.Option) (*entities.Membership, errors.ErrorInterface)
+	ReadMembership(obj *transfert.Membership, options ...database.Option) (*entities.Membership, errors.ErrorInterface)
+	ReadMemberships(obj *transfert.Membership, options ...database.Option) ([]*entities.Membership, errors.ErrorInterface)
+	DeleteMembership(obj *transfert.Membership, options ...database.Option) errors.ErrorInterface
+
+	CreateDevice(obj *transfert.Device, options ...database.Option) (*entities.Device, errors.ErrorInterface)
+	ReadDevice(obj *transfert.Device, options ...database.Option) (*entities.Device, errors.ErrorInterface)
+	ReadDevices(obj *transfert.Device, options ...database.Option) ([]*entities.Device, errors.ErrorInterface)
+	UpdateDevice(obj *entities.Device, options ...database.Option) errors.ErrorInterface
+	PairDevice(obj *entities.Device, now time.Time, options ...database.Option) errors.ErrorInterface
+
+	CreatePairingAttempt(obj *transfert.PairingAttempt, options ...database.Option) (*entities.PairingAttempt, errors.ErrorInterface)
+	ReadPairingAttempts(obj *transfert.PairingAttempt, options ...database.Option) ([]*entities.PairingAttempt, errors.ErrorInterface)
 }
 
 func NewStoreRepository(repo *database.Database) *StoreRepository {
-	repo.Engine.AutoMigrate(entities.Store{}, entities.Caisse{})
+	repo.Engine.AutoMigrate(entities.Store{}, entities.Caisse{}, entities.Opening{}, entities.OpeningException{}, entities.Membership{}, entities.Device{}, entities.PairingAttempt{})
 	return &StoreRepository{repo}
 }
 
@@ -66,6 +88,26 @@ func (r *StoreRepository) ReadStores(obj *transfert.Store, options ...database.O
 	return stores, nil
 }
 
+// PaginateStores reads a page of stores
+// The options scope the stores before they are counted, so that the page reflects them.
+//
+// Parameters:
+// - obj: *transfert.Store the fields to filter on
+// - list: *database.List the page, the sort and the filters of the request
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - *database.Page[*entities.Store]: the page of stores
+// - errors.ErrorInterface: an error if the stores cannot be read
+func (r *StoreRepository) PaginateStores(obj *transfert.Store, list *database.List, options ...database.Option) (*database.Page[*entities.Store], errors.ErrorInterface) {
+	query := r.store.Engine.Model(&entities.Store{}).Where(obj)
+	for _, option := range options {
+		query = option(query)
+	}
+
+	return database.Paginate[*entities.Store](query, list)
+}
+
 func (r *StoreRepository) ReadStore(obj *transfert.Store, options ...database.Option) (*entities.Store, errors.ErrorInterface) {
 	var store *entities.Store
 
@@ -111,6 +153,160 @@ func (r *StoreRepository) UpdateStores(obj []*entities.Store, options ...databas
 	return nil
 }
 
+// CreateStore creates a store, a store deleted earlier under the same label is restored instead
+// so that the tickets and stocks attached to its ID stay consistent.
+//
+// Parameters:
+// - obj: *transfert.Store the label and the kind of the store
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - *entities.Store: the created or restored store
+// - errors.ErrorInterface: an error if the store cannot be saved
+func (r *StoreRepository) CreateStore(obj *transfert.Store, options ...database.Option) (*entities.Store, errors.ErrorInterface) {
+	store := entities.CreateStore(obj)
+
+	err := r.store.Engine.Transaction(func(tx *gorm.DB) error {
+		deleted := &entities.Store{}
+
+		result := tx.Unscoped().Where("label = ? AND deleted_at IS NOT NULL", obj.Label).Limit(1).Find(deleted)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			query := tx.Create(store)
+			for _, option := range options {
+				option(query)
+			}
+
+			return query.Error
+		}
+
+		if err := tx.Unscoped().Model(deleted).Updates(map[string]any{
+			"deleted_at": nil,
+			"is_online":  obj.IsOnline,
+			"address":    obj.Address,
+			"latitude":   obj.Latitude,
+			"longitude":  obj.Longitude,
+			"timezone":   obj.Timezone,
+		}).Error; err != nil {
+			return err
+		}
+
+		store.ID, store.CreatedAt, store.Caisses = deleted.ID, deleted.CreatedAt, deleted.Caisses
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	return store, nil
+}
+
+// UpdateStore saves the label, the kind and the location of a store
+//
+// Parameters:
+// - obj: *entities.Store the store to save
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - errors.ErrorInterface: an error if the store cannot be saved
+func (r *StoreRepository) UpdateStore(obj *entities.Store, options ...database.Option) errors.ErrorInterface {
+	result := r.store.Engine.Model(obj).Select("label", "is_online", "address", "latitude", "longitude", "timezone", "updated_at").Updates(obj)
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// DeleteStore closes a store and its caisses, the rows are kept for the history of the tickets
+//
+// Parameters:
+// - obj: *transfert.Store the store to delete
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - errors.ErrorInterface: an error if the store cannot be deleted
+func (r *StoreRepository) DeleteStore(obj *transfert.Store, options ...database.Option) errors.ErrorInterface {
+	err := r.store.Engine.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("store_id = ?", obj.ID).Delete(&entities.Caisse{}).Error; err != nil {
+			return err
+		}
+
+		query := tx.Where("id = ?", obj.ID)
+		for _, option := range options {
+			option(query)
+		}
+
+		return query.Delete(&entities.Store{}).Error
+	})
+
+	if err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	return nil
+}
+
+// ReplaceStoreHours replaces the weekly hours and the exceptions of a store by the ones it holds
+//
+// Parameters:
+// - obj: *entities.Store the store and its new hours
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - errors.ErrorInterface: an error if the hours cannot be saved
+func (r *StoreRepository) ReplaceStoreHours(obj *entities.Store, options ...database.Option) errors.ErrorInterface {
+	for _, opening := range obj.Openings {
+		opening.StoreID = &obj.ID
+	}
+
+	for _, exception := range obj.Exceptions {
+		exception.StoreID = &obj.ID
+	}
+
+	err := r.store.Engine.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("store_id = ?", obj.ID).Delete(&entities.Opening{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("store_id = ?", obj.ID).Delete(&entities.OpeningException{}).Error; err != nil {
+			return err
+		}
+
+		if len(obj.Openings) > 0 {
+			if err := tx.Create(obj.Openings).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(obj.Exceptions) > 0 {
+			query := tx.Create(obj.Exceptions)
+			for _, option := range options {
+				option(query)
+			}
+
+			return query.Error
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	return nil
+}
+
 func (r *StoreRepository) CreateCaisse(obj *transfert.Caisse, options ...database.Option) (*entities.Caisse, errors.ErrorInterface) {
 	caisse := entities.CreateCaisse(obj)
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -51,7 +52,7 @@ func Test_CreateStores(t *testing.T) {
 
 	t.Run("successful creation", func(t *testing.T) {
 		mock.ExpectBegin()
-		// GORM will insert (id, created_at, updated_at, deleted_at, label, is_online)
+		// GORM will insert (id, created_at, updated_at, deleted_at, label, is_online, address, latitude, longitude, timezone)
 		mock.ExpectExec(`INSERT INTO "stores"`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID for store-1
@@ -60,12 +61,20 @@ func Test_CreateStores(t *testing.T) {
 				nil,              // DeletedAt
 				nil,              // Label (nil)
 				nil,              // IsOnline
+				nil,              // Address
+				nil,              // Latitude
+				nil,              // Longitude
+				nil,              // Timezone
 				sqlmock.AnyArg(), // ID for store-2
 				sqlmock.AnyArg(), // CreatedAt
 				sqlmock.AnyArg(), // UpdatedAt
 				nil,              // DeletedAt
 				nil,              // Label (nil)
 				nil,              // IsOnline
+				nil,              // Address
+				nil,              // Latitude
+				nil,              // Longitude
+				nil,              // Timezone
 			).WillReturnResult(sqlmock.NewResult(2, 2))
 		mock.ExpectCommit()
 
@@ -523,3 +532,200 @@ func Test_UpdateCaisse(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+// Test_CreateStore tests the CreateStore method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_CreateStore(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	obj := &transfert.Store{Label: aws.String("Nice"), IsOnline: aws.Bool(false)}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "stores" WHERE label = \$1 AND deleted_at IS NOT NULL LIMIT \$2`).
+			WithArgs("Nice", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec(`INSERT INTO "stores"`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "Nice", false, nil, nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		store, err := repo.CreateStore(obj)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, store.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("restores a deleted store", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "stores" WHERE label = \$1 AND deleted_at IS NOT NULL LIMIT \$2`).
+			WithArgs("Nice", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label", "is_online", "deleted_at"}).AddRow("store-1", "Nice", true, time.Now()))
+		mock.ExpectQuery(`SELECT \* FROM "caisses" WHERE "caisses"\."store_id" = \$1`).
+			WithArgs("store-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec(`UPDATE "stores" SET "address"=\$1,"deleted_at"=\$2,"is_online"=\$3,"latitude"=\$4,"longitude"=\$5,"timezone"=\$6,"updated_at"=\$7 WHERE "id" = \$8`).
+			WithArgs(nil, nil, false, nil, nil, nil, sqlmock.AnyArg(), "store-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		store, err := repo.CreateStore(obj)
+		assert.Nil(t, err)
+		assert.Equal(t, "store-1", store.ID)
+		assert.Nil(t, store.DeletedAt)
+		assert.False(t, *store.IsOnline)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "stores"`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		store, err := repo.CreateStore(obj)
+		assert.Nil(t, store)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_UpdateStore tests the UpdateStore method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_UpdateStore(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	obj := &entities.Store{ID: "store-1", Label: aws.String("Nice"), IsOnline: aws.Bool(true)}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "stores" SET "updated_at"=\$1,"label"=\$2,"is_online"=\$3,"address"=\$4,"latitude"=\$5,"longitude"=\$6,"timezone"=\$7 WHERE "stores"\."deleted_at" IS NULL AND "id" = \$8`).
+			WithArgs(sqlmock.AnyArg(), "Nice", true, nil, nil, nil, nil, "store-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.UpdateStore(obj))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "stores"`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateStore(obj)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_DeleteStore tests the DeleteStore method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_DeleteStore(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	obj := &transfert.Store{ID: aws.String("store-1")}
+
+	t.Run("deletes the store and its caisses", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "caisses" SET "deleted_at"=\$1 WHERE store_id = \$2`).
+			WithArgs(sqlmock.AnyArg(), "store-1").
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec(`UPDATE "stores" SET "deleted_at"=\$1 WHERE id = \$2`).
+			WithArgs(sqlmock.AnyArg(), "store-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.DeleteStore(obj))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "caisses"`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.DeleteStore(obj)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Test_ReplaceStoreHours tests the ReplaceStoreHours method of StoreRepository
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_ReplaceStoreHours(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("replaces the hours of the store", func(t *testing.T) {
+		obj := &entities.Store{
+			ID:         "store-1",
+			Openings:   entities.Openings{{Weekday: time.Monday, Opens: "09:00", Closes: "18:00"}},
+			Exceptions: entities.OpeningExceptions{{Date: "2026-12-25"}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "openings" WHERE store_id = \$1`).
+			WithArgs("store-1").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM "opening_exceptions" WHERE store_id = \$1`).
+			WithArgs("store-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO "openings"`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "store-1", time.Monday, "09:00", "18:00").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO "opening_exceptions"`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "store-1", "2026-12-25", nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.ReplaceStoreHours(obj))
+		assert.Equal(t, "store-1", *obj.Openings[0].StoreID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("clears the hours of the store", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "openings"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "opening_exceptions"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.ReplaceStoreHours(&entities.Store{ID: "store-1"}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "openings"`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.ReplaceStoreHours(&entities.Store{ID: "store-1"})
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
 	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
@@ -13,7 +14,7 @@ func (s *StoreService) GetCaisse(dto *transfert.Caisse) (*entities.Caisse, error
 		return nil, errors.ErrNoDto
 	}
 
-	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return nil, errors.ErrUnauthorized
 	}
 
@@ -22,6 +23,10 @@ func (s *StoreService) GetCaisse(dto *transfert.Caisse) (*entities.Caisse, error
 		return nil, err
 	}
 
+	if err := s.isMember(caisse.StoreID); err != nil {
+		return nil, err
+	}
+
 	return caisse, nil
 }
 
@@ -30,7 +35,7 @@ func (s *StoreService) CreateCaisse(dto *transfert.Caisse) (*entities.Caisse, er
 		return nil, errors.ErrNoDto
 	}
 
-	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return nil, errors.ErrUnauthorized
 	}
 
@@ -39,6 +44,10 @@ func (s *StoreService) CreateCaisse(dto *transfert.Caisse) (*entities.Caisse, er
 		return nil, err
 	}
 
+	if err := s.isMember(dto.StoreID); err != nil {
+		return nil, err
+	}
+
 	caisse, err := s.repo.CreateCaisse(dto)
 	if err != nil {
 		return nil, err
@@ -52,7 +61,7 @@ func (s *StoreService) UpdateCaisse(dto *transfert.Caisse) (*entities.Caisse, er
 		return nil, errors.ErrNoDto
 	}
 
-	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return nil, errors.ErrUnauthorized
 	}
 
@@ -61,6 +70,17 @@ func (s *StoreService) UpdateCaisse(dto *transfert.Caisse) (*entities.Caisse, er
 		return nil, err
 	}
 
+	if err := s.isMember(caisse.StoreID); err != nil {
+		return nil, err
+	}
+
+	// Moving the caisse to another store requires to be assigned to both
+	if dto.StoreID != nil && (caisse.StoreID == nil || *dto.StoreID != *caisse.StoreID) {
+		if err := s.isMember(dto.StoreID); err != nil {
+			return nil, err
+		}
+	}
+
 	data.UpdateEntityWithDto(caisse, dto)
 
 	if err := s.repo.UpdateCaisse(caisse); err != nil {
@@ -75,10 +95,19 @@ func (s *StoreService) DeleteCaisse(dto *transfert.Caisse) errors.ErrorInterface
 		return errors.ErrNoDto
 	}
 
-	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return errors.ErrUnauthorized
 	}
 
+	caisse, err := s.repo.ReadCaisse(dto)
+	if err != nil {
+		return err
+	}
+
+	if err := s.isMember(caisse.StoreID); err != nil {
+		return err
+	}
+
 	if err := s.repo.DeleteCaisse(dto); err != nil {
 		return err
 	}
@@ -42,7 +42,8 @@ func Test_GetCaisse(t *testing.T) {
 
 		caisse := &entities.Caisse{ID: "caisse-123"}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
 		mockRepo.On("ReadCaisse", dto, mock.Anything).Return(caisse, nil)
 
 		result, err := service.GetCaisse(dto)
@@ -60,7 +61,7 @@ func Test_GetCaisse(t *testing.T) {
 		idCaisse := "caisse-123"
 		dto := &transfert.Caisse{ID: &idCaisse}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
 
 		result, err := service.GetCaisse(dto)
 		assert.Nil(t, result)
@@ -70,6 +71,24 @@ func Test_GetCaisse(t *testing.T) {
 		mockPerms.AssertExpectations(t)
 	})
 
+	t.Run("Devrait retourner une erreur lorsque la caisse n'appartient pas aux magasins de l'employé", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		idCaisse := "caisse-123"
+		idStore := "store-456"
+		dto := &transfert.Caisse{ID: &idCaisse}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockRepo.On("ReadCaisse", dto, mock.Anything).Return(&entities.Caisse{ID: "caisse-123", StoreID: &idStore}, nil)
+
+		result, err := service.GetCaisse(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockPerms.AssertExpectations(t)
+	})
+
 	t.Run("Devrait retourner une erreur lorsque dto est nil", func(t *testing.T) {
 		service, _, _ := setup()
 
@@ -85,7 +104,7 @@ func Test_GetCaisse(t *testing.T) {
 		idCaisse := "caisse-123"
 		dto := &transfert.Caisse{ID: &idCaisse}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		mockRepo.On("ReadCaisse", dto, mock.Anything).Return(nil, errors.ErrNoData)
 
 		result, err := service.GetCaisse(dto)
@@ -114,7 +133,8 @@ func Test_CreateCaisse(t *testing.T) {
 		storeDTO := &transfert.Store{ID: &idStore}
 		caisse := &entities.Caisse{ID: "caisse-123"}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
 		mockRepo.On("ReadStore", storeDTO, mock.Anything).Return(&entities.Store{ID: "store-456"}, nil)
 		mockRepo.On("CreateCaisse", dto, mock.Anything).Return(caisse, nil)
 
@@ -133,7 +153,7 @@ func Test_CreateCaisse(t *testing.T) {
 		idCaisse := "caisse-123"
 		dto := &transfert.Caisse{ID: &idCaisse}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
 
 		result, err := service.CreateCaisse(dto)
 		assert.Nil(t, result)
@@ -160,7 +180,7 @@ func Test_CreateCaisse(t *testing.T) {
 		dto := &transfert.Caisse{ID: &idCaisse, StoreID: &idStore}
 		storeDTO := &transfert.Store{ID: &idStore}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		mockRepo.On("ReadStore", storeDTO, mock.Anything).Return(nil, errors.ErrNoData)
 
 		result, err := service.CreateCaisse(dto)
@@ -180,7 +200,8 @@ func Test_CreateCaisse(t *testing.T) {
 		dto := &transfert.Caisse{ID: &idCaisse, StoreID: &idStore}
 		storeDTO := &transfert.Store{ID: &idStore}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
 		mockRepo.On("ReadStore", storeDTO, mock.Anything).Return(&entities.Store{ID: "store-456"}, nil)
 		mockRepo.On("CreateCaisse", dto, mock.Anything).Return(nil, errors.ErrNoData)
 
@@ -208,7 +229,8 @@ func Test_UpdateCaisse(t *testing.T) {
 		dto := &transfert.Caisse{ID: &idCaisse}
 		caisse := &entities.Caisse{ID: "caisse-123"}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
 		mockRepo.On("ReadCaisse", &transfert.Caisse{ID: &idCaisse}, mock.Anything).Return(caisse, nil)
 		mockRepo.On("UpdateCaisse", caisse, mock.Anything).Return(nil)
 
@@ -227,7 +249,7 @@ func Test_UpdateCaisse(t *testing.T) {
 		idCaisse := "caisse-123"
 		dto := &transfert.Caisse{ID: &idCaisse}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
 
 		result, err := service.UpdateCaisse(dto)
 		assert.Nil(t, result)
@@ -252,7 +274,7 @@ func Test_UpdateCaisse(t *testing.T) {
 		idCaisse := "caisse-123"
 		dto := &transfert.Caisse{ID: &idCaisse}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		mockRepo.On("ReadCaisse", &transfert.Caisse{ID: &idCaisse}, mock.Anything).Return(nil, errors.ErrNoData)
 
 		result, err := service.UpdateCaisse(dto)
@@ -264,6 +286,27 @@ func Test_UpdateCaisse(t *testing.T) {
 		mockPerms.AssertExpectations(t)
 	})
 
+	t.Run("Devrait retourner une erreur lorsque la caisse est déplacée vers un magasin non assigné à l'employé", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		idCaisse := "caisse-123"
+		idStore := "store-456"
+		idOther := "store-789"
+		dto := &transfert.Caisse{ID: &idCaisse, StoreID: &idOther}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true).Once()
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false).Once()
+		mockRepo.On("ReadCaisse", &transfert.Caisse{ID: &idCaisse}, mock.Anything).Return(&entities.Caisse{ID: "caisse-123", StoreID: &idStore}, nil)
+
+		result, err := service.UpdateCaisse(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "UpdateCaisse", mock.Anything, mock.Anything)
+		mockPerms.AssertNumberOfCalls(t, "IsGrantedByRules", 2)
+	})
+
 	t.Run("Devrait retourner une erreur lorsque UpdateCaisse échoue", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
@@ -271,7 +314,8 @@ func Test_UpdateCaisse(t *testing.T) {
 		dto := &transfert.Caisse{ID: &idCaisse}
 		caisse := &entities.Caisse{ID: "caisse-123"}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
 		mockRepo.On("ReadCaisse", &transfert.Caisse{ID: &idCaisse}, mock.Anything).Return(caisse, nil)
 		mockRepo.On("UpdateCaisse", caisse, mock.Anything).Return(errors.ErrNoData)
 
@@ -298,7 +342,9 @@ func Test_DeleteCaisse(t *testing.T) {
 		idCaisse := "caisse-123"
 		dto := &transfert.Caisse{ID: &idCaisse}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadCaisse", dto, mock.Anything).Return(&entities.Caisse{ID: "caisse-123"}, nil)
 		mockRepo.On("DeleteCaisse", dto, mock.Anything).Return(nil)
 
 		err := service.DeleteCaisse(dto)
@@ -314,7 +360,7 @@ func Test_DeleteCaisse(t *testing.T) {
 		idCaisse := "caisse-123"
 		dto := &transfert.Caisse{ID: &idCaisse}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
 
 		err := service.DeleteCaisse(dto)
 		assert.NotNil(t, err)
@@ -331,13 +377,32 @@ func Test_DeleteCaisse(t *testing.T) {
 		assert.Equal(t, errors.ErrNoDto, err)
 	})
 
+	t.Run("Devrait retourner une erreur lorsque la caisse n'appartient pas aux magasins de l'employé", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		idCaisse := "caisse-123"
+		idStore := "store-456"
+		dto := &transfert.Caisse{ID: &idCaisse}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockRepo.On("ReadCaisse", dto, mock.Anything).Return(&entities.Caisse{ID: "caisse-123", StoreID: &idStore}, nil)
+
+		err := service.DeleteCaisse(dto)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "DeleteCaisse", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Devrait retourner une erreur lorsque DeleteCaisse échoue", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
 		idCaisse := "caisse-123"
 		dto := &transfert.Caisse{ID: &idCaisse}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadCaisse", dto, mock.Anything).Return(&entities.Caisse{ID: "caisse-123"}, nil)
 		mockRepo.On("DeleteCaisse", dto, mock.Anything).Return(errors.ErrNoData)
 
 		err := service.DeleteCaisse(dto)
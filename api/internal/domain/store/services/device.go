@@ -0,0 +1,224 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// PairedTo is the rule granting the device paired to a caisse
+// The device is read on every request, so that a revoked device is refused at once.
+//
+// Parameters:
+// - repo: repositories.StoreRepositoryInterface the repository of the devices
+// - caisseID: *string the caisse
+//
+// Returns:
+// - security.Rule: the rule to evaluate against the access of the device
+func PairedTo(repo repositories.StoreRepositoryInterface, caisseID *string) security.Rule {
+	return func(p *security.UserAccess, args ...any) bool {
+		if caisseID == nil || !p.IsAuthenticated() || !p.IsGrantedByRoles(security.ROLE_DEVICE) {
+			return false
+		}
+
+		device, err := repo.ReadDevice(&transfert.Device{ID: p.GetCredentialID(), CaisseID: caisseID})
+		if err != nil {
+			return false
+		}
+
+		return device.IsActive()
+	}
+}
+
+// CreatePairing registers a device for a caisse and returns its one-time pairing code
+//
+// Parameters:
+// - dto: *transfert.Device the caisse and the label of the device
+//
+// Returns:
+// - *entities.Device: the device waiting for its pairing, with its code
+// - errors.ErrorInterface: an error if the caisse is unknown or out of the stores of the employee
+func (s *StoreService) CreatePairing(dto *transfert.Device) (*entities.Device, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	caisse, err := s.repo.ReadCaisse(&transfert.Caisse{ID: dto.CaisseID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.isMember(caisse.StoreID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateDevice(&transfert.Device{
+		Label:     dto.Label,
+		CaisseID:  &caisse.ID,
+		StoreID:   caisse.StoreID,
+		CreatedBy: s.security.GetCredentialID(),
+	})
+}
+
+// PairDevice exchanges a one-time pairing code for the credential of the device
+// The device has no token yet, the code alone authenticates it. Failed pairings are recorded per IP
+// and lock the pairings for an escalating duration, so that the short codes cannot be guessed.
+//
+// Parameters:
+// - dto: *transfert.Device the pairing code and the label of the device
+//
+// Returns:
+// - *entities.Device: the paired device
+// - errors.ErrorInterface: an error if the code is unknown, expired or already used, or the IP is locked
+func (s *StoreService) PairDevice(dto *transfert.Device) (*entities.Device, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	ip := s.security.GetIP()
+
+	if err := s.checkPairingLockout(ip); err != nil {
+		return nil, err
+	}
+
+	device, err := s.repo.ReadDevice(&transfert.Device{Code: dto.Code})
+	if err == errors_domain_store.ErrDeviceNotFound {
+		return nil, s.failPairing(ip, dto.Code, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !device.CanPair(now) {
+		return nil, s.failPairing(ip, dto.Code, errors_domain_store.ErrDevicePairingExpired)
+	}
+
+	if dto.Label != nil {
+		device.Label = dto.Label
+	}
+
+	// Losing the race against another pairing is handled like any used code
+	if err := s.repo.PairDevice(device, now); err == errors_domain_store.ErrDevicePairingExpired {
+		return nil, s.failPairing(ip, dto.Code, err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// GetDevices lists the paired devices of a store, revoked ones included
+//
+// Parameters:
+// - dto: *transfert.Device the store
+//
+// Returns:
+// - []*entities.Device: the devices of the store
+// - errors.ErrorInterface: an error if the store is unknown or out of the stores of the employee
+func (s *StoreService) GetDevices(dto *transfert.Device) ([]*entities.Device, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadStore(&transfert.Store{ID: dto.StoreID}); err != nil {
+		return nil, err
+	}
+
+	if err := s.isMember(dto.StoreID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadDevices(&transfert.Device{StoreID: dto.StoreID}, database.Where("paired_at IS NOT NULL"), database.Order("created_at"))
+}
+
+// RevokeDevice revokes the credential of a device, its token is refused from the next request
+//
+// Parameters:
+// - dto: *transfert.Device the device
+//
+// Returns:
+// - errors.ErrorInterface: an error if the device is unknown, out of the stores of the employee or already revoked
+func (s *StoreService) RevokeDevice(dto *transfert.Device) errors.ErrorInterface {
+	if dto == nil {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return errors.ErrUnauthorized
+	}
+
+	device, err := s.repo.ReadDevice(&transfert.Device{ID: dto.ID})
+	if err != nil {
+		return err
+	}
+
+	if err := s.isMember(device.StoreID); err != nil {
+		return err
+	}
+
+	if !device.Revoke(time.Now()) {
+		return errors_domain_store.ErrDeviceRevoked
+	}
+
+	return s.repo.UpdateDevice(device)
+}
+
+// checkPairingLockout refuses the pairing while the IP is locked
+//
+// Parameters:
+// - ip: *string the IP of the device
+//
+// Returns:
+// - errors.ErrorInterface: ErrDevicePairingLocked if the pairings are locked
+func (s *StoreService) checkPairingLockout(ip *string) errors.ErrorInterface {
+	if ip == nil || *ip == "" {
+		return nil
+	}
+
+	now := time.Now()
+
+	attempts, err := s.repo.ReadPairingAttempts(&transfert.PairingAttempt{IP: ip}, database.Where("created_at > ?", now.Add(-entities.PairingAttemptWindow)))
+	if err != nil {
+		return err
+	}
+
+	if entities.PairingLockedUntil(attempts, now).After(now) {
+		return errors_domain_store.ErrDevicePairingLocked
+	}
+
+	return nil
+}
+
+// failPairing records a failed pairing and returns the error to send to the device
+//
+// Parameters:
+// - ip: *string the IP of the device
+// - code: *string the submitted code
+// - cause: errors.ErrorInterface the error of the pairing
+//
+// Returns:
+// - errors.ErrorInterface: the cause, or the error of the record
+func (s *StoreService) failPairing(ip, code *string, cause errors.ErrorInterface) errors.ErrorInterface {
+	if _, err := s.repo.CreatePairingAttempt(&transfert.PairingAttempt{IP: ip, Code: code}); err != nil {
+		return err
+	}
+
+	return cause
+}
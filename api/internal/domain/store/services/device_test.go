@@ -0,0 +1,376 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Test_PairedTo tests the PairedTo rule
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_PairedTo(t *testing.T) {
+	idCaisse := aws.String("caisse-123")
+	pairedAt := time.Now()
+
+	t.Run("Devrait autoriser l'appareil appairé à la caisse", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "device-123", Role: security.ROLE_DEVICE}
+
+		mockRepo.On("ReadDevice", &transfert.Device{ID: aws.String("device-123"), CaisseID: idCaisse}, mock.Anything).Return(&entities.Device{ID: "device-123", PairedAt: &pairedAt}, nil)
+
+		assert.True(t, access.IsGrantedByRules(services.PairedTo(mockRepo, idCaisse)))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser un appareil révoqué", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "device-123", Role: security.ROLE_DEVICE}
+
+		mockRepo.On("ReadDevice", mock.Anything, mock.Anything).Return(&entities.Device{ID: "device-123", PairedAt: &pairedAt, RevokedAt: &pairedAt}, nil)
+
+		assert.False(t, access.IsGrantedByRules(services.PairedTo(mockRepo, idCaisse)))
+	})
+
+	t.Run("Devrait refuser un appareil appairé à une autre caisse", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "device-123", Role: security.ROLE_DEVICE}
+
+		mockRepo.On("ReadDevice", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrDeviceNotFound)
+
+		assert.False(t, access.IsGrantedByRules(services.PairedTo(mockRepo, idCaisse)))
+	})
+
+	t.Run("Devrait refuser un employé", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}
+
+		assert.False(t, access.IsGrantedByRules(services.PairedTo(mockRepo, idCaisse)))
+		mockRepo.AssertNotCalled(t, "ReadDevice", mock.Anything, mock.Anything)
+	})
+}
+
+// Test_CreatePairing tests the CreatePairing method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_CreatePairing(t *testing.T) {
+	idEmployee := "employee-123"
+	caisse := &entities.Caisse{ID: "caisse-123", StoreID: aws.String("store-123")}
+	dto := &transfert.Device{CaisseID: aws.String("caisse-123"), Label: aws.String("Comptoir")}
+
+	t.Run("Devrait générer un code d'appairage pour la caisse", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		device := &entities.Device{ID: "device-123", Code: token.NewLuhn("ABCDEFGH").Pointer()}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockPerms.On("GetCredentialID").Return(&idEmployee)
+		mockRepo.On("ReadCaisse", &transfert.Caisse{ID: dto.CaisseID}, mock.Anything).Return(caisse, nil)
+		mockRepo.On("CreateDevice", &transfert.Device{
+			Label:     dto.Label,
+			CaisseID:  &caisse.ID,
+			StoreID:   caisse.StoreID,
+			CreatedBy: &idEmployee,
+		}, mock.Anything).Return(device, nil)
+
+		result, err := service.CreatePairing(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, device, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser un employé qui n'est pas assigné au store de la caisse", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockRepo.On("ReadCaisse", mock.Anything, mock.Anything).Return(caisse, nil)
+
+		result, err := service.CreatePairing(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "CreateDevice", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque la caisse n'existe pas", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadCaisse", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrCaisseNotFound)
+
+		result, err := service.CreatePairing(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrCaisseNotFound, err)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque non employé", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
+
+		result, err := service.CreatePairing(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadCaisse", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque dto est nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		result, err := service.CreatePairing(nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+// Test_PairDevice tests the PairDevice method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_PairDevice(t *testing.T) {
+	dto := &transfert.Device{Code: aws.String("ABCDEFGH"), Label: aws.String("Comptoir")}
+	ip := aws.String("203.0.113.7")
+	attempt := &transfert.PairingAttempt{IP: ip, Code: dto.Code}
+
+	pending := func(expiresAt time.Time) *entities.Device {
+		return &entities.Device{ID: "device-123", Code: token.NewLuhn("ABCDEFGH").Pointer(), ExpiresAt: &expiresAt}
+	}
+
+	failures := func(count int) []*entities.PairingAttempt {
+		attempts := make([]*entities.PairingAttempt, count)
+		for i := range attempts {
+			attempts[i] = &entities.PairingAttempt{IP: ip, CreatedAt: time.Now().Add(-time.Second)}
+		}
+
+		return attempts
+	}
+
+	t.Run("Devrait appairer l'appareil et consommer le code", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		device := pending(time.Now().Add(time.Minute))
+
+		mockPerms.On("GetIP").Return(ip)
+		mockRepo.On("ReadPairingAttempts", &transfert.PairingAttempt{IP: ip}, mock.Anything).Return(failures(0), nil)
+		mockRepo.On("ReadDevice", &transfert.Device{Code: dto.Code}, mock.Anything).Return(device, nil)
+		mockRepo.On("PairDevice", device, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*entities.Device).Pair(args.Get(1).(time.Time))
+		}).Return(nil)
+
+		result, err := service.PairDevice(dto)
+		assert.Nil(t, err)
+		assert.True(t, result.IsActive())
+		assert.Nil(t, result.Code)
+		assert.Equal(t, "Comptoir", *result.Label)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CreatePairingAttempt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait refuser un code expiré et enregistrer l'échec", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("GetIP").Return(ip)
+		mockRepo.On("ReadPairingAttempts", mock.Anything, mock.Anything).Return(failures(0), nil)
+		mockRepo.On("ReadDevice", mock.Anything, mock.Anything).Return(pending(time.Now().Add(-time.Minute)), nil)
+		mockRepo.On("CreatePairingAttempt", attempt, mock.Anything).Return(&entities.PairingAttempt{}, nil)
+
+		result, err := service.PairDevice(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrDevicePairingExpired, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "PairDevice", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait refuser un code consommé entre la lecture et l'appairage", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("GetIP").Return(ip)
+		mockRepo.On("ReadPairingAttempts", mock.Anything, mock.Anything).Return(failures(0), nil)
+		mockRepo.On("ReadDevice", mock.Anything, mock.Anything).Return(pending(time.Now().Add(time.Minute)), nil)
+		mockRepo.On("PairDevice", mock.Anything, mock.Anything, mock.Anything).Return(errors_domain_store.ErrDevicePairingExpired)
+		mockRepo.On("CreatePairingAttempt", attempt, mock.Anything).Return(&entities.PairingAttempt{}, nil)
+
+		result, err := service.PairDevice(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrDevicePairingExpired, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque le code est inconnu et enregistrer l'échec", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("GetIP").Return(ip)
+		mockRepo.On("ReadPairingAttempts", mock.Anything, mock.Anything).Return(failures(0), nil)
+		mockRepo.On("ReadDevice", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrDeviceNotFound)
+		mockRepo.On("CreatePairingAttempt", attempt, mock.Anything).Return(&entities.PairingAttempt{}, nil)
+
+		result, err := service.PairDevice(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrDeviceNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait bloquer l'IP après trop d'échecs", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("GetIP").Return(ip)
+		mockRepo.On("ReadPairingAttempts", &transfert.PairingAttempt{IP: ip}, mock.Anything).Return(failures(entities.PairingLockouts[0].Failures), nil)
+
+		result, err := service.PairDevice(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrDevicePairingLocked, err)
+		mockRepo.AssertNotCalled(t, "ReadDevice", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CreatePairingAttempt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque dto est nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		result, err := service.PairDevice(nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+// Test_GetDevices tests the GetDevices method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_GetDevices(t *testing.T) {
+	idStore := aws.String("store-123")
+	dto := &transfert.Device{StoreID: idStore}
+
+	t.Run("Devrait lister les appareils du store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{ID: idStore}, mock.Anything).Return(&entities.Store{ID: *idStore}, nil)
+		mockRepo.On("ReadDevices", &transfert.Device{StoreID: idStore}, mock.Anything).Return([]*entities.Device{{ID: "device-1"}, {ID: "device-2"}}, nil)
+
+		result, err := service.GetDevices(dto)
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser un employé qui n'est pas assigné au store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockRepo.On("ReadStore", mock.Anything, mock.Anything).Return(&entities.Store{ID: *idStore}, nil)
+
+		result, err := service.GetDevices(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadDevices", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque le store n'existe pas", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		result, err := service.GetDevices(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, err)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque dto est nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		result, err := service.GetDevices(nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+// Test_RevokeDevice tests the RevokeDevice method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_RevokeDevice(t *testing.T) {
+	dto := &transfert.Device{ID: aws.String("device-123")}
+	pairedAt := time.Now()
+
+	t.Run("Devrait révoquer l'appareil", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		device := &entities.Device{ID: "device-123", StoreID: aws.String("store-123"), PairedAt: &pairedAt}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadDevice", dto, mock.Anything).Return(device, nil)
+		mockRepo.On("UpdateDevice", device, mock.Anything).Return(nil)
+
+		assert.Nil(t, service.RevokeDevice(dto))
+		assert.True(t, device.IsRevoked())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser un appareil déjà révoqué", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
+		mockRepo.On("ReadDevice", dto, mock.Anything).Return(&entities.Device{ID: "device-123", PairedAt: &pairedAt, RevokedAt: &pairedAt}, nil)
+
+		assert.Equal(t, errors_domain_store.ErrDeviceRevoked, service.RevokeDevice(dto))
+		mockRepo.AssertNotCalled(t, "UpdateDevice", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait refuser un employé qui n'est pas assigné au store de l'appareil", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockRepo.On("ReadDevice", dto, mock.Anything).Return(&entities.Device{ID: "device-123", PairedAt: &pairedAt}, nil)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.RevokeDevice(dto))
+		mockRepo.AssertNotCalled(t, "UpdateDevice", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque l'appareil n'existe pas", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadDevice", dto, mock.Anything).Return(nil, errors_domain_store.ErrDeviceNotFound)
+
+		assert.Equal(t, errors_domain_store.ErrDeviceNotFound, service.RevokeDevice(dto))
+	})
+
+	t.Run("Devrait retourner une erreur lorsque non employé", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.RevokeDevice(dto))
+		mockRepo.AssertNotCalled(t, "ReadDevice", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+const (
+	// DefaultRadius is the radius of the search in kilometers when none is given
+	DefaultRadius = 10.0
+	// MaxRadius is the largest radius of a search in kilometers
+	MaxRadius = 100.0
+)
+
+// FindNearbyStores lists the stores around a position, the closest first
+// The search is public so that clients can pick where to redeem their prize.
+//
+// Parameters:
+// - dto: *transfert.Nearby the position and the radius in kilometers
+//
+// Returns:
+// - []*entities.NearbyStore: the stores within the radius with their distance and whether they are open now
+// - errors.ErrorInterface: an error if the stores cannot be read
+func (s *StoreService) FindNearbyStores(dto *transfert.Nearby) ([]*entities.NearbyStore, errors.ErrorInterface) {
+	if dto == nil || dto.Latitude == nil || dto.Longitude == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	radius := DefaultRadius
+	if dto.Radius != nil {
+		radius = *dto.Radius
+	}
+
+	minLat, maxLat, minLng, maxLng := entities.BoundingBox(*dto.Latitude, *dto.Longitude, radius)
+
+	stores, err := s.repo.ReadStores(&transfert.Store{},
+		database.Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", minLat, maxLat, minLng, maxLng),
+		database.Preload("Openings"),
+		database.Preload("Exceptions"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	nearby := []*entities.NearbyStore{}
+	for _, store := range stores {
+		if store.Latitude == nil || store.Longitude == nil {
+			continue
+		}
+
+		distance := entities.Distance(*dto.Latitude, *dto.Longitude, *store.Latitude, *store.Longitude)
+		if distance > radius {
+			continue
+		}
+
+		nearby = append(nearby, &entities.NearbyStore{
+			Store:    store,
+			Distance: distance,
+			IsOpen:   store.IsOpenAt(now),
+		})
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return nearby[i].Distance < nearby[j].Distance
+	})
+
+	return nearby, nil
+}
+
+// UpdateStoreHours replaces the weekly hours and the exceptions of a store
+//
+// Parameters:
+// - dto: *transfert.StoreHours the store, its weekly slots and its exceptions
+//
+// Returns:
+// - *entities.Store: the store with its new hours
+// - errors.ErrorInterface: an error if the store cannot be found or saved
+func (s *StoreService) UpdateStoreHours(dto *transfert.StoreHours) (*entities.Store, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	store, err := s.repo.ReadStore(&transfert.Store{ID: dto.StoreID})
+	if err != nil {
+		return nil, err
+	}
+
+	store.Openings = entities.Openings{}
+	for _, opening := range dto.Openings {
+		store.Openings = append(store.Openings, entities.CreateOpening(opening))
+	}
+
+	store.Exceptions = entities.OpeningExceptions{}
+	for _, exception := range dto.Exceptions {
+		store.Exceptions = append(store.Exceptions, entities.CreateOpeningException(exception))
+	}
+
+	if err := s.repo.ReplaceStoreHours(store); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
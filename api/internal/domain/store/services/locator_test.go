@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Test_FindNearbyStores tests the FindNearbyStores method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_FindNearbyStores(t *testing.T) {
+	// Paris, Versailles à 17 km et Lyon à 392 km
+	paris := &transfert.Nearby{Latitude: aws.Float64(48.8566), Longitude: aws.Float64(2.3522)}
+
+	t.Run("Devrait lister les stores du rayon du plus proche au plus lointain", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		versailles := &entities.Store{ID: "versailles", IsOnline: aws.Bool(false), Latitude: aws.Float64(48.8049), Longitude: aws.Float64(2.1204)}
+		louvre := &entities.Store{ID: "louvre", IsOnline: aws.Bool(true), Latitude: aws.Float64(48.8606), Longitude: aws.Float64(2.3376)}
+		lyon := &entities.Store{ID: "lyon", IsOnline: aws.Bool(false), Latitude: aws.Float64(45.7640), Longitude: aws.Float64(4.8357)}
+
+		mockRepo.On("ReadStores", &transfert.Store{}, mock.Anything).Return([]*entities.Store{versailles, lyon, louvre}, nil)
+
+		result, err := service.FindNearbyStores(&transfert.Nearby{Latitude: paris.Latitude, Longitude: paris.Longitude, Radius: aws.Float64(20)})
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "louvre", result[0].ID)
+		assert.True(t, result[0].IsOpen)
+		assert.Equal(t, "versailles", result[1].ID)
+		assert.False(t, result[1].IsOpen)
+		assert.InDelta(t, 17, result[1].Distance, 1)
+	})
+
+	t.Run("Devrait utiliser le rayon par défaut", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		versailles := &entities.Store{ID: "versailles", Latitude: aws.Float64(48.8049), Longitude: aws.Float64(2.1204)}
+		mockRepo.On("ReadStores", &transfert.Store{}, mock.Anything).Return([]*entities.Store{versailles}, nil)
+
+		result, err := service.FindNearbyStores(paris)
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Devrait retourner une erreur sans position", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		result, err := service.FindNearbyStores(&transfert.Nearby{Latitude: paris.Latitude})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrNoDto, err)
+		mockRepo.AssertNotCalled(t, "ReadStores", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner l'erreur du repository", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		mockRepo.On("ReadStores", &transfert.Store{}, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		result, err := service.FindNearbyStores(paris)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
+
+// Test_UpdateStoreHours tests the UpdateStoreHours method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_UpdateStoreHours(t *testing.T) {
+	idStore := "store-123"
+	dto := &transfert.StoreHours{
+		StoreID:    &idStore,
+		Openings:   []*transfert.Opening{{Weekday: aws.Int(1), Opens: aws.String("09:00"), Closes: aws.String("18:00")}},
+		Exceptions: []*transfert.OpeningException{{Date: aws.String("2026-12-25"), Reason: aws.String("Noël")}},
+	}
+
+	t.Run("Devrait remplacer les horaires du store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{ID: &idStore}, mock.Anything).Return(&entities.Store{ID: idStore}, nil)
+		mockRepo.On("ReplaceStoreHours", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := service.UpdateStoreHours(dto)
+		assert.Nil(t, err)
+		assert.Len(t, result.Openings, 1)
+		assert.Equal(t, "09:00", result.Openings[0].Opens)
+		assert.Len(t, result.Exceptions, 1)
+		assert.Equal(t, "2026-12-25", result.Exceptions[0].Date)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser un utilisateur non administrateur", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		result, err := service.UpdateStoreHours(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReplaceStoreHours", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque le store n'existe pas", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{ID: &idStore}, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		result, err := service.UpdateStoreHours(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, err)
+	})
+
+	t.Run("Devrait retourner une erreur sans dto", func(t *testing.T) {
+		service, _, _ := setup()
+
+		result, err := service.UpdateStoreHours(nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
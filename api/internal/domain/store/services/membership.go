@@ -0,0 +1,131 @@
+package services
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// MemberOf is the rule granting the employees assigned to a store, admins are always granted
+//
+// Parameters:
+// - repo: repositories.StoreRepositoryInterface the repository of the memberships
+// - storeID: *string the store
+//
+// Returns:
+// - security.Rule: the rule to evaluate against the access of the user
+func MemberOf(repo repositories.StoreRepositoryInterface, storeID *string) security.Rule {
+	return func(p *security.UserAccess, args ...any) bool {
+		if p.IsGrantedByRoles(security.ROLE_ADMIN) {
+			return true
+		}
+
+		if storeID == nil || !p.IsAuthenticated() || !p.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+			return false
+		}
+
+		_, err := repo.ReadMembership(&transfert.Membership{StoreID: storeID, CredentialID: p.GetCredentialID()})
+
+		return err == nil
+	}
+}
+
+// GetMemberships lists the employees assigned to a store
+// Only admins manage the memberships.
+//
+// Parameters:
+// - dto: *transfert.Membership the store
+//
+// Returns:
+// - []*entities.Membership: the memberships of the store
+// - errors.ErrorInterface: an error if the store is unknown
+func (s *StoreService) GetMemberships(dto *transfert.Membership) ([]*entities.Membership, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadStore(&transfert.Store{ID: dto.StoreID}); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadMemberships(&transfert.Membership{StoreID: dto.StoreID}, database.Order("created_at"))
+}
+
+// CreateMembership assigns an employee to a store
+// Only admins manage the memberships.
+//
+// Parameters:
+// - dto: *transfert.Membership the store and the credential of the employee
+//
+// Returns:
+// - *entities.Membership: the created membership
+// - errors.ErrorInterface: an error if the store is unknown or the employee already assigned to it
+func (s *StoreService) CreateMembership(dto *transfert.Membership) (*entities.Membership, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadStore(&transfert.Store{ID: dto.StoreID}); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.ReadMembership(dto); err == nil {
+		return nil, errors_domain_store.ErrMembershipAlreadyExists
+	} else if err != errors_domain_store.ErrMembershipNotFound {
+		return nil, err
+	}
+
+	return s.repo.CreateMembership(dto)
+}
+
+// DeleteMembership removes an employee from a store
+// Only admins manage the memberships.
+//
+// Parameters:
+// - dto: *transfert.Membership the store and the credential of the employee
+//
+// Returns:
+// - errors.ErrorInterface: an error if the employee is not assigned to the store
+func (s *StoreService) DeleteMembership(dto *transfert.Membership) errors.ErrorInterface {
+	if dto == nil {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadMembership(dto); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteMembership(dto)
+}
+
+// isMember checks the current user may operate the store
+//
+// Parameters:
+// - storeID: *string the store
+//
+// Returns:
+// - errors.ErrorInterface: ErrUnauthorized if the employee is not assigned to the store
+func (s *StoreService) isMember(storeID *string) errors.ErrorInterface {
+	if !s.security.IsGrantedByRules(MemberOf(s.repo, storeID)) {
+		return errors.ErrUnauthorized
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Test_MemberOf tests the MemberOf rule
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_MemberOf(t *testing.T) {
+	idStore := aws.String("store-123")
+
+	t.Run("Devrait autoriser un employé assigné au store", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}
+
+		mockRepo.On("ReadMembership", &transfert.Membership{StoreID: idStore, CredentialID: aws.String("employee-123")}, mock.Anything).Return(&entities.Membership{}, nil)
+
+		assert.True(t, access.IsGrantedByRules(services.MemberOf(mockRepo, idStore)))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser un employé qui n'est pas assigné au store", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}
+
+		mockRepo.On("ReadMembership", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrMembershipNotFound)
+
+		assert.False(t, access.IsGrantedByRules(services.MemberOf(mockRepo, idStore)))
+	})
+
+	t.Run("Devrait autoriser un administrateur sans assignation", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "admin-123", Role: security.ROLE_ADMIN}
+
+		assert.True(t, access.IsGrantedByRules(services.MemberOf(mockRepo, idStore)))
+		mockRepo.AssertNotCalled(t, "ReadMembership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait refuser un client", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "client-123", Role: user.ROLE_CLIENT}
+
+		assert.False(t, access.IsGrantedByRules(services.MemberOf(mockRepo, idStore)))
+		mockRepo.AssertNotCalled(t, "ReadMembership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait refuser un employé sans store", func(t *testing.T) {
+		mockRepo := new(StoreRepositoryMock)
+		access := &security.UserAccess{CredentialID: "employee-123", Role: user.ROLE_EMPLOYEE}
+
+		assert.False(t, access.IsGrantedByRules(services.MemberOf(mockRepo, nil)))
+		mockRepo.AssertNotCalled(t, "ReadMembership", mock.Anything, mock.Anything)
+	})
+}
+
+// Test_GetMemberships tests the GetMemberships method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_GetMemberships(t *testing.T) {
+	idStore := aws.String("store-123")
+	dto := &transfert.Membership{StoreID: idStore}
+
+	t.Run("Devrait lister les employés du store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{ID: idStore}, mock.Anything).Return(&entities.Store{ID: *idStore}, nil)
+		mockRepo.On("ReadMemberships", &transfert.Membership{StoreID: idStore}, mock.Anything).Return([]*entities.Membership{
+			{StoreID: idStore, CredentialID: aws.String("employee-1")},
+			{StoreID: idStore, CredentialID: aws.String("employee-2")},
+		}, nil)
+
+		result, err := service.GetMemberships(dto)
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque le store n'existe pas", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		result, err := service.GetMemberships(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, err)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque non administrateur", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		result, err := service.GetMemberships(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadMemberships", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque dto est nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		result, err := service.GetMemberships(nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+// Test_CreateMembership tests the CreateMembership method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_CreateMembership(t *testing.T) {
+	idStore := aws.String("store-123")
+	dto := &transfert.Membership{StoreID: idStore, CredentialID: aws.String("employee-123")}
+
+	t.Run("Devrait assigner l'employé au store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		membership := &entities.Membership{ID: "membership-123", StoreID: idStore, CredentialID: dto.CredentialID}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{ID: idStore}, mock.Anything).Return(&entities.Store{ID: *idStore}, nil)
+		mockRepo.On("ReadMembership", dto, mock.Anything).Return(nil, errors_domain_store.ErrMembershipNotFound)
+		mockRepo.On("CreateMembership", dto, mock.Anything).Return(membership, nil)
+
+		result, err := service.CreateMembership(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, membership, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser un employé déjà assigné au store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", mock.Anything, mock.Anything).Return(&entities.Store{ID: *idStore}, nil)
+		mockRepo.On("ReadMembership", dto, mock.Anything).Return(&entities.Membership{ID: "membership-123"}, nil)
+
+		result, err := service.CreateMembership(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrMembershipAlreadyExists, err)
+		mockRepo.AssertNotCalled(t, "CreateMembership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque le store n'existe pas", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", mock.Anything, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		result, err := service.CreateMembership(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, err)
+		mockRepo.AssertNotCalled(t, "CreateMembership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque non administrateur", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		result, err := service.CreateMembership(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "CreateMembership", mock.Anything, mock.Anything)
+	})
+}
+
+// Test_DeleteMembership tests the DeleteMembership method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_DeleteMembership(t *testing.T) {
+	dto := &transfert.Membership{StoreID: aws.String("store-123"), CredentialID: aws.String("employee-123")}
+
+	t.Run("Devrait retirer l'employé du store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadMembership", dto, mock.Anything).Return(&entities.Membership{ID: "membership-123"}, nil)
+		mockRepo.On("DeleteMembership", dto, mock.Anything).Return(nil)
+
+		assert.Nil(t, service.DeleteMembership(dto))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque l'employé n'est pas assigné", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadMembership", dto, mock.Anything).Return(nil, errors_domain_store.ErrMembershipNotFound)
+
+		assert.Equal(t, errors_domain_store.ErrMembershipNotFound, service.DeleteMembership(dto))
+		mockRepo.AssertNotCalled(t, "DeleteMembership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque non administrateur", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.DeleteMembership(dto))
+		mockRepo.AssertNotCalled(t, "DeleteMembership", mock.Anything, mock.Anything)
+	})
+}
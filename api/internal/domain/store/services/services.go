@@ -6,6 +6,7 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 )
 
 type StoreService struct {
@@ -18,11 +19,25 @@ func Store(security security.PermissionInterface, repo repositories.StoreReposit
 }
 
 type StoreServiceInterface interface {
-	ListStores() ([]*entities.Store, errors.ErrorInterface)
+	ListStores(*database.List) (*database.Page[*entities.Store], errors.ErrorInterface)
 	GetStoreByID(*transfert.Store) (*entities.Store, errors.ErrorInterface)
+	CreateStore(*transfert.Store) (*entities.Store, errors.ErrorInterface)
+	UpdateStore(*transfert.Store) (*entities.Store, errors.ErrorInterface)
+	DeleteStore(*transfert.Store) errors.ErrorInterface
+	UpdateStoreHours(*transfert.StoreHours) (*entities.Store, errors.ErrorInterface)
+	FindNearbyStores(*transfert.Nearby) ([]*entities.NearbyStore, errors.ErrorInterface)
 
 	GetCaisse(*transfert.Caisse) (*entities.Caisse, errors.ErrorInterface)
 	CreateCaisse(*transfert.Caisse) (*entities.Caisse, errors.ErrorInterface)
 	DeleteCaisse(*transfert.Caisse) errors.ErrorInterface
 	UpdateCaisse(*transfert.Caisse) (*entities.Caisse, errors.ErrorInterface)
+
+	GetMemberships(*transfert.Membership) ([]*entities.Membership, errors.ErrorInterface)
+	CreateMembership(*transfert.Membership) (*entities.Membership, errors.ErrorInterface)
+	DeleteMembership(*transfert.Membership) errors.ErrorInterface
+
+	CreatePairing(*transfert.Device) (*entities.Device, errors.ErrorInterface)
+	PairDevice(*transfert.Device) (*entities.Device, errors.ErrorInterface)
+	GetDevices(*transfert.Device) ([]*entities.Device, errors.ErrorInterface)
+	RevokeDevice(*transfert.Device) errors.ErrorInterface
 }
@@ -16,6 +16,8 @@
 package services_test
 
 import (
+	"time"
+
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
@@ -68,6 +70,24 @@ func (m *StoreRepositoryMock) ReadStores(obj *transfert.Store, options ...databa
 	return args.Get(0).([]*entities.Store), nil
 }
 
+// PaginateStores simulates reading a page of stores from the repository
+//
+// Parameters:
+// - obj: *transfert.Store, the conditions of the list
+// - list: *database.List, the requested page
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - *database.Page[*entities.Store]: the page of stores
+// - errors.ErrorInterface: an error if reading fails
+func (m *StoreRepositoryMock) PaginateStores(obj *transfert.Store, list *database.List, options ...database.Option) (*database.Page[*entities.Store], errors.ErrorInterface) {
+	args := m.Called(obj, list, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*database.Page[*entities.Store]), nil
+}
+
 // ReadStore simulates reading a store from the repository
 // Parameters:
 // - dto: *transfert.Store, the store dto to read
@@ -114,6 +134,61 @@ func (m *StoreRepositoryMock) UpdateStores(objs []*entities.Store, options ...da
 	return nil
 }
 
+// CreateStore simulates creating a store in the repository
+// Parameters:
+// - obj: *transfert.Store, the store dto to create
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - *entities.Store: the created store entity
+// - errors.ErrorInterface: an error if creation fails
+func (m *StoreRepositoryMock) CreateStore(obj *transfert.Store, options ...database.Option) (*entities.Store, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Store), nil
+}
+
+// ReplaceStoreHours simulates replacing the hours of a store in the repository
+func (m *StoreRepositoryMock) ReplaceStoreHours(obj *entities.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// UpdateStore simulates updating a store in the repository
+// Parameters:
+// - obj: *entities.Store, the store entity to update
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - errors.ErrorInterface: an error if update fails
+func (m *StoreRepositoryMock) UpdateStore(obj *entities.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// DeleteStore simulates deleting a store from the repository
+// Parameters:
+// - obj: *transfert.Store, the store dto to delete
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - errors.ErrorInterface: an error if deletion fails
+func (m *StoreRepositoryMock) DeleteStore(obj *transfert.Store, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
 // CreateCaisse simulates creating a caisse in the repository
 // Parameters:
 // - obj: *transfert.Caisse, the caisse dto to create
@@ -192,6 +267,180 @@ func (m *StoreRepositoryMock) UpdateCaisse(obj *entities.Caisse, options ...data
 	return nil
 }
 
+// CreateMembership simulates assigning an employee to a store in the repository
+// Parameters:
+// - obj: *transfert.Membership, the store and the credential of the employee
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - *entities.Membership: the created membership
+// - errors.ErrorInterface: an error if creation fails
+func (m *StoreRepositoryMock) CreateMembership(obj *transfert.Membership, options ...database.Option) (*entities.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Membership), nil
+}
+
+// ReadMembership simulates reading the membership of an employee to a store in the repository
+// Parameters:
+// - obj: *transfert.Membership, the store and the credential of the employee
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - *entities.Membership: the membership
+// - errors.ErrorInterface: an error if the employee is not assigned to the store
+func (m *StoreRepositoryMock) ReadMembership(obj *transfert.Membership, options ...database.Option) (*entities.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Membership), nil
+}
+
+// ReadMemberships simulates listing the memberships in the repository
+// Parameters:
+// - obj: *transfert.Membership, the store or the credential to filter on
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - []*entities.Membership: the memberships
+// - errors.ErrorInterface: an error if the read fails
+func (m *StoreRepositoryMock) ReadMemberships(obj *transfert.Membership, options ...database.Option) ([]*entities.Membership, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Membership), nil
+}
+
+// DeleteMembership simulates removing an employee from a store in the repository
+// Parameters:
+// - obj: *transfert.Membership, the store and the credential of the employee
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - errors.ErrorInterface: an error if deletion fails
+func (m *StoreRepositoryMock) DeleteMembership(obj *transfert.Membership, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreateDevice simulates registering a caisse device in the repository
+// Parameters:
+// - obj: *transfert.Device, the caisse, the store and the label of the device
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - *entities.Device: the created device
+// - errors.ErrorInterface: an error if creation fails
+func (m *StoreRepositoryMock) CreateDevice(obj *transfert.Device, options ...database.Option) (*entities.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Device), nil
+}
+
+// ReadDevice simulates reading a caisse device from the repository
+// Parameters:
+// - obj: *transfert.Device, the ID, the pairing code or the caisse of the device
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - *entities.Device: the device
+// - errors.ErrorInterface: an error if no device matches
+func (m *StoreRepositoryMock) ReadDevice(obj *transfert.Device, options ...database.Option) (*entities.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Device), nil
+}
+
+// ReadDevices simulates listing the caisse devices in the repository
+// Parameters:
+// - obj: *transfert.Device, the store or the caisse to filter on
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - []*entities.Device: the devices
+// - errors.ErrorInterface: an error if the read fails
+func (m *StoreRepositoryMock) ReadDevices(obj *transfert.Device, options ...database.Option) ([]*entities.Device, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Device), nil
+}
+
+// UpdateDevice simulates saving a caisse device in the repository
+// Parameters:
+// - obj: *entities.Device, the device to save
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - errors.ErrorInterface: an error if the update fails
+func (m *StoreRepositoryMock) UpdateDevice(obj *entities.Device, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// PairDevice simulates consuming the pairing code of a caisse device in the repository
+// Parameters:
+// - obj: *entities.Device, the device to pair
+// - now: time.Time, the time of the pairing
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - errors.ErrorInterface: an error if the code is no longer usable
+func (m *StoreRepositoryMock) PairDevice(obj *entities.Device, now time.Time, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, now, options)
+	if args.Get(0) != nil {
+		return args.Error(0).(errors.ErrorInterface)
+	}
+	return nil
+}
+
+// CreatePairingAttempt simulates recording a failed pairing in the repository
+// Parameters:
+// - obj: *transfert.PairingAttempt, the IP and the code of the attempt
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - *entities.PairingAttempt: the recorded attempt
+// - errors.ErrorInterface: an error if the creation fails
+func (m *StoreRepositoryMock) CreatePairingAttempt(obj *transfert.PairingAttempt, options ...database.Option) (*entities.PairingAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.PairingAttempt), nil
+}
+
+// ReadPairingAttempts simulates reading the failed pairings of an IP in the repository
+// Parameters:
+// - obj: *transfert.PairingAttempt, the IP of the attempts
+// - options: ...database.Option, additional database options
+//
+// Returns:
+// - []*entities.PairingAttempt: the attempts
+// - errors.ErrorInterface: an error if the read fails
+func (m *StoreRepositoryMock) ReadPairingAttempts(obj *transfert.PairingAttempt, options ...database.Option) ([]*entities.PairingAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.PairingAttempt), nil
+}
+
 // PermissionMock is the mock for PermissionInterface
 //
 // Parameters:
@@ -288,6 +537,29 @@ func (m *PermissionMock) GetCredentialID() *string {
 	return args.Get(0).(*string)
 }
 
+func (m *PermissionMock) GetRole() security.Role {
+	args := m.Called()
+	return args.Get(0).(security.Role)
+}
+
+func (m *PermissionMock) GetIP() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
+func (m *PermissionMock) GetUserAgent() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
 // setup function initializes a StoreService with mocked repository and permissions
 // Parameters:
 // - None
@@ -1,20 +1,44 @@
 package services
 
 import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
 	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 )
 
-func (s *StoreService) ListStores() ([]*entities.Store, errors.ErrorInterface) {
-	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+func (s *StoreService) ListStores(list *database.List) (*database.Page[*entities.Store], errors.ErrorInterface) {
+	if list == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return nil, errors.ErrUnauthorized
 	}
 
-	stores, err := s.repo.ReadStores(&transfert.Store{})
+	options := []database.Option{}
+
+	// Employees only see the stores they are assigned to
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		memberships, err := s.repo.ReadMemberships(&transfert.Membership{CredentialID: s.security.GetCredentialID()})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, len(memberships))
+		for i, membership := range memberships {
+			ids[i] = *membership.StoreID
+		}
+
+		options = append(options, database.Where("id IN ?", ids))
+	}
+
+	stores, err := s.repo.PaginateStores(&transfert.Store{}, list, options...)
 	if err != nil {
-		return nil, errors.ErrNoData
+		return nil, err
 	}
 
 	return stores, nil
@@ -25,14 +49,131 @@ func (s *StoreService) GetStoreByID(dto *transfert.Store) (*entities.Store, erro
 		return nil, errors.ErrNoDto
 	}
 
-	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	store, err := s.repo.ReadStore(dto, database.Preload("Openings"), database.Preload("Exceptions"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.isMember(&store.ID); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *StoreService) CreateStore(dto *transfert.Store) (*entities.Store, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if err := s.isLabelAvailable(dto.Label, nil); err != nil {
+		return nil, err
+	}
+
+	store, err := s.repo.CreateStore(&transfert.Store{
+		Label:     dto.Label,
+		IsOnline:  dto.IsOnline,
+		Address:   dto.Address,
+		Latitude:  dto.Latitude,
+		Longitude: dto.Longitude,
+		Timezone:  dto.Timezone,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *StoreService) UpdateStore(dto *transfert.Store) (*entities.Store, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
 		return nil, errors.ErrUnauthorized
 	}
 
-	store, err := s.repo.ReadStore(dto)
+	store, err := s.repo.ReadStore(&transfert.Store{ID: dto.ID})
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.isLabelAvailable(dto.Label, &store.ID); err != nil {
+		return nil, err
+	}
+
+	if dto.Label != nil {
+		store.Label = dto.Label
+	}
+
+	if dto.IsOnline != nil {
+		store.IsOnline = dto.IsOnline
+	}
+
+	if dto.Address != nil {
+		store.Address = dto.Address
+	}
+
+	if dto.Latitude != nil && dto.Longitude != nil {
+		store.Latitude, store.Longitude = dto.Latitude, dto.Longitude
+	}
+
+	if dto.Timezone != nil {
+		store.Timezone = dto.Timezone
+	}
+
+	if err := s.repo.UpdateStore(store); err != nil {
+		return nil, err
+	}
+
 	return store, nil
 }
+
+func (s *StoreService) DeleteStore(dto *transfert.Store) errors.ErrorInterface {
+	if dto == nil {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadStore(&transfert.Store{ID: dto.ID}); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteStore(dto); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// isLabelAvailable checks that no store other than storeID already uses the label
+func (s *StoreService) isLabelAvailable(label, storeID *string) errors.ErrorInterface {
+	if label == nil {
+		return nil
+	}
+
+	existing, err := s.repo.ReadStore(&transfert.Store{Label: label})
+	switch err {
+	case nil:
+		if storeID == nil || existing.ID != *storeID {
+			return errors_domain_store.ErrStoreAlreadyExists
+		}
+	case errors_domain_store.ErrStoreNotFound:
+	default:
+		return err
+	}
+
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	errors_domain_store "github.com/kodmain/thetiptop/api/internal/domain/store/errors"
 	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -22,18 +24,42 @@ func Test_ListStores(t *testing.T) {
 	t.Run("Devrait retourner les stores lorsque autorisé et disponible", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		stores := []*entities.Store{
+		stores := &database.Page[*entities.Store]{Items: []*entities.Store{
 			{ID: "store-1"},
 			{ID: "store-2"},
-		}
+		}, Total: 2}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
-		mockRepo.On("ReadStores", &transfert.Store{}, mock.Anything).Return(stores, nil)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("PaginateStores", &transfert.Store{}, mock.Anything, mock.Anything).Return(stores, nil)
 
-		result, err := service.ListStores()
+		result, err := service.ListStores(database.NewList(nil))
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
-		assert.Len(t, result, 2)
+		assert.Len(t, result.Items, 2)
+
+		mockRepo.AssertExpectations(t)
+		mockPerms.AssertExpectations(t)
+	})
+
+	t.Run("Devrait limiter un employé aux stores auxquels il est assigné", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		idEmployee := "employee-123"
+		idStore := "store-1"
+		stores := &database.Page[*entities.Store]{Items: []*entities.Store{{ID: idStore}}, Total: 1}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+		mockPerms.On("GetCredentialID").Return(&idEmployee)
+		mockRepo.On("ReadMemberships", &transfert.Membership{CredentialID: &idEmployee}, mock.Anything).Return([]*entities.Membership{
+			{StoreID: &idStore, CredentialID: &idEmployee},
+		}, nil)
+		mockRepo.On("PaginateStores", &transfert.Store{}, mock.Anything, mock.Anything).Return(stores, nil)
+
+		result, err := service.ListStores(database.NewList(nil))
+		assert.Nil(t, err)
+		assert.Len(t, result.Items, 1)
 
 		mockRepo.AssertExpectations(t)
 		mockPerms.AssertExpectations(t)
@@ -42,9 +68,9 @@ func Test_ListStores(t *testing.T) {
 	t.Run("Devrait retourner une erreur lorsque non autorisé", func(t *testing.T) {
 		service, _, mockPerms := setup()
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
 
-		result, err := service.ListStores()
+		result, err := service.ListStores(database.NewList(nil))
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, errors.ErrUnauthorized, err)
@@ -55,10 +81,11 @@ func Test_ListStores(t *testing.T) {
 	t.Run("Devrait retourner une erreur lorsque le repo retourne une erreur", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
-		mockRepo.On("ReadStores", &transfert.Store{}, mock.Anything).Return(nil, errors.ErrNoData)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("PaginateStores", &transfert.Store{}, mock.Anything, mock.Anything).Return(nil, errors.ErrNoData)
 
-		result, err := service.ListStores()
+		result, err := service.ListStores(database.NewList(nil))
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, errors.ErrNoData, err)
@@ -82,7 +109,8 @@ func Test_GetStoreByID(t *testing.T) {
 		dto := &transfert.Store{ID: &idStore}
 		store := &entities.Store{ID: "store-123"}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(true)
 		mockRepo.On("ReadStore", dto, mock.Anything).Return(store, nil)
 
 		result, err := service.GetStoreByID(dto)
@@ -100,7 +128,7 @@ func Test_GetStoreByID(t *testing.T) {
 		idStore := "store-123"
 		dto := &transfert.Store{ID: &idStore}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
 
 		result, err := service.GetStoreByID(dto)
 		assert.Nil(t, result)
@@ -110,6 +138,23 @@ func Test_GetStoreByID(t *testing.T) {
 		mockPerms.AssertExpectations(t)
 	})
 
+	t.Run("Devrait retourner une erreur lorsque l'employé n'est pas assigné au store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		idStore := "store-123"
+		dto := &transfert.Store{ID: &idStore}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
+		mockPerms.On("IsGrantedByRules", mock.Anything).Return(false)
+		mockRepo.On("ReadStore", dto, mock.Anything).Return(&entities.Store{ID: idStore}, nil)
+
+		result, err := service.GetStoreByID(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockPerms.AssertExpectations(t)
+	})
+
 	t.Run("Devrait retourner une erreur lorsque dto est nil", func(t *testing.T) {
 		service, _, _ := setup()
 
@@ -125,7 +170,7 @@ func Test_GetStoreByID(t *testing.T) {
 		idStore := "store-123"
 		dto := &transfert.Store{ID: &idStore}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		mockRepo.On("ReadStore", dto, mock.Anything).Return(nil, errors.ErrNoData)
 
 		result, err := service.GetStoreByID(dto)
@@ -137,3 +182,158 @@ func Test_GetStoreByID(t *testing.T) {
 		mockPerms.AssertExpectations(t)
 	})
 }
+
+// Test_CreateStore tests the CreateStore method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_CreateStore(t *testing.T) {
+	label := "Nice"
+	online := false
+
+	t.Run("Devrait créer un store lorsque le libellé est libre", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Store{Label: &label, IsOnline: &online}
+		store := &entities.Store{ID: "store-123", Label: &label, IsOnline: &online}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{Label: &label}, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+		mockRepo.On("CreateStore", dto, mock.Anything).Return(store, nil)
+
+		result, err := service.CreateStore(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, store, result)
+
+		mockRepo.AssertExpectations(t)
+		mockPerms.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser un libellé déjà utilisé", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{Label: &label}, mock.Anything).Return(&entities.Store{ID: "store-456"}, nil)
+
+		result, err := service.CreateStore(&transfert.Store{Label: &label, IsOnline: &online})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrStoreAlreadyExists, err)
+		mockRepo.AssertNotCalled(t, "CreateStore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque non administrateur", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		result, err := service.CreateStore(&transfert.Store{Label: &label, IsOnline: &online})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "CreateStore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque dto est nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		result, err := service.CreateStore(nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+// Test_UpdateStore tests the UpdateStore method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_UpdateStore(t *testing.T) {
+	idStore := "store-123"
+	label := "Nice"
+
+	t.Run("Devrait mettre à jour le store en gardant son libellé", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		online := true
+		dto := &transfert.Store{ID: &idStore, Label: &label, IsOnline: &online}
+		store := &entities.Store{ID: idStore, Label: &label}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{ID: &idStore}, mock.Anything).Return(store, nil)
+		mockRepo.On("ReadStore", &transfert.Store{Label: &label}, mock.Anything).Return(store, nil)
+		mockRepo.On("UpdateStore", store, mock.Anything).Return(nil)
+
+		result, err := service.UpdateStore(dto)
+		assert.Nil(t, err)
+		assert.True(t, *result.IsOnline)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait refuser le libellé d'un autre store", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{ID: &idStore}, mock.Anything).Return(&entities.Store{ID: idStore}, nil)
+		mockRepo.On("ReadStore", &transfert.Store{Label: &label}, mock.Anything).Return(&entities.Store{ID: "store-456"}, nil)
+
+		result, err := service.UpdateStore(&transfert.Store{ID: &idStore, Label: &label})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrStoreAlreadyExists, err)
+		mockRepo.AssertNotCalled(t, "UpdateStore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque le store n'existe pas", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", &transfert.Store{ID: &idStore}, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		result, err := service.UpdateStore(&transfert.Store{ID: &idStore})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, err)
+	})
+}
+
+// Test_DeleteStore tests the DeleteStore method of StoreService
+// Parameters:
+// - t: *testing.T
+//
+// Returns:
+// - None: no return value
+func Test_DeleteStore(t *testing.T) {
+	idStore := "store-123"
+	dto := &transfert.Store{ID: &idStore}
+
+	t.Run("Devrait supprimer le store lorsque administrateur", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", dto, mock.Anything).Return(&entities.Store{ID: idStore}, nil)
+		mockRepo.On("DeleteStore", dto, mock.Anything).Return(nil)
+
+		assert.Nil(t, service.DeleteStore(dto))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque non administrateur", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.DeleteStore(dto))
+		mockRepo.AssertNotCalled(t, "DeleteStore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Devrait retourner une erreur lorsque le store n'existe pas", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadStore", dto, mock.Anything).Return(nil, errors_domain_store.ErrStoreNotFound)
+
+		assert.Equal(t, errors_domain_store.ErrStoreNotFound, service.DeleteStore(dto))
+		mockRepo.AssertNotCalled(t, "DeleteStore", mock.Anything, mock.Anything)
+	})
+}
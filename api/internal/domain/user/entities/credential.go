@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/uuid"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/hash"
 	"gorm.io/gorm"
@@ -18,6 +19,44 @@ type Credential struct {
 
 	Email    *string `gorm:"type:varchar(320);uniqueIndex" json:"email"`
 	Password *string `gorm:"type:varchar(255)" json:"-"` // private field
+
+	// Additional fields
+	Role       security.Role `gorm:"type:varchar(16);index" json:"role"`
+	DisabledAt *time.Time    `gorm:"index" json:"disabled_at,omitempty"`
+}
+
+// IsDisabled reports whether an admin disabled the credential, a disabled credential cannot sign in
+func (cred *Credential) IsDisabled() bool {
+	return cred.DisabledAt != nil
+}
+
+// Disable prevents the credential from signing in and renewing its tokens
+//
+// Returns:
+// - bool: false if the credential was already disabled
+func (cred *Credential) Disable() bool {
+	if cred.IsDisabled() {
+		return false
+	}
+
+	now := time.Now()
+	cred.DisabledAt = &now
+
+	return true
+}
+
+// Enable allows a disabled credential to sign in again
+//
+// Returns:
+// - bool: false if the credential was not disabled
+func (cred *Credential) Enable() bool {
+	if !cred.IsDisabled() {
+		return false
+	}
+
+	cred.DisabledAt = nil
+
+	return true
 }
 
 func (cred *Credential) CompareHash(password string) bool {
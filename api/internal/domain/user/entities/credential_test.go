@@ -67,3 +67,17 @@ func TestCredentialBefore(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, credential.UpdatedAt.After(old))
 }
+
+func TestCredentialDisable(t *testing.T) {
+	credential := &entities.Credential{}
+	assert.False(t, credential.IsDisabled())
+	assert.False(t, credential.Enable())
+
+	assert.True(t, credential.Disable())
+	assert.True(t, credential.IsDisabled())
+	assert.False(t, credential.Disable())
+
+	assert.True(t, credential.Enable())
+	assert.False(t, credential.IsDisabled())
+	assert.Nil(t, credential.DisabledAt)
+}
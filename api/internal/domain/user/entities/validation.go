@@ -40,7 +40,12 @@ func (v *Validation) HasExpired() bool {
 // BeforeSave attribue la valeur de ClientID avant de sauvegarder
 func (v *Validation) BeforeSave(tx *gorm.DB) error {
 	if v.Token == nil {
-		v.Token = token.Generate(6).Pointer()
+		code, err := token.NewGenerator(6, token.Numeric).Generate()
+		if err != nil {
+			return err
+		}
+
+		v.Token = code.Pointer()
 	}
 
 	return nil
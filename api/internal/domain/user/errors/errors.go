@@ -24,9 +24,14 @@ var (
 	ErrEmployeeAlreadyValidated = errors.New(http.StatusConflict, "employee.already_validated")
 
 	// Credential errors
-	ErrCredentialNotFound      = errors.New(http.StatusNotFound, "credential.not_found")
-	ErrCredentialNotValid      = errors.New(http.StatusBadRequest, "credential.not_valid")
-	ErrCredentialAlreadyExists = errors.New(http.StatusConflict, "credential.already_exists")
+	ErrCredentialNotFound        = errors.New(http.StatusNotFound, "credential.not_found")
+	ErrCredentialNotValid        = errors.New(http.StatusBadRequest, "credential.not_valid")
+	ErrCredentialAlreadyExists   = errors.New(http.StatusConflict, "credential.already_exists")
+	ErrCredentialDisabled        = errors.New(http.StatusForbidden, "credential.disabled")
+	ErrCredentialAlreadyDisabled = errors.New(http.StatusConflict, "credential.already_disabled")
+	ErrCredentialNotDisabled     = errors.New(http.StatusConflict, "credential.not_disabled")
+	ErrCredentialRoleLimit       = errors.New(http.StatusConflict, "credential.role_limit")
+	ErrCredentialSelf            = errors.New(http.StatusConflict, "credential.self")
 
 	// Validation errors
 	ErrValidationNotFound         = errors.New(http.StatusNotFound, "validation.not_found")
@@ -11,6 +11,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// ClientCredentialBatchSize is the number of credential IDs filtered by a single query
+const ClientCredentialBatchSize = 1000
+
 type UserRepository struct {
 	store *database.Database
 }
@@ -22,6 +25,7 @@ type UserRepositoryInterface interface {
 	// client
 	CreateClient(obj *transfert.Client, options ...database.Option) (*entities.Client, errors.ErrorInterface)
 	ReadClient(obj *transfert.Client, options ...database.Option) (*entities.Client, errors.ErrorInterface)
+	ReadClientCredentialIDs(ids []string, options ...database.Option) ([]string, errors.ErrorInterface)
 	UpdateClient(entity *entities.Client, options ...database.Option) errors.ErrorInterface
 	DeleteClient(obj *transfert.Client, options ...database.Option) errors.ErrorInterface
 
@@ -41,6 +45,7 @@ type UserRepositoryInterface interface {
 	// Credential
 	CreateCredential(obj *transfert.Credential, options ...database.Option) (*entities.Credential, errors.ErrorInterface)
 	ReadCredential(obj *transfert.Credential, options ...database.Option) (*entities.Credential, errors.ErrorInterface)
+	PaginateCredentials(obj *transfert.Credential, list *database.List, options ...database.Option) (*database.Page[*entities.Credential], errors.ErrorInterface)
 	UpdateCredential(entity *entities.Credential, options ...database.Option) errors.ErrorInterface
 	DeleteCredential(obj *transfert.Credential, options ...database.Option) errors.ErrorInterface
 }
@@ -119,6 +124,26 @@ func (r *UserRepository) ReadCredential(obj *transfert.Credential, options ...da
 	return credential, nil
 }
 
+// PaginateCredentials reads a page of credentials
+// The options scope the credentials before they are counted, so that the page reflects them.
+//
+// Parameters:
+// - obj: *transfert.Credential the fields to filter on
+// - list: *database.List the page, the sort and the filters of the request
+// - options: ...database.Option the options of the query
+//
+// Returns:
+// - *database.Page[*entities.Credential]: the page of credentials
+// - errors.ErrorInterface: an error if the credentials cannot be read
+func (r *UserRepository) PaginateCredentials(obj *transfert.Credential, list *database.List, options ...database.Option) (*database.Page[*entities.Credential], errors.ErrorInterface) {
+	query := r.store.Engine.Model(&entities.Credential{}).Where(obj)
+	for _, option := range options {
+		query = option(query)
+	}
+
+	return database.Paginate[*entities.Credential](query, list)
+}
+
 func (r *UserRepository) UpdateCredential(entity *entities.Credential, options ...database.Option) errors.ErrorInterface {
 	query := r.store.Engine.Save(entity)
 	r.applyOptions(query, options...)
@@ -168,6 +193,35 @@ func (r *UserRepository) ReadClient(obj *transfert.Client, options ...database.O
 	return client, nil
 }
 
+// ReadClientCredentialIDs keeps the credentials that belong to a client
+// The IDs are read by batches so that a large list never exceeds the parameters of a query.
+//
+// Parameters:
+// - ids: []string the credential IDs to filter
+// - options: ...database.Option additional options to customize the query
+//
+// Returns:
+// - []string: the credential IDs of the clients
+// - errors.ErrorInterface: an error if the clients cannot be read
+func (r *UserRepository) ReadClientCredentialIDs(ids []string, options ...database.Option) ([]string, errors.ErrorInterface) {
+	clients := make([]string, 0, len(ids))
+
+	for start := 0; start < len(ids); start += ClientCredentialBatchSize {
+		end := min(start+ClientCredentialBatchSize, len(ids))
+
+		var batch []string
+		query := r.store.Engine.Model(&entities.Client{}).Where("credential_id IN ?", ids[start:end])
+		r.applyOptions(query, options...)
+		if err := query.Pluck("credential_id", &batch).Error; err != nil {
+			return nil, errors.ErrInternalServer.Log(err)
+		}
+
+		clients = append(clients, batch...)
+	}
+
+	return clients, nil
+}
+
 func (r *UserRepository) UpdateClient(entity *entities.Client, options ...database.Option) errors.ErrorInterface {
 	query := r.store.Engine.Save(entity)
 	r.applyOptions(query, options...)
@@ -75,7 +75,7 @@ func TestCreateCredential(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Correction de l'instruction SQL pour supprimer la colonne client_id qui n'existe pas dans la requête réelle
-		mock.ExpectExec(`INSERT INTO "credentials" \("id","created_at","updated_at","deleted_at","email","password"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6\)`).
+		mock.ExpectExec(`INSERT INTO "credentials" \("id","created_at","updated_at","deleted_at","email","password","role","disabled_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8\)`).
 			WithArgs(
 				sqlmock.AnyArg(),
 				sqlmock.AnyArg(),
@@ -83,6 +83,8 @@ func TestCreateCredential(t *testing.T) {
 				nil,
 				dto.Email,
 				sqlmock.AnyArg(), // Password (hashed)
+				"",               // Role
+				nil,              // DisabledAt
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		mock.ExpectCommit()
@@ -99,7 +101,7 @@ func TestCreateCredential(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Correction de l'instruction SQL pour supprimer la colonne client_id
-		mock.ExpectExec(`INSERT INTO "credentials" \("id","created_at","updated_at","deleted_at","email","password"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6\)`).
+		mock.ExpectExec(`INSERT INTO "credentials" \("id","created_at","updated_at","deleted_at","email","password","role","disabled_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8\)`).
 			WithArgs(
 				sqlmock.AnyArg(),
 				sqlmock.AnyArg(),
@@ -107,6 +109,8 @@ func TestCreateCredential(t *testing.T) {
 				nil,
 				dto.Email,
 				sqlmock.AnyArg(),
+				"",
+				nil,
 			).WillReturnError(fmt.Errorf("UNIQUE constraint failed: credentials.email"))
 
 		mock.ExpectRollback()
@@ -124,7 +128,7 @@ func TestCreateCredential(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Correction de l'instruction SQL pour supprimer la colonne client_id
-		mock.ExpectExec(`INSERT INTO "credentials" \("id","created_at","updated_at","deleted_at","email","password"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6\)`).
+		mock.ExpectExec(`INSERT INTO "credentials" \("id","created_at","updated_at","deleted_at","email","password","role","disabled_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8\)`).
 			WithArgs(
 				sqlmock.AnyArg(),
 				sqlmock.AnyArg(),
@@ -132,6 +136,8 @@ func TestCreateCredential(t *testing.T) {
 				nil,
 				dto.Email,
 				sqlmock.AnyArg(),
+				"",
+				nil,
 			).WillReturnError(fmt.Errorf("random-error"))
 
 		mock.ExpectRollback()
@@ -232,13 +238,15 @@ func TestUpdateCredential(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Correction de l'instruction SQL : suppression de la colonne `client_id`
-		mock.ExpectExec(`UPDATE "credentials" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"email"=\$4,"password"=\$5 WHERE "credentials"\."deleted_at" IS NULL AND "id" = \$6`).
+		mock.ExpectExec(`UPDATE "credentials" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"email"=\$4,"password"=\$5,"role"=\$6,"disabled_at"=\$7 WHERE "credentials"\."deleted_at" IS NULL AND "id" = \$8`).
 			WithArgs(
 				sqlmock.AnyArg(), // created_at (générée automatiquement)
 				sqlmock.AnyArg(), // updated_at (générée automatiquement)
 				nil,              // deleted_at (NULL)
 				entity.Email,     // mise à jour de l'email
 				entity.Password,  // mise à jour du mot de passe
+				"",               // rôle inchangé
+				nil,              // disabled_at (NULL)
 				entity.ID,        // ID du credential
 			).WillReturnResult(sqlmock.NewResult(1, 1)) // Résultat de succès (1 ligne affectée)
 
@@ -254,13 +262,15 @@ func TestUpdateCredential(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Correction de l'instruction SQL : suppression de la colonne `client_id`
-		mock.ExpectExec(`UPDATE "credentials" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"email"=\$4,"password"=\$5 WHERE "credentials"\."deleted_at" IS NULL AND "id" = \$6`).
+		mock.ExpectExec(`UPDATE "credentials" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"email"=\$4,"password"=\$5,"role"=\$6,"disabled_at"=\$7 WHERE "credentials"\."deleted_at" IS NULL AND "id" = \$8`).
 			WithArgs(
 				sqlmock.AnyArg(), // created_at
 				sqlmock.AnyArg(), // updated_at
 				nil,              // deleted_at
 				entity.Email,     // mise à jour de l'email
 				entity.Password,  // mise à jour du mot de passe
+				"",               // rôle inchangé
+				nil,              // disabled_at (NULL)
 				entity.ID,        // ID du credential
 			).WillReturnError(fmt.Errorf("some update error"))
 
@@ -330,7 +340,7 @@ func TestCreateValidation(t *testing.T) {
 	repo, mock, db := setup()
 	defer db.Close()
 
-	luhn := token.Generate(6)
+	luhn, _ := token.Generate(6)
 	dto := &transfert.Validation{
 		Token:    luhn.PointerString(),
 		ClientID: aws.String("client-uuid"),
@@ -400,7 +410,7 @@ func TestReadValidation(t *testing.T) {
 	defer db.Close()
 
 	// Génération d'un token de validation
-	luhn := token.Generate(6)
+	luhn, _ := token.Generate(6)
 	dto := &transfert.Validation{
 		Token:    luhn.PointerString(),
 		ClientID: aws.String("client-uuid"),
@@ -481,7 +491,7 @@ func TestUpdateValidation(t *testing.T) {
 	defer db.Close()
 
 	// Génération d'un token et d'une entité de validation
-	luhn := token.Generate(6)
+	luhn, _ := token.Generate(6)
 	entity := &entities.Validation{
 		ID:        "some-id",
 		Token:     &luhn,
@@ -562,7 +572,7 @@ func TestDeleteValidationRepository(t *testing.T) {
 	repo := repositories.NewUserRepository(dbInstance)
 
 	// Génération d'un token et d'une entité Validation à supprimer
-	luhn := token.Generate(6)
+	luhn, _ := token.Generate(6)
 	dto := &transfert.Validation{
 		Token:    luhn.PointerString(),
 		ClientID: aws.String("client-id"),
@@ -1265,3 +1275,49 @@ func TestReadUser(t *testing.T) {
 		assert.EqualError(t, err, "user.not_found")
 	})
 }
+
+// TestReadClientCredentialIDs teste le filtrage des credentials des clients
+func TestReadClientCredentialIDs(t *testing.T) {
+	repo, mock, db := setup()
+	defer db.Close()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "credential_id" FROM "clients" WHERE credential_id IN \(\$1,\$2\) AND "clients"\."deleted_at" IS NULL`).
+			WithArgs("client-id", "employee-id").
+			WillReturnRows(sqlmock.NewRows([]string{"credential_id"}).AddRow("client-id"))
+
+		ids, err := repo.ReadClientCredentialIDs([]string{"client-id", "employee-id"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"client-id"}, ids)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read by batches", func(t *testing.T) {
+		ids := make([]string, repositories.ClientCredentialBatchSize+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("credential-%d", i)
+		}
+
+		mock.ExpectQuery(`SELECT "credential_id" FROM "clients"`).WillReturnRows(sqlmock.NewRows([]string{"credential_id"}).AddRow("credential-0"))
+		mock.ExpectQuery(`SELECT "credential_id" FROM "clients"`).
+			WithArgs(ids[repositories.ClientCredentialBatchSize]).
+			WillReturnRows(sqlmock.NewRows([]string{"credential_id"}).AddRow(ids[repositories.ClientCredentialBatchSize]))
+
+		clients, err := repo.ReadClientCredentialIDs(ids)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"credential-0", ids[repositories.ClientCredentialBatchSize]}, clients)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "credential_id" FROM "clients"`).WillReturnError(fmt.Errorf("some client error"))
+
+		ids, err := repo.ReadClientCredentialIDs([]string{"client-id"})
+		assert.Nil(t, ids)
+		assert.EqualError(t, err, "common.internal_error")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// Roles lists the roles of the users from the lowest to the highest, a promotion or a demotion moves one step
+var Roles = []security.Role{entities.ROLE_CLIENT, entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}
+
+// ListUsers lists a page of the credentials of the clients, the employees and the admins
+// Only admins manage the users.
+//
+// Parameters:
+// - list: *database.List the page, the sort and the filters requested
+//
+// Returns:
+// - *database.Page[*entities.Credential]: the page of credentials with their role
+// - errors.ErrorInterface: an error if the credentials cannot be read
+func (s *UserService) ListUsers(list *database.List) (*database.Page[*entities.Credential], errors.ErrorInterface) {
+	if list == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	page, err := s.repo.PaginateCredentials(&transfert.Credential{}, list)
+	if err != nil {
+		return nil, err
+	}
+
+	// The credentials created before the roles were stored only get theirs from their user
+	for _, credential := range page.Items {
+		if credential.Role == "" {
+			credential.Role, _ = s.roleOf(credential)
+		}
+	}
+
+	return page, nil
+}
+
+// PromoteUser moves a user one role up: a client becomes an employee, an employee becomes an admin
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - *entities.Credential: the credential with its new role
+// - errors.ErrorInterface: ErrCredentialRoleLimit if the user is already an admin
+func (s *UserService) PromoteUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	return s.moveUser(dtoCredential, 1)
+}
+
+// DemoteUser moves a user one role down: an admin becomes an employee, an employee becomes a client
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - *entities.Credential: the credential with its new role
+// - errors.ErrorInterface: ErrCredentialRoleLimit if the user is already a client
+func (s *UserService) DemoteUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	return s.moveUser(dtoCredential, -1)
+}
+
+// DisableUser prevents a user from signing in and renewing their tokens
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - *entities.Credential: the disabled credential
+// - errors.ErrorInterface: ErrCredentialAlreadyDisabled if the user is already disabled
+func (s *UserService) DisableUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	credential, err := s.administrable(dtoCredential)
+	if err != nil {
+		return nil, err
+	}
+
+	if !credential.Disable() {
+		return nil, errors_domain_user.ErrCredentialAlreadyDisabled
+	}
+
+	if err := s.repo.UpdateCredential(credential); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// EnableUser allows a disabled user to sign in again
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - *entities.Credential: the enabled credential
+// - errors.ErrorInterface: ErrCredentialNotDisabled if the user is not disabled
+func (s *UserService) EnableUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	credential, err := s.administrable(dtoCredential)
+	if err != nil {
+		return nil, err
+	}
+
+	if !credential.Enable() {
+		return nil, errors_domain_user.ErrCredentialNotDisabled
+	}
+
+	if err := s.repo.UpdateCredential(credential); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// CreateAdmin registers an admin, it bootstraps the first admin from the command line
+// An existing account is promoted instead, provided the password matches: a client becomes an employee on the way.
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential the email and the password of the admin
+//
+// Returns:
+// - *entities.Credential: the credential of the admin
+// - errors.ErrorInterface: ErrCredentialNotValid if the password of an existing account does not match
+func (s *UserService) CreateAdmin(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	if dtoCredential == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	credential, err := s.repo.ReadCredential(&transfert.Credential{
+		Email: dtoCredential.Email,
+	})
+
+	switch err {
+	case nil:
+		if !credential.CompareHash(*dtoCredential.Password) {
+			return nil, errors_domain_user.ErrCredentialNotValid
+		}
+
+		role, err := s.roleOf(credential)
+		if err != nil {
+			return nil, err
+		}
+
+		if role == entities.ROLE_CLIENT {
+			if err := s.convertUser(credential, role, entities.ROLE_EMPLOYEE); err != nil {
+				return nil, err
+			}
+		}
+
+		credential.Enable()
+	case errors_domain_user.ErrCredentialNotFound:
+		if credential, err = s.repo.CreateCredential(dtoCredential); err != nil {
+			return nil, err
+		}
+
+		if _, err := s.repo.CreateEmployee(&transfert.Employee{CredentialID: &credential.ID}); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	credential.Role = security.ROLE_ADMIN
+
+	if err := s.repo.UpdateCredential(credential); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// administrable reads the credential of a user an admin manages
+// An admin cannot manage their own credential, so that the last admin cannot lock everyone out.
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential the credential of the user
+//
+// Returns:
+// - *entities.Credential: the credential
+// - errors.ErrorInterface: an error if the current user is not an admin or the credential is unknown
+func (s *UserService) administrable(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface) {
+	if dtoCredential == nil || dtoCredential.ID == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if *dtoCredential.ID == aws.ToString(s.security.GetCredentialID()) {
+		return nil, errors_domain_user.ErrCredentialSelf
+	}
+
+	return s.repo.ReadCredential(&transfert.Credential{
+		ID: dtoCredential.ID,
+	})
+}
+
+// moveUser moves a user along the roles
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential the credential of the user
+// - step: int 1 to promote the user, -1 to demote them
+//
+// Returns:
+// - *entities.Credential: the credential with its new role
+// - errors.ErrorInterface: ErrCredentialRoleLimit if there is no role in that direction
+func (s *UserService) moveUser(dtoCredential *transfert.Credential, step int) (*entities.Credential, errors.ErrorInterface) {
+	credential, err := s.administrable(dtoCredential)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleOf(credential)
+	if err != nil {
+		return nil, err
+	}
+
+	current := slices.Index(Roles, role)
+	if current == -1 || current+step < 0 || current+step >= len(Roles) {
+		return nil, errors_domain_user.ErrCredentialRoleLimit
+	}
+
+	if err := s.convertUser(credential, role, Roles[current+step]); err != nil {
+		return nil, err
+	}
+
+	credential.Role = Roles[current+step]
+
+	if err := s.repo.UpdateCredential(credential); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// convertUser replaces the client of a credential by an employee, or the other way around
+// Admins are employees, moving between the employee and the admin roles keeps the employee.
+//
+// Parameters:
+// - credential: *entities.Credential the credential of the user
+// - from: security.Role the current role of the user
+// - to: security.Role the new role of the user
+//
+// Returns:
+// - errors.ErrorInterface: an error if the user cannot be converted
+func (s *UserService) convertUser(credential *entities.Credential, from, to security.Role) errors.ErrorInterface {
+	if from != entities.ROLE_CLIENT && to != entities.ROLE_CLIENT {
+		return nil
+	}
+
+	client, employee, err := s.repo.ReadUser(&transfert.User{
+		CredentialID: &credential.ID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if from == entities.ROLE_CLIENT {
+		if client == nil {
+			return errors_domain_user.ErrClientNotFound
+		}
+
+		if _, err := s.repo.CreateEmployee(&transfert.Employee{CredentialID: &credential.ID}); err != nil {
+			return err
+		}
+
+		return s.repo.DeleteClient(&transfert.Client{ID: &client.ID})
+	}
+
+	if employee == nil {
+		return errors_domain_user.ErrEmployeeNotFound
+	}
+
+	if _, err := s.repo.CreateClient(&transfert.Client{CredentialID: &credential.ID}); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteEmployee(&transfert.Employee{ID: &employee.ID})
+}
@@ -0,0 +1,499 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminID = "42debee6-2063-4566-baf1-37a7bdd13900"
+	userID  = "42debee6-2063-4566-baf1-37a7bdd13901"
+)
+
+var adminRoles = []security.Role{security.ROLE_ADMIN}
+
+func TestListUsers(t *testing.T) {
+	list := database.NewList(map[string]string{})
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		page, err := service.ListUsers(nil)
+		assert.Nil(t, page)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		service, _, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(false)
+
+		page, err := service.ListUsers(list)
+		assert.Nil(t, page)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockPerms.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockRepo.On("PaginateCredentials", &transfert.Credential{}, list).Return(nil, errors.ErrInternalServer)
+
+		page, err := service.ListUsers(list)
+		assert.Nil(t, page)
+		assert.Equal(t, errors.ErrInternalServer, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockRepo.On("PaginateCredentials", &transfert.Credential{}, list).Return(&database.Page[*entities.Credential]{
+			Items: []*entities.Credential{
+				{ID: adminID, Role: security.ROLE_ADMIN},
+				{ID: userID},
+			},
+			Total: 2,
+		}, nil)
+
+		// Le credential sans rôle enregistré le tient de son user
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: aws.String(userID)}).Return(nil, &entities.Employee{}, nil)
+
+		page, err := service.ListUsers(list)
+		require.NoError(t, err)
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, security.ROLE_ADMIN, page.Items[0].Role)
+		assert.Equal(t, entities.ROLE_EMPLOYEE, page.Items[1].Role)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPromoteUser(t *testing.T) {
+	input := &transfert.Credential{ID: aws.String(userID)}
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		credential, err := service.PromoteUser(nil)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrNoDto, err)
+
+		credential, err = service.PromoteUser(&transfert.Credential{})
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(false)
+
+		credential, err := service.PromoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(userID))
+
+		credential, err := service.PromoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialSelf, err)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+
+	t.Run("credential not found", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(nil, errors_domain_user.ErrCredentialNotFound)
+
+		credential, err := service.PromoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("client to employee", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+
+		clientID := "42debee6-2063-4566-baf1-37a7bdd13902"
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, Role: entities.ROLE_CLIENT}, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: aws.String(userID)}).Return(&entities.Client{ID: clientID}, nil, nil)
+		mockRepo.On("CreateEmployee", &transfert.Employee{CredentialID: aws.String(userID)}).Return(&entities.Employee{}, nil)
+		mockRepo.On("DeleteClient", &transfert.Client{ID: aws.String(clientID)}).Return(nil)
+		mockRepo.On("UpdateCredential", mock.MatchedBy(func(credential *entities.Credential) bool {
+			return credential.Role == entities.ROLE_EMPLOYEE
+		})).Return(nil)
+
+		credential, err := service.PromoteUser(input)
+		require.NoError(t, err)
+		assert.Equal(t, entities.ROLE_EMPLOYEE, credential.Role)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("client conversion error", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, Role: entities.ROLE_CLIENT}, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: aws.String(userID)}).Return(&entities.Client{}, nil, nil)
+		mockRepo.On("CreateEmployee", mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		credential, err := service.PromoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrInternalServer, err)
+		mockRepo.AssertNotCalled(t, "DeleteClient", mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateCredential", mock.Anything)
+	})
+
+	t.Run("employee to admin", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+
+		// Un credential sans rôle enregistré le tient de son user
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID}, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: aws.String(userID)}).Return(nil, &entities.Employee{}, nil)
+		mockRepo.On("UpdateCredential", mock.Anything).Return(nil)
+
+		credential, err := service.PromoteUser(input)
+		require.NoError(t, err)
+		assert.Equal(t, security.ROLE_ADMIN, credential.Role)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CreateEmployee", mock.Anything)
+	})
+
+	t.Run("already admin", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, Role: security.ROLE_ADMIN}, nil)
+
+		credential, err := service.PromoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialRoleLimit, err)
+		mockRepo.AssertNotCalled(t, "UpdateCredential", mock.Anything)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, Role: entities.ROLE_EMPLOYEE}, nil)
+		mockRepo.On("UpdateCredential", mock.Anything).Return(errors.ErrInternalServer)
+
+		credential, err := service.PromoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrInternalServer, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDemoteUser(t *testing.T) {
+	input := &transfert.Credential{ID: aws.String(userID)}
+
+	t.Run("admin to employee", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, Role: security.ROLE_ADMIN}, nil)
+		mockRepo.On("UpdateCredential", mock.Anything).Return(nil)
+
+		credential, err := service.DemoteUser(input)
+		require.NoError(t, err)
+		assert.Equal(t, entities.ROLE_EMPLOYEE, credential.Role)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ReadUser", mock.Anything)
+	})
+
+	t.Run("employee to client", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+
+		employeeID := "42debee6-2063-4566-baf1-37a7bdd13903"
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, Role: entities.ROLE_EMPLOYEE}, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: aws.String(userID)}).Return(nil, &entities.Employee{ID: employeeID}, nil)
+		mockRepo.On("CreateClient", &transfert.Client{CredentialID: aws.String(userID)}).Return(&entities.Client{}, nil)
+		mockRepo.On("DeleteEmployee", &transfert.Employee{ID: aws.String(employeeID)}).Return(nil)
+		mockRepo.On("UpdateCredential", mock.Anything).Return(nil)
+
+		credential, err := service.DemoteUser(input)
+		require.NoError(t, err)
+		assert.Equal(t, entities.ROLE_CLIENT, credential.Role)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("employee record missing", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, Role: entities.ROLE_EMPLOYEE}, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: aws.String(userID)}).Return(&entities.Client{}, nil, nil)
+
+		credential, err := service.DemoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrEmployeeNotFound, err)
+		mockRepo.AssertNotCalled(t, "CreateClient", mock.Anything)
+	})
+
+	t.Run("already client", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, Role: entities.ROLE_CLIENT}, nil)
+
+		credential, err := service.DemoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialRoleLimit, err)
+		mockRepo.AssertNotCalled(t, "UpdateCredential", mock.Anything)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(userID))
+
+		credential, err := service.DemoteUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialSelf, err)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+}
+
+func TestDisableUser(t *testing.T) {
+	input := &transfert.Credential{ID: aws.String(userID)}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		service, _, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(false)
+
+		credential, err := service.DisableUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID}, nil)
+		mockRepo.On("UpdateCredential", mock.MatchedBy(func(credential *entities.Credential) bool {
+			return credential.IsDisabled()
+		})).Return(nil)
+
+		credential, err := service.DisableUser(input)
+		require.NoError(t, err)
+		assert.True(t, credential.IsDisabled())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("already disabled", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+
+		disabledAt := time.Now()
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, DisabledAt: &disabledAt}, nil)
+
+		credential, err := service.DisableUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialAlreadyDisabled, err)
+		mockRepo.AssertNotCalled(t, "UpdateCredential", mock.Anything)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(userID))
+
+		credential, err := service.DisableUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialSelf, err)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID}, nil)
+		mockRepo.On("UpdateCredential", mock.Anything).Return(errors.ErrInternalServer)
+
+		credential, err := service.DisableUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
+
+func TestEnableUser(t *testing.T) {
+	input := &transfert.Credential{ID: aws.String(userID)}
+
+	t.Run("success", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+
+		disabledAt := time.Now()
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID, DisabledAt: &disabledAt}, nil)
+		mockRepo.On("UpdateCredential", mock.MatchedBy(func(credential *entities.Credential) bool {
+			return !credential.IsDisabled()
+		})).Return(nil)
+
+		credential, err := service.EnableUser(input)
+		require.NoError(t, err)
+		assert.False(t, credential.IsDisabled())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not disabled", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String(adminID))
+		mockRepo.On("ReadCredential", input).Return(&entities.Credential{ID: userID}, nil)
+
+		credential, err := service.EnableUser(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialNotDisabled, err)
+		mockRepo.AssertNotCalled(t, "UpdateCredential", mock.Anything)
+	})
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		credential, err := service.EnableUser(nil)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func TestCreateAdmin(t *testing.T) {
+	email := aws.String("admin@thetiptop.fr")
+	password := aws.String("Sup3rP@ssw0rd")
+	hashedPassword, err := hash.Hash(aws.String(*email+":"+*password), hash.BCRYPT)
+	require.NoError(t, err)
+
+	input := &transfert.Credential{Email: email, Password: password}
+	byEmail := &transfert.Credential{Email: email}
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		credential, err := service.CreateAdmin(nil)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(false)
+
+		credential, err := service.CreateAdmin(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+
+	t.Run("new account", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockRepo.On("ReadCredential", byEmail).Return(nil, errors_domain_user.ErrCredentialNotFound)
+		mockRepo.On("CreateCredential", input).Return(&entities.Credential{ID: adminID, Email: email}, nil)
+		mockRepo.On("CreateEmployee", &transfert.Employee{CredentialID: aws.String(adminID)}).Return(&entities.Employee{}, nil)
+		mockRepo.On("UpdateCredential", mock.MatchedBy(func(credential *entities.Credential) bool {
+			return credential.Role == security.ROLE_ADMIN
+		})).Return(nil)
+
+		credential, err := service.CreateAdmin(input)
+		require.NoError(t, err)
+		assert.Equal(t, security.ROLE_ADMIN, credential.Role)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("existing client", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+
+		// Un client désactivé devient employé, puis administrateur, et il est réactivé
+		disabledAt := time.Now()
+		clientID := "42debee6-2063-4566-baf1-37a7bdd13904"
+		mockRepo.On("ReadCredential", byEmail).Return(&entities.Credential{
+			ID:         userID,
+			Email:      email,
+			Password:   hashedPassword,
+			Role:       entities.ROLE_CLIENT,
+			DisabledAt: &disabledAt,
+		}, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: aws.String(userID)}).Return(&entities.Client{ID: clientID}, nil, nil)
+		mockRepo.On("CreateEmployee", &transfert.Employee{CredentialID: aws.String(userID)}).Return(&entities.Employee{}, nil)
+		mockRepo.On("DeleteClient", &transfert.Client{ID: aws.String(clientID)}).Return(nil)
+		mockRepo.On("UpdateCredential", mock.Anything).Return(nil)
+
+		credential, err := service.CreateAdmin(input)
+		require.NoError(t, err)
+		assert.Equal(t, security.ROLE_ADMIN, credential.Role)
+		assert.False(t, credential.IsDisabled())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("existing employee", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockRepo.On("ReadCredential", byEmail).Return(&entities.Credential{
+			ID:       userID,
+			Email:    email,
+			Password: hashedPassword,
+			Role:     entities.ROLE_EMPLOYEE,
+		}, nil)
+		mockRepo.On("UpdateCredential", mock.Anything).Return(nil)
+
+		credential, err := service.CreateAdmin(input)
+		require.NoError(t, err)
+		assert.Equal(t, security.ROLE_ADMIN, credential.Role)
+		mockRepo.AssertNotCalled(t, "CreateEmployee", mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockRepo.On("ReadCredential", byEmail).Return(&entities.Credential{
+			ID:       userID,
+			Email:    email,
+			Password: hashedPassword,
+			Role:     entities.ROLE_CLIENT,
+		}, nil)
+
+		credential, err := service.CreateAdmin(&transfert.Credential{Email: email, Password: aws.String("wrong")})
+		assert.Nil(t, credential)
+		assert.Equal(t, errors_domain_user.ErrCredentialNotValid, err)
+		mockRepo.AssertNotCalled(t, "UpdateCredential", mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockPerms.On("IsGrantedByRoles", adminRoles).Return(true)
+		mockRepo.On("ReadCredential", byEmail).Return(nil, errors.ErrInternalServer)
+
+		credential, err := service.CreateAdmin(input)
+		assert.Nil(t, credential)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
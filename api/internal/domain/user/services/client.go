@@ -40,6 +40,8 @@ func (s *UserService) RegisterClient(dtoCredential *transfert.Credential, dtoCli
 		return nil, err
 	}
 
+	credential.Role = entities.ROLE_CLIENT
+
 	if err := s.repo.UpdateCredential(credential); err != nil {
 		return nil, err
 	}
@@ -34,19 +34,80 @@ func (s *UserService) UserAuth(dtoCredential *transfert.Credential) (*string, se
 		return nil, "", errors_domain_user.ErrCredentialNotValid
 	}
 
+	if credential.IsDisabled() {
+		return nil, "", errors_domain_user.ErrCredentialDisabled
+	}
+
+	role, err := s.roleOf(credential)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &credential.ID, role, nil
+}
+
+// UserAuthRenew checks a credential again before its tokens are renewed
+// The role is read again, so that a promotion or a demotion applies at the next renewal.
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential the credential of the refresh token
+//
+// Returns:
+// - *string: the ID of the credential
+// - security.Role: the current role of the credential
+// - errors.ErrorInterface: ErrCredentialDisabled if an admin disabled the credential
+func (s *UserService) UserAuthRenew(dtoCredential *transfert.Credential) (*string, security.Role, errors.ErrorInterface) {
+	if dtoCredential == nil || dtoCredential.ID == nil {
+		return nil, "", errors.ErrNoDto
+	}
+
+	credential, err := s.repo.ReadCredential(&transfert.Credential{
+		ID: dtoCredential.ID,
+	})
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if credential.IsDisabled() {
+		return nil, "", errors_domain_user.ErrCredentialDisabled
+	}
+
+	role, err := s.roleOf(credential)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &credential.ID, role, nil
+}
+
+// roleOf returns the role of a credential
+// Credentials created before the roles were stored get theirs from the user they belong to.
+//
+// Parameters:
+// - credential: *entities.Credential the credential
+//
+// Returns:
+// - security.Role: the role of the credential
+// - errors.ErrorInterface: ErrUserNotFound if the credential belongs to no user
+func (s *UserService) roleOf(credential *entities.Credential) (security.Role, errors.ErrorInterface) {
+	if credential.Role != "" {
+		return credential.Role, nil
+	}
+
 	client, _, err := s.repo.ReadUser(&transfert.User{
 		CredentialID: &credential.ID,
 	})
 
 	if err != nil {
-		return nil, "", errors_domain_user.ErrUserNotFound
+		return "", errors_domain_user.ErrUserNotFound
 	}
 
 	if client != nil {
-		return &credential.ID, entities.ROLE_CLIENT, nil
+		return entities.ROLE_CLIENT, nil
 	}
 
-	return &credential.ID, entities.ROLE_EMPLOYEE, nil
+	return entities.ROLE_EMPLOYEE, nil
 }
 
 func (s *UserService) PasswordUpdate(dto *transfert.Credential) errors.ErrorInterface {
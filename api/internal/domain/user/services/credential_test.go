@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
@@ -220,6 +221,125 @@ func TestUserAuth(t *testing.T) {
 		// Vérifier que les attentes sur le mock sont satisfaites
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("admin found", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Le rôle enregistré sur le credential évite de lire le user
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
+			Return(&entities.Credential{
+				Email:    email,
+				Password: hashedPassword,
+				Role:     security.ROLE_ADMIN,
+			}, nil)
+
+		user, userType, err := service.UserAuth(inputCredential)
+
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, security.ROLE_ADMIN, userType)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ReadUser", mock.Anything)
+	})
+
+	t.Run("credential disabled", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		disabledAt := time.Now()
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
+			Return(&entities.Credential{
+				Email:      email,
+				Password:   hashedPassword,
+				Role:       entities.ROLE_CLIENT,
+				DisabledAt: &disabledAt,
+			}, nil)
+
+		user, userType, err := service.UserAuth(inputCredential)
+
+		require.Nil(t, user)
+		assert.Empty(t, userType)
+		assert.Equal(t, errors_domain_user.ErrCredentialDisabled, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserAuthRenew(t *testing.T) {
+	credentialID := "42debee6-2063-4566-baf1-37a7bdd139f0"
+	inputCredential := &transfert.Credential{ID: &credentialID}
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		user, role, err := service.UserAuthRenew(nil)
+		assert.Nil(t, user)
+		assert.Empty(t, role)
+		assert.Equal(t, errors.ErrNoDto, err)
+
+		user, role, err = service.UserAuthRenew(&transfert.Credential{})
+		assert.Nil(t, user)
+		assert.Empty(t, role)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("credential not found", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		mockRepo.On("ReadCredential", inputCredential).Return(nil, errors_domain_user.ErrCredentialNotFound)
+
+		user, role, err := service.UserAuthRenew(inputCredential)
+		assert.Nil(t, user)
+		assert.Empty(t, role)
+		assert.Equal(t, errors_domain_user.ErrCredentialNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("credential disabled", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		disabledAt := time.Now()
+		mockRepo.On("ReadCredential", inputCredential).Return(&entities.Credential{
+			ID:         credentialID,
+			Role:       security.ROLE_ADMIN,
+			DisabledAt: &disabledAt,
+		}, nil)
+
+		user, role, err := service.UserAuthRenew(inputCredential)
+		assert.Nil(t, user)
+		assert.Empty(t, role)
+		assert.Equal(t, errors_domain_user.ErrCredentialDisabled, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("role changed", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Le rôle est relu, une rétrogradation s'applique au renouvellement suivant
+		mockRepo.On("ReadCredential", inputCredential).Return(&entities.Credential{
+			ID:   credentialID,
+			Role: entities.ROLE_EMPLOYEE,
+		}, nil)
+
+		user, role, err := service.UserAuthRenew(inputCredential)
+		require.NoError(t, err)
+		assert.Equal(t, credentialID, *user)
+		assert.Equal(t, entities.ROLE_EMPLOYEE, role)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("legacy credential", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		mockRepo.On("ReadCredential", inputCredential).Return(&entities.Credential{
+			ID: credentialID,
+		}, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: &credentialID}).Return(&entities.Client{}, nil, nil)
+
+		user, role, err := service.UserAuthRenew(inputCredential)
+		require.NoError(t, err)
+		assert.Equal(t, credentialID, *user)
+		assert.Equal(t, entities.ROLE_CLIENT, role)
+		mockRepo.AssertExpectations(t)
+	})
 }
 
 func TestPasswordUpdate(t *testing.T) {
@@ -535,7 +655,7 @@ func TestValidationRecover(t *testing.T) {
 	t.Run("success client", func(t *testing.T) {
 		service, mockRepo, mockMailer, _, _ := setup()
 
-		luhn := token.Generate(6)
+		luhn, _ := token.Generate(6)
 
 		// Simuler que le credential n'est pas trouvé
 		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
@@ -564,7 +684,7 @@ func TestValidationRecover(t *testing.T) {
 	t.Run("success employee", func(t *testing.T) {
 		service, mockRepo, mockMailer, _, _ := setup()
 
-		luhn := token.Generate(6)
+		luhn, _ := token.Generate(6)
 
 		// Simuler que le credential n'est pas trouvé
 		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
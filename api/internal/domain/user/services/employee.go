@@ -1,6 +1,7 @@
 package services
 
 import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
@@ -39,6 +40,8 @@ func (s *UserService) RegisterEmployee(dtoCredential *transfert.Credential, dtoE
 		return nil, err
 	}
 
+	credential.Role = entities.ROLE_EMPLOYEE
+
 	if err := s.repo.UpdateCredential(credential); err != nil {
 		return nil, err
 	}
@@ -53,7 +56,7 @@ func (s *UserService) UpdateEmployee(dtoEmployee *transfert.Employee) (*entities
 		return nil, errors.ErrNoDto
 	}
 
-	if !s.security.IsGrantedByRoles(entities.ROLE_EMPLOYEE) {
+	if !s.security.IsGrantedByRoles(entities.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return nil, errors.ErrUnauthorized
 	}
 
@@ -83,7 +86,7 @@ func (s *UserService) DeleteEmployee(dtoEmployee *transfert.Employee) errors.Err
 		return errors.ErrNoDto
 	}
 
-	if !s.security.IsGrantedByRoles(entities.ROLE_EMPLOYEE) {
+	if !s.security.IsGrantedByRoles(entities.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return errors.ErrUnauthorized
 	}
 
@@ -108,7 +111,7 @@ func (s *UserService) GetEmployee(dtoEmployee *transfert.Employee) (*entities.Em
 		return nil, errors.ErrNoDto
 	}
 
-	if !s.security.IsGrantedByRoles(entities.ROLE_EMPLOYEE) {
+	if !s.security.IsGrantedByRoles(entities.ROLE_EMPLOYEE, security.ROLE_ADMIN) {
 		return nil, errors.ErrUnauthorized
 	}
 
@@ -172,7 +172,7 @@ func TestGetEmployee(t *testing.T) {
 		dtoEmployee := &transfert.Employee{ID: aws.String("employee-id")}
 
 		mockRepo.On("ReadEmployee", dtoEmployee).Return(nil, errors_domain_user.ErrEmployeeNotFound)
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 
 		employee, err := service.GetEmployee(dtoEmployee)
 		assert.Nil(t, employee)
@@ -187,7 +187,7 @@ func TestGetEmployee(t *testing.T) {
 			ID: aws.String("42debee6-2063-4566-baf1-37a7bdd139ff"),
 		}
 
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(false)
 		employee, err := service.GetEmployee(dummyEmployeeDTO)
 
 		require.Error(t, err)
@@ -209,7 +209,7 @@ func TestGetEmployee(t *testing.T) {
 
 		mockRepo.On("ReadEmployee", dummyEmployeeDTO).Return(expectedEmployee, nil)
 		mockPerms.On("CanRead", expectedEmployee, mock.Anything).Return(false)
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 
 		employee, err := service.GetEmployee(dummyEmployeeDTO)
 
@@ -228,7 +228,7 @@ func TestGetEmployee(t *testing.T) {
 
 		mockRepo.On("ReadEmployee", dtoEmployee).Return(expectedEmployee, nil)
 		mockPerms.On("CanRead", mock.AnythingOfType("*entities.Employee"), mock.Anything).Return(true)
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 
 		employee, err := service.GetEmployee(dtoEmployee)
 		assert.NotNil(t, employee)
@@ -259,7 +259,7 @@ func TestDeleteEmployee(t *testing.T) {
 		dtoEmployee := &transfert.Employee{ID: employeeID}
 
 		mockRepo.On("ReadEmployee", dtoEmployee).Return(nil, errors_domain_user.ErrEmployeeNotFound)
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 
 		err := service.DeleteEmployee(dtoEmployee)
 		assert.Error(t, err)
@@ -302,7 +302,7 @@ func TestDeleteEmployee(t *testing.T) {
 		mockRepo.On("ReadEmployee", dtoEmployee).Return(&entities.Employee{ID: *employeeID}, nil)
 		// Simuler la permission de suppression
 		mockPermission.On("CanDelete", mock.AnythingOfType("*entities.Employee")).Return(false)
-		mockPermission.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPermission.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 
 		// Appel du service pour supprimer le employee
 		err := service.DeleteEmployee(dtoEmployee)
@@ -325,7 +325,7 @@ func TestDeleteEmployee(t *testing.T) {
 		mockRepo.On("ReadEmployee", dtoEmployee).Return(&entities.Employee{ID: *employeeID}, nil)
 		mockPerms.On("CanDelete", mock.AnythingOfType("*entities.Employee")).Return(true)
 		mockRepo.On("DeleteEmployee", dtoEmployee).Return(nil)
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 
 		err := service.DeleteEmployee(dtoEmployee)
 		assert.NoError(t, err)
@@ -347,7 +347,7 @@ func TestDeleteEmployee(t *testing.T) {
 		mockRepo.On("ReadEmployee", dtoEmployee).Return(&entities.Employee{ID: *employeeID}, nil)
 		// Simuler la permission de suppression
 		mockPermission.On("CanDelete", mock.AnythingOfType("*entities.Employee")).Return(true)
-		mockPermission.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPermission.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		// Simuler une erreur lors de la suppression du Employee
 		mockRepo.On("DeleteEmployee", dtoEmployee).Return(errors.ErrInternalServer)
 
@@ -376,7 +376,7 @@ func TestUpdateEmployee(t *testing.T) {
 		service, mockRepo, _, mockPerms, _ := setup()
 
 		dtoEmployee := &transfert.Employee{ID: aws.String("employee-id")}
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		mockRepo.On("ReadEmployee", mock.AnythingOfType("*transfert.Employee")).Return(nil, errors_domain_user.ErrEmployeeNotFound)
 
 		employee, err := service.UpdateEmployee(dtoEmployee)
@@ -406,7 +406,7 @@ func TestUpdateEmployee(t *testing.T) {
 			Return(mockEmployee, nil)
 
 		mockPerms.On("CanUpdate", mockEmployee, mock.Anything).Return(false)
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 
 		employee, err := service.UpdateEmployee(&transfert.Employee{ID: aws.String("valid-id")})
 
@@ -425,7 +425,7 @@ func TestUpdateEmployee(t *testing.T) {
 
 		mockRepo.On("ReadEmployee", mock.AnythingOfType("*transfert.Employee")).Return(existingEmployee, nil)
 		mockPerms.On("CanUpdate", mock.AnythingOfType("*entities.Employee"), mock.Anything).Return(true)
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 		mockRepo.On("UpdateEmployee", existingEmployee).Return(nil)
 
 		employee, err := service.UpdateEmployee(dtoEmployee)
@@ -444,7 +444,7 @@ func TestUpdateEmployee(t *testing.T) {
 		mockRepo.On("ReadEmployee", mock.AnythingOfType("*transfert.Employee")).Return(existingEmployee, nil)
 		mockPerms.On("CanUpdate", mock.AnythingOfType("*entities.Employee"), mock.Anything).Return(true)
 		mockRepo.On("UpdateEmployee", existingEmployee).Return(errors.ErrInternalServer)
-		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("IsGrantedByRoles", []security.Role{entities.ROLE_EMPLOYEE, security.ROLE_ADMIN}).Return(true)
 
 		employee, err := service.UpdateEmployee(dtoEmployee)
 		assert.Nil(t, employee)
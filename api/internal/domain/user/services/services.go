@@ -7,6 +7,7 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
 )
 
@@ -24,6 +25,7 @@ func User(security security.PermissionInterface, repo repositories.UserRepositor
 type UserServiceInterface interface {
 	// Credential
 	UserAuth(dtoCredential *transfert.Credential) (*string, security.Role, errors.ErrorInterface)
+	UserAuthRenew(dtoCredential *transfert.Credential) (*string, security.Role, errors.ErrorInterface)
 	PasswordUpdate(dtoCredential *transfert.Credential) errors.ErrorInterface
 	ValidationRecover(dtoValidation *transfert.Validation, dtoClient *transfert.Credential) errors.ErrorInterface
 	PasswordValidation(dtoValidation *transfert.Validation, dtoClient *transfert.Credential) (*entities.Validation, errors.ErrorInterface)
@@ -41,4 +43,12 @@ type UserServiceInterface interface {
 	GetEmployee(dtoEmployee *transfert.Employee) (*entities.Employee, errors.ErrorInterface)
 	DeleteEmployee(dtoEmployee *transfert.Employee) errors.ErrorInterface
 	UpdateEmployee(Employee *transfert.Employee) (*entities.Employee, errors.ErrorInterface)
+
+	// Admin
+	ListUsers(list *database.List) (*database.Page[*entities.Credential], errors.ErrorInterface)
+	PromoteUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface)
+	DemoteUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface)
+	DisableUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface)
+	EnableUser(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface)
+	CreateAdmin(dtoCredential *transfert.Credential) (*entities.Credential, errors.ErrorInterface)
 }
@@ -6,6 +6,7 @@ import (
 	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/services"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
@@ -47,6 +48,14 @@ func (m *UserRepositoryMock) ReadClient(client *transfert.Client, options ...dat
 	return args.Get(0).(*entities.Client), nil
 }
 
+func (m *UserRepositoryMock) ReadClientCredentialIDs(ids []string, options ...database.Option) ([]string, errors.ErrorInterface) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]string), nil
+}
+
 func (m *UserRepositoryMock) UpdateClient(client *entities.Client, options ...database.Option) errors.ErrorInterface {
 	args := m.Called(client)
 	if args.Get(0) == nil {
@@ -154,6 +163,14 @@ func (m *UserRepositoryMock) ReadCredential(credential *transfert.Credential, op
 	return args.Get(0).(*entities.Credential), nil
 }
 
+func (m *UserRepositoryMock) PaginateCredentials(credential *transfert.Credential, list *database.List, options ...database.Option) (*database.Page[*entities.Credential], errors.ErrorInterface) {
+	args := m.Called(credential, list)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*database.Page[*entities.Credential]), nil
+}
+
 func (m *UserRepositoryMock) UpdateCredential(credential *entities.Credential, options ...database.Option) errors.ErrorInterface {
 	args := m.Called(credential)
 	if args.Get(0) == nil {
@@ -209,6 +226,29 @@ func (m *PermissionMock) GetCredentialID() *string {
 	return args.Get(0).(*string)
 }
 
+func (m *PermissionMock) GetRole() security.Role {
+	args := m.Called()
+	return args.Get(0).(security.Role)
+}
+
+func (m *PermissionMock) GetIP() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
+func (m *PermissionMock) GetUserAgent() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
 func (m *PermissionMock) IsGrantedByRules(rules ...security.Rule) bool {
 	args := m.Called(rules)
 	return args.Bool(0)
@@ -283,6 +323,16 @@ func (m *GameRepositoryMock) ReadTickets(obj *gameTransfert.Ticket, options ...d
 	return args.Get(0).([]*gameEntity.Ticket), nil
 }
 
+// PaginateTickets simule la lecture d'une page de tickets.
+func (m *GameRepositoryMock) PaginateTickets(obj *gameTransfert.Ticket, list *database.List, options ...database.Option) (*database.Page[*gameEntity.Ticket], errors.ErrorInterface) {
+	args := m.Called(obj, list, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*database.Page[*gameEntity.Ticket]), nil
+}
+
 // UpdateTicket simule la mise à jour d'un ticket.
 func (m *GameRepositoryMock) UpdateTicket(entity *gameEntity.Ticket, options ...database.Option) errors.ErrorInterface {
 	args := m.Called(entity, options)
@@ -293,6 +343,46 @@ func (m *GameRepositoryMock) UpdateTicket(entity *gameEntity.Ticket, options ...
 	return args.Error(0).(errors.ErrorInterface)
 }
 
+// ClaimTicket simule la réclamation conditionnelle d'un ticket.
+func (m *GameRepositoryMock) ClaimTicket(entity *gameEntity.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// RedeemTicket simule la remise conditionnelle d'un ticket.
+func (m *GameRepositoryMock) RedeemTicket(entity *gameEntity.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReserveTicket simule l'enregistrement de la boutique qui réserve le lot d'un ticket.
+func (m *GameRepositoryMock) ReserveTicket(entity *gameEntity.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ExpireTicket simule l'expiration conditionnelle d'un ticket.
+func (m *GameRepositoryMock) ExpireTicket(entity *gameEntity.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
 // DeleteTicket simule la suppression d'un ticket.
 func (m *GameRepositoryMock) DeleteTicket(obj *gameTransfert.Ticket, options ...database.Option) errors.ErrorInterface {
 	args := m.Called(obj, options)
@@ -313,6 +403,431 @@ func (m *GameRepositoryMock) CountTicket(obj *gameTransfert.Ticket, options ...d
 	return args.Int(0), nil
 }
 
+// IssueTicket simule la remise d'un ticket en caisse.
+func (m *GameRepositoryMock) IssueTicket(entity *gameEntity.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// VoidTicket simule l'annulation d'un ticket.
+func (m *GameRepositoryMock) VoidTicket(entity *gameEntity.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReissueTicket simule l'annulation d'un ticket et la création de son remplaçant.
+func (m *GameRepositoryMock) ReissueTicket(voided, replacement *gameEntity.Ticket, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(voided, replacement, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// Transaction simule une transaction en exécutant les opérations sur le mock lui-même.
+func (m *GameRepositoryMock) Transaction(fn func(repo gameRepository.GameRepositoryInterface) errors.ErrorInterface) errors.ErrorInterface {
+	return fn(m)
+}
+
+// AssignTicketsToStore simule l'attribution de tickets à une boutique.
+func (m *GameRepositoryMock) AssignTicketsToStore(ids []string, storeID string, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(ids, storeID, options)
+	if args.Get(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// AggregateTickets simule le comptage des tickets par groupe.
+func (m *GameRepositoryMock) AggregateTickets(obj *gameTransfert.Ticket, options ...database.Option) ([]*gameEntity.Aggregate, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.Aggregate), nil
+}
+
+// CreateTicketEvent simule l'ajout d'un événement à l'historique d'un ticket.
+func (m *GameRepositoryMock) CreateTicketEvent(obj *gameTransfert.TicketEvent, options ...database.Option) (*gameEntity.TicketEvent, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.TicketEvent), nil
+}
+
+// ReadTicketEvents simule la lecture de l'historique d'un ticket.
+func (m *GameRepositoryMock) ReadTicketEvents(obj *gameTransfert.TicketEvent, options ...database.Option) ([]*gameEntity.TicketEvent, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.TicketEvent), nil
+}
+
+// CreateClaimAttempt simule l'enregistrement d'une tentative de réclamation.
+func (m *GameRepositoryMock) CreateClaimAttempt(obj *gameTransfert.ClaimAttempt, options ...database.Option) (*gameEntity.ClaimAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.ClaimAttempt), nil
+}
+
+// ReadClaimAttempts simule la lecture des tentatives de réclamation.
+func (m *GameRepositoryMock) ReadClaimAttempts(obj *gameTransfert.ClaimAttempt, options ...database.Option) ([]*gameEntity.ClaimAttempt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.ClaimAttempt), nil
+}
+
+// CreateDraw simule la création d'un tirage.
+func (m *GameRepositoryMock) CreateDraw(obj *gameTransfert.Draw, options ...database.Option) (*gameEntity.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Draw), nil
+}
+
+// ReadDraw simule la lecture d'un tirage.
+func (m *GameRepositoryMock) ReadDraw(obj *gameTransfert.Draw, options ...database.Option) (*gameEntity.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Draw), nil
+}
+
+// ReadDraws simule la lecture de plusieurs tirages.
+func (m *GameRepositoryMock) ReadDraws(obj *gameTransfert.Draw, options ...database.Option) ([]*gameEntity.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.Draw), nil
+}
+
+// UpdateDraw simule la mise à jour d'un tirage.
+func (m *GameRepositoryMock) UpdateDraw(entity *gameEntity.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CompleteDraw simule l'enregistrement du résultat d'un tirage.
+func (m *GameRepositoryMock) CompleteDraw(entity *gameEntity.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketHolders simule la lecture des détenteurs de tickets.
+func (m *GameRepositoryMock) ReadTicketHolders(options ...database.Option) ([]string, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]string), nil
+}
+
+// CreateShift simule l'ouverture d'un service de caisse.
+func (m *GameRepositoryMock) CreateShift(obj *gameTransfert.Shift, options ...database.Option) (*gameEntity.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Shift), nil
+}
+
+// ReadShift simule la lecture d'un service de caisse.
+func (m *GameRepositoryMock) ReadShift(obj *gameTransfert.Shift, options ...database.Option) (*gameEntity.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Shift), nil
+}
+
+// ReadShifts simule la lecture de plusieurs services de caisse.
+func (m *GameRepositoryMock) ReadShifts(obj *gameTransfert.Shift, options ...database.Option) ([]*gameEntity.Shift, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.Shift), nil
+}
+
+// UpdateShift simule la mise à jour d'un service de caisse.
+func (m *GameRepositoryMock) UpdateShift(entity *gameEntity.Shift, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CloseShift simule la clôture conditionnelle d'un service de caisse.
+func (m *GameRepositoryMock) CloseShift(entity *gameEntity.Shift, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CreateSyncOperation simule l'enregistrement d'une opération synchronisée par une caisse.
+func (m *GameRepositoryMock) CreateSyncOperation(obj *gameTransfert.SyncOperation, options ...database.Option) (*gameEntity.SyncOperation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.SyncOperation), nil
+}
+
+// UpdateSyncOperation simule l'enregistrement du résultat d'une opération synchronisée.
+func (m *GameRepositoryMock) UpdateSyncOperation(entity *gameEntity.SyncOperation, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadSyncOperation simule la lecture d'une opération synchronisée par une caisse.
+func (m *GameRepositoryMock) ReadSyncOperation(obj *gameTransfert.SyncOperation, options ...database.Option) (*gameEntity.SyncOperation, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.SyncOperation), nil
+}
+
+// ReadPrizeStock simule la lecture du stock d'un lot dans une boutique.
+func (m *GameRepositoryMock) ReadPrizeStock(obj *gameTransfert.PrizeStock, options ...database.Option) (*gameEntity.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.PrizeStock), nil
+}
+
+// ReadPrizeStocks simule la lecture des stocks des lots.
+func (m *GameRepositoryMock) ReadPrizeStocks(obj *gameTransfert.PrizeStock, options ...database.Option) ([]*gameEntity.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.PrizeStock), nil
+}
+
+// RestockPrize simule le réapprovisionnement d'un lot dans une boutique.
+func (m *GameRepositoryMock) RestockPrize(obj *gameTransfert.PrizeStock, options ...database.Option) (*gameEntity.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.PrizeStock), nil
+}
+
+// ReservePrizeStock simule la réservation d'une unité du stock.
+func (m *GameRepositoryMock) ReservePrizeStock(entity *gameEntity.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReleasePrizeStock simule la libération d'une unité réservée.
+func (m *GameRepositoryMock) ReleasePrizeStock(entity *gameEntity.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ConsumePrizeStock simule la sortie du stock d'un lot remis.
+func (m *GameRepositoryMock) ConsumePrizeStock(entity, reserved *gameEntity.PrizeStock, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, reserved, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// TransferPrizeStock simule le transfert de stock entre deux boutiques.
+func (m *GameRepositoryMock) TransferPrizeStock(obj *gameTransfert.StockTransfer, options ...database.Option) (*gameEntity.PrizeStock, *gameEntity.PrizeStock, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.PrizeStock), args.Get(1).(*gameEntity.PrizeStock), nil
+}
+
+// MarkPrizeStockAlerted simule l'enregistrement de l'alerte de stock bas.
+func (m *GameRepositoryMock) MarkPrizeStockAlerted(entity *gameEntity.PrizeStock, options ...database.Option) (bool, errors.ErrorInterface) {
+	args := m.Called(entity, options)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+
+	return args.Bool(0), args.Error(1).(errors.ErrorInterface)
+}
+
+// CreateCampaign simule la création d'une campagne.
+func (m *GameRepositoryMock) CreateCampaign(obj *gameTransfert.Campaign, options ...database.Option) (*gameEntity.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Campaign), nil
+}
+
+// ReadCampaign simule la lecture d'une campagne.
+func (m *GameRepositoryMock) ReadCampaign(obj *gameTransfert.Campaign, options ...database.Option) (*gameEntity.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Campaign), nil
+}
+
+// ReadCampaigns simule la lecture de plusieurs campagnes.
+func (m *GameRepositoryMock) ReadCampaigns(obj *gameTransfert.Campaign, options ...database.Option) ([]*gameEntity.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.Campaign), nil
+}
+
+// UpdateCampaign simule la mise à jour d'une campagne.
+func (m *GameRepositoryMock) UpdateCampaign(entity *gameEntity.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeleteCampaign simule la suppression d'une campagne.
+func (m *GameRepositoryMock) DeleteCampaign(obj *gameTransfert.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// AttachTicketsToCampaign simule le rattachement des tickets à une campagne.
+func (m *GameRepositoryMock) AttachTicketsToCampaign(entity *gameEntity.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CreatePrize simule la création d'un lot.
+func (m *GameRepositoryMock) CreatePrize(obj *gameTransfert.Prize, options ...database.Option) (*gameEntity.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Prize), nil
+}
+
+// ReadPrize simule la lecture d'un lot.
+func (m *GameRepositoryMock) ReadPrize(obj *gameTransfert.Prize, options ...database.Option) (*gameEntity.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Prize), nil
+}
+
+// ReadPrizes simule la lecture de plusieurs lots.
+func (m *GameRepositoryMock) ReadPrizes(obj *gameTransfert.Prize, options ...database.Option) ([]*gameEntity.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.Prize), nil
+}
+
+// UpdatePrize simule la mise à jour d'un lot.
+func (m *GameRepositoryMock) UpdatePrize(entity *gameEntity.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeletePrize simule la suppression d'un lot.
+func (m *GameRepositoryMock) DeletePrize(obj *gameTransfert.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
 func setup() (*services.UserService, *UserRepositoryMock, *MailServiceMock, *PermissionMock, *GameRepositoryMock) {
 	mockRepository := new(UserRepositoryMock)
 	gameRepository := new(GameRepositoryMock)
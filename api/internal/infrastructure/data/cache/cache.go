@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value  V
+	expire time.Time
+}
+
+// TTL keeps values in memory for a fixed duration
+// The expired values are dropped when they are read or when a value is stored.
+type TTL[V any] struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]entry[V]
+}
+
+// New creates a cache keeping the values for ttl
+//
+// Parameters:
+// - ttl: time.Duration how long a value is kept
+//
+// Returns:
+// - *TTL[V]: an empty cache
+func New[V any](ttl time.Duration) *TTL[V] {
+	return &TTL[V]{ttl: ttl, entries: map[string]entry[V]{}}
+}
+
+// Get reads a value still alive
+//
+// Parameters:
+// - key: string the key of the value
+//
+// Returns:
+// - V: the value, the zero value if missing
+// - bool: false if the value is missing or expired
+func (c *TTL[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expire) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+// Set stores a value until the ttl elapses
+//
+// Parameters:
+// - key: string the key of the value
+// - value: V the value to keep
+func (c *TTL[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range c.entries {
+		if now.After(e.expire) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = entry[V]{value: value, expire: now.Add(c.ttl)}
+}
+
+// Flush drops all the values
+func (c *TTL[V]) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]entry[V]{}
+}
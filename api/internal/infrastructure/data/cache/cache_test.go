@@ -0,0 +1,66 @@
+package cache_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTL(t *testing.T) {
+	t.Run("should read a value still alive", func(t *testing.T) {
+		c := cache.New[int](time.Minute)
+		c.Set("key", 42)
+
+		value, ok := c.Get("key")
+		assert.True(t, ok)
+		assert.Equal(t, 42, value)
+	})
+
+	t.Run("should miss an unknown key", func(t *testing.T) {
+		c := cache.New[int](time.Minute)
+
+		value, ok := c.Get("key")
+		assert.False(t, ok)
+		assert.Equal(t, 0, value)
+	})
+
+	t.Run("should drop an expired value", func(t *testing.T) {
+		c := cache.New[int](time.Millisecond)
+		c.Set("key", 42)
+		time.Sleep(5 * time.Millisecond)
+
+		_, ok := c.Get("key")
+		assert.False(t, ok)
+	})
+
+	t.Run("should drop all the values on flush", func(t *testing.T) {
+		c := cache.New[int](time.Minute)
+		c.Set("key", 42)
+		c.Flush()
+
+		_, ok := c.Get("key")
+		assert.False(t, ok)
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		c := cache.New[int](time.Minute)
+		wg := sync.WaitGroup{}
+
+		for i := range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.Set("key", i)
+				c.Get("key")
+			}()
+		}
+
+		wg.Wait()
+
+		_, ok := c.Get("key")
+		assert.True(t, ok)
+	})
+}
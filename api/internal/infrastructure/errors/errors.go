@@ -42,10 +42,16 @@ var (
 	ErrValueIsNotPhone                   = New(http.StatusBadRequest, "validator.is_not_phone")
 	ErrValueIsNotID                      = New(http.StatusBadRequest, "validator.is_not_id")
 	ErrValueIsNotLuhn                    = New(http.StatusBadRequest, "validator.is_not_luhn")
+	ErrValueIsNotInAlphabet              = New(http.StatusBadRequest, "validator.is_not_in_alphabet")
+	ErrValueIsForged                     = New(http.StatusBadRequest, "validator.is_forged")
+	ErrValueIsNotSheetFormat             = New(http.StatusBadRequest, "validator.is_not_sheet_format")
+	ErrValueIsNotQRFormat                = New(http.StatusBadRequest, "validator.is_not_qr_format")
 	ErrValueIsNotURL                     = New(http.StatusBadRequest, "validator.is_not_url")
 	ErrValueIsNotDate                    = New(http.StatusBadRequest, "validator.is_not_date")
 	ErrValueIsNotTime                    = New(http.StatusBadRequest, "validator.is_not_time")
 	ErrValueIsNotUUID                    = New(http.StatusBadRequest, "validator.is_not_uuid")
+	ErrValueIsNotSHA256                  = New(http.StatusBadRequest, "validator.is_not_sha256")
+	ErrValueIsNotTimezone                = New(http.StatusBadRequest, "validator.is_not_timezone")
 
 	// Auth errors
 	ErrAuthNoToken      = New(http.StatusUnauthorized, "auth.no_token")
@@ -55,6 +61,16 @@ var (
 	ErrAuthForbidden    = New(http.StatusForbidden, "auth.forbidden")
 	ErrAuthExpiredToken = New(http.StatusUnauthorized, "auth.expired_token")
 
+	// Link errors
+	ErrLinkIsForged  = New(http.StatusForbidden, "link.forged")
+	ErrLinkIsExpired = New(http.StatusGone, "link.expired")
+
+	// List errors
+	ErrListInvalidPage   = New(http.StatusBadRequest, "list.invalid_page")
+	ErrListInvalidLimit  = New(http.StatusBadRequest, "list.invalid_limit")
+	ErrListInvalidCursor = New(http.StatusBadRequest, "list.invalid_cursor")
+	ErrListUnknownField  = New(http.StatusBadRequest, "list.unknown_field")
+
 	// Mail errors
 	ErrMailSendFailed = New(http.StatusInternalServerError, "mail.send_failed")
 
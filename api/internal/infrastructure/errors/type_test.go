@@ -72,7 +72,7 @@ func TestNewError(t *testing.T) {
 	assert.Equal(t, "not.found", err.Error())
 
 	errs := errors.ListErrors()
-	assert.Equal(t, 42, len(errs))
+	assert.Equal(t, 54, len(errs))
 
 	err.Log(fmt.Errorf("error"))
 }
@@ -0,0 +1,343 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// DefaultLimit est la taille d'une page quand le client ne la précise pas
+	DefaultLimit = 20
+	// MaxLimit est la taille maximale d'une page
+	MaxLimit = 100
+	// DefaultSort trie les listes du plus récent au plus ancien
+	DefaultSort = "-created_at"
+)
+
+// List décrit la page demandée d'une liste : pagination, tri et filtres
+// Les valeurs brutes viennent de la requête, elles ne sont utilisées qu'une fois vérifiées par Check.
+type List struct {
+	Page    *string
+	Limit   *string
+	Cursor  *string
+	Sort    *string
+	Filters map[string]string
+
+	page    int
+	limit   int
+	sorting []sorting
+	filters map[string]string
+}
+
+// sorting est une colonne de tri et son sens
+type sorting struct {
+	column string
+	desc   bool
+}
+
+// Page est l'enveloppe des listes paginées
+type Page[T any] struct {
+	Items []T     `json:"items"`
+	Total int64   `json:"total"`
+	Page  int     `json:"page,omitempty"`
+	Pages int     `json:"pages,omitempty"`
+	Limit int     `json:"limit"`
+	Next  *string `json:"next,omitempty"`
+}
+
+// NewList lit la pagination, le tri et les filtres des paramètres d'une requête
+// ?page=2&limit=50 ou ?cursor=..., ?sort=-created_at,status et ?filter[status]=claimed
+//
+// Parameters:
+// - values: map[string]string les paramètres de la requête
+//
+// Returns:
+// - *List: la page demandée, triée par DefaultSort tant qu'elle n'est pas vérifiée
+func NewList(values map[string]string) *List {
+	list := &List{
+		Filters: map[string]string{},
+		page:    1,
+		limit:   DefaultLimit,
+		sorting: []sorting{parseSorting(DefaultSort)},
+	}
+
+	for key, value := range values {
+		switch {
+		case key == "page":
+			list.Page = &value
+		case key == "limit":
+			list.Limit = &value
+		case key == "cursor":
+			list.Cursor = &value
+		case key == "sort":
+			list.Sort = &value
+		case strings.HasPrefix(key, "filter[") && strings.HasSuffix(key, "]"):
+			list.Filters[strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]")] = value
+		}
+	}
+
+	return list
+}
+
+// Check vérifie la page demandée, seules les colonnes autorisées peuvent être triées ou filtrées
+//
+// Parameters:
+// - columns: ...string les colonnes que le client peut trier ou filtrer
+//
+// Returns:
+// - errors.ErrorInterface: les erreurs de chaque paramètre refusé
+func (l *List) Check(columns ...string) errors.ErrorInterface {
+	var errList errors.Errors = make(errors.Errors, 0)
+
+	if l.Page != nil {
+		if page, err := strconv.Atoi(*l.Page); err != nil || page < 1 {
+			errList.Add("page", errors.ErrListInvalidPage)
+		} else {
+			l.page = page
+		}
+	}
+
+	if l.Limit != nil {
+		if limit, err := strconv.Atoi(*l.Limit); err != nil || limit < 1 || limit > MaxLimit {
+			errList.Add("limit", errors.ErrListInvalidLimit)
+		} else {
+			l.limit = limit
+		}
+	}
+
+	if l.Sort != nil {
+		l.sorting = nil
+		for _, field := range strings.Split(*l.Sort, ",") {
+			sort := parseSorting(strings.TrimSpace(field))
+			if !slices.Contains(columns, sort.column) {
+				errList.Add("sort", errors.ErrListUnknownField)
+				continue
+			}
+
+			l.sorting = append(l.sorting, sort)
+		}
+	}
+
+	l.filters = map[string]string{}
+	for column, value := range l.Filters {
+		if !slices.Contains(columns, column) {
+			errList.Add("filter["+column+"]", errors.ErrListUnknownField)
+			continue
+		}
+
+		l.filters[column] = value
+	}
+
+	if l.Cursor != nil {
+		if _, _, err := splitCursor(*l.Cursor); err != nil || l.Page != nil || len(l.sorting) != 1 {
+			errList.Add("cursor", errors.ErrListInvalidCursor)
+		}
+	}
+
+	return errList.ToErrorInterface()
+}
+
+// Paginate lit une page d'une liste, le total compte les enregistrements filtrés de toutes les pages
+// La requête doit porter son modèle, les colonnes sont résolues par son schéma avant d'être échappées.
+//
+// Parameters:
+// - query: *gorm.DB la requête de la liste, avec son modèle et ses conditions
+// - list: *List la page demandée, vérifiée par Check
+// - options: ...Option les options appliquées à la lecture seulement, par exemple Preload
+//
+// Returns:
+// - *Page[T]: la page et le total
+// - errors.ErrorInterface: une erreur si la page ne peut pas être lue
+func Paginate[T any](query *gorm.DB, list *List, options ...Option) (*Page[T], errors.ErrorInterface) {
+	filtered := query.Session(&gorm.Session{})
+	if err := filtered.Statement.Parse(filtered.Statement.Model); err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	lookup := func(column string) (*schema.Field, errors.ErrorInterface) {
+		field := filtered.Statement.Schema.LookUpField(column)
+		if field == nil || field.DBName == "" {
+			return nil, errors.ErrListUnknownField
+		}
+
+		return field, nil
+	}
+
+	columns := make([]string, 0, len(list.filters))
+	for column := range list.filters {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+
+	for _, column := range columns {
+		field, err := lookup(column)
+		if err != nil {
+			return nil, err
+		}
+
+		value, typeErr := typed(field, list.filters[column])
+		if typeErr != nil {
+			return nil, errors.ErrListUnknownField
+		}
+
+		filtered = Filter(field.DBName, value)(filtered)
+	}
+
+	page := &Page[T]{Items: make([]T, 0), Limit: list.limit}
+	if err := filtered.Session(&gorm.Session{}).Count(&page.Total).Error; err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	read := filtered.Session(&gorm.Session{})
+	for _, option := range options {
+		read = option(read)
+	}
+
+	first, err := lookup(list.sorting[0].column)
+	if err != nil {
+		return nil, err
+	}
+
+	identifier, err := lookup("id")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sort := range list.sorting {
+		field, err := lookup(sort.column)
+		if err != nil {
+			return nil, err
+		}
+
+		if nullable(field) {
+			read = SortNullable(field.DBName, sort.desc)(read)
+		} else {
+			read = Sort(field.DBName, sort.desc)(read)
+		}
+	}
+
+	if first.DBName != "id" {
+		read = Sort("id", list.sorting[0].desc)(read)
+	}
+
+	if list.Cursor != nil {
+		value, id, err := decodeCursor(*list.Cursor, first)
+		if err != nil {
+			return nil, errors.ErrListInvalidCursor
+		}
+
+		if nullable(first) {
+			read = CursorNullable(first.DBName, value, id, list.sorting[0].desc)(read)
+		} else {
+			read = Cursor(first.DBName, value, id, list.sorting[0].desc)(read)
+		}
+	} else {
+		read = Offset((list.page - 1) * list.limit)(read)
+		page.Page = list.page
+		page.Pages = int((page.Total + int64(list.limit) - 1) / int64(list.limit))
+	}
+
+	if err := Limit(list.limit)(read).Find(&page.Items).Error; err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	more := len(page.Items) == list.limit
+	if list.Cursor == nil {
+		more = int64(list.page*list.limit) < page.Total
+	}
+
+	// Un curseur ne reprend qu'un tri sur une seule colonne
+	if more && len(page.Items) > 0 && len(list.sorting) == 1 {
+		last := reflect.ValueOf(page.Items[len(page.Items)-1])
+		value, _ := first.ValueOf(read.Statement.Context, last)
+		id, _ := identifier.ValueOf(read.Statement.Context, last)
+
+		next, err := encodeCursor(value, id)
+		if err != nil {
+			return nil, errors.ErrInternalServer.Log(err)
+		}
+
+		page.Next = &next
+	}
+
+	return page, nil
+}
+
+// parseSorting lit une colonne de tri, le préfixe - trie par ordre décroissant
+func parseSorting(field string) sorting {
+	if column, ok := strings.CutPrefix(field, "-"); ok {
+		return sorting{column, true}
+	}
+
+	return sorting{field, false}
+}
+
+// nullable indique si une colonne peut être vide, ses valeurs vides ne se comparent pas
+func nullable(field *schema.Field) bool {
+	return field.FieldType.Kind() == reflect.Pointer
+}
+
+// typed convertit une valeur de la requête dans le type de la colonne
+func typed(field *schema.Field, raw string) (any, error) {
+	value := reflect.New(field.FieldType)
+	if err := json.Unmarshal([]byte(raw), value.Interface()); err != nil {
+		if err := json.Unmarshal([]byte(strconv.Quote(raw)), value.Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	return value.Elem().Interface(), nil
+}
+
+// encodeCursor encode la valeur de tri et l'identifiant du dernier enregistrement d'une page
+func encodeCursor(value, id any) (string, error) {
+	raw, err := json.Marshal([]any{value, id})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// splitCursor décode un curseur en sa valeur de tri brute et son identifiant
+func splitCursor(cursor string) (json.RawMessage, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var parts []json.RawMessage
+	if err := json.Unmarshal(raw, &parts); err != nil || len(parts) != 2 {
+		return nil, "", errors.ErrListInvalidCursor
+	}
+
+	var id string
+	if err := json.Unmarshal(parts[1], &id); err != nil {
+		return nil, "", err
+	}
+
+	return parts[0], id, nil
+}
+
+// decodeCursor décode un curseur dans le type de la colonne triée
+func decodeCursor(cursor string, field *schema.Field) (any, string, error) {
+	raw, id, err := splitCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	value := reflect.New(field.FieldType)
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return nil, "", err
+	}
+
+	return value.Elem().Interface(), id, nil
+}
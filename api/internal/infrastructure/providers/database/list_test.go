@@ -0,0 +1,202 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type ListModel struct {
+	ID        string `gorm:"primaryKey"`
+	CreatedAt time.Time
+	ClaimedAt *time.Time
+	Status    string
+	Online    bool
+}
+
+func setupListDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&ListModel{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	// Les enregistrements partagent deux à deux la même date pour vérifier le départage par identifiant
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		model := &ListModel{
+			ID:        fmt.Sprintf("id-%02d", i),
+			CreatedAt: start.Add(time.Duration(i/2) * time.Hour),
+			Status:    []string{"issued", "claimed"}[i%2],
+			Online:    i < 3,
+		}
+
+		// Un enregistrement sur trois n'a pas de date de réclamation
+		if i%3 != 0 {
+			claimed := start.Add(time.Duration(i/4) * time.Hour)
+			model.ClaimedAt = &claimed
+		}
+
+		db.Create(model)
+	}
+
+	return db
+}
+
+func TestNewList(t *testing.T) {
+	list := NewList(map[string]string{
+		"page":           "2",
+		"limit":          "5",
+		"sort":           "-status,created_at",
+		"filter[status]": "claimed",
+		"other":          "ignored",
+	})
+
+	assert.Equal(t, "2", *list.Page)
+	assert.Equal(t, "5", *list.Limit)
+	assert.Equal(t, "-status,created_at", *list.Sort)
+	assert.Nil(t, list.Cursor)
+	assert.Equal(t, map[string]string{"status": "claimed"}, list.Filters)
+
+	assert.Nil(t, list.Check("status", "created_at"))
+	assert.Equal(t, 2, list.page)
+	assert.Equal(t, 5, list.limit)
+	assert.Equal(t, []sorting{{"status", true}, {"created_at", false}}, list.sorting)
+}
+
+func TestListCheck(t *testing.T) {
+	tests := map[string]map[string]string{
+		"page":           {"page": "0"},
+		"limit":          {"limit": "1000"},
+		"sort":           {"sort": "password"},
+		"filter[secret]": {"filter[secret]": "1"},
+		"cursor":         {"cursor": "not a cursor"},
+	}
+
+	for key, values := range tests {
+		t.Run(key, func(t *testing.T) {
+			err := NewList(values).Check("status", "created_at")
+			if assert.NotNil(t, err) {
+				assert.Equal(t, 400, err.Code())
+				assert.Contains(t, err.(errors.Errors), key)
+			}
+		})
+	}
+
+	t.Run("cursor and page", func(t *testing.T) {
+		cursor, _ := encodeCursor("claimed", "id-01")
+		err := NewList(map[string]string{"cursor": cursor, "page": "2"}).Check("status")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	db := setupListDB(t)
+	columns := []string{"status", "created_at", "claimed_at", "online"}
+
+	t.Run("Should read a page by offset", func(t *testing.T) {
+		list := NewList(map[string]string{"page": "2", "limit": "4"})
+		assert.Nil(t, list.Check(columns...))
+
+		page, err := Paginate[*ListModel](db.Model(&ListModel{}), list)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(10), page.Total)
+		assert.Equal(t, 2, page.Page)
+		assert.Equal(t, 3, page.Pages)
+		assert.Len(t, page.Items, 4)
+		assert.NotNil(t, page.Next)
+
+		// Le tri par défaut va du plus récent au plus ancien, l'identifiant départage
+		assert.Equal(t, "id-05", page.Items[0].ID)
+		assert.Equal(t, "id-02", page.Items[3].ID)
+	})
+
+	t.Run("Should walk every record by cursor", func(t *testing.T) {
+		seen := []string{}
+		values := map[string]string{"limit": "3", "sort": "created_at"}
+
+		for range 10 {
+			list := NewList(values)
+			assert.Nil(t, list.Check(columns...))
+
+			page, err := Paginate[*ListModel](db.Model(&ListModel{}), list)
+			assert.Nil(t, err)
+			for _, item := range page.Items {
+				seen = append(seen, item.ID)
+			}
+
+			if page.Next == nil {
+				break
+			}
+
+			values = map[string]string{"limit": "3", "sort": "created_at", "cursor": *page.Next}
+		}
+
+		assert.Equal(t, []string{"id-00", "id-01", "id-02", "id-03", "id-04", "id-05", "id-06", "id-07", "id-08", "id-09"}, seen)
+	})
+
+	t.Run("Should walk every record by cursor on a column with empty values", func(t *testing.T) {
+		walk := func(sort string) []string {
+			seen := []string{}
+			values := map[string]string{"limit": "3", "sort": sort}
+
+			for range 10 {
+				list := NewList(values)
+				assert.Nil(t, list.Check(columns...))
+
+				page, err := Paginate[*ListModel](db.Model(&ListModel{}), list)
+				assert.Nil(t, err)
+				for _, item := range page.Items {
+					seen = append(seen, item.ID)
+				}
+
+				if page.Next == nil {
+					break
+				}
+
+				values = map[string]string{"limit": "3", "sort": sort, "cursor": *page.Next}
+			}
+
+			return seen
+		}
+
+		// Les enregistrements vides viennent après les autres dans l'ordre croissant et avant dans l'ordre décroissant
+		assert.Equal(t, []string{"id-01", "id-02", "id-04", "id-05", "id-07", "id-08", "id-00", "id-03", "id-06", "id-09"}, walk("claimed_at"))
+		assert.Equal(t, []string{"id-09", "id-06", "id-03", "id-00", "id-08", "id-07", "id-05", "id-04", "id-02", "id-01"}, walk("-claimed_at"))
+	})
+
+	t.Run("Should filter with the type of the column", func(t *testing.T) {
+		list := NewList(map[string]string{"filter[online]": "true", "filter[status]": "claimed"})
+		assert.Nil(t, list.Check(columns...))
+
+		page, err := Paginate[*ListModel](db.Model(&ListModel{}), list)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), page.Total)
+		assert.Equal(t, "id-01", page.Items[0].ID)
+		assert.Nil(t, page.Next)
+	})
+
+	t.Run("Should ignore the filters of a list not checked", func(t *testing.T) {
+		list := NewList(map[string]string{"filter[status]": "claimed"})
+
+		page, err := Paginate[*ListModel](db.Model(&ListModel{}), list)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(10), page.Total)
+	})
+
+	t.Run("Should refuse a whitelisted column missing from the model", func(t *testing.T) {
+		list := NewList(map[string]string{"sort": "missing"})
+		assert.Nil(t, list.Check("missing"))
+
+		_, err := Paginate[*ListModel](db.Model(&ListModel{}), list)
+		assert.Equal(t, errors.ErrListUnknownField, err)
+	})
+}
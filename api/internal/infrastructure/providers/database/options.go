@@ -1,17 +1,78 @@
 package database
 
-import "gorm.io/gorm"
+import (
+	"reflect"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 // Option représente une fonction de configuration pour la requête GORM
 type Option func(*gorm.DB) *gorm.DB
 
 // GroupBy retourne une Option qui ajoute une clause GROUP BY
+// La colonne est ajoutée à la sélection, plusieurs regroupements et agrégats peuvent se cumuler
 func GroupBy(column string) Option {
 	return func(db *gorm.DB) *gorm.DB {
-		return db.Select(column).Group(column)
+		return selection(db, column).Group(column)
+	}
+}
+
+// GroupByAs retourne une Option qui regroupe par une expression nommée, par exemple DATE(created_at)
+func GroupByAs(expression, alias string) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return selection(db, expression+" AS "+alias).Group(alias)
+	}
+}
+
+// GroupByDay retourne une Option qui regroupe par le jour UTC d'une colonne de date, nommé par l'alias
+func GroupByDay(column, alias string) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return GroupByAs(day(db, column), alias)(db)
+	}
+}
+
+// WhereDay retourne une Option qui compare le jour UTC d'une colonne de date à un jour au format 2006-01-02
+// Le jour est calculé comme pour GroupByDay, les bornes et les regroupements suivent donc le même fuseau.
+func WhereDay(column, operator, date string) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(day(db, column)+" "+operator+" ?", date)
+	}
+}
+
+// Aggregate retourne une Option qui ajoute une expression nommée à la sélection
+func Aggregate(expression, alias string) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return selection(db, expression+" AS "+alias)
 	}
 }
 
+// Count retourne une Option qui compte les enregistrements de chaque groupe
+func Count(alias string) Option {
+	return Aggregate("COUNT(*)", alias)
+}
+
+// Sum retourne une Option qui additionne une colonne dans chaque groupe, zéro si elle est toujours vide
+func Sum(column, alias string) Option {
+	return Aggregate("COALESCE(SUM("+column+"), 0)", alias)
+}
+
+// day retourne l'expression du jour UTC d'une colonne de date
+// PostgreSQL convertit les dates dans le fuseau de la session, SQLite et MySQL les lisent en UTC.
+func day(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == PostgreSQL {
+		return "DATE(" + column + " AT TIME ZONE 'UTC')"
+	}
+
+	return "DATE(" + column + ")"
+}
+
+// selection ajoute une colonne aux colonnes déjà sélectionnées
+func selection(db *gorm.DB, column string) *gorm.DB {
+	return db.Select(append(slices.Clone(db.Statement.Selects), column))
+}
+
 // Where retourne une Option qui ajoute une clause WHERE
 func Where(query interface{}, args ...interface{}) Option {
 	return func(db *gorm.DB) *gorm.DB {
@@ -32,3 +93,93 @@ func Order(order string) Option {
 		return db.Order(order)
 	}
 }
+
+// Offset retourne une Option qui ajoute une clause OFFSET
+func Offset(offset int) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Offset(offset)
+	}
+}
+
+// Sort retourne une Option qui trie sur une colonne, le nom est échappé par le dialecte
+func Sort(column string, desc bool) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc})
+	}
+}
+
+// SortNullable retourne une Option qui trie sur une colonne pouvant être vide
+// Les valeurs vides sont placées après les autres dans l'ordre croissant et avant dans l'ordre décroissant, quel que soit le dialecte.
+func SortNullable(column string, desc bool) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		empty := clause.Column{Name: db.Statement.Quote(clause.Column{Name: column}) + " IS NULL", Raw: true}
+
+		return db.Order(clause.OrderByColumn{Column: empty, Desc: desc}).Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc})
+	}
+}
+
+// Filter retourne une Option qui ajoute une égalité sur une colonne, le nom est échappé par le dialecte
+func Filter(column string, value any) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Eq{Column: clause.Column{Name: column}, Value: value})
+	}
+}
+
+// Cursor retourne une Option qui reprend une liste triée après le dernier enregistrement lu
+// L'identifiant départage les enregistrements qui ont la même valeur de tri.
+func Cursor(column string, value any, id any, desc bool) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(following(column, value, id, desc))
+	}
+}
+
+// CursorNullable retourne une Option qui reprend une liste triée par SortNullable après le dernier enregistrement lu
+// Une valeur vide n'est jamais comparée, les enregistrements vides sont repris avant ou après les autres selon le sens du tri.
+func CursorNullable(column string, value any, id any, desc bool) Option {
+	empty := clause.Eq{Column: clause.Column{Name: column}, Value: nil}
+	filled := clause.Neq{Column: clause.Column{Name: column}, Value: nil}
+
+	return func(db *gorm.DB) *gorm.DB {
+		if reflected := reflect.ValueOf(value); value == nil || reflected.Kind() == reflect.Pointer && reflected.IsNil() {
+			if desc {
+				return db.Where(clause.Or(clause.And(empty, following("id", id, nil, desc)), filled))
+			}
+
+			return db.Where(clause.And(empty, following("id", id, nil, desc)))
+		}
+
+		// Dans l'ordre croissant les enregistrements vides suivent toutes les valeurs
+		if !desc {
+			return db.Where(clause.Or(following(column, value, id, desc), empty))
+		}
+
+		return db.Where(following(column, value, id, desc))
+	}
+}
+
+// following retourne la condition des enregistrements qui suivent une valeur de tri, départagés par l'identifiant s'il est donné
+func following(column string, value any, id any, desc bool) clause.Expression {
+	after := func(column string, value any) clause.Expression {
+		if desc {
+			return clause.Lt{Column: clause.Column{Name: column}, Value: value}
+		}
+
+		return clause.Gt{Column: clause.Column{Name: column}, Value: value}
+	}
+
+	if id == nil {
+		return after(column, value)
+	}
+
+	return clause.Or(
+		after(column, value),
+		clause.And(clause.Eq{Column: clause.Column{Name: column}, Value: value}, after("id", id)),
+	)
+}
+
+// Preload retourne une Option qui charge une association
+func Preload(association string, args ...interface{}) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload(association, args...)
+	}
+}
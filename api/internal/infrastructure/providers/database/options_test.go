@@ -2,6 +2,7 @@ package database
 
 import (
 	"testing"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -72,6 +73,101 @@ func TestGroupBy(t *testing.T) {
 	}
 }
 
+func TestAggregate(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	var results []struct {
+		Group string
+		Count int
+		Total int
+	}
+
+	query := db.Table("test_models")
+	for _, option := range []Option{GroupBy("`group`"), Count("count"), Sum("age", "total"), Order("`group`")} {
+		query = option(query)
+	}
+
+	if err := query.Scan(&results).Error; err != nil {
+		t.Fatalf("Failed to execute Aggregate: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(results))
+	}
+
+	// Group A: Alice (30) et Charlie (35)
+	if results[0].Group != "A" || results[0].Count != 2 || results[0].Total != 65 {
+		t.Errorf("Unexpected aggregate for group A: %+v", results[0])
+	}
+}
+
+func TestGroupByAs(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	var results []struct {
+		Bucket bool
+		Count  int
+	}
+
+	query := db.Table("test_models")
+	for _, option := range []Option{GroupByAs("age >= 35", "bucket"), Count("count"), Order("bucket")} {
+		query = option(query)
+	}
+
+	if err := query.Scan(&results).Error; err != nil {
+		t.Fatalf("Failed to execute GroupByAs: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Count != 2 || results[1].Count != 2 {
+		t.Errorf("Expected two buckets of two records, got %+v", results)
+	}
+}
+
+func TestGroupByDay(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+
+	type TestEvent struct {
+		ID uint
+		At time.Time
+	}
+
+	if err := db.AutoMigrate(&TestEvent{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	// Le 18 à 1h à Paris est encore le 17 en UTC
+	paris := time.FixedZone("Europe/Paris", 2*60*60)
+	db.Create(&TestEvent{ID: 1, At: time.Date(2026, 10, 18, 1, 0, 0, 0, paris)})
+	db.Create(&TestEvent{ID: 2, At: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)})
+
+	var results []struct {
+		Bucket string
+		Count  int
+	}
+
+	query := db.Table("test_events")
+	for _, option := range []Option{GroupByDay("at", "bucket"), Count("count"), WhereDay("at", ">=", "2026-10-17"), WhereDay("at", "<=", "2026-10-17")} {
+		query = option(query)
+	}
+
+	if err := query.Scan(&results).Error; err != nil {
+		t.Fatalf("Failed to execute GroupByDay: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Bucket != "2026-10-17" || results[0].Count != 1 {
+		t.Errorf("Expected the first event on the 17th, got %+v", results)
+	}
+}
+
 func TestWhere(t *testing.T) {
 	db, err := setupTestDB()
 	if err != nil {
@@ -127,3 +223,129 @@ func TestOrder(t *testing.T) {
 		t.Errorf("Expected first result to be David (age 40), got age %d", results[0].Age)
 	}
 }
+
+func TestPreload(t *testing.T) {
+	type Owner struct {
+		ID   uint
+		Name string
+	}
+
+	type Pet struct {
+		ID      uint
+		OwnerID uint
+		Owner   *Owner
+	}
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&Owner{}, &Pet{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	db.Create(&Owner{ID: 1, Name: "Alice"})
+	db.Create(&Pet{ID: 1, OwnerID: 1})
+
+	var pet Pet
+	query := Preload("Owner")(db).First(&pet)
+
+	if query.Error != nil {
+		t.Fatalf("Failed to execute Preload: %v", query.Error)
+	}
+
+	if pet.Owner == nil || pet.Owner.Name != "Alice" {
+		t.Errorf("Expected the owner to be preloaded, got %v", pet.Owner)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	var results []TestModel
+	query := Offset(1)(Limit(2)(Order("id")(db))).Find(&results)
+
+	if query.Error != nil {
+		t.Fatalf("Failed to execute Offset: %v", query.Error)
+	}
+
+	if len(results) != 2 || results[0].Name != "Bob" {
+		t.Errorf("Expected Bob and Charlie, got %v", results)
+	}
+}
+
+func TestSort(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	var results []TestModel
+	query := Sort("age", true)(db).Find(&results)
+
+	if query.Error != nil {
+		t.Fatalf("Failed to execute Sort: %v", query.Error)
+	}
+
+	if len(results) > 0 && results[0].Age != 40 {
+		t.Errorf("Expected first result to be David (age 40), got age %d", results[0].Age)
+	}
+
+	// Le nom de colonne est échappé, il ne peut pas porter d'expression SQL
+	query = Sort("age; DROP TABLE test_models", false)(db).Find(&results)
+	if query.Error == nil {
+		t.Errorf("Expected an unknown column error")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	var results []TestModel
+	query := Filter("group", "A")(db).Find(&results)
+
+	if query.Error != nil {
+		t.Fatalf("Failed to execute Filter: %v", query.Error)
+	}
+
+	if len(results) != 2 {
+		t.Errorf("Expected 2 results, got %d", len(results))
+	}
+}
+
+func TestCursor(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	db.Create(&TestModel{ID: 5, Name: "Eve", Age: 30, Group: "B"})
+
+	var results []TestModel
+	query := Cursor("age", 30, 1, false)(Order("age, id")(db)).Find(&results)
+
+	if query.Error != nil {
+		t.Fatalf("Failed to execute Cursor: %v", query.Error)
+	}
+
+	// Eve a le même âge qu'Alice mais un identifiant plus grand
+	if len(results) != 3 || results[0].Name != "Eve" {
+		t.Errorf("Expected Eve, Charlie and David, got %v", results)
+	}
+
+	query = Cursor("age", 30, 5, true)(Order("age DESC, id DESC")(db)).Find(&results)
+	if query.Error != nil {
+		t.Fatalf("Failed to execute Cursor: %v", query.Error)
+	}
+
+	if len(results) != 2 || results[0].Name != "Alice" {
+		t.Errorf("Expected Alice and Bob, got %v", results)
+	}
+}
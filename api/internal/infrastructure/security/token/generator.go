@@ -0,0 +1,240 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+const (
+	// Numeric is the alphabet of the decimal codes, Luhn mod 10 is then the classic Luhn algorithm
+	Numeric = "0123456789"
+	// Alphanumeric is the alphabet of the mixed codes, without the letters I and O mistaken for digits
+	Alphanumeric = "0123456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	// MaxLength is the size of the token column of the tickets, signature and check character included
+	MaxLength = 16
+)
+
+// Generator produces random codes ending with a Luhn mod N check character
+// When a secret is set, the characters before the check character hold a truncated HMAC
+// of the random part, so that a code can be authenticated without any lookup.
+type Generator struct {
+	Length    int    `yaml:"length"`
+	Alphabet  string `yaml:"alphabet"`
+	Secret    string `yaml:"secret"`
+	Signature int    `yaml:"signature"`
+}
+
+var tickets = NewGenerator(12, Numeric)
+
+// pairings generates the one-time codes typed on a caisse device to pair it
+var pairings = NewGenerator(8, Alphanumeric)
+
+// NewGenerator creates a generator of codes without signature
+//
+// Parameters:
+// - length: int the length of the codes, check character included
+// - alphabet: string the characters used by the codes
+//
+// Returns:
+// - *Generator: the generator
+func NewGenerator(length int, alphabet string) *Generator {
+	return &Generator{
+		Length:   length,
+		Alphabet: alphabet,
+	}
+}
+
+// Configure sets the generator of the ticket codes
+// A nil generator keeps 12 digits codes without signature, missing fields take the same defaults.
+//
+// Parameters:
+// - g: *Generator the generator read from the configuration
+//
+// Returns:
+// - error: an error if the generator cannot produce valid codes
+func Configure(g *Generator) error {
+	if g == nil {
+		tickets = NewGenerator(12, Numeric)
+		return nil
+	}
+
+	if g.Length == 0 {
+		g.Length = 12
+	}
+
+	if g.Alphabet == "" {
+		g.Alphabet = Numeric
+	}
+
+	if err := g.Check(); err != nil {
+		return err
+	}
+
+	tickets = g
+
+	return nil
+}
+
+// Tickets returns the generator of the ticket codes
+//
+// Returns:
+// - *Generator: the configured generator
+func Tickets() *Generator {
+	return tickets
+}
+
+// Pairings returns the generator of the pairing codes of the caisse devices
+//
+// Returns:
+// - *Generator: the generator of 8 alphanumeric characters
+func Pairings() *Generator {
+	return pairings
+}
+
+// Check verifies the generator can produce valid codes
+//
+// Returns:
+// - error: an error describing the first invalid setting
+func (g *Generator) Check() error {
+	size := len(g.Alphabet)
+	if size < 2 {
+		return fmt.Errorf("alphabet must have at least 2 characters")
+	}
+
+	seen := map[rune]bool{}
+	for _, c := range g.Alphabet {
+		if c > 127 || seen[c] {
+			return fmt.Errorf("alphabet must be made of distinct ascii characters")
+		}
+
+		seen[c] = true
+	}
+
+	if g.Signature < 0 {
+		return fmt.Errorf("signature cannot be negative")
+	}
+
+	if g.Signature > 0 && g.Secret == "" {
+		return fmt.Errorf("signature requires a secret")
+	}
+
+	// The signature is read from the first 64 bits of the HMAC
+	if float64(g.Signature)*math.Log2(float64(size)) > 64 {
+		return fmt.Errorf("signature of %d characters exceeds 64 bits", g.Signature)
+	}
+
+	if g.Length > MaxLength {
+		return fmt.Errorf("length %d exceeds %d characters", g.Length, MaxLength)
+	}
+
+	if g.Length-g.Signature < 2 {
+		return fmt.Errorf("length %d leaves no random character", g.Length)
+	}
+
+	return nil
+}
+
+// Generate draws a new code from crypto/rand
+//
+// Returns:
+// - Luhn: the code, check character included
+// - error: an error if the system random source fails
+func (g *Generator) Generate() (Luhn, error) {
+	size := big.NewInt(int64(len(g.Alphabet)))
+
+	var body strings.Builder
+	for i := 0; i < g.Length-g.Signature-1; i++ {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+
+		body.WriteByte(g.Alphabet[n.Int64()])
+	}
+
+	body.WriteString(g.sign(body.String()))
+
+	return Luhn(body.String() + string(g.checkCharacter(body.String()))), nil
+}
+
+// Validate verifies the length, the alphabet, the check character and the signature of a code
+//
+// Parameters:
+// - code: Luhn the code to verify
+//
+// Returns:
+// - errors.ErrorInterface: an error if the code was not produced by the generator
+func (g *Generator) Validate(code Luhn) errors.ErrorInterface {
+	value := code.String()
+
+	for _, c := range value {
+		if !strings.ContainsRune(g.Alphabet, c) {
+			if g.Alphabet == Numeric {
+				return errors.ErrValueIsNotNumber
+			}
+
+			return errors.ErrValueIsNotInAlphabet
+		}
+	}
+
+	if len(value) != g.Length {
+		return errors.ErrValueIsNotLuhn
+	}
+
+	body := value[:len(value)-1]
+	if g.checkCharacter(body) != value[len(value)-1] {
+		return errors.ErrValueIsNotLuhn
+	}
+
+	random := body[:len(body)-g.Signature]
+	if !hmac.Equal([]byte(g.sign(random)), []byte(body[len(random):])) {
+		return errors.ErrValueIsForged
+	}
+
+	return nil
+}
+
+// checkCharacter computes the Luhn mod N check character of a body
+// Starting from the right, every other character has its index doubled and the digits of the
+// result summed in base N; the check character brings the total to a multiple of N.
+func (g *Generator) checkCharacter(body string) byte {
+	n := len(g.Alphabet)
+	factor, sum := 2, 0
+
+	for i := len(body) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(g.Alphabet, body[i])
+		sum += addend/n + addend%n
+
+		factor = 3 - factor
+	}
+
+	return g.Alphabet[(n-sum%n)%n]
+}
+
+// sign encodes the first 64 bits of the HMAC-SHA256 of a random part with the alphabet
+func (g *Generator) sign(random string) string {
+	if g.Signature == 0 {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, []byte(g.Secret))
+	mac.Write([]byte(random))
+	value := binary.BigEndian.Uint64(mac.Sum(nil))
+
+	n := uint64(len(g.Alphabet))
+	signature := make([]byte, g.Signature)
+	for i := range signature {
+		signature[i] = g.Alphabet[value%n]
+		value /= n
+	}
+
+	return string(signature)
+}
@@ -0,0 +1,178 @@
+package token_test
+
+import (
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	t.Run("numeric codes are classic Luhn numbers", func(t *testing.T) {
+		g := token.NewGenerator(12, token.Numeric)
+
+		for range 100 {
+			code, err := g.Generate()
+			assert.NoError(t, err)
+			assert.Len(t, code, 12)
+			assert.Nil(t, code.Validate())
+			assert.Nil(t, g.Validate(code))
+		}
+	})
+
+	t.Run("every digit is drawn", func(t *testing.T) {
+		g := token.NewGenerator(12, token.Numeric)
+		seen := map[rune]bool{}
+
+		for range 200 {
+			code, _ := g.Generate()
+			for _, c := range code.String()[:11] {
+				seen[c] = true
+			}
+		}
+
+		assert.Len(t, seen, 10)
+	})
+
+	t.Run("alphanumeric codes use the alphabet", func(t *testing.T) {
+		g := token.NewGenerator(10, token.Alphanumeric)
+
+		for range 100 {
+			code, err := g.Generate()
+			assert.NoError(t, err)
+			assert.Len(t, code, 10)
+			assert.NotContains(t, code.String(), "O")
+			assert.Nil(t, g.Validate(code))
+		}
+	})
+
+	t.Run("codes are not repeated", func(t *testing.T) {
+		g := token.NewGenerator(12, token.Alphanumeric)
+		seen := map[token.Luhn]bool{}
+
+		for range 1000 {
+			code, _ := g.Generate()
+			assert.False(t, seen[code])
+			seen[code] = true
+		}
+	})
+}
+
+func TestGenerator_Validate(t *testing.T) {
+	g := token.NewGenerator(8, token.Alphanumeric)
+
+	t.Run("Luhn mod 10 matches the classic algorithm", func(t *testing.T) {
+		assert.Nil(t, token.NewGenerator(11, token.Numeric).Validate("79927398713"))
+		assert.Equal(t, errors.ErrValueIsNotLuhn, token.NewGenerator(11, token.Numeric).Validate("79927398710"))
+		assert.Equal(t, errors.ErrValueIsNotNumber, token.NewGenerator(11, token.Numeric).Validate("7992739871A"))
+	})
+
+	t.Run("a single substitution is detected", func(t *testing.T) {
+		code, _ := g.Generate()
+		value := []byte(code.String())
+
+		for i := range value {
+			for _, c := range []byte(token.Alphanumeric) {
+				if c == code.String()[i] {
+					continue
+				}
+
+				altered := append([]byte{}, value...)
+				altered[i] = c
+				assert.Equal(t, errors.ErrValueIsNotLuhn, g.Validate(token.Luhn(altered)))
+			}
+		}
+	})
+
+	t.Run("an adjacent transposition is detected", func(t *testing.T) {
+		code, _ := g.Generate()
+		value := []byte(code.String())
+
+		for i := 0; i < len(value)-1; i++ {
+			// Like 09 and 90 in base 10, swapping the first and last characters of the alphabet goes unnoticed
+			if pair := string(value[i : i+2]); value[i] == value[i+1] || pair == "0Z" || pair == "Z0" {
+				continue
+			}
+
+			altered := append([]byte{}, value...)
+			altered[i], altered[i+1] = altered[i+1], altered[i]
+			assert.Equal(t, errors.ErrValueIsNotLuhn, g.Validate(token.Luhn(altered)))
+		}
+	})
+
+	t.Run("foreign characters and lengths are refused", func(t *testing.T) {
+		assert.Equal(t, errors.ErrValueIsNotInAlphabet, g.Validate("ABCDEFGO"))
+		assert.Equal(t, errors.ErrValueIsNotLuhn, g.Validate("ABC"))
+		assert.Equal(t, errors.ErrValueIsNotLuhn, g.Validate(""))
+	})
+}
+
+func TestGenerator_Signature(t *testing.T) {
+	signed := &token.Generator{Length: 12, Alphabet: token.Alphanumeric, Secret: "secret", Signature: 4}
+
+	t.Run("signed codes are authenticated", func(t *testing.T) {
+		for range 100 {
+			code, err := signed.Generate()
+			assert.NoError(t, err)
+			assert.Len(t, code, 12)
+			assert.Nil(t, signed.Validate(code))
+		}
+	})
+
+	t.Run("a code with a valid check character but no signature is forged", func(t *testing.T) {
+		unsigned := token.NewGenerator(12, token.Alphanumeric)
+		forged := 0
+
+		for range 100 {
+			code, _ := unsigned.Generate()
+			if signed.Validate(code) == errors.ErrValueIsForged {
+				forged++
+			}
+		}
+
+		assert.GreaterOrEqual(t, forged, 99)
+	})
+
+	t.Run("another secret does not authenticate the codes", func(t *testing.T) {
+		other := &token.Generator{Length: 12, Alphabet: token.Alphanumeric, Secret: "other", Signature: 4}
+		code, _ := signed.Generate()
+
+		assert.Equal(t, errors.ErrValueIsForged, other.Validate(code))
+	})
+}
+
+func TestGenerator_Check(t *testing.T) {
+	cases := map[string]*token.Generator{
+		"short alphabet":      {Length: 12, Alphabet: "A"},
+		"duplicated alphabet": {Length: 12, Alphabet: "AAB"},
+		"missing secret":      {Length: 12, Alphabet: token.Numeric, Signature: 2},
+		"negative signature":  {Length: 12, Alphabet: token.Numeric, Signature: -1},
+		"oversized signature": {Length: 32, Alphabet: token.Numeric, Secret: "secret", Signature: 20},
+		"no random character": {Length: 4, Alphabet: token.Numeric, Secret: "secret", Signature: 3},
+		"oversized length":    {Length: token.MaxLength + 1, Alphabet: token.Numeric, Secret: "secret", Signature: 3},
+	}
+
+	for name, g := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, g.Check())
+		})
+	}
+
+	assert.NoError(t, (&token.Generator{Length: 12, Alphabet: token.Numeric, Secret: "secret", Signature: 4}).Check())
+	assert.NoError(t, (&token.Generator{Length: token.MaxLength, Alphabet: token.Numeric, Secret: "secret", Signature: 4}).Check())
+}
+
+func TestConfigure(t *testing.T) {
+	defer token.Configure(nil)
+
+	assert.NoError(t, token.Configure(&token.Generator{Alphabet: token.Alphanumeric}))
+	assert.Equal(t, 12, token.Tickets().Length)
+	assert.Equal(t, token.Alphanumeric, token.Tickets().Alphabet)
+
+	assert.Error(t, token.Configure(&token.Generator{Signature: 2}))
+	assert.Equal(t, token.Alphanumeric, token.Tickets().Alphabet)
+
+	assert.NoError(t, token.Configure(nil))
+	assert.Equal(t, token.Numeric, token.Tickets().Alphabet)
+}
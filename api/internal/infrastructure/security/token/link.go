@@ -0,0 +1,162 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// DefaultLinkExpire is the lifetime of a claim link when the configuration does not set one
+const DefaultLinkExpire = "720h"
+
+// Link signs the claim links of the tickets
+// A link is bound to the code of a ticket and to its expiry, the signature is an HMAC-SHA256 of both.
+type Link struct {
+	URL    string `yaml:"url"`
+	Secret string `yaml:"secret"`
+	Expire string `yaml:"expire"`
+}
+
+var links = &Link{Secret: randomSecret(), Expire: DefaultLinkExpire}
+
+// ConfigureLinks sets the signer of the claim links
+// A nil signer or a missing secret draws a random secret, the links are then lost on restart.
+//
+// Parameters:
+// - l: *Link the signer read from the configuration
+//
+// Returns:
+// - error: an error if the lifetime or the URL of the links is invalid
+func ConfigureLinks(l *Link) error {
+	if l == nil {
+		l = &Link{}
+	}
+
+	if l.Expire == "" {
+		l.Expire = DefaultLinkExpire
+	}
+
+	if l.Secret == "" {
+		l.Secret = randomSecret()
+	}
+
+	if err := l.Check(); err != nil {
+		return err
+	}
+
+	links = l
+
+	return nil
+}
+
+// Links returns the signer of the claim links
+//
+// Returns:
+// - *Link: the configured signer
+func Links() *Link {
+	return links
+}
+
+// Check verifies the lifetime and the URL of the links
+//
+// Returns:
+// - error: an error describing the first invalid setting
+func (l *Link) Check() error {
+	expire, err := time.ParseDuration(l.Expire)
+	if err != nil {
+		return err
+	}
+
+	if expire <= 0 {
+		return fmt.Errorf("expire must be positive")
+	}
+
+	if l.URL != "" {
+		if _, err := url.ParseRequestURI(l.URL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sign builds the claim link of a ticket
+// The link points to the claim endpoint of the ticket, under the configured URL.
+//
+// Parameters:
+// - code: string the printed code of the ticket
+// - now: time.Time the time the link is issued
+//
+// Returns:
+// - string: the signed link
+func (l *Link) Sign(code string, now time.Time) string {
+	expires := now.Add(l.expire()).Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", l.signature(code, expires))
+
+	return fmt.Sprintf("%s/game/ticket/%s/claim?%s", strings.TrimRight(l.URL, "/"), url.PathEscape(code), query.Encode())
+}
+
+// Verify checks a claim link was signed for a ticket and has not expired
+//
+// Parameters:
+// - code: string the printed code of the ticket
+// - expires: string the expiry of the link, in seconds since epoch
+// - signature: string the signature of the link
+// - now: time.Time the time the link is used
+//
+// Returns:
+// - errors.ErrorInterface: ErrLinkIsForged or ErrLinkIsExpired if the link cannot be used
+func (l *Link) Verify(code, expires, signature string, now time.Time) errors.ErrorInterface {
+	timestamp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return errors.ErrLinkIsForged
+	}
+
+	if !hmac.Equal([]byte(l.signature(code, timestamp)), []byte(signature)) {
+		return errors.ErrLinkIsForged
+	}
+
+	if now.Unix() > timestamp {
+		return errors.ErrLinkIsExpired
+	}
+
+	return nil
+}
+
+func (l *Link) expire() time.Duration {
+	expire, err := time.ParseDuration(l.Expire)
+	if err != nil || expire <= 0 {
+		expire, _ = time.ParseDuration(DefaultLinkExpire)
+	}
+
+	return expire
+}
+
+// signature encodes the HMAC-SHA256 of the code and the expiry of a link
+func (l *Link) signature(code string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(l.Secret))
+	fmt.Fprintf(mac, "%s.%d", code, expires)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func randomSecret() string {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(secret)
+}
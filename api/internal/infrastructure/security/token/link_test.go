@@ -0,0 +1,69 @@
+package token_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+)
+
+// parseLink retourne le code, l'expiration et la signature d'un lien de réclamation
+func parseLink(t *testing.T, link string) (string, string, string) {
+	t.Helper()
+
+	u, err := url.Parse(link)
+	assert.NoError(t, err)
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	assert.Equal(t, []string{"game", "ticket", parts[2], "claim"}, parts)
+
+	return parts[2], u.Query().Get("expires"), u.Query().Get("signature")
+}
+
+func TestLink(t *testing.T) {
+	signer := &token.Link{URL: "https://thetiptop.local/", Secret: "secret", Expire: "1h"}
+	now := time.Now()
+
+	link := signer.Sign("000000000001", now)
+	assert.True(t, strings.HasPrefix(link, "https://thetiptop.local/game/ticket/000000000001/claim?"))
+
+	code, expires, signature := parseLink(t, link)
+
+	t.Run("a signed link is valid until it expires", func(t *testing.T) {
+		assert.Nil(t, signer.Verify(code, expires, signature, now))
+		assert.Nil(t, signer.Verify(code, expires, signature, now.Add(time.Hour)))
+		assert.Equal(t, errors.ErrLinkIsExpired, signer.Verify(code, expires, signature, now.Add(time.Hour+time.Second)))
+	})
+
+	t.Run("a link is bound to its ticket and its expiry", func(t *testing.T) {
+		assert.Equal(t, errors.ErrLinkIsForged, signer.Verify("000000000002", expires, signature, now))
+		assert.Equal(t, errors.ErrLinkIsForged, signer.Verify(code, "99999999999", signature, now))
+		assert.Equal(t, errors.ErrLinkIsForged, signer.Verify(code, "never", signature, now))
+		assert.Equal(t, errors.ErrLinkIsForged, signer.Verify(code, expires, "", now))
+	})
+
+	t.Run("another secret does not verify the link", func(t *testing.T) {
+		other := &token.Link{Secret: "other", Expire: "1h"}
+		assert.Equal(t, errors.ErrLinkIsForged, other.Verify(code, expires, signature, now))
+	})
+}
+
+func TestConfigureLinks(t *testing.T) {
+	defer token.ConfigureLinks(nil)
+
+	assert.NoError(t, token.ConfigureLinks(nil))
+	assert.NotEmpty(t, token.Links().Secret)
+	assert.Equal(t, token.DefaultLinkExpire, token.Links().Expire)
+	assert.True(t, strings.HasPrefix(token.Links().Sign("000000000001", time.Now()), "/game/ticket/000000000001/claim?"))
+
+	assert.Error(t, token.ConfigureLinks(&token.Link{Expire: "soon"}))
+	assert.Error(t, token.ConfigureLinks(&token.Link{Expire: "-1h"}))
+	assert.Error(t, token.ConfigureLinks(&token.Link{URL: "not a url"}))
+
+	assert.NoError(t, token.ConfigureLinks(&token.Link{URL: "https://thetiptop.local", Secret: "secret"}))
+	assert.Equal(t, "secret", token.Links().Secret)
+}
@@ -2,10 +2,7 @@ package token
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
-	"strings"
-	"time"
 
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 )
@@ -77,18 +74,10 @@ func (l Luhn) Calculate() (string, string, error) {
 	return strconv.FormatInt(luhn, 10), fmt.Sprintf("%s%d", number, luhn), nil
 }
 
-// Generate génère un numéro valide Luhn de la longueur fournie.
-func Generate(length int) Luhn {
-	rnd := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
-
-	var s strings.Builder
-	for i := 0; i < length-1; i++ {
-		s.WriteString(strconv.Itoa(rnd.Intn(9)))
-	}
-
-	l := Luhn(s.String())
-	_, res, _ := l.Calculate() // ignorer l'erreur car cela sera toujours valide
-	return Luhn(res)
+// Generate génère un numéro valide Luhn de la longueur fournie à partir de crypto/rand.
+// Une erreur est retournée si la source aléatoire du système est indisponible.
+func Generate(length int) (Luhn, error) {
+	return NewGenerator(length, Numeric).Generate()
 }
 
 // calculateLuhnSum calcule la somme de Luhn pour un nombre donné avec une parité donnée.
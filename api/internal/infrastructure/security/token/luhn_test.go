@@ -81,7 +81,8 @@ func TestLuhn_PointerString(t *testing.T) {
 func TestGenerate(t *testing.T) {
 
 	t.Run("when the number is valid", func(t *testing.T) {
-		number := token.Generate(10)
+		number, err := token.Generate(10)
+		assert.NoError(t, err)
 		assert.Len(t, number, 10)
 	})
 }
@@ -12,8 +12,12 @@ type TYPE uint8 // Type de jeton
 const (
 	ACCESS  TYPE = 0 // Jeton d'accès
 	REFRESH TYPE = 1 // Jeton de rafraîchissement
+	DEVICE  TYPE = 2 // Jeton d'appareil de caisse
 )
 
+// ROLE_DEVICE est le rôle porté par tous les jetons d'appareil, quelles que soient leurs données
+const ROLE_DEVICE = "device"
+
 type Token struct {
 	ID     string         `json:"id"`
 	Exp    int64          `json:"exp"`
@@ -24,7 +28,11 @@ type Token struct {
 }
 
 func (t *Token) IsNotValid() bool {
-	return t.Type != ACCESS
+	return t.Type != ACCESS && t.Type != DEVICE
+}
+
+func (t *Token) IsDevice() bool {
+	return t.Type == DEVICE
 }
 
 func (t *Token) HasExpired() bool {
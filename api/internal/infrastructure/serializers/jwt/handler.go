@@ -42,6 +42,15 @@ func Parser(c *fiber.Ctx) error {
 		return c.Status(errors.ErrAuthFailed.Code()).JSON(errors.ErrAuthFailed)
 	}
 
+	// Un appareil de caisse n'a jamais d'autre rôle que le sien
+	if token.IsDevice() {
+		if token.Data == nil {
+			token.Data = map[string]any{}
+		}
+
+		token.Data["role"] = ROLE_DEVICE
+	}
+
 	c.Locals("token", token)
 
 	return c.Next()
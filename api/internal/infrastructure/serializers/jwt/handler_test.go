@@ -36,6 +36,10 @@ func start() error {
 		return c.SendString("Hello, Restricted!")
 	})
 
+	fbr.Get("/role", jwt.Auth, func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("token").(*jwt.Token).Data["role"].(string))
+	})
+
 	c := make(chan error, 1)
 
 	time.AfterFunc(1*time.Second, func() {
@@ -145,6 +149,15 @@ func TestParser(t *testing.T) {
 		assert.Equal(t, "Hello, Restricted!", string(content))
 	})
 
+	t.Run("TestRestrictedDeviceToken", func(t *testing.T) {
+		token, jwtErr := jwt.FromDevice("device", map[string]any{"role": "admin"})
+		assert.Nil(t, jwtErr)
+		content, status, err := request("GET", "http://localhost:3000/role", bearer+token, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, jwt.ROLE_DEVICE, string(content))
+	})
+
 	t.Run("TestRestrictedExpiredToken", func(t *testing.T) {
 		token, _, _ := jwt.FromID("hello", nil)
 		time.Sleep(5 * time.Second)
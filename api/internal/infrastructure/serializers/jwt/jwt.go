@@ -13,12 +13,14 @@ type JWT struct {
 	Secret   string        `yaml:"secret"`
 	Expire   int           `yaml:"expire"`
 	Refresh  int           `yaml:"refresh"`
+	Device   int           `yaml:"device"`
 	Duration time.Duration `yaml:"duration"`
 }
 
 var (
 	instance *JWT
 	duration time.Duration = time.Minute
+	device   int           = 365 * 24 * 60 // a year of the default duration
 )
 
 func New(t *JWT) error {
@@ -36,6 +38,7 @@ func New(t *JWT) error {
 			Secret:   pass,
 			Expire:   15,
 			Refresh:  30,
+			Device:   device,
 			Duration: duration,
 		}
 	}
@@ -52,6 +55,10 @@ func New(t *JWT) error {
 		instance.Refresh = 30
 	}
 
+	if instance.Device < 1 {
+		instance.Device = device
+	}
+
 	if instance.TZ == "" {
 		instance.TZ = location
 	}
@@ -108,6 +115,42 @@ func FromID(id string, data map[string]any) (string, string, errors.ErrorInterfa
 	return access, refresh, nil
 }
 
+// FromDevice signs the long-lived token of a paired caisse device
+// Device tokens are not refreshed, the device is paired again once its token expires.
+//
+// Parameters:
+// - id: string the ID of the device
+// - data: map[string]any the data carried by the token
+//
+// Returns:
+// - string: the device token
+// - errors.ErrorInterface: an error if the token cannot be signed
+func FromDevice(id string, data map[string]any) (string, errors.ErrorInterface) {
+	location, err := time.LoadLocation(instance.TZ)
+	if err != nil {
+		return "", errors.ErrAuthInvalidToken
+	}
+
+	now := time.Now().In(location)
+	_, offset := now.Zone()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Token{
+		ID:     id,
+		Exp:    now.Add(instance.Duration * time.Duration(instance.Device)).Unix(),
+		TZ:     location.String(),
+		Offset: offset,
+		Type:   DEVICE,
+		Data:   data,
+	}.Claims())
+
+	signed, err := token.SignedString([]byte(instance.Secret))
+	if err != nil {
+		return "", errors.ErrAuthInvalidToken
+	}
+
+	return signed, nil
+}
+
 func TokenToClaims(tokenString string) (*Token, errors.ErrorInterface) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -46,3 +46,20 @@ func TestFromID(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, claims)
 }
+
+func TestFromDevice(t *testing.T) {
+	err := jwt.New(nil)
+	assert.NoError(t, err)
+
+	token, err := jwt.FromDevice("device", map[string]any{"caisse_id": "caisse"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+
+	claims, err := jwt.TokenToClaims(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "device", claims.ID)
+	assert.True(t, claims.IsDevice())
+	assert.False(t, claims.IsNotValid())
+	assert.False(t, claims.HasExpired())
+	assert.Equal(t, "caisse", claims.Data["caisse_id"])
+}
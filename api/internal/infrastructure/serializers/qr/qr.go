@@ -0,0 +1,98 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	PNG = "png"
+	SVG = "svg"
+
+	// DefaultSize is the side of the image in pixels when none is given
+	DefaultSize = 256
+	// MaxSize bounds the side of the image, a PNG is drawn in memory
+	MaxSize = 2048
+)
+
+// Encode draws the QR code of a content
+//
+// Parameters:
+// - format: string PNG or SVG
+// - content: string the content of the QR code
+// - size: int the side of the image in pixels, DefaultSize if 0
+//
+// Returns:
+// - []byte: the image
+// - error: an error if the format or the size is invalid, or if the content is too long
+func Encode(format, content string, size int) ([]byte, error) {
+	if size == 0 {
+		size = DefaultSize
+	}
+
+	if size < 0 || size > MaxSize {
+		return nil, fmt.Errorf("size must be between 1 and %d", MaxSize)
+	}
+
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case PNG:
+		return code.PNG(size)
+	case SVG:
+		return svg(code.Bitmap(), size), nil
+	}
+
+	return nil, fmt.Errorf("unknown qr format %s", format)
+}
+
+// ContentType returns the MIME type of a format
+//
+// Parameters:
+// - format: string PNG or SVG
+//
+// Returns:
+// - string: the MIME type, application/octet-stream if the format is unknown
+func ContentType(format string) string {
+	switch format {
+	case PNG:
+		return "image/png"
+	case SVG:
+		return "image/svg+xml"
+	}
+
+	return "application/octet-stream"
+}
+
+// svg draws the modules of a QR code, quiet zone included, on a grid of one unit per module scaled to the size
+// The adjacent dark modules of a row are merged in a single path segment.
+func svg(bitmap [][]bool, size int) []byte {
+	var path bytes.Buffer
+
+	for row, modules := range bitmap {
+		for col := 0; col < len(modules); col++ {
+			if !modules[col] {
+				continue
+			}
+
+			start := col
+			for col+1 < len(modules) && modules[col+1] {
+				col++
+			}
+
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start, row, col-start+1, col-start+1)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, len(bitmap), len(bitmap))
+	out.WriteString(`<rect width="100%" height="100%" fill="#fff"/>`)
+	fmt.Fprintf(&out, `<path fill="#000" d="%s"/></svg>`, path.String())
+
+	return out.Bytes()
+}
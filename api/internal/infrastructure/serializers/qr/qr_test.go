@@ -0,0 +1,51 @@
+package qr_test
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	t.Run("png", func(t *testing.T) {
+		image, err := qr.Encode(qr.PNG, "https://thetiptop.local/game/ticket/000000000001/claim", 128)
+		assert.NoError(t, err)
+
+		decoded, err := png.Decode(bytes.NewReader(image))
+		assert.NoError(t, err)
+		assert.Equal(t, 128, decoded.Bounds().Dx())
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		image, err := qr.Encode(qr.SVG, "https://thetiptop.local/game/ticket/000000000001/claim", 0)
+		assert.NoError(t, err)
+
+		content := string(image)
+		assert.True(t, strings.HasPrefix(content, "<svg "))
+		assert.Contains(t, content, `width="256"`)
+		// Le motif de position commence après la zone de silence de 4 modules
+		assert.Contains(t, content, `d="M4 4h7v1h-7z`)
+		assert.True(t, strings.HasSuffix(content, "</svg>"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := qr.Encode("gif", "content", 0)
+		assert.Error(t, err)
+
+		_, err = qr.Encode(qr.PNG, "content", qr.MaxSize+1)
+		assert.Error(t, err)
+
+		_, err = qr.Encode(qr.PNG, "content", -1)
+		assert.Error(t, err)
+	})
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "image/png", qr.ContentType(qr.PNG))
+	assert.Equal(t, "image/svg+xml", qr.ContentType(qr.SVG))
+	assert.Equal(t, "application/octet-stream", qr.ContentType("gif"))
+}
@@ -0,0 +1,67 @@
+package sheet
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+// NewCSV creates a writer of one row per label, after a header row
+//
+// Parameters:
+// - w: io.Writer the destination of the sheet
+//
+// Returns:
+// - Writer: the writer of the sheet
+func NewCSV(w io.Writer) Writer {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(label *Label) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	return c.writer.Write([]string{label.Code, label.Store, label.QR})
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	c.writer.Flush()
+
+	return c.writer.Error()
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+
+	c.header = true
+
+	return c.writer.Write([]string{"code", "store", "qr"})
+}
+
+// WriteRows writes a table as CSV, the first row being its header
+//
+// Parameters:
+// - w: io.Writer the destination of the table
+// - rows: [][]string the rows of the table
+//
+// Returns:
+// - error: an error if the table cannot be written
+func WriteRows(w io.Writer, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
@@ -0,0 +1,253 @@
+package sheet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	// A4 portrait, in points
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 36.0
+	footer     = 20.0
+	columns    = 3
+	rows       = 4
+	qrSize     = 112.0
+	// Courier glyphs are 0.6 em wide, which makes centering the text straightforward
+	glyphWidth = 0.6
+
+	// The first objects are known in advance, pages are written after them
+	catalogObject = 1
+	pagesObject   = 2
+	fontObject    = 3
+)
+
+type pdfWriter struct {
+	out     *countingWriter
+	title   string
+	offsets []int64
+	pages   []int
+	labels  []*Label
+	started bool
+}
+
+// NewPDF creates a writer of A4 pages of labels with their QR code, ready to be cut
+// Each page is written as soon as it is full, only the position of the objects is kept
+// in memory until the sheet is closed.
+//
+// Parameters:
+// - w: io.Writer the destination of the sheet
+// - title: string the title printed at the bottom of each page
+//
+// Returns:
+// - Writer: the writer of the sheet
+func NewPDF(w io.Writer, title string) Writer {
+	return &pdfWriter{
+		out:     &countingWriter{w: w},
+		title:   title,
+		offsets: make([]int64, fontObject),
+	}
+}
+
+func (p *pdfWriter) Write(label *Label) error {
+	if err := p.start(); err != nil {
+		return err
+	}
+
+	p.labels = append(p.labels, label)
+	if len(p.labels) < columns*rows {
+		return nil
+	}
+
+	return p.writePage()
+}
+
+func (p *pdfWriter) Close() error {
+	if err := p.start(); err != nil {
+		return err
+	}
+
+	if len(p.labels) > 0 || len(p.pages) == 0 {
+		if err := p.writePage(); err != nil {
+			return err
+		}
+	}
+
+	kids := make([]string, len(p.pages))
+	for i, page := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+
+	if err := p.writeObject(pagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages))); err != nil {
+		return err
+	}
+
+	if err := p.writeObject(catalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObject)); err != nil {
+		return err
+	}
+
+	xref := p.out.n
+	var trailer bytes.Buffer
+	fmt.Fprintf(&trailer, "xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1)
+	for _, offset := range p.offsets {
+		fmt.Fprintf(&trailer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&trailer, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(p.offsets)+1, catalogObject, xref)
+
+	_, err := p.out.Write(trailer.Bytes())
+
+	return err
+}
+
+func (p *pdfWriter) start() error {
+	if p.started {
+		return nil
+	}
+
+	p.started = true
+
+	if _, err := p.out.Write([]byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")); err != nil {
+		return err
+	}
+
+	return p.writeObject(fontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+}
+
+// writePage draws the pending labels on a new page
+func (p *pdfWriter) writePage() error {
+	var content bytes.Buffer
+
+	cellWidth := (pageWidth - 2*margin) / columns
+	cellHeight := (pageHeight - 2*margin - footer) / rows
+
+	for i, label := range p.labels {
+		x := margin + float64(i%columns)*cellWidth
+		y := pageHeight - margin - float64(i/columns+1)*cellHeight
+
+		// Cutting guides
+		fmt.Fprintf(&content, "q 0.7 G 0.5 w [3 3] 0 d %.2f %.2f %.2f %.2f re S Q\n", x, y, cellWidth, cellHeight)
+
+		if err := drawQR(&content, label.QR, x+(cellWidth-qrSize)/2, y+cellHeight-qrSize-16); err != nil {
+			return err
+		}
+
+		drawText(&content, label.Code, 12, x+cellWidth/2, y+36)
+		drawText(&content, label.Store, 7, x+cellWidth/2, y+20)
+	}
+
+	drawText(&content, fmt.Sprintf("%s - %d", p.title, len(p.pages)+1), 8, pageWidth/2, margin/2)
+
+	if err := p.addPage(&content); err != nil {
+		return err
+	}
+
+	p.labels = p.labels[:0]
+
+	return nil
+}
+
+// addPage writes the content stream of a page and the page itself
+func (p *pdfWriter) addPage(content *bytes.Buffer) error {
+	number := len(p.offsets) + 1
+	if err := p.writeObject(number, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.Bytes())); err != nil {
+		return err
+	}
+
+	page := fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+		pagesObject, pageWidth, pageHeight, fontObject, number,
+	)
+
+	if err := p.writeObject(number+1, page); err != nil {
+		return err
+	}
+
+	p.pages = append(p.pages, number+1)
+
+	return nil
+}
+
+// writeObject writes an indirect object and records its position for the cross-reference table
+func (p *pdfWriter) writeObject(number int, body string) error {
+	for len(p.offsets) < number {
+		p.offsets = append(p.offsets, 0)
+	}
+
+	p.offsets[number-1] = p.out.n
+	_, err := fmt.Fprintf(p.out, "%d 0 obj\n%s\nendobj\n", number, body)
+
+	return err
+}
+
+// drawQR draws the dark modules of a QR code, merging the adjacent modules of a row in a single rectangle
+func drawQR(content *bytes.Buffer, value string, x, y float64) error {
+	if value == "" {
+		return nil
+	}
+
+	code, err := qrcode.New(value, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	code.DisableBorder = true
+	bitmap := code.Bitmap()
+	module := qrSize / float64(len(bitmap))
+
+	for row, modules := range bitmap {
+		top := y + qrSize - float64(row+1)*module
+		for col := 0; col < len(modules); col++ {
+			if !modules[col] {
+				continue
+			}
+
+			start := col
+			for col+1 < len(modules) && modules[col+1] {
+				col++
+			}
+
+			fmt.Fprintf(content, "%.2f %.2f %.2f %.2f re\n", x+float64(start)*module, top, float64(col-start+1)*module, module)
+		}
+	}
+
+	content.WriteString("f\n")
+
+	return nil
+}
+
+// drawText draws a line of text centered on x
+func drawText(content *bytes.Buffer, text string, size float64, x, y float64) {
+	if text == "" {
+		return
+	}
+
+	encoded, err := encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder()).String(text)
+	if err != nil {
+		encoded = text
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(encoded)
+	width := float64(len(encoded)) * glyphWidth * size
+
+	fmt.Fprintf(content, "BT /F1 %.0f Tf %.2f %.2f Td (%s) Tj ET\n", size, x-width/2, y, escaped)
+}
+
+// countingWriter keeps the number of bytes written, the positions of the PDF objects
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,67 @@
+package sheet
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	CSV = "csv"
+	PDF = "pdf"
+)
+
+// Label is a ticket printed on a sheet
+type Label struct {
+	Code  string
+	Store string
+	QR    string
+}
+
+// Writer writes the labels of a sheet one after the other
+// Labels are flushed as they come, Close must be called to complete the sheet.
+type Writer interface {
+	Write(label *Label) error
+	Close() error
+}
+
+// Render writes a whole sheet
+type Render func(w io.Writer) error
+
+// New creates the writer of a sheet
+//
+// Parameters:
+// - format: string CSV or PDF
+// - w: io.Writer the destination of the sheet
+// - title: string the title printed on each page of a PDF
+//
+// Returns:
+// - Writer: the writer of the sheet
+// - error: an error if the format is unknown
+func New(format string, w io.Writer, title string) (Writer, error) {
+	switch format {
+	case CSV:
+		return NewCSV(w), nil
+	case PDF:
+		return NewPDF(w, title), nil
+	}
+
+	return nil, fmt.Errorf("unknown sheet format %s", format)
+}
+
+// ContentType returns the MIME type of a format
+//
+// Parameters:
+// - format: string CSV or PDF
+//
+// Returns:
+// - string: the MIME type, application/octet-stream if the format is unknown
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case PDF:
+		return "application/pdf"
+	}
+
+	return "application/octet-stream"
+}
@@ -0,0 +1,164 @@
+package sheet_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
+	"github.com/stretchr/testify/assert"
+)
+
+func labels(count int) []*sheet.Label {
+	result := make([]*sheet.Label, count)
+	for i := range result {
+		code := fmt.Sprintf("%012d", i)
+		result[i] = &sheet.Label{Code: code, Store: "Boutique Élysée", QR: code}
+	}
+
+	return result
+}
+
+func TestNew(t *testing.T) {
+	for _, format := range []string{sheet.CSV, sheet.PDF} {
+		w, err := sheet.New(format, &bytes.Buffer{}, "tickets")
+		assert.NoError(t, err)
+		assert.NotNil(t, w)
+		assert.NotEqual(t, "application/octet-stream", sheet.ContentType(format))
+	}
+
+	w, err := sheet.New("xls", &bytes.Buffer{}, "tickets")
+	assert.Error(t, err)
+	assert.Nil(t, w)
+	assert.Equal(t, "application/octet-stream", sheet.ContentType("xls"))
+}
+
+func TestCSV(t *testing.T) {
+	t.Run("one row per label after the header", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		w := sheet.NewCSV(out)
+
+		for _, label := range labels(3) {
+			assert.NoError(t, w.Write(label))
+		}
+		assert.NoError(t, w.Close())
+
+		records, err := csv.NewReader(out).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, records, 4)
+		assert.Equal(t, []string{"code", "store", "qr"}, records[0])
+		assert.Equal(t, []string{"000000000002", "Boutique Élysée", "000000000002"}, records[3])
+	})
+
+	t.Run("an empty sheet keeps its header", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, sheet.NewCSV(out).Close())
+		assert.Equal(t, "code,store,qr\n", out.String())
+	})
+}
+
+func TestWriteRows(t *testing.T) {
+	out := &bytes.Buffer{}
+
+	assert.NoError(t, sheet.WriteRows(out, [][]string{
+		{"prize", "issued"},
+		{"Infuseur, thé", "2"},
+	}))
+	assert.Equal(t, "prize,issued\n\"Infuseur, thé\",2\n", out.String())
+}
+
+// checkPDF vérifie que chaque entrée de la table de références pointe sur son objet
+func checkPDF(t *testing.T, document []byte) {
+	t.Helper()
+
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(document, []byte("%%EOF\n")))
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(document)
+	if !assert.NotNil(t, startxref) {
+		return
+	}
+
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	assert.True(t, bytes.HasPrefix(document[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(document[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(document[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestPDF(t *testing.T) {
+	t.Run("labels are laid out on pages of 12", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		w := sheet.NewPDF(out, "Lot (1)")
+
+		for _, label := range labels(25) {
+			assert.NoError(t, w.Write(label))
+		}
+		assert.NoError(t, w.Close())
+
+		document := out.Bytes()
+		checkPDF(t, document)
+		assert.Contains(t, string(document), "/Count 3")
+		assert.Equal(t, 3, strings.Count(string(document), "/Type /Page "))
+		assert.Contains(t, string(document), `(Lot \(1\) - 3)`)
+		assert.Contains(t, string(document), "(000000000024)")
+		// Le nom de la boutique est encodé en WinAnsi
+		assert.Contains(t, string(document), "Boutique \xc9lys\xe9e")
+	})
+
+	t.Run("full pages are written before the sheet is closed", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		w := sheet.NewPDF(out, "tickets")
+
+		for _, label := range labels(11) {
+			assert.NoError(t, w.Write(label))
+		}
+		written := out.Len()
+
+		assert.NoError(t, w.Write(labels(1)[0]))
+		assert.Greater(t, out.Len(), written)
+		assert.NoError(t, w.Close())
+	})
+
+	t.Run("an empty sheet has a single page", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, sheet.NewPDF(out, "tickets").Close())
+
+		checkPDF(t, out.Bytes())
+		assert.Contains(t, out.String(), "/Count 1")
+	})
+}
+
+func TestWriteVoucher(t *testing.T) {
+	t.Run("a voucher is a single page with the prize, the code and the deadline", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, sheet.WriteVoucher(out, &sheet.Voucher{
+			Title:    "The Tip Top",
+			Prize:    "Coffret thé",
+			Code:     "000000000018",
+			Deadline: "31/12/2026 23:59",
+		}))
+
+		document := out.Bytes()
+		checkPDF(t, document)
+		assert.Contains(t, string(document), "/Count 1")
+		assert.Contains(t, string(document), "(000000000018)")
+		assert.Contains(t, string(document), "Coffret th\xe9")
+		assert.Contains(t, string(document), "31/12/2026 23:59")
+	})
+
+	t.Run("the deadline is optional", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, sheet.WriteVoucher(out, &sheet.Voucher{Title: "The Tip Top", Prize: "Coffret", Code: "000000000018"}))
+
+		checkPDF(t, out.Bytes())
+		assert.NotContains(t, out.String(), "retirer avant")
+	})
+}
@@ -0,0 +1,58 @@
+package sheet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// Voucher is the proof of a prize won, handed over at a caisse to collect it
+type Voucher struct {
+	Title    string
+	Prize    string
+	Code     string
+	Deadline string
+}
+
+// WriteVoucher writes a single page PDF of a voucher, the QR code carries the code of the ticket
+//
+// Parameters:
+// - w: io.Writer the destination of the voucher
+// - voucher: *Voucher the prize, the code and the redemption deadline
+//
+// Returns:
+// - error: an error if the voucher cannot be written
+func WriteVoucher(w io.Writer, voucher *Voucher) error {
+	p := NewPDF(w, voucher.Title).(*pdfWriter)
+	if err := p.start(); err != nil {
+		return err
+	}
+
+	var content bytes.Buffer
+	center := pageWidth / 2
+	top := pageHeight - margin
+
+	// Frame of the voucher
+	fmt.Fprintf(&content, "q 0.5 G 1 w %.2f %.2f %.2f %.2f re S Q\n", margin, top-360, pageWidth-2*margin, 360.0)
+
+	drawText(&content, voucher.Title, 20, center, top-50)
+	drawText(&content, voucher.Prize, 16, center, top-90)
+
+	if err := drawQR(&content, voucher.Code, center-qrSize/2, top-240); err != nil {
+		return err
+	}
+
+	drawText(&content, voucher.Code, 18, center, top-275)
+
+	if voucher.Deadline != "" {
+		drawText(&content, "À retirer avant le "+voucher.Deadline, 11, center, top-315)
+	}
+
+	drawText(&content, "Présentez ce bon en boutique pour retirer votre lot", 9, center, top-340)
+
+	if err := p.addPage(&content); err != nil {
+		return err
+	}
+
+	return p.Close()
+}
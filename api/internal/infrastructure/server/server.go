@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -50,7 +51,9 @@ func (server *Server) Stop() error {
 // API is a method of the `Server` struct that registers the provided API with the server.
 // It creates a new version of the API router, adds the provided API to the router's namespace, and registers the new router with the server's main `app` instance.
 func (server *Server) Register(handlers map[string]fiber.Handler) {
-	for url, pathItem := range interfaces.Mapping.Paths {
+	for _, url := range sortPaths(interfaces.Mapping.Paths) {
+		pathItem := interfaces.Mapping.Paths[url]
+
 		// Remplacer {property} par :property dans l'URL
 		url = replaceCurlyBracesWithColons(url)
 
@@ -68,6 +71,35 @@ func (server *Server) Register(handlers map[string]fiber.Handler) {
 	}
 }
 
+// sortPaths trie les URL pour enregistrer les segments fixes avant les paramètres
+// Fiber retient la première route correspondante, /store/nearby doit donc précéder /store/{id}
+func sortPaths[T any](paths map[string]T) []string {
+	urls := make([]string, 0, len(paths))
+	for url := range paths {
+		urls = append(urls, url)
+	}
+
+	sort.Slice(urls, func(i, j int) bool {
+		left, right := strings.Split(urls[i], "/"), strings.Split(urls[j], "/")
+		for k := 0; k < len(left) && k < len(right); k++ {
+			if left[k] == right[k] {
+				continue
+			}
+
+			leftParam, rightParam := strings.HasPrefix(left[k], "{"), strings.HasPrefix(right[k], "{")
+			if leftParam != rightParam {
+				return rightParam
+			}
+
+			return left[k] < right[k]
+		}
+
+		return len(left) < len(right)
+	})
+
+	return urls
+}
+
 // replaceCurlyBracesWithColons remplace toutes les occurences {property} par :property
 func replaceCurlyBracesWithColons(url string) string {
 	// Utilisation d'une expression régulière pour remplacer {property} par :property
@@ -23,3 +23,23 @@ func TestRegister(t *testing.T) {
 	assert.Nil(t, srv.Start())
 	assert.Nil(t, srv.Stop())
 }
+
+func TestSortPaths(t *testing.T) {
+	paths := map[string]bool{
+		"/store/{id}":        true,
+		"/store":             true,
+		"/store/nearby":      true,
+		"/caisse/{id}":       true,
+		"/game/ticket/{id}":  true,
+		"/game/ticket/claim": true,
+	}
+
+	assert.Equal(t, []string{
+		"/caisse/{id}",
+		"/game/ticket/claim",
+		"/game/ticket/{id}",
+		"/store",
+		"/store/nearby",
+		"/store/{id}",
+	}, sortPaths(paths))
+}
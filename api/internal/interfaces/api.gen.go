@@ -23,34 +23,84 @@ func init() {
 // API represents a collection of HTTP endpoints grouped by namespace and version.
 var (
 	Endpoints map[string]fiber.Handler = map[string]func(*fiber.Ctx) error{
-		"code.ListErrors":        code.ListErrors,
-		"game.GetTicket":         game.GetTicket,
-		"game.GetTicketById":     game.GetTicketById,
-		"game.GetTickets":        game.GetTickets,
-		"game.UpdateTicket":      game.UpdateTicket,
-		"jwt.Auth":               jwt.Auth,
-		"status.HealthCheck":     status.HealthCheck,
-		"status.IP":              status.IP,
-		"store.CreateCaisse":     store.CreateCaisse,
-		"store.DeleteCaisse":     store.DeleteCaisse,
-		"store.GetCaisse":        store.GetCaisse,
-		"store.GetStoreByID":     store.GetStoreByID,
-		"store.List":             store.List,
-		"store.UpdateCaisse":     store.UpdateCaisse,
-		"user.CredentialUpdate":  user.CredentialUpdate,
-		"user.DeleteClient":      user.DeleteClient,
-		"user.DeleteEmployee":    user.DeleteEmployee,
-		"user.ExportClient":      user.ExportClient,
-		"user.GetClient":         user.GetClient,
-		"user.GetEmployee":       user.GetEmployee,
-		"user.MailValidation":    user.MailValidation,
-		"user.RegisterClient":    user.RegisterClient,
-		"user.RegisterEmployee":  user.RegisterEmployee,
-		"user.UpdateClient":      user.UpdateClient,
-		"user.UpdateEmployee":    user.UpdateEmployee,
-		"user.UserAuth":          user.UserAuth,
-		"user.UserAuthRenew":     user.UserAuthRenew,
-		"user.ValidationRecover": user.ValidationRecover,
+		"code.ListErrors":             code.ListErrors,
+		"game.ClaimLinkedTicket":      game.ClaimLinkedTicket,
+		"game.ClaimTicket":            game.ClaimTicket,
+		"game.CloseShift":             game.CloseShift,
+		"game.CreateCampaign":         game.CreateCampaign,
+		"game.CreateDraw":             game.CreateDraw,
+		"game.CreatePrize":            game.CreatePrize,
+		"game.DeleteCampaign":         game.DeleteCampaign,
+		"game.DeletePrize":            game.DeletePrize,
+		"game.ExportTickets":          game.ExportTickets,
+		"game.GetCampaign":            game.GetCampaign,
+		"game.GetCampaigns":           game.GetCampaigns,
+		"game.GetClaimAttempts":       game.GetClaimAttempts,
+		"game.GetDraw":                game.GetDraw,
+		"game.GetDraws":               game.GetDraws,
+		"game.GetPrize":               game.GetPrize,
+		"game.GetPrizeStocks":         game.GetPrizeStocks,
+		"game.GetPrizes":              game.GetPrizes,
+		"game.GetShiftReport":         game.GetShiftReport,
+		"game.GetShifts":              game.GetShifts,
+		"game.GetStatisticsBreakdown": game.GetStatisticsBreakdown,
+		"game.GetStatisticsSeries":    game.GetStatisticsSeries,
+		"game.GetTicketById":          game.GetTicketById,
+		"game.GetTicketHistory":       game.GetTicketHistory,
+		"game.GetTicketQR":            game.GetTicketQR,
+		"game.GetTickets":             game.GetTickets,
+		"game.IssueTicket":            game.IssueTicket,
+		"game.OpenShift":              game.OpenShift,
+		"game.RedeemTicket":           game.RedeemTicket,
+		"game.ReissueTicket":          game.ReissueTicket,
+		"game.RestockPrize":           game.RestockPrize,
+		"game.RunDraw":                game.RunDraw,
+		"game.SyncCaisse":             game.SyncCaisse,
+		"game.TransferPrizeStock":     game.TransferPrizeStock,
+		"game.UpdateCampaign":         game.UpdateCampaign,
+		"game.UpdatePrize":            game.UpdatePrize,
+		"game.UpdateTicket":           game.UpdateTicket,
+		"game.VoidTicket":             game.VoidTicket,
+		"jwt.Auth":                    jwt.Auth,
+		"status.HealthCheck":          status.HealthCheck,
+		"status.IP":                   status.IP,
+		"store.CreateCaisse":          store.CreateCaisse,
+		"store.CreateMembership":      store.CreateMembership,
+		"store.CreatePairing":         store.CreatePairing,
+		"store.CreateStore":           store.CreateStore,
+		"store.DeleteCaisse":          store.DeleteCaisse,
+		"store.DeleteMembership":      store.DeleteMembership,
+		"store.DeleteStore":           store.DeleteStore,
+		"store.FindNearbyStores":      store.FindNearbyStores,
+		"store.GetCaisse":             store.GetCaisse,
+		"store.GetDevices":            store.GetDevices,
+		"store.GetMemberships":        store.GetMemberships,
+		"store.GetStoreByID":          store.GetStoreByID,
+		"store.List":                  store.List,
+		"store.PairDevice":            store.PairDevice,
+		"store.RevokeDevice":          store.RevokeDevice,
+		"store.UpdateCaisse":          store.UpdateCaisse,
+		"store.UpdateStore":           store.UpdateStore,
+		"store.UpdateStoreHours":      store.UpdateStoreHours,
+		"user.CredentialUpdate":       user.CredentialUpdate,
+		"user.DeleteClient":           user.DeleteClient,
+		"user.DeleteEmployee":         user.DeleteEmployee,
+		"user.DemoteUser":             user.DemoteUser,
+		"user.DisableUser":            user.DisableUser,
+		"user.EnableUser":             user.EnableUser,
+		"user.ExportClient":           user.ExportClient,
+		"user.GetClient":              user.GetClient,
+		"user.GetEmployee":            user.GetEmployee,
+		"user.ListUsers":              user.ListUsers,
+		"user.MailValidation":         user.MailValidation,
+		"user.PromoteUser":            user.PromoteUser,
+		"user.RegisterClient":         user.RegisterClient,
+		"user.RegisterEmployee":       user.RegisterEmployee,
+		"user.UpdateClient":           user.UpdateClient,
+		"user.UpdateEmployee":         user.UpdateEmployee,
+		"user.UserAuth":               user.UserAuth,
+		"user.UserAuthRenew":          user.UserAuthRenew,
+		"user.ValidationRecover":      user.ValidationRecover,
 	}
 	Mapping = &docs.Swagger{}
 	doc, _  = swag.ReadDoc()
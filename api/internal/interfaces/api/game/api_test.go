@@ -5,20 +5,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kodmain/thetiptop/api/config"
 	"github.com/kodmain/thetiptop/api/env"
 	"github.com/kodmain/thetiptop/api/internal/application/hook"
+	storeTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
 	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/server"
 	"github.com/kodmain/thetiptop/api/internal/interfaces"
 )
@@ -26,18 +32,27 @@ import (
 const (
 	email    = "employe@yopmail.com"
 	password = "Aa1@azetyuiop"
+	client   = "client@yopmail.com"
 )
 
 var srv *server.Server
+
+// caisseID is the caisse issuing the tickets in the tests
+var caisseID string
+
 var callBack hook.HandlerSync = func(tags ...string) {
 	if len(tags) > 0 && tags[0] == "default" {
 		user := userRepository.NewUserRepository(database.Get(config.GetString("services.employee.database", config.DEFAULT)))
+		gameRepository.Migrate(database.Get(config.GetString("services.game.database", config.DEFAULT)))
 		game := gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT)))
+		store := storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT)))
 
-		if crd, _ := user.ReadCredential(&userTransfert.Credential{
+		cred, _ := user.ReadCredential(&userTransfert.Credential{
 			Email: aws.String(email),
-		}); crd == nil {
-			cred, _ := user.CreateCredential(&userTransfert.Credential{
+		})
+
+		if cred == nil {
+			cred, _ = user.CreateCredential(&userTransfert.Credential{
 				Email:    aws.String(email),
 				Password: aws.String(password),
 			})
@@ -47,15 +62,59 @@ var callBack hook.HandlerSync = func(tags ...string) {
 			})
 		}
 
-		for i := 0; i < 100; i++ {
+		player, _ := user.ReadCredential(&userTransfert.Credential{
+			Email: aws.String(client),
+		})
+
+		if player == nil {
+			player, _ = user.CreateCredential(&userTransfert.Credential{
+				Email:    aws.String(client),
+				Password: aws.String(password),
+			})
+
+			user.CreateClient(&userTransfert.Client{
+				CredentialID: &player.ID,
+			})
+		}
+
+		store.CreateStores([]*storeTransfert.Store{{Label: aws.String("store")}})
+		if str, _ := store.ReadStore(&storeTransfert.Store{Label: aws.String("store")}); str != nil {
+			if caisse, _ := store.CreateCaisse(&storeTransfert.Caisse{Label: aws.String("caisse"), StoreID: &str.ID}); caisse != nil {
+				caisseID = caisse.ID
+			}
+
+			store.CreateMembership(&storeTransfert.Membership{StoreID: &str.ID, CredentialID: &cred.ID})
+		}
+
+		prize, _ := game.CreatePrize(&transfert.Prize{
+			Code:       aws.String("prize"),
+			Label:      aws.String("prize"),
+			Percentage: aws.Int(100),
+		})
+
+		for range 100 {
 			game.CreateTicket(&transfert.Ticket{
-				Prize: aws.String("prize"),
-				Token: aws.String("token:" + fmt.Sprintf("%d", i)),
+				PrizeID: &prize.ID,
+				Token:   aws.String(code()),
 			})
 		}
+
+		// Seuls les clients participent au tirage au sort
+		if ticket, _ := game.CreateTicket(&transfert.Ticket{PrizeID: &prize.ID, Token: aws.String(code())}); ticket != nil && ticket.Claim(&player.ID) {
+			game.ClaimTicket(ticket)
+		}
 	}
 }
 
+// code génère un code de 12 chiffres pour les tickets et les reçus des tests
+//
+// Returns:
+// - string: le code, chiffre de contrôle inclus
+func code() string {
+	generated, _ := token.Generate(12)
+	return generated.String()
+}
+
 func start(http, https int) error {
 	env.DEFAULT_PORT_HTTP = http
 	env.DEFAULT_PORT_HTTPS = https
@@ -68,7 +127,32 @@ func start(http, https int) error {
 	srv = server.Create()
 	srv.Register(interfaces.Endpoints)
 
-	return srv.Start()
+	if err := srv.Start(); err != nil {
+		return err
+	}
+
+	return waitForPort(http)
+}
+
+// waitForPort attend que le serveur accepte les connexions sur le port donné
+//
+// Parameters:
+// - port: int Le port à surveiller
+//
+// Returns:
+// - error: L'erreur de connexion si le serveur n'écoute toujours pas
+func waitForPort(port int) error {
+	var err error
+	for i := 0; i < 50; i++ {
+		var conn net.Conn
+		if conn, err = net.Dial("tcp", "localhost:"+strconv.Itoa(port)); err == nil {
+			return conn.Close()
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	return err
 }
 
 func stop() error {
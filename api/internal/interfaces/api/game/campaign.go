@@ -0,0 +1,165 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		Create a campaign with its participation and redemption windows.
+// @Produce		application/json
+// @Router		/game/campaign [post]
+// @Id			jwt.Auth => game.CreateCampaign
+// @Security 	Bearer
+// @Param		label		formData	string	true	"Campaign label"
+// @Param		start		formData	string	true	"Start of the participation window" default(2025-01-01 00:00:00)
+// @Param		end			formData	string	true	"End of the participation window" default(2025-01-31 23:59:59)
+// @Param		claim_until	formData	string	true	"Deadline to collect the prizes" default(2025-03-01 23:59:59)
+// @Param		timezone	formData	string	true	"Timezone of the dates" default(Europe/Paris)
+// @Success		201	{object} 	nil "Campaign created"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		409	{object} 	nil "Campaign already exists"
+func CreateCampaign(ctx *fiber.Ctx) error {
+	dtoCampaign := &transfert.Campaign{}
+	if err := ctx.BodyParser(dtoCampaign); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.CreateCampaign(
+		services.Campaign(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), dtoCampaign,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		Get a campaign by id.
+// @Produce		application/json
+// @Router		/game/campaign/{id} [get]
+// @Id			jwt.Auth => game.GetCampaign
+// @Security 	Bearer
+// @Param		id	path	string	true	"Campaign ID" format(uuid)
+// @Success		200	{object} 	nil "Campaign details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func GetCampaign(ctx *fiber.Ctx) error {
+	campaignID := ctx.Params("id")
+	if campaignID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Campaign ID is required")
+	}
+
+	status, response := game.GetCampaign(
+		services.Campaign(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), &transfert.Campaign{
+			ID: &campaignID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		List all campaigns.
+// @Produce		application/json
+// @Router		/game/campaigns [get]
+// @Id			jwt.Auth => game.GetCampaigns
+// @Security 	Bearer
+// @Success		200	{object} 	nil "Campaigns details"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetCampaigns(ctx *fiber.Ctx) error {
+	status, response := game.GetCampaigns(
+		services.Campaign(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		Update a campaign by id.
+// @Produce		application/json
+// @Router		/game/campaign/{id} [put]
+// @Id			jwt.Auth => game.UpdateCampaign
+// @Security 	Bearer
+// @Param		id			path		string	true	"Campaign ID" format(uuid)
+// @Param		label		formData	string	false	"Campaign label"
+// @Param		start		formData	string	false	"Start of the participation window"
+// @Param		end			formData	string	false	"End of the participation window"
+// @Param		claim_until	formData	string	false	"Deadline to collect the prizes"
+// @Param		timezone	formData	string	false	"Timezone of the dates"
+// @Success		200	{object} 	nil "Campaign updated"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Campaign already exists"
+func UpdateCampaign(ctx *fiber.Ctx) error {
+	dtoCampaign := &transfert.Campaign{}
+	if err := ctx.BodyParser(dtoCampaign); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	campaignID := ctx.Params("id")
+	if campaignID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Campaign ID is required")
+	}
+
+	dtoCampaign.ID = &campaignID
+
+	status, response := game.UpdateCampaign(
+		services.Campaign(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), dtoCampaign,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		Delete a campaign by id.
+// @Produce		application/json
+// @Router		/game/campaign/{id} [delete]
+// @Id			jwt.Auth => game.DeleteCampaign
+// @Security 	Bearer
+// @Param		id	path	string	true	"Campaign ID" format(uuid)
+// @Success		204	{object} 	nil "Campaign deleted"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func DeleteCampaign(ctx *fiber.Ctx) error {
+	campaignID := ctx.Params("id")
+	if campaignID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Campaign ID is required")
+	}
+
+	status, response := game.DeleteCampaign(
+		services.Campaign(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), &transfert.Campaign{
+			ID: &campaignID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,88 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	storeEntities "github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDevice(t *testing.T, authorization string, encoding EncodingType) {
+	content, status, err := request("POST", "http://localhost:8888/caisse/"+caisseID+"/pairing", authorization, encoding, map[string][]any{
+		"label": {"Comptoir"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, status)
+
+	pending := &storeEntities.Device{}
+	assert.Nil(t, json.Unmarshal(content, pending))
+	if !assert.NotNil(t, pending.Code) {
+		return
+	}
+
+	// L'appareil n'a pas encore de jeton, seul le code l'authentifie
+	content, status, err = request("POST", "http://localhost:8888/device/pair", "", encoding, map[string][]any{
+		"code": {pending.Code.String()},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, status)
+
+	var paired fiber.Map
+	assert.Nil(t, json.Unmarshal(content, &paired))
+	device := "Bearer " + paired["access_token"].(string)
+
+	claims, err := jwt.TokenToClaims(paired["access_token"].(string))
+	assert.Nil(t, err)
+	assert.True(t, claims.IsDevice())
+	assert.Equal(t, pending.ID, claims.ID)
+
+	// Le code ne sert qu'une fois
+	_, status, err = request("POST", "http://localhost:8888/device/pair", "", encoding, map[string][]any{
+		"code": {pending.Code.String()},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 404, status)
+
+	_, status, err = request("POST", "http://localhost:8888/game/ticket/issue", device, encoding, map[string][]any{
+		"caisse_id": {caisseID},
+		"receipt":   {code()},
+		"amount":    {54.9},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	// Un appareil n'ouvre pas de service, c'est l'affaire d'un employé
+	_, status, err = request("POST", "http://localhost:8888/game/shift", device, encoding, map[string][]any{
+		"caisse_id": {caisseID},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+
+	content, status, err = request("GET", "http://localhost:8888/store/"+*pending.StoreID+"/devices", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	devices := []*storeEntities.Device{}
+	assert.Nil(t, json.Unmarshal(content, &devices))
+	assert.NotEmpty(t, devices)
+
+	_, status, err = request("DELETE", "http://localhost:8888/device/"+pending.ID, authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 204, status)
+
+	_, status, err = request("DELETE", "http://localhost:8888/device/"+pending.ID, authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 409, status)
+
+	// Le jeton d'un appareil révoqué est refusé dès la requête suivante
+	_, status, err = request("POST", "http://localhost:8888/game/ticket/issue", device, encoding, map[string][]any{
+		"caisse_id": {caisseID},
+		"receipt":   {code()},
+		"amount":    {54.9},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+}
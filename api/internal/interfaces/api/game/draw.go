@@ -0,0 +1,139 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Draw
+// @Accept		multipart/form-data
+// @Summary		Register a draw with its published seed commitment.
+// @Produce		application/json
+// @Router		/game/draw [post]
+// @Id			jwt.Auth => game.CreateDraw
+// @Security 	Bearer
+// @Param		commitment	formData	string	true	"SHA-256 of the seed, hexadecimal"
+// @Param		count		formData	int		false	"Number of winners" default(1)
+// @Success		201	{object} 	nil "Draw registered"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		409	{object} 	nil "Draw already exists"
+func CreateDraw(ctx *fiber.Ctx) error {
+	dtoDraw := &transfert.Draw{}
+	if err := ctx.BodyParser(dtoDraw); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.CreateDraw(
+		services.Draw(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoDraw,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Draw
+// @Accept		multipart/form-data
+// @Summary		Reveal the seed of a draw and select the winners.
+// @Produce		application/json
+// @Router		/game/draw/{id} [put]
+// @Id			jwt.Auth => game.RunDraw
+// @Security 	Bearer
+// @Param		id		path		string	true	"Draw ID" format(uuid)
+// @Param		seed	formData	string	true	"Seed matching the commitment"
+// @Success		200	{object} 	nil "Draw result"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Draw already done"
+// @Failure		422	{object} 	nil "No entries"
+func RunDraw(ctx *fiber.Ctx) error {
+	dtoDraw := &transfert.Draw{}
+	if err := ctx.BodyParser(dtoDraw); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	drawID := ctx.Params("id")
+	if drawID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Draw ID is required")
+	}
+
+	dtoDraw.ID = &drawID
+
+	status, response := game.RunDraw(
+		services.Draw(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoDraw,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Draw
+// @Accept		multipart/form-data
+// @Summary		Get a draw by id.
+// @Produce		application/json
+// @Router		/game/draw/{id} [get]
+// @Id			jwt.Auth => game.GetDraw
+// @Security 	Bearer
+// @Param		id	path	string	true	"Draw ID" format(uuid)
+// @Success		200	{object} 	nil "Draw details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func GetDraw(ctx *fiber.Ctx) error {
+	drawID := ctx.Params("id")
+	if drawID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Draw ID is required")
+	}
+
+	status, response := game.GetDraw(
+		services.Draw(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Draw{
+			ID: &drawID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Draw
+// @Accept		multipart/form-data
+// @Summary		List all draws.
+// @Produce		application/json
+// @Router		/game/draws [get]
+// @Id			jwt.Auth => game.GetDraws
+// @Security 	Bearer
+// @Success		200	{object} 	nil "Draws details"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetDraws(ctx *fiber.Ctx) error {
+	status, response := game.GetDraws(
+		services.Draw(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
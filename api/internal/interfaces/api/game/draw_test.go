@@ -0,0 +1,58 @@
+package game_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDraw(t *testing.T, authorization string, encoding EncodingType, seed string) {
+	sum := sha256.Sum256([]byte(seed))
+	commitment := hex.EncodeToString(sum[:])
+
+	content, status, err := request("POST", "http://localhost:8888/game/draw", authorization, encoding, map[string][]any{
+		"commitment": {commitment},
+		"count":      {1},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, status)
+
+	draw := entities.Draw{}
+	assert.Nil(t, json.Unmarshal(content, &draw))
+	assert.Equal(t, commitment, *draw.Commitment)
+
+	_, status, err = request("POST", "http://localhost:8888/game/draw", authorization, encoding, map[string][]any{
+		"commitment": {commitment},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 409, status)
+
+	_, status, err = request("PUT", "http://localhost:8888/game/draw/"+draw.ID, authorization, encoding, map[string][]any{
+		"seed": {"wrong-" + seed},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	content, status, err = request("PUT", "http://localhost:8888/game/draw/"+draw.ID, authorization, encoding, map[string][]any{
+		"seed": {seed},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	draw = entities.Draw{}
+	assert.Nil(t, json.Unmarshal(content, &draw))
+	assert.True(t, draw.IsDrawn())
+	assert.Len(t, draw.Winners, 1)
+
+	_, status, err = request("GET", "http://localhost:8888/game/draw/"+draw.ID, authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	_, status, err = request("GET", "http://localhost:8888/game/draws", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+}
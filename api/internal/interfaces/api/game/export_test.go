@@ -0,0 +1,56 @@
+package game_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+const storeID = "123e4567-e89b-12d3-a456-426614174000"
+
+func testExport(t *testing.T, authorization string, encoding EncodingType) {
+	_, status, err := request("POST", "http://localhost:8888/game/tickets/export", authorization, encoding, map[string][]any{
+		"format": {"csv"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+
+	claims, err := jwt.TokenToClaims(strings.TrimPrefix(authorization, "Bearer "))
+	assert.Nil(t, err)
+
+	access, _, jwtErr := jwt.FromID(claims.ID, map[string]any{"role": "admin"})
+	assert.Nil(t, jwtErr)
+	admin := "Bearer " + access
+
+	content, status, err := request("POST", "http://localhost:8888/game/tickets/export", admin, encoding, map[string][]any{
+		"format":   {"csv"},
+		"store_id": {storeID},
+		"count":    {5},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.GreaterOrEqual(t, len(lines), 6)
+	assert.Equal(t, "code,store,qr", lines[0])
+	assert.Contains(t, lines[1], storeID)
+
+	content, status, err = request("POST", "http://localhost:8888/game/tickets/export", admin, encoding, map[string][]any{
+		"format":   {"pdf"},
+		"store_id": {storeID},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-")))
+
+	_, status, err = request("POST", "http://localhost:8888/game/tickets/export", admin, encoding, map[string][]any{
+		"format":   {"csv"},
+		"store_id": {storeID},
+		"count":    {100000},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 409, status)
+}
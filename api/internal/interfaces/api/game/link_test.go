@@ -0,0 +1,72 @@
+package game_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLink(t *testing.T, authorization string, encoding EncodingType) {
+	content, status, err := request("POST", "http://localhost:8888/game/ticket/issue", authorization, encoding, map[string][]any{
+		"caisse_id": {caisseID},
+		"receipt":   {code()},
+		"amount":    {54.9},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	ticket := entities.Ticket{}
+	assert.Nil(t, json.Unmarshal(content, &ticket))
+	code := ticket.Token.String()
+
+	content, status, err = request("GET", "http://localhost:8888/game/ticket/"+code+"/qr?size=128", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	image, err := png.Decode(bytes.NewReader(content))
+	assert.Nil(t, err)
+	if assert.NotNil(t, image) {
+		assert.Equal(t, 128, image.Bounds().Dx())
+	}
+
+	content, status, err = request("GET", "http://localhost:8888/game/ticket/"+code+"/qr?format=svg", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+	assert.True(t, strings.HasPrefix(string(content), "<svg "))
+
+	_, status, err = request("GET", "http://localhost:8888/game/ticket/"+code+"/qr?format=gif", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	_, status, err = request("GET", "http://localhost:8888/game/ticket/"+code+"/qr", "", encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+
+	link, _ := url.Parse(token.Links().Sign(code, time.Now()))
+
+	forged := url.Values{"expires": {link.Query().Get("expires")}, "signature": {"forged"}}
+	_, status, err = request("PUT", "http://localhost:8888"+link.Path+"?"+forged.Encode(), authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 403, status)
+
+	expired, _ := url.Parse(token.Links().Sign(code, time.Now().AddDate(-1, 0, 0)))
+	_, status, err = request("PUT", "http://localhost:8888"+expired.RequestURI(), authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 410, status)
+
+	content, status, err = request("PUT", "http://localhost:8888"+link.RequestURI(), authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	claimed := entities.Ticket{}
+	assert.Nil(t, json.Unmarshal(content, &claimed))
+	assert.Equal(t, ticket.ID, claimed.ID)
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// @Tags		Prize
+// @Accept		multipart/form-data
+// @Summary		Add a prize to the catalog.
+// @Produce		application/json
+// @Router		/game/prize [post]
+// @Id			jwt.Auth => game.CreatePrize
+// @Security 	Bearer
+// @Param		code		formData	string	true	"Prize code" default(infuser)
+// @Param		label		formData	string	true	"Prize label"
+// @Param		description	formData	string	false	"Prize description"
+// @Param		value		formData	number	true	"Retail value"
+// @Param		image		formData	string	false	"Image URL"
+// @Param		percentage	formData	integer	true	"Share of the tickets"
+// @Success		201	{object} 	nil "Prize created"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		409	{object} 	nil "Prize already exists"
+func CreatePrize(ctx *fiber.Ctx) error {
+	dtoPrize := &transfert.Prize{}
+	if err := ctx.BodyParser(dtoPrize); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.CreatePrize(
+		services.Prize(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), dtoPrize,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Prize
+// @Accept		multipart/form-data
+// @Summary		Get a prize by id.
+// @Produce		application/json
+// @Router		/game/prize/{id} [get]
+// @Id			game.GetPrize
+// @Param		id	path	string	true	"Prize ID" format(uuid)
+// @Success		200	{object} 	nil "Prize details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		404	{object} 	nil "Not found"
+func GetPrize(ctx *fiber.Ctx) error {
+	prizeID := ctx.Params("id")
+	if prizeID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Prize ID is required")
+	}
+
+	status, response := game.GetPrize(
+		services.Prize(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), &transfert.Prize{
+			ID: &prizeID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Prize
+// @Accept		multipart/form-data
+// @Summary		List the prizes of the game.
+// @Produce		application/json
+// @Router		/game/prizes [get]
+// @Id			game.GetPrizes
+// @Success		200	{object} 	nil "Prizes details"
+func GetPrizes(ctx *fiber.Ctx) error {
+	status, response := game.GetPrizes(
+		services.Prize(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Prize
+// @Accept		multipart/form-data
+// @Summary		Update a prize by id.
+// @Produce		application/json
+// @Router		/game/prize/{id} [put]
+// @Id			jwt.Auth => game.UpdatePrize
+// @Security 	Bearer
+// @Param		id			path		string	true	"Prize ID" format(uuid)
+// @Param		code		formData	string	false	"Prize code"
+// @Param		label		formData	string	false	"Prize label"
+// @Param		description	formData	string	false	"Prize description"
+// @Param		value		formData	number	false	"Retail value"
+// @Param		image		formData	string	false	"Image URL"
+// @Param		percentage	formData	integer	false	"Share of the tickets"
+// @Success		200	{object} 	nil "Prize updated"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Prize already exists"
+func UpdatePrize(ctx *fiber.Ctx) error {
+	dtoPrize := &transfert.Prize{}
+	if err := ctx.BodyParser(dtoPrize); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	prizeID := ctx.Params("id")
+	if prizeID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Prize ID is required")
+	}
+
+	dtoPrize.ID = &prizeID
+
+	status, response := game.UpdatePrize(
+		services.Prize(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), dtoPrize,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Prize
+// @Accept		multipart/form-data
+// @Summary		Remove a prize from the catalog.
+// @Produce		application/json
+// @Router		/game/prize/{id} [delete]
+// @Id			jwt.Auth => game.DeletePrize
+// @Security 	Bearer
+// @Param		id	path	string	true	"Prize ID" format(uuid)
+// @Success		204	{object} 	nil "Prize deleted"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Prize used by tickets"
+func DeletePrize(ctx *fiber.Ctx) error {
+	prizeID := ctx.Params("id")
+	if prizeID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Prize ID is required")
+	}
+
+	status, response := game.DeletePrize(
+		services.Prize(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), &transfert.Prize{
+			ID: &prizeID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
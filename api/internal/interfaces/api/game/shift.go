@@ -0,0 +1,160 @@
+package game
+
+import (
+	"bufio"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
+)
+
+// @Tags		Shift
+// @Accept		multipart/form-data
+// @Summary		Open a shift of the current employee on a caisse.
+// @Produce		application/json
+// @Router		/game/shift [post]
+// @Id			jwt.Auth => game.OpenShift
+// @Security 	Bearer
+// @Param		caisse_id	formData	string	true	"Caisse ID" format(uuid)
+// @Success		201	{object} 	nil "Opened shift"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Caisse not found"
+// @Failure		409	{object} 	nil "A shift is already open on the caisse"
+func OpenShift(ctx *fiber.Ctx) error {
+	dtoShift := &transfert.Shift{}
+	if err := ctx.BodyParser(dtoShift); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.OpenShift(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoShift,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Shift
+// @Summary		Close a shift and compute its Z-report.
+// @Produce		application/json
+// @Router		/game/shift/{id}/close [put]
+// @Id			jwt.Auth => game.CloseShift
+// @Security 	Bearer
+// @Param		id	path	string	true	"Shift ID" format(uuid)
+// @Success		200	{object} 	nil "Closed shift with its Z-report"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Shift not found"
+// @Failure		409	{object} 	nil "Shift already closed"
+func CloseShift(ctx *fiber.Ctx) error {
+	shiftID := ctx.Params("id")
+
+	status, response := game.CloseShift(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Shift{ID: &shiftID},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Shift
+// @Summary		List the shifts of a store or a caisse, the latest first.
+// @Produce		application/json
+// @Router		/game/shifts [get]
+// @Id			jwt.Auth => game.GetShifts
+// @Security 	Bearer
+// @Param		store_id	query	string	false	"Store ID" format(uuid)
+// @Param		caisse_id	query	string	false	"Caisse ID" format(uuid)
+// @Success		200	{object} 	nil "List of shifts"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetShifts(ctx *fiber.Ctx) error {
+	dtoShift := &transfert.Shift{}
+
+	if storeID := ctx.Query("store_id"); storeID != "" {
+		dtoShift.StoreID = &storeID
+	}
+
+	if caisseID := ctx.Query("caisse_id"); caisseID != "" {
+		dtoShift.CaisseID = &caisseID
+	}
+
+	status, response := game.GetShifts(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoShift,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Shift
+// @Summary		Get the Z-report of a closed shift.
+// @Produce		application/json
+// @Produce		text/csv
+// @Router		/game/shift/{id}/report [get]
+// @Id			jwt.Auth => game.GetShiftReport
+// @Security 	Bearer
+// @Param		id		path	string	true	"Shift ID" format(uuid)
+// @Param		format	query	string	false	"Format of the report" Enums(json, csv)
+// @Success		200	{object} 	nil "Z-report"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Shift not found"
+// @Failure		409	{object} 	nil "Shift still open"
+func GetShiftReport(ctx *fiber.Ctx) error {
+	shiftID := ctx.Params("id")
+	dtoReport := &transfert.ShiftReport{ShiftID: &shiftID}
+
+	if format := ctx.Query("format"); format != "" {
+		dtoReport.Format = &format
+	}
+
+	status, response := game.GetShiftReport(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoReport,
+	)
+
+	render, ok := response.(sheet.Render)
+	if !ok {
+		return ctx.Status(status).JSON(response)
+	}
+
+	ctx.Status(status).Attachment("shift-" + shiftID + "." + sheet.CSV)
+	ctx.Set(fiber.HeaderContentType, sheet.ContentType(sheet.CSV))
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		logger.Error(render(w))
+	})
+
+	return nil
+}
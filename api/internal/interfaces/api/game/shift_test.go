@@ -0,0 +1,92 @@
+package game_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func testShift(t *testing.T, authorization string, encoding EncodingType) {
+	content, status, err := request("POST", "http://localhost:8888/game/shift", authorization, encoding, map[string][]any{
+		"caisse_id": {caisseID},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, status)
+
+	shift := &entities.Shift{}
+	assert.Nil(t, json.Unmarshal(content, shift))
+	assert.True(t, shift.IsOpen())
+
+	_, status, err = request("POST", "http://localhost:8888/game/shift", authorization, encoding, map[string][]any{
+		"caisse_id": {caisseID},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 409, status)
+
+	// The employee works in the store of the caisse, the report is only missing until the shift is closed
+	_, status, err = request("GET", "http://localhost:8888/game/shift/"+shift.ID+"/report", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 409, status)
+
+	claims, err := jwt.TokenToClaims(strings.TrimPrefix(authorization, "Bearer "))
+	assert.Nil(t, err)
+
+	access, _, jwtErr := jwt.FromID(claims.ID, map[string]any{"role": "admin"})
+	assert.Nil(t, jwtErr)
+	admin := "Bearer " + access
+
+	_, status, err = request("GET", "http://localhost:8888/game/shift/"+shift.ID+"/report", admin, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 409, status)
+
+	_, status, err = request("PUT", "http://localhost:8888/game/shift/"+shift.ID+"/close", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	_, status, err = request("PUT", "http://localhost:8888/game/shift/"+shift.ID+"/close", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 409, status)
+
+	content, status, err = request("GET", "http://localhost:8888/game/shift/"+shift.ID+"/report", admin, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	report := &entities.Shift{}
+	assert.Nil(t, json.Unmarshal(content, report))
+	assert.False(t, report.IsOpen())
+
+	content, status, err = request("GET", "http://localhost:8888/game/shift/"+shift.ID+"/report?format=csv", admin, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+	assert.True(t, strings.HasPrefix(string(content), "row,prize_id,issued,redeemed,voided,amount,ticket_id,credential_id\n"))
+
+	_, status, err = request("GET", "http://localhost:8888/game/shift/"+shift.ID+"/report?format=pdf", admin, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	content, status, err = request("GET", "http://localhost:8888/game/shifts?caisse_id="+caisseID, admin, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	shifts := []*entities.Shift{}
+	assert.Nil(t, json.Unmarshal(content, &shifts))
+	assert.NotEmpty(t, shifts)
+
+	content, status, err = request("GET", "http://localhost:8888/game/shifts?caisse_id="+caisseID, authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+	assert.Nil(t, json.Unmarshal(content, &shifts))
+	assert.NotEmpty(t, shifts)
+
+	_, status, err = request("GET", "http://localhost:8888/game/shift/"+shift.ID+"/report", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	_, status, err = request("GET", "http://localhost:8888/game/shifts", "", encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// @Tags		Statistics
+// @Summary		Count the tickets issued, claimed and redeemed per day.
+// @Produce		application/json
+// @Router		/game/statistics/series [get]
+// @Id			jwt.Auth => game.GetStatisticsSeries
+// @Security 	Bearer
+// @Param		from		query	string	false	"First day of the period" format(date)
+// @Param		to			query	string	false	"Last day of the period" format(date)
+// @Param		store_id	query	string	false	"Store ID" format(uuid)
+// @Param		prize_id	query	string	false	"Prize ID" format(uuid)
+// @Param		campaign_id	query	string	false	"Campaign ID" format(uuid)
+// @Success		200	{object} 	nil "Time series"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetStatisticsSeries(ctx *fiber.Ctx) error {
+	status, response := game.GetStatisticsSeries(
+		services.Statistics(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), statisticsQuery(ctx),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Statistics
+// @Summary		Count the tickets issued, claimed and redeemed per prize, per store or per day.
+// @Produce		application/json
+// @Router		/game/statistics/breakdown [get]
+// @Id			jwt.Auth => game.GetStatisticsBreakdown
+// @Security 	Bearer
+// @Param		by			query	string	true	"Dimension of the breakdown" Enums(day, prize, store)
+// @Param		from		query	string	false	"First day of the period" format(date)
+// @Param		to			query	string	false	"Last day of the period" format(date)
+// @Param		store_id	query	string	false	"Store ID" format(uuid)
+// @Param		prize_id	query	string	false	"Prize ID" format(uuid)
+// @Param		campaign_id	query	string	false	"Campaign ID" format(uuid)
+// @Success		200	{object} 	nil "Breakdown"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetStatisticsBreakdown(ctx *fiber.Ctx) error {
+	status, response := game.GetStatisticsBreakdown(
+		services.Statistics(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		), statisticsQuery(ctx),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// statisticsQuery reads the dimension, the period and the filters from the query string
+func statisticsQuery(ctx *fiber.Ctx) *transfert.Statistics {
+	dtoStatistics := &transfert.Statistics{}
+
+	for key, field := range map[string]**string{
+		"by":          &dtoStatistics.By,
+		"from":        &dtoStatistics.From,
+		"to":          &dtoStatistics.To,
+		"store_id":    &dtoStatistics.StoreID,
+		"prize_id":    &dtoStatistics.PrizeID,
+		"campaign_id": &dtoStatistics.CampaignID,
+	} {
+		if value := ctx.Query(key); value != "" {
+			*field = &value
+		}
+	}
+
+	return dtoStatistics
+}
@@ -0,0 +1,46 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func testStatistics(t *testing.T, authorization string, encoding EncodingType) {
+	today := time.Now().UTC().Format(time.DateOnly)
+
+	content, status, err := request("GET", "http://localhost:8888/game/statistics/series?from="+today+"&to="+today, authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	series := []*entities.Statistic{}
+	assert.Nil(t, json.Unmarshal(content, &series))
+	if assert.Len(t, series, 1) {
+		assert.Equal(t, today, series[0].Key)
+		assert.Positive(t, series[0].Issued)
+		assert.Positive(t, series[0].Claimed)
+	}
+
+	content, status, err = request("GET", "http://localhost:8888/game/statistics/breakdown?by=store", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	breakdown := []*entities.Statistic{}
+	assert.Nil(t, json.Unmarshal(content, &breakdown))
+	assert.NotEmpty(t, breakdown)
+
+	_, status, err = request("GET", "http://localhost:8888/game/statistics/breakdown?by=color", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	_, status, err = request("GET", "http://localhost:8888/game/statistics/series?from="+today+"&to=2000-01-01", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	_, status, err = request("GET", "http://localhost:8888/game/statistics/series", "", encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+}
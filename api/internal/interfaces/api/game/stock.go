@@ -0,0 +1,119 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Stock
+// @Summary		List the stock of the prizes in the stores.
+// @Produce		application/json
+// @Router		/game/stocks [get]
+// @Id			jwt.Auth => game.GetPrizeStocks
+// @Security 	Bearer
+// @Param		prize_id	query	string	false	"Prize ID" format(uuid)
+// @Param		store_id	query	string	false	"Store ID" format(uuid)
+// @Success		200	{object} 	nil "List of stocks"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetPrizeStocks(ctx *fiber.Ctx) error {
+	dtoStock := &transfert.PrizeStock{}
+
+	if prizeID := ctx.Query("prize_id"); prizeID != "" {
+		dtoStock.PrizeID = &prizeID
+	}
+
+	if storeID := ctx.Query("store_id"); storeID != "" {
+		dtoStock.StoreID = &storeID
+	}
+
+	status, response := game.GetPrizeStocks(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoStock,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Stock
+// @Accept		multipart/form-data
+// @Summary		Record a delivery of a prize to a store.
+// @Produce		application/json
+// @Router		/game/stock [put]
+// @Id			jwt.Auth => game.RestockPrize
+// @Security 	Bearer
+// @Param		prize_id	formData	string	true	"Prize ID" format(uuid)
+// @Param		store_id	formData	string	true	"Store ID" format(uuid)
+// @Param		quantity	formData	int		true	"Delivered units"
+// @Param		threshold	formData	int		false	"Available units triggering the low stock alert"
+// @Success		200	{object} 	nil "Stock after the delivery"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Prize or store not found"
+func RestockPrize(ctx *fiber.Ctx) error {
+	dtoStock := &transfert.PrizeStock{}
+	if err := ctx.BodyParser(dtoStock); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.RestockPrize(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoStock,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Stock
+// @Accept		multipart/form-data
+// @Summary		Move available units of a prize from a store to another.
+// @Produce		application/json
+// @Router		/game/stock/transfer [post]
+// @Id			jwt.Auth => game.TransferPrizeStock
+// @Security 	Bearer
+// @Param		prize_id		formData	string	true	"Prize ID" format(uuid)
+// @Param		from_store_id	formData	string	true	"Source store ID" format(uuid)
+// @Param		to_store_id		formData	string	true	"Destination store ID" format(uuid)
+// @Param		quantity		formData	int		true	"Units to move"
+// @Success		200	{object} 	nil "Stocks of the source and destination stores"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Stock or store not found"
+// @Failure		409	{object} 	nil "Not enough available units in the source store"
+func TransferPrizeStock(ctx *fiber.Ctx) error {
+	dtoTransfer := &transfert.StockTransfer{}
+	if err := ctx.BodyParser(dtoTransfer); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.TransferPrizeStock(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoTransfer,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
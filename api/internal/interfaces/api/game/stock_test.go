@@ -0,0 +1,39 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func testStock(t *testing.T, authorization string, encoding EncodingType) {
+	content, status, err := request("GET", "http://localhost:8888/game/stocks?store_id="+storeID, authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	stocks := []*entities.PrizeStock{}
+	assert.Nil(t, json.Unmarshal(content, &stocks))
+
+	_, status, err = request("GET", "http://localhost:8888/game/stocks?store_id=store", authorization, encoding)
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	_, status, err = request("PUT", "http://localhost:8888/game/stock", authorization, encoding, map[string][]any{
+		"prize_id": {storeID},
+		"store_id": {storeID},
+		"quantity": {0},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	_, status, err = request("POST", "http://localhost:8888/game/stock/transfer", authorization, encoding, map[string][]any{
+		"prize_id":      {storeID},
+		"from_store_id": {storeID},
+		"to_store_id":   {storeID},
+		"quantity":      {1},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Game
+// @Accept		application/json
+// @Summary		Upload the issuances and redemptions queued by a caisse while it was offline.
+// @Produce		application/json
+// @Router		/game/sync [post]
+// @Id			jwt.Auth => game.SyncCaisse
+// @Security 	Bearer
+// @Param		sync	body	object	true	"Caisse ID in caisse_id, operations with id, type (issue or redeem), occurred_at (RFC 3339), receipt and amount or ticket_id"
+// @Success		200	{object} 	nil "Outcome of each operation, applied or conflict"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Caisse not found"
+// @Failure		413	{object} 	nil "Too many operations"
+func SyncCaisse(ctx *fiber.Ctx) error {
+	dtoSync := &transfert.Sync{}
+	if err := ctx.BodyParser(dtoSync); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.SyncCaisse(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoSync,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,79 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSync(t *testing.T, authorization string, encoding EncodingType) {
+	operations := []map[string]any{
+		{
+			"id":          uuid.New().String(),
+			"type":        entities.SyncOperationRedeem,
+			"occurred_at": time.Now().Add(-time.Minute).Format(time.RFC3339),
+			"ticket_id":   uuid.New().String(),
+		},
+		{
+			"id":          uuid.New().String(),
+			"type":        entities.SyncOperationIssue,
+			"occurred_at": time.Now().Add(-time.Hour).Format(time.RFC3339),
+			"receipt":     code(),
+			"amount":      54.9,
+		},
+	}
+
+	content, status, err := request("POST", "http://localhost:8888/game/sync", authorization, encoding, map[string][]any{
+		"caisse_id":  {caisseID},
+		"operations": {operations},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	// L'émission hors ligne a eu lieu avant la remise, elle est appliquée en premier
+	synced := []*entities.SyncOperation{}
+	assert.Nil(t, json.Unmarshal(content, &synced))
+	if !assert.Len(t, synced, 2) {
+		return
+	}
+
+	assert.Equal(t, operations[1]["id"], synced[0].ID)
+	assert.True(t, synced[0].IsApplied())
+	assert.NotNil(t, synced[0].TicketID)
+	assert.Equal(t, operations[0]["id"], synced[1].ID)
+	assert.Equal(t, entities.SyncOperationConflict, synced[1].Status)
+	assert.Equal(t, "ticket.not_found", *synced[1].Error)
+
+	// Une caisse qui n'a pas reçu la réponse renvoie le même lot sans rien appliquer deux fois
+	content, status, err = request("POST", "http://localhost:8888/game/sync", authorization, encoding, map[string][]any{
+		"caisse_id":  {caisseID},
+		"operations": {operations},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	replayed := []*entities.SyncOperation{}
+	assert.Nil(t, json.Unmarshal(content, &replayed))
+	if assert.Len(t, replayed, 2) {
+		assert.True(t, replayed[0].Replayed)
+		assert.Equal(t, synced[0].TicketID, replayed[0].TicketID)
+		assert.True(t, replayed[1].Replayed)
+	}
+
+	_, status, err = request("POST", "http://localhost:8888/game/sync", authorization, encoding, map[string][]any{
+		"operations": {operations},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	_, status, err = request("POST", "http://localhost:8888/game/sync", "", encoding, map[string][]any{
+		"caisse_id":  {caisseID},
+		"operations": {operations},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+}
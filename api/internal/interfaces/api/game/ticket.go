@@ -1,6 +1,9 @@
 package game
 
 import (
+	"bufio"
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/kodmain/thetiptop/api/config"
 	"github.com/kodmain/thetiptop/api/internal/application/security"
@@ -8,25 +11,45 @@ import (
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/sheet"
 )
 
 // @Tags		Game
 // @Accept		multipart/form-data
-// @Summary		Get a random ticket.
+// @Summary		Issue the next ticket of the pool at a caisse for a purchase.
 // @Produce		application/json
-// @Router		/game/random [get]
-// @Id			jwt.Auth => game.GetTicket
+// @Router		/game/ticket/issue [post]
+// @Id			jwt.Auth => game.IssueTicket
 // @Security 	Bearer
+// @Param		caisse_id	formData	string	true	"Caisse ID" format(uuid)
+// @Param		receipt		formData	string	true	"Receipt number of the purchase"
+// @Param		amount		formData	number	true	"Amount of the purchase"
 // @Success		200	{object} 	nil "Ticket details"
 // @Failure		400	{object} 	nil "Bad request"
 // @Failure		401	{object} 	nil "Unauthorized"
-func GetTicket(ctx *fiber.Ctx) error {
-	status, response := game.GetRandomTicket(
+// @Failure		404	{object} 	nil "Caisse not found"
+// @Failure		409	{object} 	nil "Receipt already rewarded or no ticket left"
+// @Failure		422	{object} 	nil "Amount under the minimum"
+func IssueTicket(ctx *fiber.Ctx) error {
+	dtoIssuance := &transfert.Issuance{}
+	if err := ctx.BodyParser(dtoIssuance); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.IssueTicket(
 		services.Game(
-			security.NewUserAccess(ctx.Locals("token")),
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
 			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
-		),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoIssuance,
 	)
 
 	return ctx.Status(status).JSON(response)
@@ -34,20 +57,28 @@ func GetTicket(ctx *fiber.Ctx) error {
 
 // @Tags		Game
 // @Accept		multipart/form-data
-// @Summary		List all tickets likend to the authenticated user.
+// @Summary		List a page of the tickets likend to the authenticated user.
 // @Produce		application/json
 // @Router		/game/tickets [get]
 // @Id			jwt.Auth => game.GetTickets
 // @Security 	Bearer
-// @Success		200	{object} 	nil "Tickets details"
+// @Param		page	query	int		false	"Page number, starting at 1"
+// @Param		limit	query	int		false	"Page size, 20 by default and 100 at most"
+// @Param		cursor	query	string	false	"Cursor of the next page, replaces page"
+// @Param		sort	query	string	false	"Columns to sort by, prefixed by - for descending order" example(-created_at)
+// @Param		filter[status]	query	string	false	"Status of the tickets"
+// @Success		200	{object} 	nil "Page of tickets"
 // @Failure		400	{object} 	nil "Bad request"
 // @Failure		404	{object} 	nil "Not found"
 func GetTickets(ctx *fiber.Ctx) error {
 	status, response := game.GetTickets(
 		services.Game(
-			security.NewUserAccess(ctx.Locals("token")),
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
 			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
-		),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), database.NewList(ctx.Queries()),
 	)
 
 	return ctx.Status(status).JSON(response)
@@ -55,16 +86,19 @@ func GetTickets(ctx *fiber.Ctx) error {
 
 // @Tags		Game
 // @Accept		multipart/form-data
-// @Summary	  	Update a ticket.
+// @Summary		Claim a ticket with its printed code, kept for the older clients.
 // @Produce		application/json
 // @Router		/game/ticket [put]
 // @Id			jwt.Auth => game.UpdateTicket
 // @Security 	Bearer
-// @Param		id	formData	string	true	"Ticket ID" format(uuid)
+// @Param		token	formData	string	true	"Printed code of the ticket"
 // @Success		200	{object} 	nil "Ticket details"
 // @Failure		400	{object} 	nil "Bad request"
 // @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Campaign not started"
 // @Failure		404	{object} 	nil "Not found"
+// @Failure		410	{object} 	nil "Campaign ended"
+// @Failure		429	{object} 	nil "Too many failed attempts"
 func UpdateTicket(ctx *fiber.Ctx) error {
 	dtoTicket := &transfert.Ticket{}
 	if err := ctx.BodyParser(dtoTicket); err != nil {
@@ -73,8 +107,11 @@ func UpdateTicket(ctx *fiber.Ctx) error {
 
 	status, response := game.UpdateTicket(
 		services.Game(
-			security.NewUserAccess(ctx.Locals("token")),
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
 			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
 		), dtoTicket,
 	)
 
@@ -104,10 +141,369 @@ func GetTicketById(ctx *fiber.Ctx) error {
 
 	status, response := game.GetTicketById(
 		services.Game(
-			security.NewUserAccess(ctx.Locals("token")),
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
 			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
 		), dtoTicket,
 	)
 
 	return ctx.Status(status).JSON(response)
 }
+
+// @Tags		Game
+// @Summary		List the audit trail of a ticket.
+// @Produce		application/json
+// @Router		/game/ticket/{id}/history [get]
+// @Id			jwt.Auth => game.GetTicketHistory
+// @Security 	Bearer
+// @Param		id	path		string	true	"Ticket ID" format(uuid)
+// @Success		200	{object} 	nil "Ticket events, oldest first"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func GetTicketHistory(ctx *fiber.Ctx) error {
+	ticketID := ctx.Params("id")
+
+	status, response := game.GetTicketHistory(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Ticket{
+			ID: &ticketID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Redeem the prize of a claimed ticket at a caisse.
+// @Produce		application/json
+// @Router		/game/ticket/{id}/redeem [put]
+// @Id			jwt.Auth => game.RedeemTicket
+// @Security 	Bearer
+// @Param		id			path		string	true	"Ticket ID" format(uuid)
+// @Param		caisse_id	formData	string	true	"Caisse ID" format(uuid)
+// @Success		200	{object} 	nil "Ticket details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Ticket not claimed, already redeemed or prize out of stock in the store"
+// @Failure		410	{object} 	nil "Ticket expired or voided"
+func RedeemTicket(ctx *fiber.Ctx) error {
+	dtoRedemption := &transfert.Redemption{}
+	if err := ctx.BodyParser(dtoRedemption); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	TicketID := ctx.Params("id")
+	if TicketID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON("Ticket ID is required")
+	}
+
+	dtoRedemption.TicketID = &TicketID
+
+	status, response := game.RedeemTicket(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoRedemption,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Void a damaged or misprinted ticket, its record is kept.
+// @Produce		application/json
+// @Router		/game/ticket/{id}/void [put]
+// @Id			jwt.Auth => game.VoidTicket
+// @Security 	Bearer
+// @Param		id		path		string	true	"Ticket ID" format(uuid)
+// @Param		reason	formData	string	true	"Reason of the void"
+// @Success		200	{object} 	nil "Voided ticket"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Ticket already redeemed"
+// @Failure		410	{object} 	nil "Ticket expired or already voided"
+func VoidTicket(ctx *fiber.Ctx) error {
+	dtoVoid := &transfert.Void{}
+	if err := ctx.BodyParser(dtoVoid); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	ticketID := ctx.Params("id")
+	dtoVoid.TicketID = &ticketID
+
+	status, response := game.VoidTicket(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoVoid,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Void a ticket and replace it under a fresh code with the same prize.
+// @Produce		application/json
+// @Router		/game/ticket/{id}/reissue [post]
+// @Id			jwt.Auth => game.ReissueTicket
+// @Security 	Bearer
+// @Param		id		path		string	true	"Ticket ID" format(uuid)
+// @Param		reason	formData	string	true	"Reason of the void"
+// @Success		201	{object} 	nil "Replacement ticket"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Ticket already redeemed or no fresh code left"
+// @Failure		410	{object} 	nil "Ticket expired or already voided"
+func ReissueTicket(ctx *fiber.Ctx) error {
+	dtoVoid := &transfert.Void{}
+	if err := ctx.BodyParser(dtoVoid); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	ticketID := ctx.Params("id")
+	dtoVoid.TicketID = &ticketID
+
+	status, response := game.ReissueTicket(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoVoid,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Claim a ticket with its printed code.
+// @Produce		application/json
+// @Router		/game/ticket/claim [put]
+// @Id			jwt.Auth => game.ClaimTicket
+// @Security 	Bearer
+// @Param		token	formData	string	true	"Printed code of the ticket"
+// @Success		200	{object} 	nil "Ticket details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Campaign not started"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		410	{object} 	nil "Campaign ended"
+// @Failure		429	{object} 	nil "Too many failed attempts"
+func ClaimTicket(ctx *fiber.Ctx) error {
+	dtoClaim := &transfert.Claim{}
+	if err := ctx.BodyParser(dtoClaim); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	ip := ctx.IP()
+	dtoClaim.IP = &ip
+
+	status, response := game.ClaimTicket(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoClaim,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		List the failed attempts to claim a ticket.
+// @Produce		application/json
+// @Router		/game/claim/attempts [get]
+// @Id			jwt.Auth => game.GetClaimAttempts
+// @Security 	Bearer
+// @Param		credential_id	query	string	false	"Credential ID" format(uuid)
+// @Param		ip				query	string	false	"IP address"
+// @Success		200	{object} 	nil "Failed attempts"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetClaimAttempts(ctx *fiber.Ctx) error {
+	dtoAttempt := &transfert.ClaimAttempt{}
+
+	if credentialID := ctx.Query("credential_id"); credentialID != "" {
+		dtoAttempt.CredentialID = &credentialID
+	}
+
+	if ip := ctx.Query("ip"); ip != "" {
+		dtoAttempt.IP = &ip
+	}
+
+	status, response := game.GetClaimAttempts(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoAttempt,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Export the tickets to print, after assigning new tickets to a store.
+// @Produce		text/csv
+// @Produce		application/pdf
+// @Router		/game/tickets/export [post]
+// @Id			jwt.Auth => game.ExportTickets
+// @Security 	Bearer
+// @Param		format		formData	string	true	"Format of the sheet" Enums(csv, pdf)
+// @Param		store_id	formData	string	false	"Store ID" format(uuid)
+// @Param		count		formData	int		false	"Number of new tickets assigned to the store"
+// @Success		200	{file} 		file "Sheet of tickets"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		409	{object} 	nil "Not enough tickets"
+func ExportTickets(ctx *fiber.Ctx) error {
+	dtoExport := &transfert.TicketExport{}
+	if err := ctx.BodyParser(dtoExport); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.ExportTickets(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoExport,
+	)
+
+	render, ok := response.(sheet.Render)
+	if !ok {
+		return ctx.Status(status).JSON(response)
+	}
+
+	// The sheet is written while the tickets are read, it is never held in memory
+	ctx.Status(status).Attachment("tickets." + *dtoExport.Format)
+	ctx.Set(fiber.HeaderContentType, sheet.ContentType(*dtoExport.Format))
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		logger.Error(render(w))
+	})
+
+	return nil
+}
+
+// @Tags		Game
+// @Summary		Draw the QR code of the signed claim link of a ticket.
+// @Produce		image/png
+// @Produce		image/svg+xml
+// @Router		/game/ticket/{token}/qr [get]
+// @Id			jwt.Auth => game.GetTicketQR
+// @Security 	Bearer
+// @Param		token	path	string	true	"Printed code of the ticket"
+// @Param		format	query	string	false	"Format of the image" Enums(png, svg)
+// @Param		size	query	int		false	"Side of the image in pixels"
+// @Success		200	{file} 		file "QR code"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func GetTicketQR(ctx *fiber.Ctx) error {
+	token := ctx.Params("token")
+	format := ctx.Query("format", qr.PNG)
+	dtoQR := &transfert.TicketQR{
+		Token:  &token,
+		Format: &format,
+	}
+
+	if value := ctx.Query("size"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(err)
+		}
+
+		dtoQR.Size = &size
+	}
+
+	status, response := game.GetTicketQR(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoQR,
+	)
+
+	image, ok := response.([]byte)
+	if !ok {
+		return ctx.Status(status).JSON(response)
+	}
+
+	ctx.Set(fiber.HeaderContentType, qr.ContentType(format))
+
+	return ctx.Status(status).Send(image)
+}
+
+// @Tags		Game
+// @Summary		Claim a ticket from its signed claim link.
+// @Produce		application/json
+// @Router		/game/ticket/{token}/claim [put]
+// @Id			jwt.Auth => game.ClaimLinkedTicket
+// @Security 	Bearer
+// @Param		token		path	string	true	"Printed code of the ticket"
+// @Param		expires		query	string	true	"Expiry of the link"
+// @Param		signature	query	string	true	"Signature of the link"
+// @Success		200	{object} 	nil "Ticket details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Forged link or campaign not started"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		410	{object} 	nil "Expired link or campaign ended"
+// @Failure		429	{object} 	nil "Too many failed attempts"
+func ClaimLinkedTicket(ctx *fiber.Ctx) error {
+	token := ctx.Params("token")
+	expires := ctx.Query("expires")
+	signature := ctx.Query("signature")
+	ip := ctx.IP()
+
+	dtoClaim := &transfert.Claim{
+		Token:     &token,
+		Expires:   &expires,
+		Signature: &signature,
+		IP:        &ip,
+	}
+
+	status, response := game.ClaimLinkedTicket(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+			userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoClaim,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
 	"github.com/stretchr/testify/assert"
 )
@@ -39,20 +40,52 @@ func TestGame(t *testing.T) {
 			encodingName = "JSONEncoded"
 		}
 
-		t.Run("GetTicket/"+encodingName, func(t *testing.T) {
-			randomTicket, status, err := request("GET", "http://localhost:8888/game/random", authorization, encoding)
+		t.Run("IssueTicket/"+encodingName, func(t *testing.T) {
+			receipt := code()
+
+			_, status, err := request("POST", "http://localhost:8888/game/ticket/issue", authorization, encoding, map[string][]any{
+				"caisse_id": {caisseID},
+				"receipt":   {receipt},
+				"amount":    {5.0},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 422, status)
+
+			issuedTicket, status, err := request("POST", "http://localhost:8888/game/ticket/issue", authorization, encoding, map[string][]any{
+				"caisse_id": {caisseID},
+				"receipt":   {receipt},
+				"amount":    {54.9},
+			})
 			assert.Nil(t, err)
 			assert.Equal(t, 200, status)
 
 			ticket := entities.Ticket{}
-			json.Unmarshal(randomTicket, &ticket)
+			json.Unmarshal(issuedTicket, &ticket)
 
 			assert.NotNil(t, ticket)
+			if assert.NotNil(t, ticket.IssuedAt) {
+				assert.Equal(t, receipt, *ticket.Receipt)
+			}
+
+			_, status, err = request("POST", "http://localhost:8888/game/ticket/issue", authorization, encoding, map[string][]any{
+				"caisse_id": {caisseID},
+				"receipt":   {receipt},
+				"amount":    {54.9},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 409, status)
+
 			t.Run("UpdateTicket/"+encodingName, func(t *testing.T) {
-				updatedTicket, status, err := request("PUT", "http://localhost:8888/game/ticket", authorization, encoding, map[string][]any{
+				_, status, err := request("PUT", "http://localhost:8888/game/ticket", authorization, encoding, map[string][]any{
 					"id": {ticket.ID},
 				})
 				assert.Nil(t, err)
+				assert.Equal(t, 400, status)
+
+				updatedTicket, status, err := request("PUT", "http://localhost:8888/game/ticket", authorization, encoding, map[string][]any{
+					"token": {ticket.Token.String()},
+				})
+				assert.Nil(t, err)
 				assert.Equal(t, 200, status)
 
 				ticket = entities.Ticket{}
@@ -65,11 +98,45 @@ func TestGame(t *testing.T) {
 					assert.Nil(t, err)
 					assert.Equal(t, 200, status)
 
-					ticketsArray := []*entities.Ticket{}
-					json.Unmarshal(tickets, &ticketsArray)
+					page := database.Page[*entities.Ticket]{}
+					json.Unmarshal(tickets, &page)
+
+					assert.NotNil(t, page.Items)
+					assert.Equal(t, int64(len(page.Items)), page.Total)
+					if assert.NotEmpty(t, page.Items) && assert.NotNil(t, page.Items[0].Prize) {
+						assert.Equal(t, "prize", *page.Items[0].Prize.Code)
+					}
+
+					tickets, status, err = request("GET", "http://localhost:8888/game/tickets?limit=1&sort=-claimed_at&filter[status]=claimed", authorization, encoding)
+					assert.Nil(t, err)
+					assert.Equal(t, 200, status)
+					json.Unmarshal(tickets, &page)
+					assert.Len(t, page.Items, 1)
+					assert.Equal(t, 1, page.Limit)
+
+					_, status, err = request("GET", "http://localhost:8888/game/tickets?filter[credential_id]=someone", authorization, encoding)
+					assert.Nil(t, err)
+					assert.Equal(t, 400, status)
+				})
+			})
+
+			t.Run("ClaimTicket/"+encodingName, func(t *testing.T) {
+				_, status, err := request("PUT", "http://localhost:8888/game/ticket/claim", authorization, encoding, map[string][]any{
+					"token": {"000000000001"},
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, 400, status)
 
-					assert.NotNil(t, ticketsArray)
+				claimedTicket, status, err := request("PUT", "http://localhost:8888/game/ticket/claim", authorization, encoding, map[string][]any{
+					"token": {ticket.Token.String()},
 				})
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				claimed := entities.Ticket{}
+				json.Unmarshal(claimedTicket, &claimed)
+
+				assert.Equal(t, ticket.ID, claimed.ID)
 			})
 
 			t.Run("GetTicketById/"+encodingName, func(t *testing.T) {
@@ -83,8 +150,70 @@ func TestGame(t *testing.T) {
 				assert.NotNil(t, ticket)
 			})
 
+			t.Run("GetTicketHistory/"+encodingName, func(t *testing.T) {
+				content, status, err := request("GET", "http://localhost:8888/game/ticket/"+ticket.ID+"/history", authorization, encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				events := []*entities.TicketEvent{}
+				json.Unmarshal(content, &events)
+
+				actions := []string{}
+				for _, event := range events {
+					assert.Equal(t, ticket.ID, event.TicketID)
+					actions = append(actions, event.Action)
+				}
+
+				if assert.NotEmpty(t, actions) {
+					assert.Equal(t, entities.TicketEventIssued, actions[0])
+					assert.Contains(t, actions, entities.TicketEventClaimed)
+					assert.Contains(t, actions, entities.TicketEventViewed)
+				}
+
+				_, status, err = request("GET", "http://localhost:8888/game/ticket/"+ticket.ID+"/history", "", encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 401, status)
+			})
+		})
+
+		t.Run("Draw/"+encodingName, func(t *testing.T) {
+			testDraw(t, authorization, encoding, encodingName)
+		})
+
+		t.Run("ExportTickets/"+encodingName, func(t *testing.T) {
+			testExport(t, authorization, encoding)
+		})
+
+		t.Run("ClaimLinkedTicket/"+encodingName, func(t *testing.T) {
+			testLink(t, authorization, encoding)
 		})
 
+		t.Run("Statistics/"+encodingName, func(t *testing.T) {
+			testStatistics(t, authorization, encoding)
+		})
+
+		t.Run("VoidTicket/"+encodingName, func(t *testing.T) {
+			testVoid(t, authorization, encoding)
+		})
+
+		t.Run("PrizeStock/"+encodingName, func(t *testing.T) {
+			testStock(t, authorization, encoding)
+		})
+
+		t.Run("Shift/"+encodingName, func(t *testing.T) {
+			testShift(t, authorization, encoding)
+		})
+
+		t.Run("Device/"+encodingName, func(t *testing.T) {
+			testDevice(t, authorization, encoding)
+		})
+
+		// Les opérations d'un lot sont imbriquées, seul le JSON les transporte
+		if encoding == JSONEncoded {
+			t.Run("Sync/"+encodingName, func(t *testing.T) {
+				testSync(t, authorization, encoding)
+			})
+		}
 	}
 
 	assert.Nil(t, stop())
@@ -0,0 +1,84 @@
+package game_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func testVoid(t *testing.T, authorization string, encoding EncodingType) {
+	content, status, err := request("POST", "http://localhost:8888/game/ticket/issue", authorization, encoding, map[string][]any{
+		"caisse_id": {caisseID},
+		"receipt":   {code()},
+		"amount":    {54.9},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	ticket := entities.Ticket{}
+	assert.Nil(t, json.Unmarshal(content, &ticket))
+
+	// Un employé ne peut pas annuler de ticket
+	_, status, err = request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/reissue", authorization, encoding, map[string][]any{
+		"reason": {"damaged"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+
+	claims, err := jwt.TokenToClaims(strings.TrimPrefix(authorization, "Bearer "))
+	assert.Nil(t, err)
+
+	access, _, jwtErr := jwt.FromID(claims.ID, map[string]any{"role": "admin"})
+	assert.Nil(t, jwtErr)
+	admin := "Bearer " + access
+
+	_, status, err = request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/reissue", admin, encoding, map[string][]any{})
+	assert.Nil(t, err)
+	assert.Equal(t, 400, status)
+
+	content, status, err = request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/reissue", admin, encoding, map[string][]any{
+		"reason": {"damaged"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, status)
+
+	replacement := entities.Ticket{}
+	assert.Nil(t, json.Unmarshal(content, &replacement))
+	assert.NotEqual(t, ticket.ID, replacement.ID)
+	assert.NotEqual(t, ticket.Token, replacement.Token)
+	assert.Equal(t, ticket.PrizeID, replacement.PrizeID)
+	assert.Equal(t, ticket.Receipt, replacement.Receipt)
+	if assert.NotNil(t, replacement.ReissuedFromID) {
+		assert.Equal(t, ticket.ID, *replacement.ReissuedFromID)
+	}
+
+	// Le ticket remplacé ne peut plus être réclamé
+	_, status, err = request("PUT", "http://localhost:8888/game/ticket/claim", authorization, encoding, map[string][]any{
+		"token": {ticket.Token.String()},
+	})
+	assert.Nil(t, err)
+	assert.NotEqual(t, 200, status)
+
+	content, status, err = request("PUT", "http://localhost:8888/game/ticket/"+replacement.ID+"/void", admin, encoding, map[string][]any{
+		"reason": {"misprinted"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	voided := entities.Ticket{}
+	assert.Nil(t, json.Unmarshal(content, &voided))
+	assert.Equal(t, entities.TicketVoided, voided.Status)
+	if assert.NotNil(t, voided.VoidReason) {
+		assert.Equal(t, "misprinted", *voided.VoidReason)
+	}
+
+	_, status, err = request("PUT", "http://localhost:8888/game/ticket/"+replacement.ID+"/void", admin, encoding, map[string][]any{
+		"reason": {"misprinted"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 410, status)
+}
@@ -0,0 +1,139 @@
+package user
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Admin
+// @Summary		List a page of the clients, employees and admins.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		page			query	int		false	"Page number, starting at 1"
+// @Param		limit			query	int		false	"Page size, 20 by default and 100 at most"
+// @Param		cursor			query	string	false	"Cursor of the next page, replaces page"
+// @Param		sort			query	string	false	"Columns to sort by, prefixed by - for descending order" example(email)
+// @Param		filter[role]	query	string	false	"Role of the users" Enums(client, employee, admin)
+// @Param		filter[email]	query	string	false	"Email address of the user"
+// @Success		200	{object}	nil "Page of users"
+// @Failure		400	{object}	nil "Bad request"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Router		/users [get]
+// @Id			jwt.Auth => user.ListUsers
+func ListUsers(ctx *fiber.Ctx) error {
+	status, response := services.ListUsers(admin(ctx), database.NewList(ctx.Queries()))
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Admin
+// @Summary		Promote a user: a client becomes an employee, an employee becomes an admin.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id	path	string	true	"Credential ID" format(uuid)
+// @Success		200	{object}	nil "User promoted"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Credential not found"
+// @Failure		409	{object}	nil "User already admin or self"
+// @Router		/user/{id}/promote [put]
+// @Id			jwt.Auth => user.PromoteUser
+func PromoteUser(ctx *fiber.Ctx) error {
+	credentialID := ctx.Params("id")
+
+	status, response := services.PromoteUser(admin(ctx), &transfert.Credential{
+		ID: &credentialID,
+	})
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Admin
+// @Summary		Demote a user: an admin becomes an employee, an employee becomes a client.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id	path	string	true	"Credential ID" format(uuid)
+// @Success		200	{object}	nil "User demoted"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Credential not found"
+// @Failure		409	{object}	nil "User already client or self"
+// @Router		/user/{id}/demote [put]
+// @Id			jwt.Auth => user.DemoteUser
+func DemoteUser(ctx *fiber.Ctx) error {
+	credentialID := ctx.Params("id")
+
+	status, response := services.DemoteUser(admin(ctx), &transfert.Credential{
+		ID: &credentialID,
+	})
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Admin
+// @Summary		Disable a user, who can no longer sign in nor renew their tokens.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id	path	string	true	"Credential ID" format(uuid)
+// @Success		200	{object}	nil "User disabled"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Credential not found"
+// @Failure		409	{object}	nil "User already disabled or self"
+// @Router		/user/{id}/disable [put]
+// @Id			jwt.Auth => user.DisableUser
+func DisableUser(ctx *fiber.Ctx) error {
+	credentialID := ctx.Params("id")
+
+	status, response := services.DisableUser(admin(ctx), &transfert.Credential{
+		ID: &credentialID,
+	})
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Admin
+// @Summary		Enable a disabled user again.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id	path	string	true	"Credential ID" format(uuid)
+// @Success		200	{object}	nil "User enabled"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Credential not found"
+// @Failure		409	{object}	nil "User not disabled or self"
+// @Router		/user/{id}/enable [put]
+// @Id			jwt.Auth => user.EnableUser
+func EnableUser(ctx *fiber.Ctx) error {
+	credentialID := ctx.Params("id")
+
+	status, response := services.EnableUser(admin(ctx), &transfert.Credential{
+		ID: &credentialID,
+	})
+
+	return ctx.Status(status).JSON(response)
+}
+
+// admin builds the user service of the admin endpoints
+//
+// Parameters:
+// - ctx: *fiber.Ctx the request
+//
+// Returns:
+// - *domain.UserService: the user service acting for the authenticated user
+func admin(ctx *fiber.Ctx) *domain.UserService {
+	return domain.User(
+		security.NewUserAccess(ctx.Locals("token")),
+		repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+		gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+	)
+}
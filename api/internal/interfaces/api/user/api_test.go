@@ -16,6 +16,7 @@ import (
 	"github.com/kodmain/thetiptop/api/env"
 	"github.com/kodmain/thetiptop/api/internal/application/hook"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
@@ -80,6 +81,7 @@ var srv *server.Server
 
 var callBack hook.HandlerSync = func(tags ...string) {
 	if len(tags) > 0 && tags[0] == "default" {
+		gameRepository.Migrate(database.Get(config.GetString("services.game.database", config.DEFAULT)))
 		user := userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT)))
 		if crd, _ := user.ReadCredential(&transfert.Credential{
 			Email: aws.String(emailEmployee),
@@ -52,6 +52,7 @@ func UserAuth(ctx *fiber.Ctx) error {
 // @Success		200	{object}	nil "JWT token renewed"
 // @Failure		400	{object}	nil "Invalid token"
 // @Failure		401	{object}	nil "Token expired"
+// @Failure		403	{object}	nil "Credential disabled"
 // @Failure		500	{object}	nil "Internal server error"
 // @Param 		Authorization header string true "With the bearer started"
 // @Router		/user/auth/renew [get]
@@ -63,7 +64,12 @@ func UserAuthRenew(ctx *fiber.Ctx) error {
 	}
 
 	status, response := services.UserAuthRenew(
-		token.(*jwt.Token),
+		domain.User(
+			security.NewUserAccess(token),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), token.(*jwt.Token),
 	)
 
 	return ctx.Status(status).JSON(response)
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kodmain/thetiptop/api/config"
@@ -36,10 +39,12 @@ var callBack hook.HandlerSync = func(tags ...string) {
 	if len(tags) > 0 && tags[0] == "default" {
 		user := userRepository.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT)))
 		storeRepo := storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT)))
-		if crd, _ := user.ReadCredential(&userTransfert.Credential{
+		cred, _ := user.ReadCredential(&userTransfert.Credential{
 			Email: aws.String(email),
-		}); crd == nil {
-			cred, _ := user.CreateCredential(&userTransfert.Credential{
+		})
+
+		if cred == nil {
+			cred, _ = user.CreateCredential(&userTransfert.Credential{
 				Email:    aws.String(email),
 				Password: aws.String(password),
 			})
@@ -67,6 +72,12 @@ var callBack hook.HandlerSync = func(tags ...string) {
 				StoreID: &store.ID,
 				Label:   aws.String("Caisse1"),
 			})
+
+			// L'employé de test opère les caisses de toutes les boutiques
+			storeRepo.CreateMembership(&transfert.Membership{
+				StoreID:      &store.ID,
+				CredentialID: &cred.ID,
+			})
 		}
 	}
 }
@@ -85,7 +96,32 @@ func start(http, https int) error {
 	srv = server.Create()
 	srv.Register(interfaces.Endpoints)
 
-	return srv.Start()
+	if err := srv.Start(); err != nil {
+		return err
+	}
+
+	return waitForPort(http)
+}
+
+// waitForPort attend que le serveur accepte les connexions sur le port donné
+//
+// Parameters:
+// - port: int Le port à surveiller
+//
+// Returns:
+// - error: L'erreur de connexion si le serveur n'écoute toujours pas
+func waitForPort(port int) error {
+	var err error
+	for i := 0; i < 50; i++ {
+		var conn net.Conn
+		if conn, err = net.Dial("tcp", "localhost:"+strconv.Itoa(port)); err == nil {
+			return conn.Close()
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	return err
 }
 
 func stop() error {
@@ -8,6 +8,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
 	"github.com/stretchr/testify/assert"
 )
@@ -45,9 +46,10 @@ func TestCaisse(t *testing.T) {
 			content, status, err := request("GET", DOMAIN+"/store", authorization, encoding, nil)
 			assert.Nil(t, err)
 			assert.Equal(t, http.StatusOK, status)
-			var stores []*entities.Store
-			assert.Nil(t, json.Unmarshal(content, &stores), "Response should be valid JSON")
-			assert.NotNil(t, stores, "Response should not be nil")
+			var page database.Page[*entities.Store]
+			assert.Nil(t, json.Unmarshal(content, &page), "Response should be valid JSON")
+			assert.NotNil(t, page.Items, "Response should not be nil")
+			stores := page.Items
 
 			for _, store := range stores {
 				t.Run("CreateCaisse/"+encodingName, func(t *testing.T) {
@@ -87,10 +89,8 @@ func TestCaisse(t *testing.T) {
 				t.Run("GetDelete/"+encodingName, func(t *testing.T) {
 					content, status, err := request("DELETE", DOMAIN+"/caisse/"+store.Caisses[0].ID, authorization, encoding, nil)
 					assert.Nil(t, err)
-					assert.Equal(t, http.StatusOK, status)
-					var caisse entities.Caisse
-					assert.Nil(t, json.Unmarshal(content, &caisse), "Response should be valid JSON")
-					assert.NotNil(t, caisse, "Response should not be nil")
+					assert.Equal(t, http.StatusNoContent, status)
+					assert.Empty(t, content, "Response should have no body")
 				})
 
 			}
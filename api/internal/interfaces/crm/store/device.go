@@ -0,0 +1,129 @@
+package store
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/store"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// @Tags		Store
+// @Accept		multipart/form-data
+// @Summary		Generate a one-time pairing code for a device of a caisse.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id		path		string	true	"Caisse ID" format(uuid)
+// @Param		label	formData	string	false	"Label of the device"
+// @Success		201	{object}	nil "Device waiting for its pairing, with its code"
+// @Failure		400	{object}	nil "Invalid input"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Caisse not found"
+// @Router		/caisse/{id}/pairing [post]
+// @Id			jwt.Auth => store.CreatePairing
+func CreatePairing(ctx *fiber.Ctx) error {
+	dtoDevice := &transfert.Device{}
+	if err := ctx.BodyParser(dtoDevice); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	caisseID := ctx.Params("id")
+	dtoDevice.CaisseID = &caisseID
+
+	status, response := services.CreatePairing(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), dtoDevice,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Store
+// @Accept		multipart/form-data
+// @Summary		Exchange a pairing code for the token of the device.
+// @Produce		application/json
+// @Param		code	formData	string	true	"One-time pairing code"
+// @Param		label	formData	string	false	"Label of the device"
+// @Success		201	{object}	nil "Device paired, with its token"
+// @Failure		400	{object}	nil "Invalid code"
+// @Failure		404	{object}	nil "Unknown code"
+// @Failure		410	{object}	nil "Code expired or already used"
+// @Failure		429	{object}	nil "Too many failed pairings from this IP"
+// @Router		/device/pair [post]
+// @Id			store.PairDevice
+func PairDevice(ctx *fiber.Ctx) error {
+	dtoDevice := &transfert.Device{}
+	if err := ctx.BodyParser(dtoDevice); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := services.PairDevice(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")).WithClient(ctx.IP(), ctx.Get(fiber.HeaderUserAgent)),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), &transfert.Device{
+			Code:  dtoDevice.Code,
+			Label: dtoDevice.Label,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Store
+// @Summary		List the paired devices of a store.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id	path	string	true	"Store ID" format(uuid)
+// @Success		200	{object}	nil "Devices of the store"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Store not found"
+// @Router		/store/{id}/devices [get]
+// @Id			jwt.Auth => store.GetDevices
+func GetDevices(ctx *fiber.Ctx) error {
+	storeID := ctx.Params("id")
+
+	status, response := services.GetDevices(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), &transfert.Device{
+			StoreID: &storeID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Store
+// @Summary		Revoke the credential of a device.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id	path	string	true	"Device ID" format(uuid)
+// @Success		204	{object}	nil "Device revoked"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Device not found"
+// @Failure		409	{object}	nil "Device already revoked"
+// @Router		/device/{id} [delete]
+// @Id			jwt.Auth => store.RevokeDevice
+func RevokeDevice(ctx *fiber.Ctx) error {
+	deviceID := ctx.Params("id")
+
+	status, response := services.RevokeDevice(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), &transfert.Device{
+			ID: &deviceID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
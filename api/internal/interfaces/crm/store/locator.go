@@ -0,0 +1,70 @@
+package store
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/store"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// @Tags		Store
+// @Summary		List the stores around a position, the closest first.
+// @Produce		application/json
+// @Param		lat		query	number	true	"Latitude in degrees"
+// @Param		lng		query	number	true	"Longitude in degrees"
+// @Param		radius	query	number	false	"Radius in kilometers, 10 by default and 100 at most"
+// @Success		200	{object}	nil "Stores with their distance and whether they are open"
+// @Failure		400	{object}	nil "Bad request"
+// @Router		/store/nearby [get]
+// @Id			store.FindNearbyStores
+func FindNearbyStores(ctx *fiber.Ctx) error {
+	dtoNearby := &transfert.Nearby{}
+	if err := ctx.QueryParser(dtoNearby); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := services.FindNearbyStores(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), dtoNearby,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Store
+// @Accept		application/json
+// @Summary		Replace the weekly opening hours and the exceptions of a store.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id		path	string	true	"Store ID" format(uuid)
+// @Param		hours	body	object	true	"Weekly slots in openings, dated exceptions in exceptions"
+// @Success		200	{object}	nil "Store with its hours"
+// @Failure		400	{object}	nil "Invalid input"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Store not found"
+// @Router		/store/{id}/hours [put]
+// @Id			jwt.Auth => store.UpdateStoreHours
+func UpdateStoreHours(ctx *fiber.Ctx) error {
+	dtoHours := &transfert.StoreHours{}
+	if err := ctx.BodyParser(dtoHours); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	storeID := ctx.Params("id")
+	dtoHours.StoreID = &storeID
+
+	status, response := services.UpdateStoreHours(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), dtoHours,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
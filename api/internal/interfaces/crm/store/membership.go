@@ -0,0 +1,100 @@
+package store
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/store"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/crm"
+	storeRepository "github.com/kodmain/thetiptop/api/internal/domain/store/repositories"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/store/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// @Tags		Store
+// @Summary		List the employees assigned to a store.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id	path	string	true	"Store ID" format(uuid)
+// @Success		200	{object}	nil "Memberships of the store"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Store not found"
+// @Router		/store/{id}/employees [get]
+// @Id			jwt.Auth => store.GetMemberships
+func GetMemberships(ctx *fiber.Ctx) error {
+	storeID := ctx.Params("id")
+
+	status, response := services.GetMemberships(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), &transfert.Membership{
+			StoreID: &storeID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Store
+// @Accept		multipart/form-data
+// @Summary		Assign an employee to a store.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id				path		string	true	"Store ID" format(uuid)
+// @Param		credential_id	formData	string	true	"Credential ID of the employee" format(uuid)
+// @Success		201	{object}	nil "Employee assigned"
+// @Failure		400	{object}	nil "Invalid input"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Store not found"
+// @Failure		409	{object}	nil "Employee already assigned"
+// @Router		/store/{id}/employee [post]
+// @Id			jwt.Auth => store.CreateMembership
+func CreateMembership(ctx *fiber.Ctx) error {
+	dtoMembership := &transfert.Membership{}
+	if err := ctx.BodyParser(dtoMembership); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	storeID := ctx.Params("id")
+	dtoMembership.StoreID = &storeID
+
+	status, response := services.CreateMembership(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), dtoMembership,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Store
+// @Summary		Remove an employee from a store.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id				path	string	true	"Store ID" format(uuid)
+// @Param		credential_id	path	string	true	"Credential ID of the employee" format(uuid)
+// @Success		204	{object}	nil "Employee removed"
+// @Failure		400	{object}	nil "Invalid input"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Employee not assigned to the store"
+// @Router		/store/{id}/employee/{credential_id} [delete]
+// @Id			jwt.Auth => store.DeleteMembership
+func DeleteMembership(ctx *fiber.Ctx) error {
+	storeID := ctx.Params("id")
+	credentialID := ctx.Params("credential_id")
+
+	status, response := services.DeleteMembership(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), &transfert.Membership{
+			StoreID:      &storeID,
+			CredentialID: &credentialID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -14,9 +14,15 @@ import (
 
 // @Tags		Store
 // @Accept		multipart/form-data
-// @Summary		List all store.
+// @Summary		List a page of the stores.
 // @Produce		application/json
-// @Success		200	{object}	nil "list of store"
+// @Param		page	query	int		false	"Page number, starting at 1"
+// @Param		limit	query	int		false	"Page size, 20 by default and 100 at most"
+// @Param		cursor	query	string	false	"Cursor of the next page, replaces page"
+// @Param		sort	query	string	false	"Columns to sort by, prefixed by - for descending order" example(label)
+// @Param		filter[is_online]	query	bool	false	"Online stores only"
+// @Success		200	{object}	nil "page of stores"
+// @Failure		400	{object}	nil "Bad request"
 // @Router		/store [get]
 // @Id			store.List
 func List(ctx *fiber.Ctx) error {
@@ -24,7 +30,7 @@ func List(ctx *fiber.Ctx) error {
 		domain.Store(
 			security.NewUserAccess(ctx.Locals("token")),
 			repositories.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
-		),
+		), database.NewList(ctx.Queries()),
 	)
 
 	return ctx.Status(status).JSON(response)
@@ -58,3 +64,100 @@ func GetStoreByID(ctx *fiber.Ctx) error {
 
 	return ctx.Status(status).JSON(response)
 }
+
+// @Tags		Store
+// @Accept		multipart/form-data
+// @Summary		Create a store.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		label		formData	string	true	"Label of the store"
+// @Param		is_online	formData	bool	true	"Online store"
+// @Param		address		formData	string	false	"Postal address of the store"
+// @Param		latitude	formData	number	false	"Latitude of the store in degrees"
+// @Param		longitude	formData	number	false	"Longitude of the store in degrees"
+// @Param		timezone	formData	string	false	"IANA timezone of the store" example(Europe/Paris)
+// @Success		201	{object}	nil "Store created"
+// @Failure		400	{object}	nil "Invalid input"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		409	{object}	nil "Label already used"
+// @Router		/store [post]
+// @Id			jwt.Auth => store.CreateStore
+func CreateStore(ctx *fiber.Ctx) error {
+	dtoStore := &transfert.Store{}
+	if err := ctx.BodyParser(dtoStore); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := services.CreateStore(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), dtoStore,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Store
+// @Accept		multipart/form-data
+// @Summary		Update the label, the kind or the location of a store.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id			path		string	true	"Store ID" format(uuid)
+// @Param		label		formData	string	false	"Label of the store"
+// @Param		is_online	formData	bool	false	"Online store"
+// @Param		address		formData	string	false	"Postal address of the store"
+// @Param		latitude	formData	number	false	"Latitude of the store in degrees"
+// @Param		longitude	formData	number	false	"Longitude of the store in degrees"
+// @Param		timezone	formData	string	false	"IANA timezone of the store" example(Europe/Paris)
+// @Success		200	{object}	nil "Store updated"
+// @Failure		400	{object}	nil "Invalid input"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Store not found"
+// @Failure		409	{object}	nil "Label already used"
+// @Router		/store/{id} [put]
+// @Id			jwt.Auth => store.UpdateStore
+func UpdateStore(ctx *fiber.Ctx) error {
+	dtoStore := &transfert.Store{}
+	if err := ctx.BodyParser(dtoStore); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	storeID := ctx.Params("id")
+	dtoStore.ID = &storeID
+
+	status, response := services.UpdateStore(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), dtoStore,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Store
+// @Summary		Close a store and its caisses.
+// @Produce		application/json
+// @Security 	Bearer
+// @Param		id	path	string	true	"Store ID" format(uuid)
+// @Success		204	{object}	nil "Store deleted"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Store not found"
+// @Router		/store/{id} [delete]
+// @Id			jwt.Auth => store.DeleteStore
+func DeleteStore(ctx *fiber.Ctx) error {
+	storeID := ctx.Params("id")
+
+	status, response := services.DeleteStore(
+		domain.Store(
+			security.NewUserAccess(ctx.Locals("token")),
+			storeRepository.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
+		), &transfert.Store{
+			ID: &storeID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
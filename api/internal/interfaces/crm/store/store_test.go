@@ -7,6 +7,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/kodmain/thetiptop/api/internal/domain/store/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,9 +45,14 @@ func TestStores(t *testing.T) {
 			content, status, err := request("GET", DOMAIN+"/store", authorization, encoding, nil)
 			assert.Nil(t, err)
 			assert.Equal(t, http.StatusOK, status)
-			var stores []*entities.Store
-			assert.Nil(t, json.Unmarshal(content, &stores), "Response should be valid JSON")
-			assert.NotNil(t, stores, "Response should not be nil")
+			var page database.Page[*entities.Store]
+			assert.Nil(t, json.Unmarshal(content, &page), "Response should be valid JSON")
+			assert.NotNil(t, page.Items, "Response should not be nil")
+			stores := page.Items
+
+			_, status, err = request("GET", DOMAIN+"/store?sort=deleted_at", authorization, encoding, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusBadRequest, status)
 
 			t.Run("StoreByID/"+encodingName, func(t *testing.T) {
 				for _, store := range stores {
@@ -60,7 +66,183 @@ func TestStores(t *testing.T) {
 			})
 
 		})
+
+		t.Run("StoreManagement/"+encodingName, func(t *testing.T) {
+			testStoreManagement(t, authorization, claims.ID, encoding, encodingName)
+		})
 	}
 
 	assert.Nil(t, stop())
 }
+
+func testStoreManagement(t *testing.T, authorization, credentialID string, encoding EncodingType, label string) {
+	// Un employé ne peut pas créer de boutique
+	_, status, err := request("POST", DOMAIN+"/store", authorization, encoding, map[string][]any{
+		"label":     {label},
+		"is_online": {false},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	access, _, jwtErr := jwt.FromID(credentialID, map[string]any{"role": "admin"})
+	assert.Nil(t, jwtErr)
+	admin := "Bearer " + access
+
+	content, status, err := request("POST", DOMAIN+"/store", admin, encoding, map[string][]any{
+		"label":     {label},
+		"is_online": {false},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+
+	var created entities.Store
+	assert.Nil(t, json.Unmarshal(content, &created))
+	assert.NotEmpty(t, created.ID)
+
+	_, status, err = request("POST", DOMAIN+"/store", admin, encoding, map[string][]any{
+		"label":     {label},
+		"is_online": {true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusConflict, status)
+
+	content, status, err = request("PUT", DOMAIN+"/store/"+created.ID, admin, encoding, map[string][]any{
+		"is_online": {true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	var updated entities.Store
+	assert.Nil(t, json.Unmarshal(content, &updated))
+	if assert.NotNil(t, updated.IsOnline) {
+		assert.True(t, *updated.IsOnline)
+	}
+
+	testStoreLocator(t, admin, created.ID, encoding)
+	testStoreMemberships(t, authorization, admin, created.ID, credentialID, encoding)
+
+	_, status, err = request("DELETE", DOMAIN+"/store/"+created.ID, admin, encoding, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	_, status, err = request("GET", DOMAIN+"/store/"+created.ID, authorization, encoding, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// Une boutique recréée sous le même libellé reprend son identifiant
+	content, status, err = request("POST", DOMAIN+"/store", admin, encoding, map[string][]any{
+		"label":     {label},
+		"is_online": {false},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+
+	var restored entities.Store
+	assert.Nil(t, json.Unmarshal(content, &restored))
+	assert.Equal(t, created.ID, restored.ID)
+}
+
+func testStoreMemberships(t *testing.T, authorization, admin, storeID, credentialID string, encoding EncodingType) {
+	// Un employé ne voit pas une boutique à laquelle il n'est pas assigné
+	_, status, err := request("GET", DOMAIN+"/store/"+storeID, authorization, encoding, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	_, status, err = request("POST", DOMAIN+"/store/"+storeID+"/employee", authorization, encoding, map[string][]any{
+		"credential_id": {credentialID},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	_, status, err = request("POST", DOMAIN+"/store/"+storeID+"/employee", admin, encoding, map[string][]any{
+		"credential_id": {credentialID},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+
+	_, status, err = request("POST", DOMAIN+"/store/"+storeID+"/employee", admin, encoding, map[string][]any{
+		"credential_id": {credentialID},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusConflict, status)
+
+	content, status, err := request("GET", DOMAIN+"/store/"+storeID+"/employees", admin, encoding, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	var memberships []*entities.Membership
+	assert.Nil(t, json.Unmarshal(content, &memberships))
+	if assert.Len(t, memberships, 1) {
+		assert.Equal(t, credentialID, *memberships[0].CredentialID)
+	}
+
+	_, status, err = request("GET", DOMAIN+"/store/"+storeID, authorization, encoding, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	_, status, err = request("DELETE", DOMAIN+"/store/"+storeID+"/employee/"+credentialID, admin, encoding, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	_, status, err = request("GET", DOMAIN+"/store/"+storeID, authorization, encoding, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func testStoreLocator(t *testing.T, admin, storeID string, encoding EncodingType) {
+	_, status, err := request("PUT", DOMAIN+"/store/"+storeID, admin, encoding, map[string][]any{
+		"is_online": {false},
+		"latitude":  {48.8566},
+		"longitude": {2.3522},
+		"timezone":  {"Europe/Paris"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	if encoding == JSONEncoded {
+		content, status, err := request("PUT", DOMAIN+"/store/"+storeID+"/hours", admin, encoding, map[string][]any{
+			"openings":   {[]map[string]any{{"weekday": 1, "opens": "09:00", "closes": "18:00"}}},
+			"exceptions": {[]map[string]any{{"date": "2026-12-25", "reason": "Noël"}}},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		var store entities.Store
+		assert.Nil(t, json.Unmarshal(content, &store))
+		assert.Len(t, store.Openings, 1)
+		assert.Len(t, store.Exceptions, 1)
+	}
+
+	// La recherche est publique et ignore les boutiques hors du rayon
+	content, status, err := request("GET", DOMAIN+"/store/nearby", "", encoding, map[string][]any{
+		"lat":    {48.8606},
+		"lng":    {2.3376},
+		"radius": {5},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	var nearby []map[string]any
+	assert.Nil(t, json.Unmarshal(content, &nearby))
+	found := false
+	for _, store := range nearby {
+		if store["id"] == storeID {
+			found = true
+			assert.InDelta(t, 1.2, store["distance"], 0.2)
+		}
+	}
+	assert.True(t, found)
+
+	_, status, err = request("GET", DOMAIN+"/store/nearby", "", encoding, map[string][]any{
+		"lat": {45.7640},
+		"lng": {4.8357},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	_, status, err = request("GET", DOMAIN+"/store/nearby", "", encoding, map[string][]any{
+		"lat": {48.8606},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+}